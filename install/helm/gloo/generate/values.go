@@ -349,6 +349,7 @@ type GatewayProxy struct {
 	GlobalDownstreamMaxConnections *uint32                      `json:"globalDownstreamMaxConnections,omitempty" desc:"the number of concurrent connections needed. limit used to protect against exhausting file descriptors on host machine"`
 	HealthyPanicThreshold          *int8                        `json:"healthyPanicThreshold,omitempty" desc:"the percentage of healthy hosts required to load balance based on health status of hosts"`
 	Service                        *GatewayProxyService         `json:"service,omitempty"`
+	Http3                          *bool                        `json:"http3,omitempty" desc:"also expose the HTTPS port over UDP on the gateway proxy pods and service, so that the Gateways with the http3 option can serve HTTP/3. LoadBalancer services then mix TCP and UDP ports, which requires Kubernetes 1.24+"`
 	AntiAffinity                   *bool                        `json:"antiAffinity,omitempty" desc:"configure anti affinity such that pods are preferably not co-located"`
 	Affinity                       map[string]interface{}       `json:"affinity,omitempty"`
 	Tracing                        *Tracing                     `json:"tracing,omitempty"`
@@ -368,6 +369,7 @@ type GatewayProxy struct {
 	Failover                       Failover                     `json:"failover,omitempty" desc:"(Enterprise Only): Failover configuration"`
 	Disabled                       *bool                        `json:"disabled,omitempty" desc:"Skips creation of this gateway proxy. Used to turn off gateway proxies created by preceding configurations"`
 	EnvoyApiVersion                *string                      `json:"envoyApiVersion,omitempty" desc:"Version of the envoy API to use for the xDS transport and resources. Default is V3"`
+	XdsApiType                     *string                      `json:"xdsApiType,omitempty" desc:"The xDS protocol used by the proxy to fetch its configuration from Gloo. Either GRPC, to receive all resources on each change, or DELTA_GRPC, to only receive the resources which changed. Default is GRPC"`
 	EnvoyBootstrapExtensions       []map[string]interface{}     `json:"envoyBootstrapExtensions,omitempty" desc:"List of bootstrap extensions to add to envoy bootstrap config. Examples include Wasm Service (https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/wasm/v3/wasm.proto#extensions-wasm-v3-wasmservice)."`
 	EnvoyStaticClusters            []map[string]interface{}     `json:"envoyStaticClusters,omitempty" desc:"List of extra static clusters to be added to envoy bootstrap config. https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/cluster/v3/cluster.proto#envoy-v3-api-msg-config-cluster-v3-cluster"`
 	HorizontalPodAutoscaler        *HorizontalPodAutoscaler     `json:"horizontalPodAutoscaler,omitempty" desc:"HorizontalPodAutoscaler for the GatewayProxy. Used only when Kind is set to Deployment. Resources must be set on the gateway-proxy deployment for HorizontalPodAutoscalers to function correctly"`
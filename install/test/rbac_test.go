@@ -286,6 +286,11 @@ var _ = Describe("RBAC Test", func() {
 								Resources: []string{"configmaps"},
 								Verbs:     []string{"get", "update"},
 							},
+							{
+								APIGroups: []string{""},
+								Resources: []string{"secrets"},
+								Verbs:     []string{"get", "update", "create", "delete"},
+							},
 						},
 						RoleRef: rbacv1.RoleRef{
 							APIGroup: "rbac.authorization.k8s.io",
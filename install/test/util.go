@@ -45,6 +45,13 @@ func GetServiceAccountPermissions(namespace string) *manifesttestutils.ServiceAc
 		[]string{"configmaps"},
 		[]string{"get", "update"},
 	)
+	permissions.AddExpectedPermission(
+		"gloo-system.gloo",
+		namespace,
+		[]string{""},
+		[]string{"secrets"},
+		[]string{"get", "update", "create", "delete"},
+	)
 	permissions.AddExpectedPermission(
 		"gloo-system.gloo",
 		namespace,
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	errors "github.com/rotisserie/eris"
@@ -25,6 +26,9 @@ const (
 	AWS_WEB_IDENTITY_TOKEN_FILE = "AWS_WEB_IDENTITY_TOKEN_FILE"
 	AWS_ROLE_ARN                = "AWS_ROLE_ARN"
 	AWS_REGION                  = "AWS_REGION"
+
+	// the role session name used when assuming a role, if the upstream does not specify one
+	defaultRoleSessionName = "gloo"
 )
 
 func NewFunctionDiscoveryFactory() fds.FunctionDiscoveryFactory {
@@ -140,18 +144,38 @@ func (f *AWSLambdaFunctionDiscovery) DetectFunctionsOnce(ctx context.Context, se
 	}
 
 	var svc *lambda.Lambda
+	var creds *credentials.Credentials
 
 	tokenPath := os.Getenv(AWS_WEB_IDENTITY_TOKEN_FILE)
 	roleArn := os.Getenv(AWS_ROLE_ARN)
+	if lambdaSpec.GetRoleArn() != "" {
+		roleArn = lambdaSpec.GetRoleArn()
+	}
 	// If aws web token, and role arn are available, authenticate lambda service using mounted credentials.
 	// See: https://aws.amazon.com/blogs/opensource/introducing-fine-grained-iam-roles-service-accounts/
 	if tokenPath != "" && roleArn != "" {
-		if awsSpec.Aws.GetRoleArn() != "" {
-			roleArn = awsSpec.Aws.GetRoleArn()
-		}
 		contextutils.LoggerFrom(ctx).Debugf("Discovering lambda functions using assumed role [%s]", roleArn)
-		webProvider := stscreds.NewWebIdentityCredentials(sess, roleArn, "", tokenPath)
-		svc = lambda.New(sess, aws.NewConfig().WithCredentials(webProvider))
+		creds = stscreds.NewWebIdentityCredentials(sess, roleArn, "", tokenPath)
+	}
+	// The role of the upstream is assumed with the credentials above, or the ones of the session
+	if assumeRole := lambdaSpec.GetAssumeRole(); assumeRole != nil {
+		contextutils.LoggerFrom(ctx).Debugf("Discovering lambda functions using chained role [%s]", assumeRole.GetRoleArn())
+		baseSess := sess
+		if creds != nil {
+			baseSess = sess.Copy(aws.NewConfig().WithCredentials(creds))
+		}
+		creds = stscreds.NewCredentials(baseSess, assumeRole.GetRoleArn(), func(provider *stscreds.AssumeRoleProvider) {
+			provider.RoleSessionName = defaultRoleSessionName
+			if assumeRole.GetSessionName() != "" {
+				provider.RoleSessionName = assumeRole.GetSessionName()
+			}
+			if assumeRole.GetExternalId() != "" {
+				provider.ExternalID = aws.String(assumeRole.GetExternalId())
+			}
+		})
+	}
+	if creds != nil {
+		svc = lambda.New(sess, aws.NewConfig().WithCredentials(creds))
 	} else {
 		svc = lambda.New(sess)
 	}
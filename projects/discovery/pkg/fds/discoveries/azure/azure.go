@@ -0,0 +1,125 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/solo-io/go-utils/contextutils"
+
+	"github.com/solo-io/gloo/projects/discovery/pkg/fds"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	plugins "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options"
+	glooazure "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/azure"
+	azureplugin "github.com/solo-io/gloo/projects/gloo/pkg/plugins/azure"
+)
+
+func NewFunctionDiscoveryFactory(settings *v1.Settings) fds.FunctionDiscoveryFactory {
+	return &AzureFunctionDiscoveryFactory{
+		// the management API throttles the reads of a subscription, so we do not poll it too often
+		PollingTime:   30 * time.Second,
+		TokenUri:      azureplugin.GetTokenUri(settings.GetGloo().GetAzureOptions()),
+		ManagementUrl: defaultManagementUrl,
+	}
+}
+
+// AzureFunctionDiscoveryFactory represents a factory for Azure Functions function discovery.
+type AzureFunctionDiscoveryFactory struct {
+	PollingTime time.Duration
+	// the endpoint of the Instance Metadata Service that issues the access tokens of the managed identities
+	TokenUri string
+	// the Azure Resource Manager endpoint
+	ManagementUrl string
+}
+
+func (f *AzureFunctionDiscoveryFactory) NewFunctionDiscovery(u *v1.Upstream, _ fds.AdditionalClients) fds.UpstreamFunctionDiscovery {
+	return &AzureFunctionDiscovery{
+		timeToWait: f.PollingTime,
+		upstream:   u,
+		functionsLister: &managementApi{
+			client:        http.DefaultClient,
+			tokenUri:      f.TokenUri,
+			managementUrl: f.ManagementUrl,
+		},
+	}
+}
+
+// AzureFunctionDiscovery is a discovery that polls the Azure Resource Manager API for the functions of a function app.
+type AzureFunctionDiscovery struct {
+	timeToWait      time.Duration
+	upstream        *v1.Upstream
+	functionsLister *managementApi
+}
+
+// Only the Azure upstreams that locate their function app in a subscription and a resource group are discovered,
+// the others keep the functions they were created with.
+func (f *AzureFunctionDiscovery) IsFunctional() bool {
+	azureSpec, ok := f.upstream.GetUpstreamType().(*v1.Upstream_Azure)
+	return ok && azureSpec.Azure.GetSubscriptionId() != "" && azureSpec.Azure.GetResourceGroup() != ""
+}
+
+func (f *AzureFunctionDiscovery) DetectType(ctx context.Context, url *url.URL) (*plugins.ServiceSpec, error) {
+	return nil, nil
+}
+
+func (f *AzureFunctionDiscovery) DetectFunctions(ctx context.Context, _ *url.URL, _ func() fds.Dependencies, updatecb func(fds.UpstreamMutator) error) error {
+	err := contextutils.NewExponentialBackoff(contextutils.ExponentialBackoff{}).Backoff(ctx, func(ctx context.Context) error {
+		newFunctions, err := f.DetectFunctionsOnce(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = updatecb(func(out *v1.Upstream) error {
+			if out == nil {
+				return errors.New("nil upstream")
+			}
+			azureSpec, ok := out.GetUpstreamType().(*v1.Upstream_Azure)
+			if !ok {
+				return errors.New("not azure upstream")
+			}
+			azureSpec.Azure.Functions = newFunctions
+			return nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "unable to update upstream")
+		}
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// only log other errors as we would like to continue forever.
+		contextutils.LoggerFrom(ctx).Warnf("Unable to perform azure function discovery for upstream %s in namespace %s, error: %s",
+			f.upstream.GetMetadata().GetName(),
+			f.upstream.GetMetadata().GetNamespace(),
+			err.Error(),
+		)
+	}
+
+	// sleep so we are not hogging
+	if err := contextutils.Sleep(ctx, f.timeToWait); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (f *AzureFunctionDiscovery) DetectFunctionsOnce(ctx context.Context) ([]*glooazure.UpstreamSpec_FunctionSpec, error) {
+	azureSpec, ok := f.upstream.GetUpstreamType().(*v1.Upstream_Azure)
+	if !ok {
+		return nil, errors.New("not an azure upstream spec")
+	}
+	functions, err := f.functionsLister.listFunctions(ctx, azureSpec.Azure)
+	if err != nil {
+		return nil, err
+	}
+
+	// sort for idempotency
+	sort.Slice(functions, func(i, j int) bool {
+		return functions[i].GetFunctionName() < functions[j].GetFunctionName()
+	})
+	return functions, nil
+}
@@ -0,0 +1,123 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/solo-io/gloo/projects/discovery/pkg/fds"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	glooazure "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/azure"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+)
+
+var _ = Describe("Azure function discovery", func() {
+
+	var (
+		server        *httptest.Server
+		tokenRequests int
+		upstream      *v1.Upstream
+		discovery     fds.UpstreamFunctionDiscovery
+	)
+
+	BeforeEach(func() {
+		tokenRequests = 0
+		mux := http.NewServeMux()
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("client_id") != "client-id" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			tokenRequests++
+			expiresOn := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+			_, _ = fmt.Fprintf(w, `{"access_token": "access-token", "expires_on": "%s"}`, expiresOn)
+		})
+		mux.HandleFunc("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/sites/app/functions", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer access-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Query().Get("page") == "" {
+				_, _ = fmt.Fprintf(w, `{
+					"value": [
+						{"properties": {"name": "hello", "config": {"bindings": [{"type": "httpTrigger", "authLevel": "function"}, {"type": "http"}]}}},
+						{"properties": {"name": "queue", "config": {"bindings": [{"type": "queueTrigger"}]}}},
+						{"properties": {"name": "disabled", "isDisabled": true, "config": {"bindings": [{"type": "httpTrigger", "authLevel": "anonymous"}]}}}
+					],
+					"nextLink": "%s%s?page=2"
+				}`, server.URL, r.URL.Path)
+				return
+			}
+			_, _ = w.Write([]byte(`{
+				"value": [
+					{"properties": {"name": "admin", "config": {"bindings": [{"type": "httpTrigger", "authLevel": "admin"}]}}},
+					{"properties": {"name": "anonymous", "config": {"bindings": [{"type": "httpTrigger", "authLevel": "Anonymous"}]}}}
+				]
+			}`))
+		})
+		server = httptest.NewServer(mux)
+
+		upstream = &v1.Upstream{
+			Metadata: &core.Metadata{Name: "azure", Namespace: "gloo-system"},
+			UpstreamType: &v1.Upstream_Azure{
+				Azure: &glooazure.UpstreamSpec{
+					FunctionAppName: "app",
+					SubscriptionId:  "sub",
+					ResourceGroup:   "rg",
+					ManagedIdentity: &glooazure.UpstreamSpec_ManagedIdentity{
+						Resource: "api://app-id",
+						ClientId: "client-id",
+					},
+				},
+			},
+		}
+		factory := &AzureFunctionDiscoveryFactory{
+			PollingTime:   time.Millisecond,
+			TokenUri:      server.URL + "/token",
+			ManagementUrl: server.URL,
+		}
+		discovery = factory.NewFunctionDiscovery(upstream, fds.AdditionalClients{})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should only discover upstreams with a subscription and a resource group", func() {
+		Expect(discovery.IsFunctional()).To(BeTrue())
+		upstream.GetAzure().ResourceGroup = ""
+		Expect(discovery.IsFunctional()).To(BeFalse())
+	})
+
+	It("should list the enabled functions with an http trigger", func() {
+		functions, err := discovery.(*AzureFunctionDiscovery).DetectFunctionsOnce(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(functions).To(Equal([]*glooazure.UpstreamSpec_FunctionSpec{
+			{FunctionName: "admin", AuthLevel: glooazure.UpstreamSpec_FunctionSpec_Admin},
+			{FunctionName: "anonymous", AuthLevel: glooazure.UpstreamSpec_FunctionSpec_Anonymous},
+			{FunctionName: "hello", AuthLevel: glooazure.UpstreamSpec_FunctionSpec_Function},
+		}))
+	})
+
+	It("should reuse the access token until it expires", func() {
+		for i := 0; i < 2; i++ {
+			_, err := discovery.(*AzureFunctionDiscovery).DetectFunctionsOnce(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(tokenRequests).To(Equal(1))
+	})
+
+	It("should update the functions of the upstream", func() {
+		err := discovery.DetectFunctions(context.Background(), nil, nil, func(mutator fds.UpstreamMutator) error {
+			return mutator(upstream)
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(upstream.GetAzure().GetFunctions()).To(HaveLen(3))
+	})
+})
@@ -0,0 +1,158 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	errors "github.com/rotisserie/eris"
+
+	glooazure "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/azure"
+)
+
+const (
+	defaultManagementUrl = "https://management.azure.com"
+
+	// https://docs.microsoft.com/en-us/rest/api/appservice/web-apps/list-functions
+	functionsApiVersion = "2022-03-01"
+	// https://docs.microsoft.com/en-us/azure/active-directory/managed-identities-azure-resources/how-to-use-vm-token
+	imdsApiVersion = "2018-02-01"
+
+	httpTriggerBinding = "httpTrigger"
+
+	// refresh the access tokens a bit before they expire
+	tokenExpiryMargin = time.Minute
+)
+
+// Lists the functions of a function app with the Azure Resource Manager API, authenticated with the access tokens of
+// a managed identity.
+type managementApi struct {
+	client        *http.Client
+	tokenUri      string
+	managementUrl string
+
+	// the last access token, and when it expires
+	accessToken string
+	expiresOn   time.Time
+}
+
+// https://docs.microsoft.com/en-us/rest/api/appservice/web-apps/list-functions#functionenvelopecollection
+type listFunctionsResponse struct {
+	Value []struct {
+		Properties struct {
+			Name       string `json:"name"`
+			IsDisabled bool   `json:"isDisabled"`
+			Config     struct {
+				Bindings []struct {
+					Type      string `json:"type"`
+					AuthLevel string `json:"authLevel"`
+				} `json:"bindings"`
+			} `json:"config"`
+		} `json:"properties"`
+	} `json:"value"`
+	NextLink string `json:"nextLink"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresOn   string `json:"expires_on"`
+}
+
+// lists the enabled functions of the function app that have an http trigger
+func (m *managementApi) listFunctions(ctx context.Context, spec *glooazure.UpstreamSpec) ([]*glooazure.UpstreamSpec_FunctionSpec, error) {
+	token, err := m.getAccessToken(ctx, spec.GetManagedIdentity().GetClientId())
+	if err != nil {
+		return nil, err
+	}
+
+	var functions []*glooazure.UpstreamSpec_FunctionSpec
+	listUrl := fmt.Sprintf("%s/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Web/sites/%s/functions?api-version=%s",
+		m.managementUrl,
+		url.PathEscape(spec.GetSubscriptionId()),
+		url.PathEscape(spec.GetResourceGroup()),
+		url.PathEscape(spec.GetFunctionAppName()),
+		functionsApiVersion)
+	for listUrl != "" {
+		var response listFunctionsResponse
+		if err := m.get(ctx, listUrl, http.Header{"Authorization": []string{"Bearer " + token}}, &response); err != nil {
+			return nil, errors.Wrapf(err, "listing the functions of function app %s", spec.GetFunctionAppName())
+		}
+		for _, function := range response.Value {
+			if function.Properties.IsDisabled {
+				continue
+			}
+			for _, binding := range function.Properties.Config.Bindings {
+				if binding.Type != httpTriggerBinding {
+					continue
+				}
+				functions = append(functions, &glooazure.UpstreamSpec_FunctionSpec{
+					FunctionName: function.Properties.Name,
+					AuthLevel:    getAuthLevel(binding.AuthLevel),
+				})
+				break
+			}
+		}
+		listUrl = response.NextLink
+	}
+	return functions, nil
+}
+
+// the auth level of the http triggers defaults to function
+func getAuthLevel(authLevel string) glooazure.UpstreamSpec_FunctionSpec_AuthLevel {
+	switch strings.ToLower(authLevel) {
+	case "anonymous":
+		return glooazure.UpstreamSpec_FunctionSpec_Anonymous
+	case "admin":
+		return glooazure.UpstreamSpec_FunctionSpec_Admin
+	default:
+		return glooazure.UpstreamSpec_FunctionSpec_Function
+	}
+}
+
+// gets an access token for the management API from the Instance Metadata Service, for the given user-assigned
+// managed identity, or the system-assigned one if the client ID is empty
+func (m *managementApi) getAccessToken(ctx context.Context, clientId string) (string, error) {
+	if m.accessToken != "" && time.Now().Add(tokenExpiryMargin).Before(m.expiresOn) {
+		return m.accessToken, nil
+	}
+
+	query := url.Values{}
+	query.Set("api-version", imdsApiVersion)
+	query.Set("resource", m.managementUrl+"/")
+	if clientId != "" {
+		query.Set("client_id", clientId)
+	}
+	var response tokenResponse
+	if err := m.get(ctx, m.tokenUri+"?"+query.Encode(), http.Header{"Metadata": []string{"true"}}, &response); err != nil {
+		return "", errors.Wrapf(err, "getting an access token from the instance metadata service")
+	}
+	expiresOn, err := strconv.ParseInt(response.ExpiresOn, 10, 64)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing the expiry of the access token")
+	}
+	m.accessToken = response.AccessToken
+	m.expiresOn = time.Unix(expiresOn, 0)
+	return m.accessToken, nil
+}
+
+func (m *managementApi) get(ctx context.Context, getUrl string, header http.Header, response interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getUrl, nil)
+	if err != nil {
+		return err
+	}
+	req.Header = header
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
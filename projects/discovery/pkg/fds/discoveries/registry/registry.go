@@ -3,6 +3,7 @@ package registry
 import (
 	"github.com/solo-io/gloo/projects/discovery/pkg/fds"
 	"github.com/solo-io/gloo/projects/discovery/pkg/fds/discoveries/aws"
+	"github.com/solo-io/gloo/projects/discovery/pkg/fds/discoveries/azure"
 	"github.com/solo-io/gloo/projects/discovery/pkg/fds/discoveries/grpc"
 	"github.com/solo-io/gloo/projects/discovery/pkg/fds/discoveries/swagger"
 	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
@@ -21,6 +22,7 @@ var globalRegistry = func(opts bootstrap.Opts, pluginExtensions ...func() plugin
 	// plugins should be added here
 	reg.plugins = append(reg.plugins,
 		aws.NewFunctionDiscoveryFactory(),
+		azure.NewFunctionDiscoveryFactory(opts.Settings),
 		grpc.NewFunctionDiscoveryFactory(),
 		swagger.NewFunctionDiscoveryFactory(),
 	)
@@ -128,14 +128,15 @@ func selectUpstreamsWhitelist(upstreams v1.UpstreamList, whitelistedNamespaces,
 		blacklisted := isBlacklistedUpstream(us)
 		whitelisted := isWhitelistedUpstream(us)
 
-		// if an upstream is AWS, then include it only if it would be included in blacklist mode (https://github.com/solo-io/solo-projects/issues/1339)
-		// otherwise, include the upstream only if it is *not* AWS, and either condition holds:
+		// if an upstream is AWS or Azure, then include it only if it would be included in blacklist mode (https://github.com/solo-io/solo-projects/issues/1339)
+		// otherwise, include the upstream only if it is *not* AWS or Azure, and either condition holds:
 		//   - the upstream is in a whitelisted namespace and not explicitly blacklisted
 		//   - the upstream itself is explicitly whitelisted
-		shouldIncludeAwsUpstream := us.GetAws() != nil && shouldIncludeUpstreamInBlacklistMode(us, blacklistedNamespaces)
-		shouldIncludeNonAwsUpstream := us.GetAws() == nil && ((inWhitelistedNamespace && !blacklisted) || whitelisted)
+		isCloudFunctionsUpstream := us.GetAws() != nil || us.GetAzure() != nil
+		shouldIncludeCloudFunctionsUpstream := isCloudFunctionsUpstream && shouldIncludeUpstreamInBlacklistMode(us, blacklistedNamespaces)
+		shouldIncludeOtherUpstream := !isCloudFunctionsUpstream && ((inWhitelistedNamespace && !blacklisted) || whitelisted)
 
-		if shouldIncludeAwsUpstream || shouldIncludeNonAwsUpstream {
+		if shouldIncludeCloudFunctionsUpstream || shouldIncludeOtherUpstream {
 			selected = append(selected, us)
 		}
 	}
@@ -11,6 +11,7 @@ import (
 
 	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
 	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/aws"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/azure"
 	kubeplugin "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/kubernetes"
 	"github.com/solo-io/gloo/projects/gloo/pkg/bootstrap"
 )
@@ -58,10 +59,12 @@ var _ = Describe("selectUpstreamsForDiscovery", func() {
 	enabledAwsUs1 := makeAwsUpstream("enabledAwsUs1", enabledNs.Name, nil)
 	enabledAwsUs2 := makeAwsUpstream("enabledAwsUs2", disabledNs.Name, enabledLabels)
 	enabledAwsUs3 := makeAwsUpstream("enabledAwsUs3", "other-namespace", enabledLabels)
+	disabledAzureUs1 := makeAzureUpstream("disabledAzureUs1", disabledNs.Name, nil)
+	enabledAzureUs1 := makeAzureUpstream("enabledAzureUs1", enabledNs.Name, nil)
 	explicitlyEnabledUs1 := makeKubeUpstream("explicitlyEnabledUs1", explicitlyEnabledNs.Name, nil)
 	explicitlyEnabledUs2 := makeKubeUpstream("explicitlyEnabledUs2", enabledNs.Name, enabledLabels)
 
-	usList := gloov1.UpstreamList{disabledUs1, disabledUs2, disabledUs3, enabledUs1, enabledUs2, explicitlyEnabledUs1, explicitlyEnabledUs2, disabledAwsUs1, enabledAwsUs3, disabledAwsUs2, enabledAwsUs1, enabledAwsUs2}
+	usList := gloov1.UpstreamList{disabledUs1, disabledUs2, disabledUs3, enabledUs1, enabledUs2, explicitlyEnabledUs1, explicitlyEnabledUs2, disabledAwsUs1, enabledAwsUs3, disabledAwsUs2, enabledAwsUs1, enabledAwsUs2, disabledAzureUs1, enabledAzureUs1}
 
 	var filtered gloov1.UpstreamList
 
@@ -125,6 +128,10 @@ var _ = Describe("selectUpstreamsForDiscovery", func() {
 			Expect(filtered).NotTo(ContainElement(disabledAwsUs1))
 			Expect(filtered).NotTo(ContainElement(disabledAwsUs2))
 		})
+		It("includes Azure upstreams as if they were in blacklist mode", func() {
+			Expect(filtered).To(ContainElement(enabledAzureUs1))
+			Expect(filtered).NotTo(ContainElement(disabledAzureUs1))
+		})
 	})
 
 	Context("RunFDS", func() {
@@ -171,3 +178,18 @@ func makeAwsUpstream(name, namespace string, labels map[string]string) *gloov1.U
 	}
 	return us
 }
+
+func makeAzureUpstream(name, namespace string, labels map[string]string) *gloov1.Upstream {
+	us := gloov1.NewUpstream(namespace, name)
+	us.UpstreamType = &gloov1.Upstream_Azure{
+		Azure: &azure.UpstreamSpec{
+			FunctionAppName: "test-app",
+			SubscriptionId:  "test-subscription",
+			ResourceGroup:   "test-resource-group",
+		},
+	}
+	us.DiscoveryMetadata = &gloov1.DiscoveryMetadata{
+		Labels: labels,
+	}
+	return us
+}
@@ -56,6 +56,12 @@ func (m *RouteTable) Clone() proto.Message {
 		target.Weight = proto.Clone(m.GetWeight()).(*github_com_golang_protobuf_ptypes_wrappers.Int32Value)
 	}
 
+	if h, ok := interface{}(m.GetDelegationPolicy()).(clone.Cloner); ok {
+		target.DelegationPolicy = h.Clone().(*DelegationPolicy)
+	} else {
+		target.DelegationPolicy = proto.Clone(m.GetDelegationPolicy()).(*DelegationPolicy)
+	}
+
 	if h, ok := interface{}(m.GetNamespacedStatuses()).(clone.Cloner); ok {
 		target.NamespacedStatuses = h.Clone().(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.NamespacedStatuses)
 	} else {
@@ -70,3 +76,49 @@ func (m *RouteTable) Clone() proto.Message {
 
 	return target
 }
+
+// Clone function
+func (m *DelegationPolicy) Clone() proto.Message {
+	var target *DelegationPolicy
+	if m == nil {
+		return target
+	}
+	target = &DelegationPolicy{}
+
+	if m.GetAllowedNamespaces() != nil {
+		target.AllowedNamespaces = make([]string, len(m.GetAllowedNamespaces()))
+		for idx, v := range m.GetAllowedNamespaces() {
+
+			target.AllowedNamespaces[idx] = v
+
+		}
+	}
+
+	if m.GetAllowedVirtualServices() != nil {
+		target.AllowedVirtualServices = make([]*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef, len(m.GetAllowedVirtualServices()))
+		for idx, v := range m.GetAllowedVirtualServices() {
+
+			if h, ok := interface{}(v).(clone.Cloner); ok {
+				target.AllowedVirtualServices[idx] = h.Clone().(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef)
+			} else {
+				target.AllowedVirtualServices[idx] = proto.Clone(v).(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef)
+			}
+
+		}
+	}
+
+	if m.GetAllowedRouteTables() != nil {
+		target.AllowedRouteTables = make([]*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef, len(m.GetAllowedRouteTables()))
+		for idx, v := range m.GetAllowedRouteTables() {
+
+			if h, ok := interface{}(v).(clone.Cloner); ok {
+				target.AllowedRouteTables[idx] = h.Clone().(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef)
+			} else {
+				target.AllowedRouteTables[idx] = proto.Clone(v).(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef)
+			}
+
+		}
+	}
+
+	return target
+}
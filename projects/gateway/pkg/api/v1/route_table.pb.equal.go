@@ -73,6 +73,16 @@ func (m *RouteTable) Equal(that interface{}) bool {
 		}
 	}
 
+	if h, ok := interface{}(m.GetDelegationPolicy()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetDelegationPolicy()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetDelegationPolicy(), target.GetDelegationPolicy()) {
+			return false
+		}
+	}
+
 	if h, ok := interface{}(m.GetNamespacedStatuses()).(equality.Equalizer); ok {
 		if !h.Equal(target.GetNamespacedStatuses()) {
 			return false
@@ -95,3 +105,72 @@ func (m *RouteTable) Equal(that interface{}) bool {
 
 	return true
 }
+
+// Equal function
+func (m *DelegationPolicy) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*DelegationPolicy)
+	if !ok {
+		that2, ok := that.(DelegationPolicy)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if len(m.GetAllowedNamespaces()) != len(target.GetAllowedNamespaces()) {
+		return false
+	}
+	for idx, v := range m.GetAllowedNamespaces() {
+
+		if strings.Compare(v, target.GetAllowedNamespaces()[idx]) != 0 {
+			return false
+		}
+
+	}
+
+	if len(m.GetAllowedVirtualServices()) != len(target.GetAllowedVirtualServices()) {
+		return false
+	}
+	for idx, v := range m.GetAllowedVirtualServices() {
+
+		if h, ok := interface{}(v).(equality.Equalizer); ok {
+			if !h.Equal(target.GetAllowedVirtualServices()[idx]) {
+				return false
+			}
+		} else {
+			if !proto.Equal(v, target.GetAllowedVirtualServices()[idx]) {
+				return false
+			}
+		}
+
+	}
+
+	if len(m.GetAllowedRouteTables()) != len(target.GetAllowedRouteTables()) {
+		return false
+	}
+	for idx, v := range m.GetAllowedRouteTables() {
+
+		if h, ok := interface{}(v).(equality.Equalizer); ok {
+			if !h.Equal(target.GetAllowedRouteTables()[idx]) {
+				return false
+			}
+		} else {
+			if !proto.Equal(v, target.GetAllowedRouteTables()[idx]) {
+				return false
+			}
+		}
+
+	}
+
+	return true
+}
@@ -311,11 +311,66 @@ func (m *DelegateAction) Clone() proto.Message {
 			}
 		}
 
+	case *DelegateAction_WeightedRouteTables:
+
+		if h, ok := interface{}(m.GetWeightedRouteTables()).(clone.Cloner); ok {
+			target.DelegationType = &DelegateAction_WeightedRouteTables{
+				WeightedRouteTables: h.Clone().(*WeightedRouteTables),
+			}
+		} else {
+			target.DelegationType = &DelegateAction_WeightedRouteTables{
+				WeightedRouteTables: proto.Clone(m.GetWeightedRouteTables()).(*WeightedRouteTables),
+			}
+		}
+
+	}
+
+	return target
+}
+
+// Clone function
+func (m *WeightedRouteTables) Clone() proto.Message {
+	var target *WeightedRouteTables
+	if m == nil {
+		return target
+	}
+	target = &WeightedRouteTables{}
+
+	if m.GetRouteTables() != nil {
+		target.RouteTables = make([]*WeightedRouteTable, len(m.GetRouteTables()))
+		for idx, v := range m.GetRouteTables() {
+
+			if h, ok := interface{}(v).(clone.Cloner); ok {
+				target.RouteTables[idx] = h.Clone().(*WeightedRouteTable)
+			} else {
+				target.RouteTables[idx] = proto.Clone(v).(*WeightedRouteTable)
+			}
+
+		}
 	}
 
 	return target
 }
 
+// Clone function
+func (m *WeightedRouteTable) Clone() proto.Message {
+	var target *WeightedRouteTable
+	if m == nil {
+		return target
+	}
+	target = &WeightedRouteTable{}
+
+	if h, ok := interface{}(m.GetRef()).(clone.Cloner); ok {
+		target.Ref = h.Clone().(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef)
+	} else {
+		target.Ref = proto.Clone(m.GetRef()).(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef)
+	}
+
+	target.Weight = m.GetWeight()
+
+	return target
+}
+
 // Clone function
 func (m *RouteTableSelector) Clone() proto.Message {
 	var target *RouteTableSelector
@@ -464,6 +464,21 @@ func (m *DelegateAction) Equal(that interface{}) bool {
 			}
 		}
 
+	case *DelegateAction_WeightedRouteTables:
+		if _, ok := target.DelegationType.(*DelegateAction_WeightedRouteTables); !ok {
+			return false
+		}
+
+		if h, ok := interface{}(m.GetWeightedRouteTables()).(equality.Equalizer); ok {
+			if !h.Equal(target.GetWeightedRouteTables()) {
+				return false
+			}
+		} else {
+			if !proto.Equal(m.GetWeightedRouteTables(), target.GetWeightedRouteTables()) {
+				return false
+			}
+		}
+
 	default:
 		// m is nil but target is not nil
 		if m.DelegationType != target.DelegationType {
@@ -474,6 +489,85 @@ func (m *DelegateAction) Equal(that interface{}) bool {
 	return true
 }
 
+// Equal function
+func (m *WeightedRouteTables) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*WeightedRouteTables)
+	if !ok {
+		that2, ok := that.(WeightedRouteTables)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if len(m.GetRouteTables()) != len(target.GetRouteTables()) {
+		return false
+	}
+	for idx, v := range m.GetRouteTables() {
+
+		if h, ok := interface{}(v).(equality.Equalizer); ok {
+			if !h.Equal(target.GetRouteTables()[idx]) {
+				return false
+			}
+		} else {
+			if !proto.Equal(v, target.GetRouteTables()[idx]) {
+				return false
+			}
+		}
+
+	}
+
+	return true
+}
+
+// Equal function
+func (m *WeightedRouteTable) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*WeightedRouteTable)
+	if !ok {
+		that2, ok := that.(WeightedRouteTable)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if h, ok := interface{}(m.GetRef()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetRef()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetRef(), target.GetRef()) {
+			return false
+		}
+	}
+
+	if m.GetWeight() != target.GetWeight() {
+		return false
+	}
+
+	return true
+}
+
 // Equal function
 func (m *RouteTableSelector) Equal(that interface{}) bool {
 	if that == nil {
@@ -29,6 +29,7 @@ import (
 	errors "github.com/rotisserie/eris"
 	gwv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
 	"github.com/solo-io/gloo/projects/gateway/pkg/validation"
+	validationutils "github.com/solo-io/gloo/projects/gloo/pkg/utils/validation"
 	"github.com/solo-io/go-utils/contextutils"
 	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
 	"k8s.io/api/admission/v1beta1"
@@ -174,7 +175,8 @@ type AdmissionRequestWithProxies struct {
 	ReturnProxies bool `json:"returnProxies,omitempty"`
 }
 
-// Validation webhook works properly even if extra fields are provided in the response
+// Validation webhook works properly even if extra fields are provided in the response.
+// When the proxies are returned, the warnings of their validation are returned in the Warnings of the response.
 type AdmissionResponseWithProxies struct {
 	*v1beta1.AdmissionResponse
 	Proxies []*gloov1.Proxy `json:"proxies,omitempty"`
@@ -328,6 +330,11 @@ func (wh *gatewayValidationWebhook) makeAdmissionResponse(ctx context.Context, r
 
 	reports, validationErrs := wh.validate(ctx, gvk, ref, req.Object.Raw, isDelete, dryRun)
 
+	var warnings []string
+	if review.ReturnProxies {
+		warnings = getProxyWarnings(reports)
+	}
+
 	hasUnmarshalErr := false
 	if validationErrs != nil {
 		for _, e := range validationErrs.Errors {
@@ -343,7 +350,8 @@ func (wh *gatewayValidationWebhook) makeAdmissionResponse(ctx context.Context, r
 		incrementMetric(ctx, gvk.String(), ref, mGatewayResourcesAccepted)
 		return &AdmissionResponseWithProxies{
 			AdmissionResponse: &v1beta1.AdmissionResponse{
-				Allowed: true,
+				Allowed:  true,
+				Warnings: warnings,
 			},
 			Proxies: reports.GetProxies(),
 		}
@@ -366,11 +374,25 @@ func (wh *gatewayValidationWebhook) makeAdmissionResponse(ctx context.Context, r
 				Message: finalErr.Error(),
 				Details: details,
 			},
+			Warnings: warnings,
 		},
 		Proxies: reports.GetProxies(),
 	}
 }
 
+// Returns the warnings of the validation of the proxies by Gloo. They make the resources be rejected unless warnings
+// are allowed, in which case they are only reported here.
+func getProxyWarnings(reports *validation.Reports) []string {
+	if reports == nil || reports.ProxyReports == nil {
+		return nil
+	}
+	var warnings []string
+	for _, proxyReport := range *reports.ProxyReports {
+		warnings = append(warnings, validationutils.GetProxyWarning(proxyReport)...)
+	}
+	return warnings
+}
+
 func getFailureCauses(validationErr *multierror.Error) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	for _, e := range validationErr.Errors {
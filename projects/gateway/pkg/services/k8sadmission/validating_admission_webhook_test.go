@@ -212,6 +212,31 @@ var _ = Describe("ValidatingAdmissionWebhook", func() {
 			Expect(review.Response.Allowed).To(BeFalse())
 			Expect(review.Response.Result).ToNot(BeNil())
 		})
+
+		It("returns the warnings of the proxies only if proxies are requested", func() {
+			mv.fValidateGateway = func(ctx context.Context, gw *v1.Gateway, dryRun bool) (*validation.Reports, error) {
+				return warningReports("no upstream"), nil
+			}
+
+			for _, returnProxies := range []bool{true, false} {
+				req, err := makeReviewRequestWithProxies(srv.URL, v1.GatewayCrd, gateway.GroupVersionKind(), v1beta1.Create, gateway, returnProxies)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := srv.Client().Do(req)
+				Expect(err).NotTo(HaveOccurred())
+
+				review, err := parseReviewResponse(res)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(review.Response).NotTo(BeNil())
+
+				Expect(review.Response.Allowed).To(BeTrue())
+				if returnProxies {
+					Expect(review.Response.Warnings).To(ConsistOf("Route Warning: InvalidDestinationWarning. Reason: no upstream"))
+				} else {
+					Expect(review.Response.Warnings).To(BeEmpty())
+				}
+			}
+		})
 	})
 
 	Context("namespace scoping", func() {
@@ -446,3 +471,26 @@ func reports() *validation.Reports {
 		},
 	}
 }
+
+func warningReports(reason string) *validation.Reports {
+	return &validation.Reports{
+		ProxyReports: &validation.ProxyReports{
+			&validation2.ProxyReport{
+				ListenerReports: []*validation2.ListenerReport{{
+					ListenerTypeReport: &validation2.ListenerReport_HttpListenerReport{
+						HttpListenerReport: &validation2.HttpListenerReport{
+							VirtualHostReports: []*validation2.VirtualHostReport{{
+								RouteReports: []*validation2.RouteReport{{
+									Warnings: []*validation2.RouteReport_Warning{{
+										Type:   validation2.RouteReport_Warning_InvalidDestinationWarning,
+										Reason: reason,
+									}},
+								}},
+							}},
+						},
+					},
+				}},
+			},
+		},
+	}
+}
@@ -2,6 +2,8 @@ package translator
 
 import (
 	"fmt"
+	"math"
+	"math/bits"
 	"strings"
 
 	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/transformation"
@@ -55,6 +57,16 @@ var (
 	InvalidRouteTableForDelegateMethodsWarning = func(delegateMethods, childMethods []string) error {
 		return errors.Wrapf(InvalidMethodErr, "required methods: %v, methods: %v", delegateMethods, childMethods)
 	}
+	DelegationNotAllowedErr = func(parent resources.InputResource, routeTable *gatewayv1.RouteTable) error {
+		return errors.Errorf("invalid route: %s %s is not allowed to delegate to route table %s by its delegation policy",
+			resourceKindName(parent), parent.GetMetadata().Ref().Key(), routeTable.GetMetadata().Ref().Key())
+	}
+	MissingWeightedRouteTablesErr = errors.New("invalid route: weighted delegation must specify at least one route " +
+		"table with a non-zero weight")
+	IncompatibleWeightedRouteTablesErr = func(routeTable *gatewayv1.RouteTable, reason string) error {
+		return errors.Errorf("invalid route: route table %s is not compatible with the other weighted route tables: %s",
+			routeTable.GetMetadata().Ref().Key(), reason)
+	}
 	TopLevelVirtualResourceErr = func(rtRef *core.Metadata, err error) error {
 		return errors.Wrapf(err, "on sub route table %s", rtRef.Ref().Key())
 	}
@@ -241,6 +253,28 @@ func (rv *routeVisitor) visit(
 				continue
 			}
 
+			// Weighted delegation merges the route trees of all the route tables into a single one
+			if weighted := action.DelegateAction.GetWeightedRouteTables(); weighted != nil {
+				currentRouteInfo := &routeInfo{
+					matcher:                 delegateMatcher,
+					options:                 routeClone.GetOptions(),
+					name:                    name,
+					hasName:                 routeHasName,
+					inheritableMatchers:     routeClone.GetInheritableMatchers().GetValue(),
+					inheritablePathMatchers: routeClone.GetInheritablePathMatchers().GetValue(),
+				}
+				routes = append(routes, rv.visitWeightedRouteTables(
+					weighted,
+					resource.InputResource(),
+					gateway,
+					proxyName,
+					currentRouteInfo,
+					visitedRouteTables,
+					reporterHelper,
+				)...)
+				continue
+			}
+
 			// Determine the route tables to delegate to
 			routeTables, err := rv.routeTableSelector.SelectRouteTables(action.DelegateAction, resource.InputResource().GetMetadata().GetNamespace())
 			if err != nil {
@@ -248,6 +282,9 @@ func (rv *routeVisitor) visit(
 				continue
 			}
 
+			// Drop the route tables whose delegation policy does not allow this resource to delegate to them
+			routeTables = filterByDelegationPolicy(routeTables, resource.InputResource(), reporterHelper)
+
 			// Default missing weights to 0
 			for _, routeTable := range routeTables {
 				if routeTable.GetWeight() == nil {
@@ -353,6 +390,210 @@ func (rv *routeVisitor) visit(
 	return routes
 }
 
+// Visits each of the weighted route tables and merges the resulting route trees into a single one, in which every
+// route splits traffic between the destinations of the corresponding routes in each tree.
+// Missing route tables result in a warning, like for regular delegation. Any other problem results in an error on the
+// parent resource, since the route trees cannot be merged and the parent explicitly asked for all of them.
+func (rv *routeVisitor) visitWeightedRouteTables(
+	weighted *gatewayv1.WeightedRouteTables,
+	parent resources.InputResource,
+	gateway *gatewayv1.Gateway,
+	proxyName string,
+	parentRoute *routeInfo,
+	visitedRouteTables gatewayv1.RouteTableList,
+	reporterHelper *reporterHelper,
+) []*gloov1.Route {
+	var (
+		routeTables gatewayv1.RouteTableList
+		routeTrees  [][]*gloov1.Route
+		weights     []uint32
+		totalWeight uint64
+	)
+	for _, weightedRouteTable := range weighted.GetRouteTables() {
+		selected, err := rv.routeTableSelector.SelectRouteTables(&gatewayv1.DelegateAction{
+			DelegationType: &gatewayv1.DelegateAction_Ref{Ref: weightedRouteTable.GetRef()},
+		}, parent.GetMetadata().GetNamespace())
+		if err != nil {
+			reporterHelper.addWarning(parent, err)
+			return nil
+		}
+
+		// The error has already been reported if the delegation policy rejects the route table
+		selected = filterByDelegationPolicy(selected, parent, reporterHelper)
+		if len(selected) == 0 {
+			return nil
+		}
+		routeTable := selected[0]
+
+		if err := checkForCycles(routeTable, visitedRouteTables); err != nil {
+			reporterHelper.addError(routeTable, err)
+			return nil
+		}
+
+		visitedRtCopy := append(append([]*gatewayv1.RouteTable{}, visitedRouteTables...), routeTable)
+		subRoutes := rv.visit(
+			&visitableRouteTable{routeTable},
+			gateway,
+			proxyName,
+			parentRoute,
+			visitedRtCopy,
+			reporterHelper,
+		)
+
+		routeTables = append(routeTables, routeTable)
+		routeTrees = append(routeTrees, subRoutes)
+		weights = append(weights, weightedRouteTable.GetWeight())
+		totalWeight += uint64(weightedRouteTable.GetWeight())
+	}
+
+	if totalWeight == 0 {
+		reporterHelper.addError(parent, MissingWeightedRouteTablesErr)
+		return nil
+	}
+
+	routes, err := mergeWeightedRouteTrees(routeTables, routeTrees, weights)
+	if err != nil {
+		reporterHelper.addError(parent, err)
+		return nil
+	}
+	return routes
+}
+
+// Merges the route trees produced by weighted route tables. The trees must contain the same number of routes, and
+// routes in the same position must have the same matchers and options. The name of each merged route is the one of
+// the route in the first tree.
+func mergeWeightedRouteTrees(routeTables gatewayv1.RouteTableList, routeTrees [][]*gloov1.Route, weights []uint32) ([]*gloov1.Route, error) {
+	firstTree := routeTrees[0]
+	for i, tree := range routeTrees {
+		if len(tree) != len(firstTree) {
+			return nil, IncompatibleWeightedRouteTablesErr(routeTables[i],
+				fmt.Sprintf("expected %d routes but found %d", len(firstTree), len(tree)))
+		}
+	}
+
+	var merged []*gloov1.Route
+	for routeIdx, firstRoute := range firstTree {
+		mergedRoute := proto.Clone(firstRoute).(*gloov1.Route)
+		mergedMeta, err := GetSourceMeta(mergedRoute)
+		if err != nil {
+			return nil, err
+		}
+
+		type treeDestinations struct {
+			destinations []*gloov1.WeightedDestination
+			total        uint64
+		}
+		var allDestinations []treeDestinations
+		commonMultiple := uint64(1)
+		for treeIdx, tree := range routeTrees {
+			route := tree[routeIdx]
+			if treeIdx > 0 {
+				if !matchersEqual(route.GetMatchers(), firstRoute.GetMatchers()) {
+					return nil, IncompatibleWeightedRouteTablesErr(routeTables[treeIdx],
+						fmt.Sprintf("route %d has different matchers", routeIdx))
+				}
+				if !proto.Equal(route.GetOptions(), firstRoute.GetOptions()) {
+					return nil, IncompatibleWeightedRouteTablesErr(routeTables[treeIdx],
+						fmt.Sprintf("route %d has different options", routeIdx))
+				}
+				meta, err := GetSourceMeta(route)
+				if err != nil {
+					return nil, err
+				}
+				mergedMeta.Sources = append(mergedMeta.Sources, meta.Sources...)
+			}
+
+			destinations, total := weightedDestinations(route)
+			if total == 0 {
+				return nil, IncompatibleWeightedRouteTablesErr(routeTables[treeIdx],
+					fmt.Sprintf("route %d must route to a single destination or to multiple destinations with a non-zero total weight", routeIdx))
+			}
+			allDestinations = append(allDestinations, treeDestinations{destinations: destinations, total: total})
+			var ok bool
+			if commonMultiple, ok = lcm(commonMultiple, total); !ok {
+				return nil, IncompatibleWeightedRouteTablesErr(routeTables[treeIdx],
+					fmt.Sprintf("the weights of the destinations of route %d are too large to be combined", routeIdx))
+			}
+		}
+
+		// Scale the destination weights so that the share of traffic of each tree is proportional to its weight,
+		// while preserving the relative weights of the destinations within each tree.
+		multi := &gloov1.MultiDestination{}
+		for treeIdx, treeDests := range allDestinations {
+			for _, dest := range treeDests.destinations {
+				weight, ok := mulUint64(uint64(weights[treeIdx])*uint64(dest.GetWeight()), commonMultiple/treeDests.total)
+				if !ok || weight > math.MaxUint32 {
+					return nil, IncompatibleWeightedRouteTablesErr(routeTables[treeIdx],
+						fmt.Sprintf("the weights of the destinations of route %d are too large to be combined", routeIdx))
+				}
+				dest.Weight = uint32(weight)
+				multi.Destinations = append(multi.GetDestinations(), dest)
+			}
+		}
+
+		mergedRoute.Action = &gloov1.Route_RouteAction{
+			RouteAction: &gloov1.RouteAction{
+				Destination: &gloov1.RouteAction_Multi{Multi: multi},
+			},
+		}
+		if err := setObjMeta(mergedRoute, mergedMeta); err != nil {
+			return nil, err
+		}
+		merged = append(merged, mergedRoute)
+	}
+	return merged, nil
+}
+
+// Returns copies of the destinations of the given route along with their total weight.
+// The total weight is 0 if the route cannot take part in a weighted delegation.
+func weightedDestinations(route *gloov1.Route) ([]*gloov1.WeightedDestination, uint64) {
+	switch dest := route.GetRouteAction().GetDestination().(type) {
+	case *gloov1.RouteAction_Single:
+		return []*gloov1.WeightedDestination{{
+			Destination: proto.Clone(dest.Single).(*gloov1.Destination),
+			Weight:      1,
+		}}, 1
+	case *gloov1.RouteAction_Multi:
+		var (
+			destinations []*gloov1.WeightedDestination
+			total        uint64
+		)
+		for _, weightedDest := range dest.Multi.GetDestinations() {
+			destinations = append(destinations, proto.Clone(weightedDest).(*gloov1.WeightedDestination))
+			total += uint64(weightedDest.GetWeight())
+		}
+		return destinations, total
+	default:
+		return nil, 0
+	}
+}
+
+func matchersEqual(a, b []*matchersv1.Matcher) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func lcm(a, b uint64) (uint64, bool) {
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return mulUint64(a/x, b)
+}
+
+// Returns the product of a and b, and false if it overflows.
+func mulUint64(a, b uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(a, b)
+	return lo, hi == 0
+}
+
 // Returns the name of the route and a flag that is true if either the route or the parent route are explicitly named.
 // Route names have the following format: "vs:mygateway_myproxy_myvirtualservice_route:myfirstroute_rt:myroutetable_route:<unnamed-0>"
 func routeName(resource resources.InputResource, gateway *gatewayv1.Gateway, proxyName string, route *gatewayv1.Route, parentRouteInfo *routeInfo, index int) (string, bool) {
@@ -448,6 +689,75 @@ func checkForCycles(toVisit *gatewayv1.RouteTable, visited gatewayv1.RouteTableL
 	return nil
 }
 
+// Returns the subset of the given route tables that the parent resource is allowed to delegate to.
+// The route tables that do not allow the parent, whether they are referenced directly or matched by a selector, result
+// in an error on the parent, and the route table owner is warned about the rejected delegation attempt.
+func filterByDelegationPolicy(
+	routeTables gatewayv1.RouteTableList,
+	parent resources.InputResource,
+	reporterHelper *reporterHelper,
+) gatewayv1.RouteTableList {
+	var allowed gatewayv1.RouteTableList
+	for _, routeTable := range routeTables {
+		if isDelegationAllowed(parent, routeTable) {
+			allowed = append(allowed, routeTable)
+			continue
+		}
+
+		err := DelegationNotAllowedErr(parent, routeTable)
+		reporterHelper.addError(parent, err)
+		reporterHelper.reports.AddWarning(routeTable, err.Error())
+	}
+	return allowed
+}
+
+// Resources can always delegate to route tables in their own namespace. Delegation across namespaces is allowed
+// unless the route table defines a delegation policy that does not include the parent resource.
+func isDelegationAllowed(parent resources.InputResource, routeTable *gatewayv1.RouteTable) bool {
+	policy := routeTable.GetDelegationPolicy()
+	if policy == nil {
+		return true
+	}
+
+	parentNamespace := parent.GetMetadata().GetNamespace()
+	if parentNamespace == routeTable.GetMetadata().GetNamespace() {
+		return true
+	}
+
+	for _, ns := range policy.GetAllowedNamespaces() {
+		if ns == allNamespaceRouteTableSelector || ns == parentNamespace {
+			return true
+		}
+	}
+
+	var allowedRefs []*core.ResourceRef
+	switch parent.(type) {
+	case *gatewayv1.VirtualService:
+		allowedRefs = policy.GetAllowedVirtualServices()
+	case *gatewayv1.RouteTable:
+		allowedRefs = policy.GetAllowedRouteTables()
+	}
+	parentRef := parent.GetMetadata().Ref()
+	for _, ref := range allowedRefs {
+		if ref.GetName() == parentRef.GetName() && ref.GetNamespace() == parentRef.GetNamespace() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func resourceKindName(resource resources.InputResource) string {
+	switch resource.(type) {
+	case *gatewayv1.VirtualService:
+		return "virtual service"
+	case *gatewayv1.RouteTable:
+		return "route table"
+	default:
+		return resources.Kind(resource)
+	}
+}
+
 func getDelegateRouteMatcher(route *gatewayv1.Route) (*matchersv1.Matcher, error) {
 	switch len(route.GetMatchers()) {
 	case 0:
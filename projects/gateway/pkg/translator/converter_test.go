@@ -1,6 +1,9 @@
 package translator_test
 
 import (
+	"fmt"
+	"math"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	. "github.com/onsi/ginkgo"
@@ -1717,6 +1720,103 @@ var _ = Describe("Route converter", func() {
 			})
 		})
 
+		Describe("route tables with a delegation policy", func() {
+
+			var (
+				allowedByNamespace, allowedByVs, forbidden, withoutPolicy *v1.RouteTable
+			)
+
+			BeforeEach(func() {
+				allowedByNamespace = buildRouteTableWithSimpleAction("rt-1", "ns-2", "/foo/1", map[string]string{"foo": "bar"})
+				allowedByNamespace.DelegationPolicy = &v1.DelegationPolicy{
+					AllowedNamespaces: []string{"ns-1"},
+				}
+
+				allowedByVs = buildRouteTableWithSimpleAction("rt-2", "ns-3", "/foo/2", map[string]string{"foo": "bar"})
+				allowedByVs.DelegationPolicy = &v1.DelegationPolicy{
+					AllowedVirtualServices: []*core.ResourceRef{{Name: "vs-1", Namespace: "ns-1"}},
+				}
+
+				forbidden = buildRouteTableWithSimpleAction("rt-3", "ns-4", "/foo/3", map[string]string{"foo": "bar"})
+				forbidden.DelegationPolicy = &v1.DelegationPolicy{
+					AllowedNamespaces:      []string{"ns-5"},
+					AllowedVirtualServices: []*core.ResourceRef{{Name: "vs-1", Namespace: "ns-5"}},
+				}
+
+				withoutPolicy = buildRouteTableWithSimpleAction("rt-4", "ns-5", "/foo/4", map[string]string{"foo": "bar"})
+
+				allRouteTables = v1.RouteTableList{allowedByNamespace, allowedByVs, forbidden, withoutPolicy}
+			})
+
+			It("reports an error when a forbidden route table is selected", func() {
+				vs = buildVirtualService(&v1.RouteTableSelector{
+					Namespaces: []string{"*"},
+					Labels:     map[string]string{"foo": "bar"},
+				})
+
+				converted := visitor.ConvertVirtualService(vs, gw, "proxy1", snapshot, reports)
+				Expect(converted).To(ConsistOf(
+					WithTransform(getFirstPrefixMatcher, Equal("/foo/1")),
+					WithTransform(getFirstPrefixMatcher, Equal("/foo/2")),
+					WithTransform(getFirstPrefixMatcher, Equal("/foo/4")),
+				))
+
+				expectedErr := translator.DelegationNotAllowedErr(vs, forbidden)
+
+				_, vsReport := reports.Find("*v1.VirtualService", vs.Metadata.Ref())
+				Expect(vsReport.Errors).To(MatchError(ContainSubstring(expectedErr.Error())))
+				Expect(vsReport.Warnings).To(BeEmpty())
+
+				_, rtReport := reports.Find("*v1.RouteTable", forbidden.Metadata.Ref())
+				Expect(rtReport.Errors).NotTo(HaveOccurred())
+				Expect(rtReport.Warnings).To(ConsistOf(expectedErr.Error()))
+			})
+
+			It("reports an error when a forbidden route table is referenced directly", func() {
+				vs = buildVirtualService(nil)
+				vs.VirtualHost.Routes[0].GetDelegateAction().DelegationType = &v1.DelegateAction_Ref{
+					Ref: forbidden.GetMetadata().Ref(),
+				}
+
+				converted := visitor.ConvertVirtualService(vs, gw, "proxy1", snapshot, reports)
+				Expect(converted).To(BeEmpty())
+
+				expectedErr := translator.DelegationNotAllowedErr(vs, forbidden)
+
+				_, vsReport := reports.Find("*v1.VirtualService", vs.Metadata.Ref())
+				Expect(vsReport.Errors).To(MatchError(ContainSubstring(expectedErr.Error())))
+
+				_, rtReport := reports.Find("*v1.RouteTable", forbidden.Metadata.Ref())
+				Expect(rtReport.Errors).NotTo(HaveOccurred())
+				Expect(rtReport.Warnings).To(ConsistOf(expectedErr.Error()))
+			})
+
+			It("allows route tables to delegate to route tables that list them", func() {
+				parent := buildRouteTableWithDelegateAction("rt-parent", "ns-6", "/foo", nil,
+					&v1.DelegateAction{
+						DelegationType: &v1.DelegateAction_Ref{
+							Ref: forbidden.GetMetadata().Ref(),
+						},
+					})
+				forbidden.DelegationPolicy.AllowedRouteTables = []*core.ResourceRef{parent.GetMetadata().Ref()}
+				allRouteTables = append(allRouteTables, parent)
+
+				vs = buildVirtualService(nil)
+				vs.VirtualHost.Routes[0].GetDelegateAction().DelegationType = &v1.DelegateAction_Ref{
+					Ref: parent.GetMetadata().Ref(),
+				}
+
+				visitor = translator.NewRouteConverter(
+					translator.NewRouteTableSelector(allRouteTables),
+					translator.NewRouteTableIndexer(),
+				)
+				converted := visitor.ConvertVirtualService(vs, gw, "proxy1", snapshot, reports)
+				Expect(converted).To(HaveLen(1))
+				Expect(converted[0]).To(WithTransform(getFirstPrefixMatcher, Equal("/foo/3")))
+				Expect(reports.Validate()).NotTo(HaveOccurred())
+			})
+		})
+
 		Describe("route tables with weights", func() {
 
 			var rt1, rt2, rt3, rt1a, rt1b, rt3a, rt3b, rt3c *v1.RouteTable
@@ -1786,6 +1886,182 @@ var _ = Describe("Route converter", func() {
 				})
 			})
 		})
+
+		Describe("weighted delegation", func() {
+
+			var stable, canary *v1.RouteTable
+
+			buildUpstreamRouteTable := func(name, upstream string, prefixes ...string) *v1.RouteTable {
+				rt := &v1.RouteTable{
+					Metadata: &core.Metadata{
+						Name:      name,
+						Namespace: "ns-1",
+					},
+				}
+				for _, prefix := range prefixes {
+					rt.Routes = append(rt.Routes, &v1.Route{
+						Matchers: []*matchers.Matcher{{
+							PathSpecifier: &matchers.Matcher_Prefix{
+								Prefix: prefix,
+							},
+						}},
+						Action: &v1.Route_RouteAction{
+							RouteAction: &gloov1.RouteAction{
+								Destination: &gloov1.RouteAction_Single{
+									Single: &gloov1.Destination{
+										DestinationType: &gloov1.Destination_Upstream{
+											Upstream: &core.ResourceRef{Name: upstream, Namespace: "ns-1"},
+										},
+									},
+								},
+							},
+						},
+					})
+				}
+				return rt
+			}
+
+			getUpstreamWeights := func(route *gloov1.Route) map[string]uint32 {
+				weights := map[string]uint32{}
+				for _, dest := range route.GetRouteAction().GetMulti().GetDestinations() {
+					weights[dest.GetDestination().GetUpstream().GetName()] = dest.GetWeight()
+				}
+				return weights
+			}
+
+			BeforeEach(func() {
+				stable = buildUpstreamRouteTable("rt-stable", "us-stable", "/foo/a", "/foo/b")
+				canary = buildUpstreamRouteTable("rt-canary", "us-canary", "/foo/a", "/foo/b")
+				allRouteTables = v1.RouteTableList{stable, canary}
+
+				vs = buildVirtualService(nil)
+				vs.VirtualHost.Routes[0].GetDelegateAction().DelegationType = &v1.DelegateAction_WeightedRouteTables{
+					WeightedRouteTables: &v1.WeightedRouteTables{
+						RouteTables: []*v1.WeightedRouteTable{
+							{Ref: stable.GetMetadata().Ref(), Weight: 90},
+							{Ref: canary.GetMetadata().Ref(), Weight: 10},
+						},
+					},
+				}
+			})
+
+			It("merges the route trees into weighted routes", func() {
+				converted := visitor.ConvertVirtualService(vs, gw, "proxy1", snapshot, reports)
+				Expect(reports.Validate()).NotTo(HaveOccurred())
+				Expect(converted).To(HaveLen(2))
+
+				Expect(converted[0]).To(WithTransform(getFirstPrefixMatcher, Equal("/foo/a")))
+				Expect(getUpstreamWeights(converted[0])).To(Equal(map[string]uint32{"us-stable": 90, "us-canary": 10}))
+				Expect(converted[1]).To(WithTransform(getFirstPrefixMatcher, Equal("/foo/b")))
+				Expect(getUpstreamWeights(converted[1])).To(Equal(map[string]uint32{"us-stable": 90, "us-canary": 10}))
+
+				var sources []string
+				err := translator.ForEachSource(converted[0], func(source translator.SourceRef) error {
+					sources = append(sources, source.ResourceRef.Key())
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sources).To(ConsistOf("ns-1.rt-stable", "ns-1.rt-canary", "ns-1.vs-1"))
+			})
+
+			It("preserves the relative weights of multi destination routes", func() {
+				canary.Routes[0].GetRouteAction().Destination = &gloov1.RouteAction_Multi{
+					Multi: &gloov1.MultiDestination{
+						Destinations: []*gloov1.WeightedDestination{
+							{
+								Destination: &gloov1.Destination{
+									DestinationType: &gloov1.Destination_Upstream{
+										Upstream: &core.ResourceRef{Name: "us-canary-1", Namespace: "ns-1"},
+									},
+								},
+								Weight: 1,
+							},
+							{
+								Destination: &gloov1.Destination{
+									DestinationType: &gloov1.Destination_Upstream{
+										Upstream: &core.ResourceRef{Name: "us-canary-2", Namespace: "ns-1"},
+									},
+								},
+								Weight: 3,
+							},
+						},
+					},
+				}
+
+				converted := visitor.ConvertVirtualService(vs, gw, "proxy1", snapshot, reports)
+				Expect(reports.Validate()).NotTo(HaveOccurred())
+				Expect(converted).To(HaveLen(2))
+				Expect(getUpstreamWeights(converted[0])).To(Equal(map[string]uint32{
+					"us-stable":   360,
+					"us-canary-1": 10,
+					"us-canary-2": 30,
+				}))
+			})
+
+			It("reports an error instead of overflowing if the weights cannot be combined", func() {
+				multiDestination := func(upstream string, weights ...uint32) *gloov1.RouteAction_Multi {
+					multi := &gloov1.MultiDestination{}
+					for i, weight := range weights {
+						multi.Destinations = append(multi.Destinations, &gloov1.WeightedDestination{
+							Destination: &gloov1.Destination{
+								DestinationType: &gloov1.Destination_Upstream{
+									Upstream: &core.ResourceRef{Name: fmt.Sprintf("%s-%d", upstream, i), Namespace: "ns-1"},
+								},
+							},
+							Weight: weight,
+						})
+					}
+					return &gloov1.RouteAction_Multi{Multi: multi}
+				}
+				// the total weights of the trees are coprime, and their least common multiple does not fit in 64 bits
+				stable.Routes[0].GetRouteAction().Destination = multiDestination("us-stable", math.MaxUint32, math.MaxUint32-1)
+				canary.Routes[0].GetRouteAction().Destination = multiDestination("us-canary", math.MaxUint32-4, math.MaxUint32-16)
+
+				converted := visitor.ConvertVirtualService(vs, gw, "proxy1", snapshot, reports)
+				Expect(converted).To(BeEmpty())
+
+				_, vsReport := reports.Find("*v1.VirtualService", vs.Metadata.Ref())
+				Expect(vsReport.Errors).To(MatchError(ContainSubstring(
+					translator.IncompatibleWeightedRouteTablesErr(canary, "the weights of the destinations of route 0 are too large to be combined").Error())))
+			})
+
+			It("reports an error if the route trees are not compatible", func() {
+				canary.Routes = canary.Routes[:1]
+
+				converted := visitor.ConvertVirtualService(vs, gw, "proxy1", snapshot, reports)
+				Expect(converted).To(BeEmpty())
+
+				_, vsReport := reports.Find("*v1.VirtualService", vs.Metadata.Ref())
+				Expect(vsReport.Errors).To(MatchError(ContainSubstring(
+					translator.IncompatibleWeightedRouteTablesErr(canary, "expected 2 routes but found 1").Error())))
+			})
+
+			It("reports an error if the matchers differ", func() {
+				canary.Routes[1].GetMatchers()[0].PathSpecifier = &matchers.Matcher_Prefix{Prefix: "/foo/c"}
+
+				converted := visitor.ConvertVirtualService(vs, gw, "proxy1", snapshot, reports)
+				Expect(converted).To(BeEmpty())
+
+				_, vsReport := reports.Find("*v1.VirtualService", vs.Metadata.Ref())
+				Expect(vsReport.Errors).To(MatchError(ContainSubstring(
+					translator.IncompatibleWeightedRouteTablesErr(canary, "route 1 has different matchers").Error())))
+			})
+
+			It("reports a warning if a route table is missing", func() {
+				allRouteTables = v1.RouteTableList{stable}
+
+				visitor = translator.NewRouteConverter(
+					translator.NewRouteTableSelector(allRouteTables),
+					translator.NewRouteTableIndexer(),
+				)
+				converted := visitor.ConvertVirtualService(vs, gw, "proxy1", snapshot, reports)
+				Expect(converted).To(BeEmpty())
+
+				_, vsReport := reports.Find("*v1.VirtualService", vs.Metadata.Ref())
+				Expect(vsReport.Errors).NotTo(HaveOccurred())
+				Expect(vsReport.Warnings).To(ConsistOf(translator.RouteTableMissingWarning(*canary.GetMetadata().Ref()).Error()))
+			})
+		})
 	})
 })
 
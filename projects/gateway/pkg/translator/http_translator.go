@@ -3,6 +3,7 @@ package translator
 import (
 	"context"
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 
@@ -13,9 +14,11 @@ import (
 	v1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
 	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
 	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/core/matchers"
-	"github.com/solo-io/gloo/projects/gloo/pkg/utils"
 	"github.com/solo-io/go-utils/contextutils"
 	"github.com/solo-io/go-utils/hashutils"
+	"github.com/solo-io/go-utils/stringutils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
 	"github.com/solo-io/solo-kit/pkg/api/v2/reporter"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -63,6 +66,14 @@ var (
 		return errors.Errorf("virtual host [%s] has unordered regex routes, earlier regex [%s] short-circuited "+
 			"later route [%v]", vh, regex, matcher)
 	}
+	UnorderedExactErr = func(vh, path string, matcher *matchers.Matcher) error {
+		return errors.Errorf("virtual host [%s] has unordered exact path routes, earlier exact path [%s] "+
+			"short-circuited later route [%v]", vh, path, matcher)
+	}
+	ShortCircuitingRouteSourceErr = func(err error, earlierSource resources.InputResource) error {
+		return errors.Errorf("%v, the earlier route is defined on %s %s", err, resourceKindName(earlierSource),
+			earlierSource.GetMetadata().Ref().Key())
+	}
 
 	VirtualServiceSelectorInvalidExpressionWarning = errors.New("the virtual service selector expression is invalid")
 	// Map connecting Gloo Virtual Services expression operator values and Kubernetes expression operator string values.
@@ -130,6 +141,7 @@ func (t *HttpTranslator) ComputeHttpListener(
 	proxyName string,
 ) *gloov1.HttpListener {
 	applyGlobalVirtualServiceSettings(params.ctx, virtualServicesForHttpGateway)
+	applyAcmeDefaults(virtualServicesForHttpGateway)
 	validateVirtualServiceDomains(parentGateway, virtualServicesForHttpGateway, params.reports)
 
 	return &gloov1.HttpListener{
@@ -149,6 +161,38 @@ func applyGlobalVirtualServiceSettings(ctx context.Context, virtualServices v1.V
 	}
 }
 
+// If not defined on the ACME ssl config of a virtual service, the certificate is issued for the SNI domains, or else
+// the domains of the virtual service, and stored in a secret named after the virtual service.
+func applyAcmeDefaults(virtualServices v1.VirtualServiceList) {
+	for _, vs := range virtualServices {
+		acme := vs.GetSslConfig().GetAcme()
+		if acme == nil {
+			continue
+		}
+		if acme.GetSecretRef() == nil {
+			acme.SecretRef = &core.ResourceRef{
+				Name:      vs.GetMetadata().GetName() + "-acme",
+				Namespace: vs.GetMetadata().GetNamespace(),
+			}
+		}
+		if len(acme.GetDomains()) == 0 {
+			domains := vs.GetSslConfig().GetSniDomains()
+			if len(domains) == 0 {
+				domains = vs.GetVirtualHost().GetDomains()
+			}
+			for _, domain := range domains {
+				if host, _, err := net.SplitHostPort(domain); err == nil {
+					domain = host
+				}
+				// wildcard domains cannot be validated with the HTTP-01 challenge
+				if !strings.Contains(domain, "*") && !stringutils.ContainsString(domain, acme.GetDomains()) {
+					acme.Domains = append(acme.GetDomains(), domain)
+				}
+			}
+		}
+	}
+}
+
 // Errors will be added to the report object.
 func validateVirtualServiceDomains(gateway *v1.Gateway, virtualServices v1.VirtualServiceList, reports reporter.ResourceReports) {
 
@@ -338,7 +382,7 @@ func (t *HttpTranslator) virtualServiceToVirtualHost(vs *v1.VirtualService, gate
 	validateRoutes(vs, vh, reports)
 
 	if t.WarnOnRouteShortCircuiting {
-		validateRouteShortCircuiting(vs, vh, reports)
+		validateRouteShortCircuiting(vs, vh, snapshot, reports)
 	}
 
 	if err := appendSource(vh, vs); err != nil {
@@ -385,20 +429,19 @@ func validateRoutes(vs *v1.VirtualService, vh *gloov1.VirtualHost, reports repor
 
 // this function is written with the assumption that the routes will not be modified afterwards,
 // and are in their final sorted form
-func validateRouteShortCircuiting(vs *v1.VirtualService, vh *gloov1.VirtualHost, reports reporter.ResourceReports) {
-	validateAnyDuplicateMatchers(vs, vh, reports)
-	validatePrefixHijacking(vs, vh, reports)
-	validateRegexHijacking(vs, vh, reports)
+func validateRouteShortCircuiting(vs *v1.VirtualService, vh *gloov1.VirtualHost, snapshot *v1.ApiSnapshot, reports reporter.ResourceReports) {
+	validateAnyDuplicateMatchers(vs, vh, snapshot, reports)
+	validateMatcherShortCircuiting(vs, vh, snapshot, reports)
 }
 
-func validateAnyDuplicateMatchers(vs *v1.VirtualService, vh *gloov1.VirtualHost, reports reporter.ResourceReports) {
+func validateAnyDuplicateMatchers(vs *v1.VirtualService, vh *gloov1.VirtualHost, snapshot *v1.ApiSnapshot, reports reporter.ResourceReports) {
 	// warn on duplicate matchers
 	seenMatchers := make(map[uint64]bool)
 	for _, rt := range vh.GetRoutes() {
 		for _, matcher := range rt.GetMatchers() {
 			hash := hashutils.MustHash(matcher)
 			if _, ok := seenMatchers[hash]; ok == true {
-				reports.AddWarning(vs, ConflictingMatcherErr(vh.GetName(), matcher).Error())
+				reports.AddWarning(routeSource(rt, vs, snapshot), ConflictingMatcherErr(vh.GetName(), matcher).Error())
 			} else {
 				seenMatchers[hash] = true
 			}
@@ -406,269 +449,63 @@ func validateAnyDuplicateMatchers(vs *v1.VirtualService, vh *gloov1.VirtualHost,
 	}
 }
 
-func validatePrefixHijacking(vs *v1.VirtualService, vh *gloov1.VirtualHost, reports reporter.ResourceReports) {
-	// warn on early prefix matchers that short-circuit later routes
-
-	var seenPrefixMatchers []*matchers.Matcher
-	for _, rt := range vh.GetRoutes() {
-		for _, matcher := range rt.GetMatchers() {
-			// make sure the current matcher doesn't match any previously defined prefix.
-			// this code is written with the assumption that the routes are already in their final order;
-			// we are trying to help users avoid misconfiguration and short-circuiting errors
-			for _, prefix := range seenPrefixMatchers {
-				if prefixShortCircuits(matcher, prefix) && nonPathEarlyMatcherShortCircuitsLateMatcher(matcher, prefix) {
-					reports.AddWarning(vs, UnorderedPrefixErr(vh.GetName(), prefix.GetPrefix(), matcher).Error())
-				}
-			}
-			if matcher.GetPrefix() != "" {
-				seenPrefixMatchers = append(seenPrefixMatchers, matcher)
-			}
-		}
-	}
+// a matcher seen on an earlier route, along with the resource which defines the route
+type sourcedMatcher struct {
+	matcher *matchers.Matcher
+	source  resources.InputResource
 }
 
-func prefixShortCircuits(laterMatcher, earlierMatcher *matchers.Matcher) bool {
-	laterPath := utils.PathAsString(laterMatcher)
-	return strings.HasPrefix(laterPath, earlierMatcher.GetPrefix()) && laterMatcher.GetCaseSensitive() == earlierMatcher.GetCaseSensitive()
-}
-
-func validateRegexHijacking(vs *v1.VirtualService, vh *gloov1.VirtualHost, reports reporter.ResourceReports) {
-	// warn on early regex matchers that short-circuit later routes
-
-	var seenRegexMatchers []*matchers.Matcher
+func validateMatcherShortCircuiting(vs *v1.VirtualService, vh *gloov1.VirtualHost, snapshot *v1.ApiSnapshot, reports reporter.ResourceReports) {
+	// warn on early matchers that short-circuit later routes.
+	// this code is written with the assumption that the routes are already in their final order;
+	// we are trying to help users avoid misconfiguration and short-circuiting errors
+	coverage := newMatcherCoverage()
+	var seenMatchers []sourcedMatcher
 	for _, rt := range vh.GetRoutes() {
+		source := routeSource(rt, vs, snapshot)
 		for _, matcher := range rt.GetMatchers() {
-			if matcher.GetRegex() != "" {
-				seenRegexMatchers = append(seenRegexMatchers, matcher)
-			} else {
-				// make sure the current matcher doesn't match any previously defined regex.
-				// this code is written with the assumption that the routes are already in their final order;
-				// we are trying to help users avoid misconfiguration and short-circuiting errors
-				for _, regex := range seenRegexMatchers {
-					if regexShortCircuits(matcher, regex) && nonPathEarlyMatcherShortCircuitsLateMatcher(matcher, regex) {
-						reports.AddWarning(vs, UnorderedRegexErr(vh.GetName(), regex.GetRegex(), matcher).Error())
-					}
+			// only the first matcher which short-circuits the current one is reported, as it is the one envoy selects
+			for _, earlier := range seenMatchers {
+				if !coverage.shortCircuits(earlier.matcher, matcher) {
+					continue
 				}
-			}
-		}
-	}
-}
-
-func regexShortCircuits(laterMatcher, earlierMatcher *matchers.Matcher) bool {
-	laterPath := utils.PathAsString(laterMatcher)
-	re, err := regexp.Compile(earlierMatcher.GetRegex())
-	if err != nil {
-		// invalid regex should already be reported on the virtual service
-		return false
-	}
-	foundIndex := re.FindStringIndex(laterPath)
-	// later matcher is always non-regex. to validate against the regex, we need to ensure that it's either
-	// unset or set to false
-	return foundIndex != nil && laterMatcher.GetCaseSensitive().GetValue() == false
-}
-
-// As future matcher APIs get added, this validation will need to be updated as well.
-// If it gets too complex, consider modeling as a constraint satisfaction problem.
-func nonPathEarlyMatcherShortCircuitsLateMatcher(laterMatcher, earlierMatcher *matchers.Matcher) bool {
-
-	// we play a trick here to validate the methods by writing them as header
-	// matchers and just reusing the header matcher logic
-	earlyMatcher := *earlierMatcher
-	if len(earlyMatcher.GetMethods()) > 0 {
-		earlyMatcher.Headers = append(earlyMatcher.GetHeaders(), &matchers.HeaderMatcher{
-			Name:  ":method",
-			Value: fmt.Sprintf("(%s)", strings.Join(earlyMatcher.GetMethods(), "|")),
-			Regex: true,
-		})
-	}
-
-	lateMatcher := *laterMatcher
-	if len(lateMatcher.GetMethods()) > 0 {
-		lateMatcher.Headers = append(lateMatcher.GetHeaders(), &matchers.HeaderMatcher{
-			Name:  ":method",
-			Value: fmt.Sprintf("(%s)", strings.Join(lateMatcher.GetMethods(), "|")),
-			Regex: true,
-		})
-	}
-
-	queryParamsShortCircuited := earlyQueryParametersShortCircuitedLaterOnes(lateMatcher, earlyMatcher)
-	headersShortCircuited := earlyHeaderMatchersShortCircuitLaterOnes(lateMatcher, earlyMatcher)
-	return queryParamsShortCircuited && headersShortCircuited
-}
-
-// returns true if the query parameter matcher conditions (or lack thereof) on the early matcher can completely
-// short-circuit the query parameter matcher conditions of the latter.
-func earlyQueryParametersShortCircuitedLaterOnes(laterMatcher, earlyMatcher matchers.Matcher) bool {
-	for _, earlyQpm := range earlyMatcher.GetQueryParameters() {
-
-		foundOverlappingCondition := false
-		for _, laterQpm := range laterMatcher.GetQueryParameters() {
-			if earlyQpm.GetName() == laterQpm.GetName() {
-				// we found an overlapping condition
-				foundOverlappingCondition = true
-
-				// let's check if the early condition overlaps the later one
-				if earlyQpm.GetRegex() && !laterQpm.GetRegex() {
-					re, err := regexp.Compile(earlyQpm.GetValue())
-					if err != nil {
-						// invalid regex should already be reported on the virtual service
-						return false
-					}
-					foundIndex := re.FindStringIndex(laterQpm.GetValue())
-					if foundIndex == nil {
-						// early regex doesn't capture the later matcher
-						return false
-					}
-				} else if !earlyQpm.GetRegex() && !laterQpm.GetRegex() {
-					matches := earlyQpm.GetValue() == laterQpm.GetValue() || earlyQpm.GetValue() == ""
-					if !matches {
-						// early and late have non-compatible conditions on the same query parameter matcher
-						return false
-					}
-				} else {
-					// either:
-					//   - early header match is regex and late header match is regex
-					//   - or early header match is not regex but late header match is regex
-					// in both cases, we can't validate the constraint properly, so we mark
-					// the route as not short-circuited to avoid reporting flawed warnings.
-					return false
+				err := shortCircuitedMatcherErr(vh.GetName(), earlier.matcher, matcher)
+				if earlier.source != source {
+					err = ShortCircuitingRouteSourceErr(err, earlier.source)
 				}
+				reports.AddWarning(source, err.Error())
+				break
 			}
-		}
-
-		if !foundOverlappingCondition {
-			// by default, this matcher cannot short-circuit because it is more specific
-			return false
+			seenMatchers = append(seenMatchers, sourcedMatcher{matcher: matcher, source: source})
 		}
 	}
-
-	// every single qpm matcher defined on the later matcher was short-circuited
-	return true
 }
 
-// returns true if the header matcher conditions (or lack thereof) on the early matcher can completely short-circuit
-// the header matcher conditions of the latter.
-func earlyHeaderMatchersShortCircuitLaterOnes(laterMatcher, earlyMatcher matchers.Matcher) bool {
-	for _, earlyHeaderMatcher := range earlyMatcher.GetHeaders() {
-
-		foundOverlappingCondition := false
-
-		for _, laterHeaderMatcher := range laterMatcher.GetHeaders() {
-			if earlyHeaderMatcher.GetName() == laterHeaderMatcher.GetName() {
-				// we found an overlapping condition
-				foundOverlappingCondition = true
-
-				// let's check if the early condition overlaps the later one
-				if earlyHeaderMatcher.GetRegex() && !laterHeaderMatcher.GetRegex() {
-					re, err := regexp.Compile(earlyHeaderMatcher.GetValue())
-					if err != nil {
-						// invalid regex should already be reported on the virtual service
-						return false
-					}
-					foundIndex := re.FindStringIndex(laterHeaderMatcher.GetValue())
-					if foundIndex == nil && !earlyHeaderMatcher.GetInvertMatch() {
-						// early regex doesn't capture the later matcher
-						return false
-					} else if foundIndex != nil && earlyHeaderMatcher.GetInvertMatch() {
-						// early regex captures the later matcher, but we invert the result.
-						// so there are conflicting conditions here on the same matcher
-						return false
-					}
-				} else if !earlyHeaderMatcher.GetRegex() && !laterHeaderMatcher.GetRegex() {
-					matches := earlyHeaderMatcher.GetValue() == laterHeaderMatcher.GetValue() || earlyHeaderMatcher.GetValue() == ""
-					if !matches && !earlyHeaderMatcher.GetInvertMatch() {
-						// early and late have non-compatible conditions on the same header matcher
-						return false
-					} else if matches && earlyHeaderMatcher.GetInvertMatch() {
-						// early and late have compatible conditions on the same header matcher, but we invert
-						// the result. so there are conflicting conditions here on the same matcher
-						return false
-					}
-				} else {
-					// either:
-					//   - early header match is regex and late header match is regex
-					//   - or early header match is not regex but late header match is regex
-					// in both cases, we can't validate the constraint properly, so we mark
-					// the route as not short-circuited to avoid reporting flawed warnings.
-
-					if laterOrRegexPartiallyShortCircuited(laterHeaderMatcher, earlyHeaderMatcher) {
-						continue
-					}
-
-					return false
-				}
-			}
-		}
-
-		if !foundOverlappingCondition {
-			// by default, this matcher cannot short-circuit because it is more specific
-			return false
-		}
+func shortCircuitedMatcherErr(vh string, earlier, later *matchers.Matcher) error {
+	switch path := earlier.GetPathSpecifier().(type) {
+	case *matchers.Matcher_Regex:
+		return UnorderedRegexErr(vh, path.Regex, later)
+	case *matchers.Matcher_Exact:
+		return UnorderedExactErr(vh, path.Exact, later)
 	}
-
-	// every single header matcher defined on the later matcher was short-circuited
-	return true
+	return UnorderedPrefixErr(vh, earlier.GetPrefix(), later)
 }
 
-// special case to catch the following:
-//	- matchers:
-//	  - prefix: /foo
-//      headers:
-//	    - name: :method
-//        value: GET
-//        invertMatch: true
-//    directResponseAction:
-//      status: 405
-//      body: 'Invalid HTTP Method'
-//	...
-//	- matchers:
-//	  - methods:
-//	    - GET
-//	    - POST # this one cannot be reached
-//      prefix: /foo
-//    routeAction:
-//	    ....
-func laterOrRegexPartiallyShortCircuited(laterHeaderMatcher, earlyHeaderMatcher *matchers.HeaderMatcher) bool {
-
-	// regex matches simple OR regex, e.g. (GET|POST|...)
-	re, err := regexp.Compile("^\\([\\w]+([|[\\w]+)+\\)$")
+// Returns the resource which defines the route: the innermost route table for delegated routes, the virtual service
+// otherwise.
+func routeSource(route *gloov1.Route, vs *v1.VirtualService, snapshot *v1.ApiSnapshot) resources.InputResource {
+	meta, err := GetSourceMeta(route)
 	if err != nil {
-		// invalid regex should already be reported on the virtual service
-		return false
+		return vs
 	}
-	foundIndex := re.FindStringIndex(laterHeaderMatcher.GetValue())
-	if foundIndex != nil {
-
-		// regex matches, is a simple OR. we can try to do some additional validation
-
-		matches := strings.Split(laterHeaderMatcher.GetValue()[1:len(laterHeaderMatcher.GetValue())-1], "|")
-		shortCircuitedMatchExists := false
-
-		for _, match := range matches {
-			if earlyHeaderMatcher.GetRegex() {
-				re, err := regexp.Compile(earlyHeaderMatcher.GetValue())
-				if err != nil {
-					// invalid regex should already be reported on the virtual service
-					return false
-				}
-				foundIndex := re.FindStringIndex(match)
-				if foundIndex != nil && !earlyHeaderMatcher.GetInvertMatch() ||
-					foundIndex == nil && earlyHeaderMatcher.GetInvertMatch() {
-					// one of the OR'ed conditions cannot be reached, likely an error!
-					shortCircuitedMatchExists = true
-				}
-			} else {
-				if match == earlyHeaderMatcher.GetValue() && !earlyHeaderMatcher.GetInvertMatch() ||
-					match != earlyHeaderMatcher.GetValue() && earlyHeaderMatcher.GetInvertMatch() {
-					// one of the OR'ed conditions cannot be reached, likely an error!
-					shortCircuitedMatchExists = true
-				}
-			}
+	// the sources of delegated routes are ordered from the innermost route table
+	for _, src := range meta.Sources {
+		if src.ResourceKind != resources.Kind(&v1.RouteTable{}) {
+			continue
 		}
-
-		if shortCircuitedMatchExists {
-			return true
+		if rt, err := snapshot.RouteTables.Find(src.GetNamespace(), src.GetName()); err == nil {
+			return rt
 		}
 	}
-	return false
+	return vs
 }
@@ -437,6 +437,49 @@ var _ = Describe("Http Translator", func() {
 
 		})
 
+		Context("acme ssl config", func() {
+
+			It("defaults the secret and the domains of the certificate to the virtual service", func() {
+				snap.Gateways[0].Ssl = true
+				snap.VirtualServices[0].VirtualHost.Domains = []string{"d1.com", "d1.com:8443", "*.d1.com"}
+				snap.VirtualServices[0].SslConfig = &gloov1.SslConfig{
+					SslSecrets: &gloov1.SslConfig_Acme{
+						Acme: &gloov1.AcmeConfig{AcceptTermsOfService: true},
+					},
+				}
+				params := NewTranslatorParams(ctx, snap, reports)
+
+				listener := translator.ComputeListener(params, defaults.GatewayProxyName, snap.Gateways[0])
+				Expect(listener).NotTo(BeNil())
+				Expect(reports.ValidateStrict()).NotTo(HaveOccurred())
+
+				acme := listener.GetSslConfigurations()[0].GetAcme()
+				Expect(acme.GetSecretRef()).To(Equal(&core.ResourceRef{Name: "name1-acme", Namespace: ns}))
+				Expect(acme.GetDomains()).To(Equal([]string{"d1.com"}))
+			})
+
+			It("prefers the sni domains and keeps what is set explicitly", func() {
+				snap.Gateways[0].Ssl = true
+				snap.VirtualServices[0].SslConfig = &gloov1.SslConfig{
+					SniDomains: []string{"sni.d1.com"},
+					SslSecrets: &gloov1.SslConfig_Acme{
+						Acme: &gloov1.AcmeConfig{
+							AcceptTermsOfService: true,
+							SecretRef:            &core.ResourceRef{Name: "custom", Namespace: "gloo-system"},
+						},
+					},
+				}
+				params := NewTranslatorParams(ctx, snap, reports)
+
+				listener := translator.ComputeListener(params, defaults.GatewayProxyName, snap.Gateways[0])
+				Expect(listener).NotTo(BeNil())
+
+				acme := listener.GetSslConfigurations()[0].GetAcme()
+				Expect(acme.GetSecretRef()).To(Equal(&core.ResourceRef{Name: "custom", Namespace: "gloo-system"}))
+				Expect(acme.GetDomains()).To(Equal([]string{"sni.d1.com"}))
+			})
+		})
+
 		It("should not have vhosts with ssl", func() {
 			snap.VirtualServices[0].SslConfig = new(gloov1.SslConfig)
 			params := NewTranslatorParams(ctx, snap, reports)
@@ -652,27 +695,83 @@ var _ = Describe("Http Translator", func() {
 					nil),
 				Entry("regex hijacking",
 					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/foo/.*/bar"}},
-					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo/user/info/bar"}},
-					UnorderedRegexErr("gloo-system.name1", "/foo/.*/bar", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo/user/info/bar"}})),
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo/user/info/bar"}},
+					UnorderedRegexErr("gloo-system.name1", "/foo/.*/bar", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo/user/info/bar"}})),
 				Entry("regex hijacking - with match all header matcher",
 					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/foo/.*/bar"}, Headers: []*matchers.HeaderMatcher{{Name: "foo", Value: ""}}}, // empty value will match anything
-					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo/user/info/bar"}, Headers: []*matchers.HeaderMatcher{{Name: "foo", Value: "bar"}}},
-					UnorderedRegexErr("gloo-system.name1", "/foo/.*/bar", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo/user/info/bar"}, Headers: []*matchers.HeaderMatcher{{Name: "foo", Value: "bar"}}})),
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo/user/info/bar"}, Headers: []*matchers.HeaderMatcher{{Name: "foo", Value: "bar"}}},
+					UnorderedRegexErr("gloo-system.name1", "/foo/.*/bar", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo/user/info/bar"}, Headers: []*matchers.HeaderMatcher{{Name: "foo", Value: "bar"}}})),
 				Entry("regex hijacking - with match all query parameter matcher",
 					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/foo/.*/bar"}, QueryParameters: []*matchers.QueryParameterMatcher{{Name: "foo", Value: ""}}}, // empty value will match anything
-					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo/user/info/bar"}, QueryParameters: []*matchers.QueryParameterMatcher{{Name: "foo", Value: "bar"}}},
-					UnorderedRegexErr("gloo-system.name1", "/foo/.*/bar", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo/user/info/bar"}, QueryParameters: []*matchers.QueryParameterMatcher{{Name: "foo", Value: "bar"}}})),
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo/user/info/bar"}, QueryParameters: []*matchers.QueryParameterMatcher{{Name: "foo", Value: "bar"}}},
+					UnorderedRegexErr("gloo-system.name1", "/foo/.*/bar", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo/user/info/bar"}, QueryParameters: []*matchers.QueryParameterMatcher{{Name: "foo", Value: "bar"}}})),
+				Entry("regex does not hijack a prefix it only partially matches",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/foo/.*/bar"}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo/user/info/bar"}}, // "/foo/user/info/bar/baz" is reachable
+					nil),
+				Entry("regex hijacking - regex covers later prefix",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/foo/.*"}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo/bar"}},
+					UnorderedRegexErr("gloo-system.name1", "/foo/.*", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo/bar"}})),
+				Entry("regex hijacking - regex covers later regex",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/users/[0-9a-z]+"}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/users/[0-9]{1,3}"}},
+					UnorderedRegexErr("gloo-system.name1", "/users/[0-9a-z]+", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/users/[0-9]{1,3}"}})),
+				Entry("regex does not hijack a regex matching other paths",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/users/[0-9]+"}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/users/[0-9a-z]+"}},
+					nil),
+				Entry("exact hijacking",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo"}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo"}, Methods: []string{"GET"}},
+					UnorderedExactErr("gloo-system.name1", "/foo", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo"}, Methods: []string{"GET"}})),
+				Entry("exact hijacking - case insensitive exact covers case sensitive regex",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo"}, CaseSensitive: &wrappers.BoolValue{Value: false}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/(f|F)oo"}},
+					UnorderedExactErr("gloo-system.name1", "/foo", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/(f|F)oo"}})),
+				Entry("exact does not hijack a prefix",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo/bar"}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo"}},
+					nil),
+				Entry("prefix hijacking - case insensitive prefix covers case sensitive one",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo"}, CaseSensitive: &wrappers.BoolValue{Value: false}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/FOO/bar"}},
+					UnorderedPrefixErr("gloo-system.name1", "/foo", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/FOO/bar"}})),
+				Entry("prefix hijacking - prefix covers regex",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/api/"}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/api/v[0-9]+/.*"}},
+					UnorderedPrefixErr("gloo-system.name1", "/api/", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/api/v[0-9]+/.*"}})),
+				Entry("prefix hijacking - earlier methods cover later methods",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, Methods: []string{"GET", "POST", "PUT"}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, Methods: []string{"POST", "GET"}},
+					UnorderedPrefixErr("gloo-system.name1", "/1", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, Methods: []string{"POST", "GET"}})),
+				Entry("earlier methods don't cover later methods",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, Methods: []string{"GET"}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, Methods: []string{"DELETE"}},
+					nil),
+				Entry("prefix hijacking - header regex covers later header value",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, Headers: []*matchers.HeaderMatcher{{Name: "x-version", Value: "v[0-9]+", Regex: true}}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, Headers: []*matchers.HeaderMatcher{{Name: "X-Version", Value: "v2"}}},
+					UnorderedPrefixErr("gloo-system.name1", "/1", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, Headers: []*matchers.HeaderMatcher{{Name: "X-Version", Value: "v2"}}})),
+				Entry("prefix hijacking - query parameter regex covers later query parameter regex",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, QueryParameters: []*matchers.QueryParameterMatcher{{Name: "id", Value: "[0-9]+", Regex: true}}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, QueryParameters: []*matchers.QueryParameterMatcher{{Name: "id", Value: "1[0-9]", Regex: true}}},
+					UnorderedPrefixErr("gloo-system.name1", "/1", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, QueryParameters: []*matchers.QueryParameterMatcher{{Name: "id", Value: "1[0-9]", Regex: true}}})),
+				Entry("query parameter regex doesn't cover later query parameter value",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, QueryParameters: []*matchers.QueryParameterMatcher{{Name: "id", Value: "[0-9]+", Regex: true}}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/1"}, QueryParameters: []*matchers.QueryParameterMatcher{{Name: "id", Value: "abc"}}},
+					nil),
 				Entry("prefix hijacking - handles case sensitive",
 					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo"}, CaseSensitive: &wrappers.BoolValue{Value: true}},
 					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo"}, CaseSensitive: &wrappers.BoolValue{Value: false}},
 					nil),
 				Entry("regex hijacking - handles case sensitive (by ignoring it if set on the regex, since envoy will ignore case sensitive on regex routes)",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/foo/.*/bar"}, CaseSensitive: &wrappers.BoolValue{Value: false}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo/user/info/bar"}},
+					UnorderedRegexErr("gloo-system.name1", "/foo/.*/bar", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo/user/info/bar"}})),
+				Entry("regex hijacking - handles case sensitive (a case sensitive regex doesn't cover a case insensitive path)",
 					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/foo/.*/bar"}, CaseSensitive: &wrappers.BoolValue{Value: true}},
-					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo/user/info/bar"}, CaseSensitive: &wrappers.BoolValue{Value: false}},
-					UnorderedRegexErr("gloo-system.name1", "/foo/.*/bar", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo/user/info/bar"}, CaseSensitive: &wrappers.BoolValue{Value: false}})),
-				Entry("regex hijacking - handles case sensitive (by skipping validation; we can't validate case insensitive against a regex)",
-					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/foo/.*/bar"}, CaseSensitive: &wrappers.BoolValue{Value: true}},
-					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo/user/info/bar"}, CaseSensitive: &wrappers.BoolValue{Value: true}},
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo/user/info/bar"}, CaseSensitive: &wrappers.BoolValue{Value: false}},
 					nil),
 				Entry("inverted header matcher hijacks possible method matchers",
 					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/foo"},
@@ -1300,6 +1399,78 @@ var _ = Describe("Http Translator", func() {
 			})
 		})
 
+		Context("route short-circuiting", func() {
+
+			BeforeEach(func() {
+				translator = &HttpTranslator{
+					WarnOnRouteShortCircuiting: true,
+				}
+				directResponse := func(body string) *v1.Route_DirectResponseAction {
+					return &v1.Route_DirectResponseAction{DirectResponseAction: &gloov1.DirectResponseAction{Body: body}}
+				}
+				snap = &v1.ApiSnapshot{
+					Gateways: v1.GatewayList{
+						{
+							Metadata: &core.Metadata{Namespace: ns, Name: "name"},
+							GatewayType: &v1.Gateway_HttpGateway{
+								HttpGateway: &v1.HttpGateway{},
+							},
+							BindPort: 2,
+						},
+					},
+					VirtualServices: v1.VirtualServiceList{
+						{
+							Metadata: &core.Metadata{Namespace: ns, Name: "vs"},
+							VirtualHost: &v1.VirtualHost{
+								Domains: []string{"d1.com"},
+								Routes: []*v1.Route{
+									{
+										Matchers: []*matchers.Matcher{{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/a"}}},
+										Action:   directResponse("vs"),
+									},
+									{
+										Matchers: []*matchers.Matcher{{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/a/b"}}},
+										Action: &v1.Route_DelegateAction{
+											DelegateAction: &v1.DelegateAction{
+												DelegationType: &v1.DelegateAction_Ref{
+													Ref: &core.ResourceRef{Name: "delegate", Namespace: ns},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					RouteTables: []*v1.RouteTable{
+						{
+							Metadata: &core.Metadata{Name: "delegate", Namespace: ns},
+							Routes: []*v1.Route{
+								{
+									Matchers: []*matchers.Matcher{{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/a/b/c"}}},
+									Action:   directResponse("rt"),
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It("reports the warning on the route table defining the short-circuited route", func() {
+				params := NewTranslatorParams(ctx, snap, reports)
+
+				_ = translator.ComputeListener(params, defaults.GatewayProxyName, snap.Gateways[0])
+				Expect(reports.Validate()).NotTo(HaveOccurred())
+
+				Expect(reports[snap.VirtualServices[0]].Warnings).To(BeEmpty())
+				warnings := reports[snap.RouteTables[0]].Warnings
+				Expect(warnings).To(HaveLen(1))
+				Expect(warnings[0]).To(ContainSubstring(UnorderedPrefixErr("gloo-system.vs", "/a",
+					&matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/a/b/c"}}).Error()))
+				Expect(warnings[0]).To(ContainSubstring("the earlier route is defined on virtual service gloo-system.vs"))
+			})
+		})
+
 	})
 
 	Context("generating unique route names", func() {
@@ -0,0 +1,476 @@
+package translator
+
+import (
+	"encoding/binary"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/core/matchers"
+)
+
+// the maximum number of states explored to prove that a matcher covers another one, above which the analysis gives up
+const maxCoverageStates = 10000
+
+// Analyzes matchers to find the ones which short-circuit later ones: an earlier matcher short-circuits a later one
+// when it matches every request the later one matches, so that Envoy never selects the route of the later one.
+// Paths, header values and query parameter values are modelled as regular languages, which makes the analysis exact
+// across path types, case sensitivity, regexes and inverted matchers. The analysis is conservative: it only reports
+// a matcher as short-circuited when it can prove it.
+type matcherCoverage struct {
+	// compiled regexes, nil for the ones the analysis does not support
+	progs map[string]*syntax.Prog
+}
+
+func newMatcherCoverage() *matcherCoverage {
+	return &matcherCoverage{progs: map[string]*syntax.Prog{}}
+}
+
+// Returns true if the earlier matcher matches every request the later one matches. A later matcher with several
+// methods is short-circuited as soon as one of its methods is, since the route is then partially unreachable.
+func (c *matcherCoverage) shortCircuits(earlier, later *matchers.Matcher) bool {
+	methods := later.GetMethods()
+	if len(methods) <= 1 {
+		return c.covers(earlier, later, methods)
+	}
+	for _, method := range methods {
+		if c.covers(earlier, later, []string{method}) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *matcherCoverage) covers(earlier, later *matchers.Matcher, laterMethods []string) bool {
+	return c.headersCover(requestHeaderMatchers(earlier.GetHeaders(), earlier.GetMethods()), requestHeaderMatchers(later.GetHeaders(), laterMethods)) &&
+		c.queryParametersCover(earlier.GetQueryParameters(), later.GetQueryParameters()) &&
+		c.pathCovers(earlier, later)
+}
+
+// methods are matched by envoy as a regex on the :method header
+func requestHeaderMatchers(headers []*matchers.HeaderMatcher, methods []string) []*matchers.HeaderMatcher {
+	if len(methods) == 0 {
+		return headers
+	}
+	return append(append([]*matchers.HeaderMatcher{}, headers...), &matchers.HeaderMatcher{
+		Name:  ":method",
+		Value: strings.Join(methods, "|"),
+		Regex: true,
+	})
+}
+
+// the values of a header or query parameter matched by a matcher
+type valueSet struct {
+	// whether the matcher matches requests without the header
+	absent bool
+	// whether the matcher matches requests with the header, restricted to the values of the regex
+	present bool
+	// the values matched, empty for any value
+	regex string
+	// whether the values matched are the ones which do not match the regex
+	invert bool
+}
+
+func headerValueSet(header *matchers.HeaderMatcher) valueSet {
+	switch {
+	case header.GetValue() == "" && header.GetInvertMatch():
+		return valueSet{absent: true}
+	case header.GetValue() == "":
+		return valueSet{present: true}
+	}
+	regex := header.GetValue()
+	if !header.GetRegex() {
+		regex = regexp.QuoteMeta(regex)
+	}
+	// envoy never matches a missing header on its value, even when the match is inverted
+	return valueSet{present: true, regex: regex, invert: header.GetInvertMatch()}
+}
+
+func queryParameterValueSet(queryParameter *matchers.QueryParameterMatcher) valueSet {
+	if queryParameter.GetValue() == "" {
+		return valueSet{present: true}
+	}
+	regex := queryParameter.GetValue()
+	if !queryParameter.GetRegex() {
+		regex = regexp.QuoteMeta(regex)
+	}
+	return valueSet{present: true, regex: regex}
+}
+
+// header names are case-insensitive
+func (c *matcherCoverage) headersCover(earlier, later []*matchers.HeaderMatcher) bool {
+	laterValues := map[string][]valueSet{}
+	for _, header := range later {
+		name := strings.ToLower(header.GetName())
+		laterValues[name] = append(laterValues[name], headerValueSet(header))
+	}
+	for _, header := range earlier {
+		if !c.valueSetCovers(headerValueSet(header), laterValues[strings.ToLower(header.GetName())]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *matcherCoverage) queryParametersCover(earlier, later []*matchers.QueryParameterMatcher) bool {
+	laterValues := map[string][]valueSet{}
+	for _, queryParameter := range later {
+		laterValues[queryParameter.GetName()] = append(laterValues[queryParameter.GetName()], queryParameterValueSet(queryParameter))
+	}
+	for _, queryParameter := range earlier {
+		if !c.valueSetCovers(queryParameterValueSet(queryParameter), laterValues[queryParameter.GetName()]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the values matched by the earlier matcher include the ones matched by all the later matchers on the
+// same header. Without later matchers, the header may be missing or have any value.
+func (c *matcherCoverage) valueSetCovers(earlier valueSet, later []valueSet) bool {
+	laterAbsent, laterPresent := true, true
+	var laterConstraints []languageConstraint
+	for _, values := range later {
+		laterAbsent = laterAbsent && values.absent
+		laterPresent = laterPresent && values.present
+		if values.regex != "" {
+			laterConstraints = append(laterConstraints, languageConstraint{regex: values.regex, negate: values.invert})
+		}
+	}
+	if laterAbsent && !earlier.absent {
+		return false
+	}
+	switch {
+	case !laterPresent:
+		return true
+	case !earlier.present:
+		// the later matchers may still contradict each other
+		return c.provenEmpty(laterConstraints)
+	case earlier.regex == "":
+		return true
+	}
+	return c.provenEmpty(append(laterConstraints, languageConstraint{regex: earlier.regex, negate: !earlier.invert}))
+}
+
+func (c *matcherCoverage) pathCovers(earlier, later *matchers.Matcher) bool {
+	// most routes use case-sensitive prefix and exact matchers, which do not need the full analysis
+	if !isCaseInsensitive(earlier) && !isCaseInsensitive(later) {
+		switch earlierPath := earlier.GetPathSpecifier().(type) {
+		case *matchers.Matcher_Prefix:
+			switch laterPath := later.GetPathSpecifier().(type) {
+			case *matchers.Matcher_Prefix:
+				return strings.HasPrefix(laterPath.Prefix, earlierPath.Prefix)
+			case *matchers.Matcher_Exact:
+				return strings.HasPrefix(laterPath.Exact, earlierPath.Prefix)
+			}
+		case *matchers.Matcher_Exact:
+			switch laterPath := later.GetPathSpecifier().(type) {
+			case *matchers.Matcher_Prefix:
+				return false
+			case *matchers.Matcher_Exact:
+				return laterPath.Exact == earlierPath.Exact
+			}
+		}
+	}
+
+	earlierRegex, ok := pathRegex(earlier)
+	if !ok {
+		return false
+	}
+	laterRegex, ok := pathRegex(later)
+	if !ok {
+		return false
+	}
+	return c.provenEmpty([]languageConstraint{
+		{regex: laterRegex},
+		{regex: earlierRegex, negate: true},
+	})
+}
+
+func isCaseInsensitive(matcher *matchers.Matcher) bool {
+	return matcher.GetCaseSensitive() != nil && !matcher.GetCaseSensitive().GetValue()
+}
+
+// Returns the paths matched by the matcher as a regex, matched against the path without its query string.
+// Prefixes are matched by envoy against the path including its query string, which is equivalent as long as the
+// prefix does not contain a query string itself. Request paths never contain newlines, so a prefix is followed by `.*`.
+func pathRegex(matcher *matchers.Matcher) (string, bool) {
+	switch path := matcher.GetPathSpecifier().(type) {
+	case *matchers.Matcher_Prefix:
+		if strings.Contains(path.Prefix, "?") {
+			return "", false
+		}
+		return literalRegex(path.Prefix, isCaseInsensitive(matcher)) + ".*", true
+	case *matchers.Matcher_Exact:
+		return literalRegex(path.Exact, isCaseInsensitive(matcher)), true
+	case *matchers.Matcher_Regex:
+		// envoy ignores the case sensitivity of regex matchers
+		return path.Regex, true
+	}
+	return "", false
+}
+
+// envoy only ignores the case of ASCII letters
+func literalRegex(literal string, caseInsensitive bool) string {
+	if !caseInsensitive {
+		return regexp.QuoteMeta(literal)
+	}
+	var sb strings.Builder
+	for _, r := range literal {
+		lower, upper := unicode.ToLower(r), unicode.ToUpper(r)
+		if r < utf8.RuneSelf && lower != upper {
+			sb.WriteString("[" + string(lower) + string(upper) + "]")
+			continue
+		}
+		sb.WriteString(regexp.QuoteMeta(string(r)))
+	}
+	return sb.String()
+}
+
+// A set of strings, the ones which (do not) match a regex. Regexes must match whole strings, as they do in envoy.
+type languageConstraint struct {
+	regex  string
+	negate bool
+}
+
+// Returns true if it is proven that no string satisfies all the constraints, by exploring the product of the
+// automata of the regexes. Gives up and returns false for regexes which cannot be analyzed, and when the
+// exploration becomes too large.
+func (c *matcherCoverage) provenEmpty(constraints []languageConstraint) bool {
+	progs := make([]*syntax.Prog, len(constraints))
+	for i, constraint := range constraints {
+		progs[i] = c.compile(constraint.regex)
+		if progs[i] == nil {
+			return false
+		}
+	}
+	alphabet := runeClasses(progs)
+
+	start := make([][]uint32, len(progs))
+	for i, prog := range progs {
+		start[i] = closure(prog, []uint32{uint32(prog.Start)}, true)
+	}
+	visited := map[string]bool{stateKey(start, true): true}
+	queue := [][][]uint32{start}
+	for first := true; len(queue) > 0; first = false {
+		current := queue[0]
+		queue = queue[1:]
+
+		satisfied := true
+		for i, prog := range progs {
+			if acceptsAtEnd(prog, current[i], first) == constraints[i].negate {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return false
+		}
+
+		for _, r := range alphabet {
+			next := make([][]uint32, len(progs))
+			dead := false
+			for i, prog := range progs {
+				next[i] = step(prog, current[i], r)
+				// no string with this prefix can match the regex
+				if len(next[i]) == 0 && !constraints[i].negate {
+					dead = true
+					break
+				}
+			}
+			if dead {
+				continue
+			}
+			key := stateKey(next, false)
+			if visited[key] {
+				continue
+			}
+			if len(visited) >= maxCoverageStates {
+				return false
+			}
+			visited[key] = true
+			queue = append(queue, next)
+		}
+	}
+	return true
+}
+
+// compiles a regex, returning nil if it is invalid or uses assertions the analysis does not support
+func (c *matcherCoverage) compile(regex string) *syntax.Prog {
+	if prog, ok := c.progs[regex]; ok {
+		return prog
+	}
+	var prog *syntax.Prog
+	if re, err := syntax.Parse(regex, syntax.Perl); err == nil {
+		prog, err = syntax.Compile(re.Simplify())
+		if err != nil || !supportedProg(prog) {
+			prog = nil
+		}
+	}
+	c.progs[regex] = prog
+	return prog
+}
+
+// only the assertions on the beginning and end of the text are supported
+func supportedProg(prog *syntax.Prog) bool {
+	for _, inst := range prog.Inst {
+		if inst.Op != syntax.InstEmptyWidth {
+			continue
+		}
+		switch syntax.EmptyOp(inst.Arg) {
+		case syntax.EmptyBeginText, syntax.EmptyEndText:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the instructions reachable from the given ones without consuming a rune: the rune instructions, the
+// match instructions and the assertions on the end of the text.
+func closure(prog *syntax.Prog, pcs []uint32, atStart bool) []uint32 {
+	seen := map[uint32]bool{}
+	var out []uint32
+	var visit func(pc uint32)
+	visit = func(pc uint32) {
+		if seen[pc] {
+			return
+		}
+		seen[pc] = true
+		inst := prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			visit(inst.Out)
+			visit(inst.Arg)
+		case syntax.InstCapture, syntax.InstNop:
+			visit(inst.Out)
+		case syntax.InstEmptyWidth:
+			if syntax.EmptyOp(inst.Arg) == syntax.EmptyBeginText {
+				if atStart {
+					visit(inst.Out)
+				}
+				return
+			}
+			out = append(out, pc)
+		case syntax.InstFail:
+		default:
+			out = append(out, pc)
+		}
+	}
+	for _, pc := range pcs {
+		visit(pc)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func step(prog *syntax.Prog, pcs []uint32, r rune) []uint32 {
+	var next []uint32
+	for _, pc := range pcs {
+		inst := prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+			if inst.MatchRune(r) {
+				next = append(next, inst.Out)
+			}
+		}
+	}
+	if len(next) == 0 {
+		return nil
+	}
+	return closure(prog, next, false)
+}
+
+// returns true if the regex matches the strings which reach the given instructions
+func acceptsAtEnd(prog *syntax.Prog, pcs []uint32, atStart bool) bool {
+	seen := map[uint32]bool{}
+	var visit func(pc uint32) bool
+	visit = func(pc uint32) bool {
+		if seen[pc] {
+			return false
+		}
+		seen[pc] = true
+		inst := prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstMatch:
+			return true
+		case syntax.InstAlt, syntax.InstAltMatch:
+			return visit(inst.Out) || visit(inst.Arg)
+		case syntax.InstCapture, syntax.InstNop:
+			return visit(inst.Out)
+		case syntax.InstEmptyWidth:
+			// the end of the text is reached, and its beginning too for the empty string
+			if syntax.EmptyOp(inst.Arg) == syntax.EmptyEndText || atStart {
+				return visit(inst.Out)
+			}
+		}
+		return false
+	}
+	for _, pc := range pcs {
+		if visit(pc) {
+			return true
+		}
+	}
+	return false
+}
+
+// Splits the runes in classes of runes which are matched by the same instructions of the regexes, returning a
+// rune of each class.
+func runeClasses(progs []*syntax.Prog) []rune {
+	bounds := map[rune]bool{0: true}
+	addRange := func(lo, hi rune) {
+		bounds[lo] = true
+		if hi < unicode.MaxRune {
+			bounds[hi+1] = true
+		}
+	}
+	for _, prog := range progs {
+		for _, inst := range prog.Inst {
+			switch inst.Op {
+			case syntax.InstRune, syntax.InstRune1:
+				if len(inst.Rune) == 1 {
+					r := inst.Rune[0]
+					addRange(r, r)
+					if syntax.Flags(inst.Arg)&syntax.FoldCase != 0 {
+						for folded := unicode.SimpleFold(r); folded != r; folded = unicode.SimpleFold(folded) {
+							addRange(folded, folded)
+						}
+					}
+					continue
+				}
+				for i := 0; i+1 < len(inst.Rune); i += 2 {
+					addRange(inst.Rune[i], inst.Rune[i+1])
+				}
+			case syntax.InstRuneAnyNotNL:
+				addRange('\n', '\n')
+			}
+		}
+	}
+	classes := make([]rune, 0, len(bounds))
+	for r := range bounds {
+		classes = append(classes, r)
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i] < classes[j] })
+	return classes
+}
+
+// the start state is distinguished, as the assertions on the beginning of the text only hold there
+func stateKey(state [][]uint32, atStart bool) string {
+	var sb strings.Builder
+	if atStart {
+		sb.WriteByte('^')
+	}
+	buf := make([]byte, 4)
+	for _, pcs := range state {
+		binary.LittleEndian.PutUint32(buf, uint32(len(pcs)))
+		sb.Write(buf)
+		for _, pc := range pcs {
+			binary.LittleEndian.PutUint32(buf, pc)
+			sb.Write(buf)
+		}
+	}
+	return sb.String()
+}
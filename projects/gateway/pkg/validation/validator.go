@@ -862,13 +862,10 @@ func routesContainRefs(routes []*v1.Route, refs sets.String) bool {
 			continue
 		}
 
-		rtRef := getDelegateRef(delegate)
-		if rtRef == nil {
-			continue
-		}
-
-		if _, ok := refs[gloo_translator.UpstreamToClusterName(rtRef)]; ok {
-			return true
+		for _, rtRef := range getDelegateRefs(delegate) {
+			if _, ok := refs[gloo_translator.UpstreamToClusterName(rtRef)]; ok {
+				return true
+			}
 		}
 	}
 	return false
@@ -890,10 +887,11 @@ func routesContainSelectorsOrRefs(routes []*v1.Route, parentNamespace string, ro
 		}
 
 		// check if this route delegates to any of the given route tables via ref
-		rtRef := getDelegateRef(delegate)
-		if rtRef != nil {
-			if _, ok := routeTables[gloo_translator.UpstreamToClusterName(rtRef)]; ok {
-				return true
+		if rtRefs := getDelegateRefs(delegate); len(rtRefs) > 0 {
+			for _, rtRef := range rtRefs {
+				if _, ok := routeTables[gloo_translator.UpstreamToClusterName(rtRef)]; ok {
+					return true
+				}
 			}
 			continue
 		}
@@ -915,18 +913,27 @@ func routesContainSelectorsOrRefs(routes []*v1.Route, parentNamespace string, ro
 	return false
 }
 
-func getDelegateRef(delegate *v1.DelegateAction) *core.ResourceRef {
+// Returns the route tables that the given delegate action references directly, either via a single reference or
+// via weighted delegation.
+func getDelegateRefs(delegate *v1.DelegateAction) []*core.ResourceRef {
 	// handle deprecated route table resource reference format
 	// TODO(marco): remove when we remove the deprecated fields from the API
 	if delegate.GetNamespace() != "" || delegate.GetName() != "" {
-		return &core.ResourceRef{
+		return []*core.ResourceRef{{
 			Namespace: delegate.GetNamespace(),
 			Name:      delegate.GetName(),
-		}
+		}}
 	} else if delegate.GetRef() != nil {
-		return delegate.GetRef()
+		return []*core.ResourceRef{delegate.GetRef()}
 	}
-	return nil
+
+	var refs []*core.ResourceRef
+	for _, weighted := range delegate.GetWeightedRouteTables().GetRouteTables() {
+		if weighted.GetRef() != nil {
+			refs = append(refs, weighted.GetRef())
+		}
+	}
+	return refs
 }
 
 func gatewayListContainsVirtualService(ctx context.Context, gwList v1.GatewayList, httpGwList v1.MatchableHttpGatewayList, vs *v1.VirtualService) bool {
@@ -4,12 +4,13 @@ import (
 	"os"
 
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmdutils"
 )
 
 func main() {
 	app := cmd.GlooCli()
 	if err := app.Execute(); err != nil {
 		//fmt.Println(err)
-		os.Exit(1)
+		os.Exit(cmdutils.ExitCode(err))
 	}
 }
@@ -0,0 +1,403 @@
+package check
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	gatewayv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/helpers"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/printers"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+)
+
+const (
+	// certificates expiring within this window are reported, unless set with --certificate-expiry-window
+	DefaultCertificateExpiryWindow = 30 * 24 * time.Hour
+
+	minRsaKeyBits   = 2048
+	minEcdsaKeyBits = 256
+)
+
+// A secret referenced by ssl configs, with the resources referencing it
+type referencedSecret struct {
+	ref          *core.ResourceRef
+	referencedBy []secretReference
+}
+
+// A resource referencing a TLS secret in its ssl config
+type secretReference struct {
+	kind     string
+	metadata *core.Metadata
+	// the domains the certificate is served for, which are only known for virtual services
+	domains []string
+}
+
+// Parses the TLS secrets referenced by the ssl configs of the virtual services, gateways and upstreams, and reports
+// the certificates which are expired or about to be, which do not match their key, which lack their intermediate
+// certificates, which are not valid for the domains of the virtual services or which have weak keys.
+func checkCertificates(opts *options.Options, namespaces []string) error {
+	printer.AppendCheck("Checking certificates... ")
+	var multiErr *multierror.Error
+
+	refs, err := listSecretReferences(opts, namespaces)
+	if err != nil {
+		multiErr = multierror.Append(multiErr, err)
+	}
+
+	secretClient, err := helpers.GetSecretClient(opts.Top.Ctx, opts.Check.SecretClientTimeout, namespaces)
+	if err != nil {
+		multiErr = multierror.Append(multiErr, err)
+		printer.AppendStatus("certificates", fmt.Sprintf("%v Errors!", multiErr.Len()))
+		return multiErr
+	}
+	var secrets v1.SecretList
+	for _, ns := range namespaces {
+		nsSecrets, err := secretClient.List(ns, clients.ListOpts{})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		secrets = append(secrets, nsSecrets...)
+	}
+
+	window := opts.Check.CertificateExpiryWindow
+	if window == 0 {
+		window = DefaultCertificateExpiryWindow
+	}
+	now := time.Now()
+
+	for _, referenced := range refs {
+		secretRef, referencedBy := referenced.ref, referenced.referencedBy
+		secret, err := secrets.Find(secretRef.Strings())
+		if err != nil {
+			for _, ref := range referencedBy {
+				multiErr = multierror.Append(multiErr, resourceError(ref.kind, ref.metadata,
+					fmt.Sprintf("%s %s references missing secret %s", ref.kind, renderMetadata(ref.metadata), renderRef(secretRef))))
+			}
+			continue
+		}
+		tlsSecret := secret.GetTls()
+		if tlsSecret == nil {
+			for _, ref := range referencedBy {
+				multiErr = multierror.Append(multiErr, resourceError(ref.kind, ref.metadata,
+					fmt.Sprintf("%s %s references secret %s which is not a TLS secret", ref.kind, renderMetadata(ref.metadata), renderRef(secretRef))))
+			}
+			continue
+		}
+
+		for _, issue := range checkTlsSecret(tlsSecret, now, window) {
+			message := fmt.Sprintf("secret %s: %s", renderMetadata(secret.GetMetadata()), issue.message)
+			multiErr = multierror.Append(multiErr, newResourceIssue(issue.severity, "Secret", secretRef.GetNamespace(), secretRef.GetName(), message))
+		}
+
+		leaf, err := leafCertificate(tlsSecret)
+		if err != nil || leaf == nil {
+			continue
+		}
+		for _, ref := range referencedBy {
+			for _, domain := range domainsNotCoveredBy(leaf, ref.domains) {
+				multiErr = multierror.Append(multiErr, resourceWarning(ref.kind, ref.metadata,
+					fmt.Sprintf("the certificate of secret %s is not valid for the domain %s of %s %s",
+						renderRef(secretRef), domain, ref.kind, renderMetadata(ref.metadata))))
+			}
+		}
+	}
+
+	if multiErr != nil {
+		printer.AppendStatus("certificates", fmt.Sprintf("%v Errors!", multiErr.Len()))
+		return multiErr
+	}
+	printer.AppendStatus("certificates", "OK")
+	return nil
+}
+
+// Returns the secrets referenced in the ssl configs, sorted by namespace and name
+func listSecretReferences(opts *options.Options, namespaces []string) ([]*referencedSecret, error) {
+	var multiErr *multierror.Error
+	refs := map[string]*referencedSecret{}
+	addRef := func(secretRef *core.ResourceRef, ref secretReference) {
+		if secretRef == nil {
+			return
+		}
+		key := renderRef(secretRef)
+		if refs[key] == nil {
+			refs[key] = &referencedSecret{ref: secretRef}
+		}
+		refs[key].referencedBy = append(refs[key].referencedBy, ref)
+	}
+
+	for _, ns := range namespaces {
+		virtualServiceClient, err := helpers.VirtualServiceClient(opts.Top.Ctx, []string{ns})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		virtualServices, err := virtualServiceClient.List(ns, clients.ListOpts{})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		for _, virtualService := range virtualServices {
+			sslConfig := virtualService.GetSslConfig()
+			domains := append(append([]string{}, virtualService.GetVirtualHost().GetDomains()...), sslConfig.GetSniDomains()...)
+			addRef(sslConfig.GetSecretRef(), secretReference{kind: "VirtualService", metadata: virtualService.GetMetadata(), domains: domains})
+		}
+
+		gatewayClient, err := helpers.GatewayClient(opts.Top.Ctx, []string{ns})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		gateways, err := gatewayClient.List(ns, clients.ListOpts{})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		for _, gateway := range gateways {
+			for _, sslConfig := range gatewaySslConfigs(gateway) {
+				addRef(sslConfig.GetSecretRef(), secretReference{kind: "Gateway", metadata: gateway.GetMetadata()})
+			}
+		}
+
+		upstreamClient, err := helpers.UpstreamClient(opts.Top.Ctx, []string{ns})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		upstreams, err := upstreamClient.List(ns, clients.ListOpts{})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		for _, upstream := range upstreams {
+			addRef(upstream.GetSslConfig().GetSecretRef(), secretReference{kind: "Upstream", metadata: upstream.GetMetadata()})
+		}
+	}
+	sorted := make([]*referencedSecret, 0, len(refs))
+	for _, referenced := range refs {
+		sorted = append(sorted, referenced)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return renderRef(sorted[i].ref) < renderRef(sorted[j].ref)
+	})
+	return sorted, multiErr.ErrorOrNil()
+}
+
+func gatewaySslConfigs(gateway *gatewayv1.Gateway) []*v1.SslConfig {
+	var sslConfigs []*v1.SslConfig
+	tcpSslConfigs := func(tcpGateway *gatewayv1.TcpGateway) {
+		for _, tcpHost := range tcpGateway.GetTcpHosts() {
+			if tcpHost.GetSslConfig() != nil {
+				sslConfigs = append(sslConfigs, tcpHost.GetSslConfig())
+			}
+		}
+	}
+	tcpSslConfigs(gateway.GetTcpGateway())
+	for _, matchedGateway := range gateway.GetHybridGateway().GetMatchedGateways() {
+		if matchedGateway.GetMatcher().GetSslConfig() != nil {
+			sslConfigs = append(sslConfigs, matchedGateway.GetMatcher().GetSslConfig())
+		}
+		tcpSslConfigs(matchedGateway.GetTcpGateway())
+	}
+	return sslConfigs
+}
+
+// A problem found with the certificates of a TLS secret
+type certificateIssue struct {
+	severity printers.CheckSeverity
+	message  string
+}
+
+func certificateError(format string, args ...interface{}) certificateIssue {
+	return certificateIssue{severity: printers.CheckSeverityError, message: fmt.Sprintf(format, args...)}
+}
+
+func certificateWarning(format string, args ...interface{}) certificateIssue {
+	return certificateIssue{severity: printers.CheckSeverityWarning, message: fmt.Sprintf(format, args...)}
+}
+
+// Returns the problems with the certificates of a TLS secret, as of now
+func checkTlsSecret(tlsSecret *v1.TlsSecret, now time.Time, expiryWindow time.Duration) []certificateIssue {
+	certChain, err := parseCertificates(tlsSecret.GetCertChain())
+	if err != nil {
+		return []certificateIssue{certificateError("invalid certificate chain: %v", err)}
+	}
+	rootCas, err := parseCertificates(tlsSecret.GetRootCa())
+	if err != nil {
+		return []certificateIssue{certificateError("invalid root ca: %v", err)}
+	}
+
+	var issues []certificateIssue
+	// as for gloo, a secret with only a root ca has no key pair to validate
+	if tlsSecret.GetCertChain() != "" || tlsSecret.GetPrivateKey() != "" {
+		if _, err := tls.X509KeyPair([]byte(tlsSecret.GetCertChain()), []byte(tlsSecret.GetPrivateKey())); err != nil {
+			issues = append(issues, certificateError("the private key does not match the certificate chain: %v", err))
+		}
+	}
+
+	for _, cert := range append(append([]*x509.Certificate{}, certChain...), rootCas...) {
+		switch {
+		case now.After(cert.NotAfter):
+			issues = append(issues, certificateError("certificate %s expired on %s", certificateName(cert), cert.NotAfter.UTC().Format(time.RFC3339)))
+		case now.Add(expiryWindow).After(cert.NotAfter):
+			issues = append(issues, certificateWarning("certificate %s expires on %s", certificateName(cert), cert.NotAfter.UTC().Format(time.RFC3339)))
+		case now.Before(cert.NotBefore):
+			issues = append(issues, certificateWarning("certificate %s is not valid before %s", certificateName(cert), cert.NotBefore.UTC().Format(time.RFC3339)))
+		}
+		if bits, weak := weakKeyBits(cert); weak {
+			issues = append(issues, certificateWarning("certificate %s has a weak %d bit key", certificateName(cert), bits))
+		}
+	}
+
+	if len(certChain) > 0 {
+		if issue := checkIntermediates(certChain, rootCas, now); issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+	return issues
+}
+
+// The leaf certificate must be followed by the certificate of its issuer, unless the issuer is a root certificate
+// authority: clients trusting the root would not be able to verify the leaf certificate otherwise.
+func checkIntermediates(certChain, rootCas []*x509.Certificate, now time.Time) *certificateIssue {
+	leaf := certChain[0]
+	if isSelfSigned(leaf) {
+		return nil
+	}
+	if len(certChain) > 1 {
+		if leaf.CheckSignatureFrom(certChain[1]) != nil {
+			issue := certificateWarning("certificate %s is not followed by the certificate of its issuer %s in the certificate chain",
+				certificateName(leaf), leaf.Issuer.String())
+			return &issue
+		}
+		return nil
+	}
+	for _, rootCa := range rootCas {
+		if leaf.CheckSignatureFrom(rootCa) == nil {
+			return nil
+		}
+	}
+	if systemRoots, err := x509.SystemCertPool(); err == nil {
+		currentTime := now
+		if currentTime.After(leaf.NotAfter) || currentTime.Before(leaf.NotBefore) {
+			// expired certificates are reported already
+			currentTime = leaf.NotBefore
+		}
+		_, err := leaf.Verify(x509.VerifyOptions{
+			Roots:       systemRoots,
+			CurrentTime: currentTime,
+			KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err == nil {
+			return nil
+		}
+	}
+	issue := certificateWarning("the certificate chain is missing the intermediate certificates of certificate %s issued by %s",
+		certificateName(leaf), leaf.Issuer.String())
+	return &issue
+}
+
+// Returns the domains of a virtual service the certificate is not valid for. The wildcard domains of envoy are
+// covered only by the same wildcard in the certificate, and the catch-all domain by any certificate.
+func domainsNotCoveredBy(leaf *x509.Certificate, domains []string) []string {
+	var notCovered []string
+	for _, domain := range domains {
+		host := domain
+		if h, _, err := net.SplitHostPort(domain); err == nil {
+			host = h
+		}
+		if host == "*" || host == "" {
+			continue
+		}
+		if strings.Contains(host, "*") {
+			if !strings.HasPrefix(host, "*.") || strings.Contains(host[1:], "*") {
+				// prefix and suffix wildcards of envoy cannot be matched against a certificate
+				continue
+			}
+			if !containsFold(leaf.DNSNames, host) {
+				notCovered = append(notCovered, domain)
+			}
+			continue
+		}
+		if leaf.VerifyHostname(host) != nil {
+			notCovered = append(notCovered, domain)
+		}
+	}
+	return notCovered
+}
+
+func leafCertificate(tlsSecret *v1.TlsSecret) (*x509.Certificate, error) {
+	certs, err := parseCertificates(tlsSecret.GetCertChain())
+	if err != nil || len(certs) == 0 {
+		return nil, err
+	}
+	return certs[0], nil
+}
+
+func parseCertificates(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 && strings.TrimSpace(data) != "" {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return certs, nil
+}
+
+func weakKeyBits(cert *x509.Certificate) (int, bool) {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return key.N.BitLen(), key.N.BitLen() < minRsaKeyBits
+	case *ecdsa.PublicKey:
+		return key.Curve.Params().BitSize, key.Curve.Params().BitSize < minEcdsaKeyBits
+	}
+	return 0, false
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawSubject, cert.RawIssuer) &&
+		cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+func certificateName(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	return cert.Subject.String()
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
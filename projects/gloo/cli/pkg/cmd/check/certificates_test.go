@@ -0,0 +1,314 @@
+package check_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	gatewayv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/check"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmdutils"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/helpers"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/printers"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/testutils"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/defaults"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	"github.com/solo-io/solo-kit/pkg/utils/statusutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Certificates", func() {
+
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		rootCa *testCertificate
+	)
+
+	BeforeEach(func() {
+		Expect(os.Setenv(statusutils.PodNamespaceEnvName, defaults.GlooSystem)).NotTo(HaveOccurred())
+		helpers.UseMemoryClients()
+		ctx, cancel = context.WithCancel(context.Background())
+
+		_, err := helpers.MustKubeClient().CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: defaults.GlooSystem},
+		}, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = helpers.MustNamespacedSettingsClient(ctx, defaults.GlooSystem).Write(&v1.Settings{
+			Metadata: &core.Metadata{Name: "default", Namespace: defaults.GlooSystem},
+		}, clients.WriteOpts{})
+		Expect(err).NotTo(HaveOccurred())
+
+		rootCa = newTestCertificate(certificateOptions{commonName: "root-ca", isCa: true})
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv(statusutils.PodNamespaceEnvName)).NotTo(HaveOccurred())
+		cancel()
+	})
+
+	writeTlsSecret := func(name string, certChain []*testCertificate, privateKey string) {
+		var chain string
+		for _, cert := range certChain {
+			chain += cert.certPem
+		}
+		_, err := helpers.MustSecretClient(ctx).Write(&v1.Secret{
+			Metadata: &core.Metadata{Name: name, Namespace: defaults.GlooSystem},
+			Kind: &v1.Secret_Tls{Tls: &v1.TlsSecret{
+				CertChain:  chain,
+				PrivateKey: privateKey,
+			}},
+		}, clients.WriteOpts{OverwriteExisting: true})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	writeVirtualService := func(secretName string, domains ...string) {
+		_, err := helpers.MustNamespacedVirtualServiceClient(ctx, defaults.GlooSystem).Write(&gatewayv1.VirtualService{
+			Metadata: &core.Metadata{Name: "vs", Namespace: defaults.GlooSystem},
+			SslConfig: &v1.SslConfig{
+				SslSecrets: &v1.SslConfig_SecretRef{
+					SecretRef: &core.ResourceRef{Name: secretName, Namespace: defaults.GlooSystem},
+				},
+			},
+			VirtualHost: &gatewayv1.VirtualHost{Domains: domains},
+		}, clients.WriteOpts{})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	checkCertificates := func(args string) (printers.CheckReport, error) {
+		output, err := testutils.GlooctlOut("check --include certificates -o json" + args)
+		var result printers.CheckResult
+		Expect(json.Unmarshal([]byte(jsonLine(output)), &result)).NotTo(HaveOccurred())
+		Expect(result.Checks).To(HaveLen(1))
+		Expect(result.Checks[0].Id).To(Equal(check.CertificatesCheck))
+		return result.Checks[0], err
+	}
+
+	secretIssue := func(severity printers.CheckSeverity, message string) printers.CheckIssue {
+		return printers.CheckIssue{
+			Severity: severity,
+			Message:  message,
+			Resource: &printers.CheckResourceRef{Kind: "Secret", Namespace: defaults.GlooSystem, Name: "tls"},
+		}
+	}
+
+	It("passes for valid certificates", func() {
+		leaf := newTestCertificate(certificateOptions{commonName: "example.com", dnsNames: []string{"example.com", "*.example.com"}})
+		writeTlsSecret("tls", []*testCertificate{leaf}, leaf.keyPem)
+		writeVirtualService("tls", "example.com", "www.example.com:443", "*.example.com", "*")
+
+		report, err := checkCertificates("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(Equal(printers.CheckReport{Id: check.CertificatesCheck, Status: "ok"}))
+	})
+
+	It("reports certificates expiring within the window", func() {
+		leaf := newTestCertificate(certificateOptions{commonName: "example.com", dnsNames: []string{"example.com"}, validFor: 10 * 24 * time.Hour})
+		writeTlsSecret("tls", []*testCertificate{leaf}, leaf.keyPem)
+		writeVirtualService("tls", "example.com")
+
+		report, err := checkCertificates("")
+		Expect(cmdutils.ExitCode(err)).To(Equal(check.WarningsExitCode))
+		Expect(report.Status).To(Equal("warning"))
+		Expect(report.Issues).To(HaveLen(1))
+		Expect(report.Issues[0].Severity).To(Equal(printers.CheckSeverityWarning))
+		Expect(report.Issues[0].Message).To(ContainSubstring("secret gloo-system tls: certificate example.com expires on"))
+
+		report, err = checkCertificates(" --certificate-expiry-window 24h")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Status).To(Equal("ok"))
+	})
+
+	It("reports expired certificates", func() {
+		leaf := newTestCertificate(certificateOptions{commonName: "example.com", dnsNames: []string{"example.com"}, validFor: -time.Hour})
+		writeTlsSecret("tls", []*testCertificate{leaf}, leaf.keyPem)
+		writeVirtualService("tls", "example.com")
+
+		report, err := checkCertificates("")
+		Expect(cmdutils.ExitCode(err)).To(Equal(check.ErrorsExitCode))
+		Expect(report.Status).To(Equal("error"))
+		Expect(report.Issues).To(HaveLen(1))
+		Expect(report.Issues[0].Message).To(ContainSubstring("certificate example.com expired on"))
+	})
+
+	It("reports private keys which do not match the certificate", func() {
+		leaf := newTestCertificate(certificateOptions{commonName: "example.com", dnsNames: []string{"example.com"}})
+		other := newTestCertificate(certificateOptions{commonName: "example.com", dnsNames: []string{"example.com"}})
+		writeTlsSecret("tls", []*testCertificate{leaf}, other.keyPem)
+		writeVirtualService("tls", "example.com")
+
+		report, err := checkCertificates("")
+		Expect(cmdutils.ExitCode(err)).To(Equal(check.ErrorsExitCode))
+		Expect(report.Issues).To(HaveLen(1))
+		Expect(report.Issues[0].Severity).To(Equal(printers.CheckSeverityError))
+		Expect(report.Issues[0].Message).To(ContainSubstring("secret gloo-system tls: the private key does not match the certificate chain"))
+	})
+
+	It("reports certificate chains missing the intermediate certificates", func() {
+		intermediate := newTestCertificate(certificateOptions{commonName: "intermediate-ca", isCa: true, issuer: rootCa})
+		leaf := newTestCertificate(certificateOptions{commonName: "example.com", dnsNames: []string{"example.com"}, issuer: intermediate})
+		writeTlsSecret("tls", []*testCertificate{leaf}, leaf.keyPem)
+		writeVirtualService("tls", "example.com")
+
+		report, err := checkCertificates("")
+		Expect(cmdutils.ExitCode(err)).To(Equal(check.WarningsExitCode))
+		Expect(report.Issues).To(ConsistOf(secretIssue(printers.CheckSeverityWarning,
+			"secret gloo-system tls: the certificate chain is missing the intermediate certificates of certificate example.com issued by CN=intermediate-ca")))
+
+		writeTlsSecret("tls", []*testCertificate{intermediate, leaf}, leaf.keyPem)
+		report, _ = checkCertificates("")
+		Expect(report.Issues).To(ContainElement(secretIssue(printers.CheckSeverityWarning,
+			"secret gloo-system tls: certificate intermediate-ca is not followed by the certificate of its issuer CN=root-ca in the certificate chain")))
+
+		writeTlsSecret("tls", []*testCertificate{leaf, intermediate}, leaf.keyPem)
+		report, err = checkCertificates("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Status).To(Equal("ok"))
+	})
+
+	It("reports the domains of virtual services the certificate is not valid for", func() {
+		leaf := newTestCertificate(certificateOptions{commonName: "example.com", dnsNames: []string{"example.com", "*.example.com"}})
+		writeTlsSecret("tls", []*testCertificate{leaf}, leaf.keyPem)
+		writeVirtualService("tls", "example.com", "a.b.example.com", "*.other.com")
+
+		report, err := checkCertificates("")
+		Expect(cmdutils.ExitCode(err)).To(Equal(check.WarningsExitCode))
+		vsRef := &printers.CheckResourceRef{Kind: "VirtualService", Namespace: defaults.GlooSystem, Name: "vs"}
+		Expect(report.Issues).To(ConsistOf(
+			printers.CheckIssue{
+				Severity: printers.CheckSeverityWarning,
+				Message:  "the certificate of secret gloo-system tls is not valid for the domain a.b.example.com of VirtualService gloo-system vs",
+				Resource: vsRef,
+			},
+			printers.CheckIssue{
+				Severity: printers.CheckSeverityWarning,
+				Message:  "the certificate of secret gloo-system tls is not valid for the domain *.other.com of VirtualService gloo-system vs",
+				Resource: vsRef,
+			},
+		))
+	})
+
+	It("reports weak keys", func() {
+		leaf := newTestCertificate(certificateOptions{commonName: "example.com", dnsNames: []string{"example.com"}, rsaBits: 1024})
+		writeTlsSecret("tls", []*testCertificate{leaf}, leaf.keyPem)
+		writeVirtualService("tls", "example.com")
+
+		report, err := checkCertificates("")
+		Expect(cmdutils.ExitCode(err)).To(Equal(check.WarningsExitCode))
+		Expect(report.Issues).To(ConsistOf(secretIssue(printers.CheckSeverityWarning,
+			"secret gloo-system tls: certificate example.com has a weak 1024 bit key")))
+	})
+
+	It("reports the resources referencing missing secrets", func() {
+		_, err := helpers.MustNamespacedUpstreamClient(ctx, defaults.GlooSystem).Write(&v1.Upstream{
+			Metadata: &core.Metadata{Name: "us", Namespace: defaults.GlooSystem},
+			SslConfig: &v1.UpstreamSslConfig{
+				SslSecrets: &v1.UpstreamSslConfig_SecretRef{
+					SecretRef: &core.ResourceRef{Name: "missing", Namespace: defaults.GlooSystem},
+				},
+			},
+		}, clients.WriteOpts{})
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := checkCertificates("")
+		Expect(cmdutils.ExitCode(err)).To(Equal(check.ErrorsExitCode))
+		Expect(report.Issues).To(ConsistOf(printers.CheckIssue{
+			Severity: printers.CheckSeverityError,
+			Message:  "Upstream gloo-system us references missing secret gloo-system missing",
+			Resource: &printers.CheckResourceRef{Kind: "Upstream", Namespace: defaults.GlooSystem, Name: "us"},
+		}))
+	})
+})
+
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     interface{}
+	certPem string
+	keyPem  string
+}
+
+type certificateOptions struct {
+	commonName string
+	dnsNames   []string
+	isCa       bool
+	// the certificate is self-signed if not set
+	issuer *testCertificate
+	// the certificate is valid for a year if not set, and expired if negative
+	validFor time.Duration
+	// the key is an ECDSA P-256 key if not set
+	rsaBits int
+}
+
+func newTestCertificate(opts certificateOptions) *testCertificate {
+	var (
+		key       interface{}
+		publicKey interface{}
+	)
+	if opts.rsaBits > 0 {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, opts.rsaBits)
+		Expect(err).NotTo(HaveOccurred())
+		key, publicKey = rsaKey, &rsaKey.PublicKey
+	} else {
+		ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		key, publicKey = ecdsaKey, &ecdsaKey.PublicKey
+	}
+
+	validFor := opts.validFor
+	if validFor == 0 {
+		validFor = 365 * 24 * time.Hour
+	}
+	notBefore := time.Now().Add(-time.Hour)
+	if validFor < 0 {
+		notBefore = time.Now().Add(2 * validFor)
+	}
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: opts.commonName},
+		DNSNames:              opts.dnsNames,
+		NotBefore:             notBefore,
+		NotAfter:              time.Now().Add(validFor),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  opts.isCa,
+	}
+	if opts.isCa {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+
+	parent, parentKey := template, key
+	if opts.issuer != nil {
+		parent, parentKey = opts.issuer.cert, opts.issuer.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, publicKey, parentKey)
+	Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).NotTo(HaveOccurred())
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPem: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPem:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})),
+	}
+}
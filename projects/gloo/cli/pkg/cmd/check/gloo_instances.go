@@ -7,8 +7,10 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
+	"github.com/hashicorp/go-multierror"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/helpers"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/printers"
 	glooinstancev1 "github.com/solo-io/solo-apis/pkg/api/fed.solo.io/v1"
 	"github.com/solo-io/solo-apis/pkg/api/fed.solo.io/v1/types"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -22,7 +24,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
 
-func CheckMulticlusterResources(opts *options.Options) {
+// Checks the Gloo Instances detected by Gloo Federation, returning the errors and warnings they report
+func CheckMulticlusterResources(opts *options.Options) error {
 	// check if the gloo fed deployment exists
 	client := helpers.MustKubeClient()
 	_, err := client.AppsV1().Deployments(opts.Metadata.GetNamespace()).Get(opts.Top.Ctx, "gloo-fed", metav1.GetOptions{})
@@ -30,64 +33,86 @@ func CheckMulticlusterResources(opts *options.Options) {
 		if apierrors.IsNotFound(err) {
 			printer.AppendMessage("Skipping Gloo Instance check -- Gloo Federation not detected")
 		} else {
-			fmt.Printf("Warning: could not get Gloo Fed deployment: %v. Skipping Gloo Instance check.\n", err)
+			printer.AppendMessage(fmt.Sprintf("Warning: could not get Gloo Fed deployment: %v. Skipping Gloo Instance check.", err))
 		}
-		return
+		return nil
 	}
 
 	cfg, err := config.GetConfigWithContext("")
 	if err != nil {
-		fmt.Printf("Warning: could not get kubernetes config to check multicluster resources: %v. "+
-			"Skipping Gloo Instance check.\n", err)
-		return
+		printer.AppendMessage(fmt.Sprintf("Warning: could not get kubernetes config to check multicluster resources: %v. "+
+			"Skipping Gloo Instance check.", err))
+		return nil
 	}
 	instanceReader, err := getUnstructuredGlooInstanceReader(cfg)
 	if err != nil {
-		fmt.Printf("Warning: could not get Gloo Instance client: %v. Skipping Gloo Instance check.\n", err)
-		return
+		printer.AppendMessage(fmt.Sprintf("Warning: could not get Gloo Instance client: %v. Skipping Gloo Instance check.", err))
+		return nil
 	}
 	glooInstanceList, err := instanceReader.listGlooInstances(opts.Top.Ctx)
 	if err != nil {
 		if meta.IsNoMatchError(err) {
 			printer.AppendMessage("Skipping Gloo Instance check -- Gloo Federation not detected")
-			return
+			return nil
 		}
-		fmt.Printf("Warning: could not list Gloo Instances: %v\n", err)
-		return
+		printer.AppendMessage(fmt.Sprintf("Warning: could not list Gloo Instances: %v", err))
+		return nil
 	}
 	printer.AppendMessage("\nDetected Gloo Federation!")
+	var multiErr *multierror.Error
 	for _, glooInstance := range glooInstanceList.Items {
-		fmt.Printf("\nChecking Gloo Instance %s... ", glooInstance.GetName())
-		printGlooInstanceCheckSummary("deployments", glooInstance.Spec.GetCheck().GetDeployments())
-		printGlooInstanceCheckSummary("pods", glooInstance.Spec.GetCheck().GetPods())
-		printGlooInstanceCheckSummary("settings", glooInstance.Spec.GetCheck().GetSettings())
-		printGlooInstanceCheckSummary("upstreams", glooInstance.Spec.GetCheck().GetUpstreams())
-		printGlooInstanceCheckSummary("upstream groups", glooInstance.Spec.GetCheck().GetUpstreamGroups())
-		printGlooInstanceCheckSummary("auth configs", glooInstance.Spec.GetCheck().GetAuthConfigs())
-		printGlooInstanceCheckSummary("virtual services", glooInstance.Spec.GetCheck().GetVirtualServices())
-		printGlooInstanceCheckSummary("route tables", glooInstance.Spec.GetCheck().GetRouteTables())
-		printGlooInstanceCheckSummary("gateways", glooInstance.Spec.GetCheck().GetGateways())
-		printGlooInstanceCheckSummary("proxies", glooInstance.Spec.GetCheck().GetProxies())
-		fmt.Printf("\n\n")
+		printTable("\nChecking Gloo Instance %s... ", glooInstance.GetName())
+		for _, summary := range []struct {
+			resourceType string
+			kind         string
+			summary      *types.GlooInstanceSpec_Check_Summary
+		}{
+			{"deployments", "Deployment", glooInstance.Spec.GetCheck().GetDeployments()},
+			{"pods", "Pod", glooInstance.Spec.GetCheck().GetPods()},
+			{"settings", "Settings", glooInstance.Spec.GetCheck().GetSettings()},
+			{"upstreams", "Upstream", glooInstance.Spec.GetCheck().GetUpstreams()},
+			{"upstream groups", "UpstreamGroup", glooInstance.Spec.GetCheck().GetUpstreamGroups()},
+			{"auth configs", "AuthConfig", glooInstance.Spec.GetCheck().GetAuthConfigs()},
+			{"virtual services", "VirtualService", glooInstance.Spec.GetCheck().GetVirtualServices()},
+			{"route tables", "RouteTable", glooInstance.Spec.GetCheck().GetRouteTables()},
+			{"gateways", "Gateway", glooInstance.Spec.GetCheck().GetGateways()},
+			{"proxies", "Proxy", glooInstance.Spec.GetCheck().GetProxies()},
+		} {
+			if err := printGlooInstanceCheckSummary(summary.resourceType, summary.kind, summary.summary); err != nil {
+				multiErr = multierror.Append(multiErr, err)
+			}
+		}
+		printTable("\n\n")
 	}
+	return multiErr.ErrorOrNil()
 }
 
-func printGlooInstanceCheckSummary(resourceType string, resource *types.GlooInstanceSpec_Check_Summary) {
-	fmt.Printf("\nChecking %s... ", resourceType)
+func printGlooInstanceCheckSummary(resourceType, kind string, resource *types.GlooInstanceSpec_Check_Summary) error {
+	printTable("\nChecking %s... ", resourceType)
 
-	ok := true
+	var multiErr *multierror.Error
 	for _, errReport := range resource.GetErrors() {
-		fmt.Printf("\nFound error in %s %s\n", errReport.GetRef().GetNamespace(), errReport.GetRef().GetName())
-		fmt.Printf("Reason: %s\n", errReport.GetMessage())
-		ok = false
+		printTable("\nFound error in %s %s\n", errReport.GetRef().GetNamespace(), errReport.GetRef().GetName())
+		printTable("Reason: %s\n", errReport.GetMessage())
+		multiErr = multierror.Append(multiErr, newResourceIssue(printers.CheckSeverityError, kind,
+			errReport.GetRef().GetNamespace(), errReport.GetRef().GetName(), errReport.GetMessage()))
 	}
 	for _, warningReport := range resource.GetWarnings() {
-		fmt.Printf("Found warning in %s %s\n", warningReport.GetRef().GetNamespace(), warningReport.GetRef().GetName())
-		fmt.Printf("Reason: %s\n", warningReport.GetMessage())
-		ok = false
+		printTable("Found warning in %s %s\n", warningReport.GetRef().GetNamespace(), warningReport.GetRef().GetName())
+		printTable("Reason: %s\n", warningReport.GetMessage())
+		multiErr = multierror.Append(multiErr, newResourceIssue(printers.CheckSeverityWarning, kind,
+			warningReport.GetRef().GetNamespace(), warningReport.GetRef().GetName(), warningReport.GetMessage()))
+	}
+	if multiErr == nil {
+		printTable("OK")
 	}
-	if ok {
-		fmt.Printf("OK")
+	return multiErr.ErrorOrNil()
+}
+
+// the summaries of the gloo instances are only printed for table output, other outputs report their issues
+func printTable(format string, a ...interface{}) {
+	if printer.OutputType.IsTable() {
+		fmt.Printf(format, a...)
 	}
 }
 
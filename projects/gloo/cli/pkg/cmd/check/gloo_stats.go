@@ -43,7 +43,7 @@ func ResourcesSyncedOverXds(stats, deploymentName string) bool {
 		}
 	}
 	if len(outOfSyncResources) > 0 {
-		fmt.Println(resourcesOutOfSyncMessage(outOfSyncResources))
+		printer.AppendMessage(resourcesOutOfSyncMessage(outOfSyncResources))
 		return false
 	}
 	return true
@@ -56,7 +56,7 @@ func RateLimitIsConnected(stats string) bool {
 	metrics := parseMetrics(stats, []string{GlooeRateLimitConnectedState}, "gloo")
 
 	if val, ok := metrics[GlooeRateLimitConnectedState]; ok && val == 0 {
-		fmt.Println(connectedStateErrMessage)
+		printer.AppendMessage(connectedStateErrMessage)
 		return false
 	}
 
@@ -66,13 +66,13 @@ func RateLimitIsConnected(stats string) bool {
 func checkXdsMetrics(opts *options.Options, glooNamespace string, deployments *v1.DeploymentList) error {
 	errMessage := "Problem while checking for gloo xds errors"
 	if deployments == nil {
-		fmt.Println("Skipping due to an error in checking deployments")
+		printer.AppendMessage("Skipping due to an error in checking deployments")
 		return fmt.Errorf("xds metrics check was skipped due to an error in checking deployments")
 	}
 	// port-forward proxy deployment and get prometheus metrics
 	freePort, err := cliutil.GetFreePort()
 	if err != nil {
-		fmt.Println(errMessage)
+		printer.AppendMessage(errMessage)
 		return err
 	}
 	localPort := strconv.Itoa(freePort)
@@ -90,12 +90,12 @@ func checkXdsMetrics(opts *options.Options, glooNamespace string, deployments *v
 
 	if strings.TrimSpace(stats) == "" {
 		err := fmt.Sprint(errMessage+": could not find any metrics at", glooStatsPath, "endpoint of the "+glooDeployment+" deployment")
-		fmt.Println(err)
+		printer.AppendMessage(err)
 		return fmt.Errorf(err)
 	}
 
 	if !ResourcesSyncedOverXds(stats, glooDeployment) {
-		fmt.Println(errMessage)
+		printer.AppendMessage(errMessage)
 		return fmt.Errorf(errMessage)
 	}
 
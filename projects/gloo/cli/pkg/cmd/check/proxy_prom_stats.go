@@ -39,7 +39,7 @@ func checkProxyPromStats(ctx context.Context, glooNamespace string, deploymentNa
 	// port-forward proxy deployment and get prometheus metrics
 	freePort, err := cliutil.GetFreePort()
 	if err != nil {
-		fmt.Println(errMessage)
+		printer.AppendMessage(errMessage)
 		return err
 	}
 	localPort := strconv.Itoa(freePort)
@@ -48,7 +48,7 @@ func checkProxyPromStats(ctx context.Context, glooNamespace string, deploymentNa
 	stats, portFwdCmd, err := cliutil.PortForwardGet(ctx, glooNamespace, "deploy/"+deploymentName,
 		localPort, adminPort, false, promStatsPath)
 	if err != nil {
-		fmt.Println(errMessage)
+		printer.AppendMessage(errMessage)
 		return err
 	}
 	if portFwdCmd.Process != nil {
@@ -90,7 +90,7 @@ func checkProxyUpdate(stats string, localPort string, deploymentName string, err
 	// gather metrics again
 	res, err := http.Get("http://localhost:" + localPort + promStatsPath)
 	if err != nil {
-		fmt.Println(errMessage)
+		printer.AppendMessage(errMessage)
 		return err
 	}
 	if res.StatusCode != 200 {
@@ -99,7 +99,7 @@ func checkProxyUpdate(stats string, localPort string, deploymentName string, err
 	}
 	b, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		fmt.Println(errMessage)
+		printer.AppendMessage(errMessage)
 		return err
 	}
 	res.Body.Close()
@@ -160,8 +160,8 @@ func parseMetrics(stats string, desiredMetricSegments []string, deploymentName s
 			metric := strings.Join(pieces[0:len(pieces)-1], "")   // get all but last piece (as one string)- this is metric name and labels
 			metricVal, err := strconv.Atoi(pieces[len(pieces)-1]) // get last piece (as int)- this is metric value
 			if err != nil {
-				fmt.Printf("Found an unexpected format in metrics at %v endpoint of the "+deploymentName+" deployment. "+
-					"Expected %v metric to have an int value but got value %v.\nContinuing check...", promStatsPath, metric, pieces[len(pieces)-1])
+				printer.AppendMessage(fmt.Sprintf("Found an unexpected format in metrics at %v endpoint of the "+deploymentName+" deployment. "+
+					"Expected %v metric to have an int value but got value %v.\nContinuing check...", promStatsPath, metric, pieces[len(pieces)-1]))
 				continue
 			}
 			statsMap[metric] = metricVal
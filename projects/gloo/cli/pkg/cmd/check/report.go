@@ -0,0 +1,187 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/rotisserie/eris"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmdutils"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/printers"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+)
+
+// The identifiers of the checks, as included or excluded with --include and --exclude.
+// They are part of the output of glooctl check and must not change.
+const (
+	DeploymentsCheck        = "deployments"
+	PodsCheck               = "pods"
+	UpstreamsCheck          = "upstreams"
+	UpstreamGroupsCheck     = "upstreamgroup"
+	AuthConfigsCheck        = "auth-configs"
+	RateLimitConfigsCheck   = "rate-limit-configs"
+	VirtualHostOptionsCheck = "virtual-host-options"
+	RouteOptionsCheck       = "route-options"
+	SecretsCheck            = "secrets"
+	CertificatesCheck       = "certificates"
+	VirtualServicesCheck    = "virtual-services"
+	GatewaysCheck           = "gateways"
+	ProxiesCheck            = "proxies"
+	XdsMetricsCheck         = "xds-metrics"
+	GlooInstancesCheck      = "gloo-instances"
+)
+
+var AllChecks = []string{
+	DeploymentsCheck,
+	PodsCheck,
+	UpstreamsCheck,
+	UpstreamGroupsCheck,
+	AuthConfigsCheck,
+	RateLimitConfigsCheck,
+	VirtualHostOptionsCheck,
+	RouteOptionsCheck,
+	SecretsCheck,
+	CertificatesCheck,
+	VirtualServicesCheck,
+	GatewaysCheck,
+	ProxiesCheck,
+	XdsMetricsCheck,
+	GlooInstancesCheck,
+}
+
+const (
+	// glooctl check exits with this code when a check found errors
+	ErrorsExitCode = 1
+	// glooctl check exits with this code when the checks only found warnings
+	WarningsExitCode = 2
+)
+
+var (
+	UnknownCheckErr = func(name string) error {
+		return eris.Errorf("unknown check %s, must be one of: %s", name, strings.Join(AllChecks, ", "))
+	}
+)
+
+// An issue found by a check on a resource. Issues are errors, so that they are returned by the checks like any other
+// error; errors that are not issues are reported as issues of error severity without a resource.
+type checkIssue struct {
+	severity printers.CheckSeverity
+	resource *printers.CheckResourceRef
+	message  string
+}
+
+func (i *checkIssue) Error() string {
+	return i.message
+}
+
+func resourceError(kind string, metadata *core.Metadata, message string) error {
+	return newResourceIssue(printers.CheckSeverityError, kind, metadata.GetNamespace(), metadata.GetName(), message)
+}
+
+func resourceWarning(kind string, metadata *core.Metadata, message string) error {
+	return newResourceIssue(printers.CheckSeverityWarning, kind, metadata.GetNamespace(), metadata.GetName(), message)
+}
+
+// Returns the issue for a status reported on a resource, or nil if the status is neither rejected nor a warning
+func resourceStatusIssue(kind string, metadata *core.Metadata, state core.Status_State, message string) error {
+	switch state {
+	case core.Status_Rejected:
+		return resourceError(kind, metadata, message)
+	case core.Status_Warning:
+		return resourceWarning(kind, metadata, message)
+	}
+	return nil
+}
+
+func newResourceIssue(severity printers.CheckSeverity, kind, namespace, name, message string) error {
+	return &checkIssue{
+		severity: severity,
+		resource: &printers.CheckResourceRef{Kind: kind, Namespace: namespace, Name: name},
+		message:  message,
+	}
+}
+
+// Returns an error if the included or excluded checks are unknown
+func validateCheckNames(opts *options.Options) error {
+	for _, name := range append(append([]string{}, opts.Check.Include...), opts.Top.CheckName...) {
+		if doesNotContain(AllChecks, name) {
+			return UnknownCheckErr(name)
+		}
+	}
+	return nil
+}
+
+func isCheckIncluded(opts *options.Options, id string) bool {
+	if len(opts.Check.Include) > 0 && doesNotContain(opts.Check.Include, id) {
+		return false
+	}
+	return doesNotContain(opts.Top.CheckName, id)
+}
+
+// Runs the check and records its report, returning the error of the check
+func runCheck(id string, check func() error) error {
+	err := check()
+	printer.AppendCheckReport(checkReport(id, err))
+	return err
+}
+
+func checkReport(id string, err error) printers.CheckReport {
+	report := printers.CheckReport{Id: id, Status: "ok"}
+	for _, err := range flattenErrors(err) {
+		issue := printers.CheckIssue{Severity: printers.CheckSeverityError, Message: err.Error()}
+		var ci *checkIssue
+		if errors.As(err, &ci) {
+			issue.Severity = ci.severity
+			issue.Resource = ci.resource
+		}
+		report.Issues = append(report.Issues, issue)
+		if issue.Severity == printers.CheckSeverityError || report.Status == "ok" {
+			report.Status = string(issue.Severity)
+		}
+	}
+	return report
+}
+
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	var multiErr *multierror.Error
+	if errors.As(err, &multiErr) {
+		var errs []error
+		for _, wrapped := range multiErr.WrappedErrors() {
+			errs = append(errs, flattenErrors(wrapped)...)
+		}
+		return errs
+	}
+	return []error{err}
+}
+
+// Returns the error glooctl check fails with: warnings only make it exit with a distinct code, so that they can be
+// told apart from errors.
+func checkExitError(err error, summarize bool) error {
+	errs := flattenErrors(err)
+	if len(errs) == 0 {
+		return nil
+	}
+	var errCount, warningCount int
+	for _, err := range errs {
+		var ci *checkIssue
+		if errors.As(err, &ci) && ci.severity == printers.CheckSeverityWarning {
+			warningCount++
+		} else {
+			errCount++
+		}
+	}
+	code := ErrorsExitCode
+	if errCount == 0 {
+		code = WarningsExitCode
+	}
+	if summarize {
+		// the issues are part of the output already
+		err = fmt.Errorf("checks found %d errors and %d warnings", errCount, warningCount)
+	}
+	return &cmdutils.ExitError{Code: code, Err: err}
+}
@@ -1,10 +1,10 @@
 package check
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	gatewayv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
@@ -57,11 +57,14 @@ func RootCmd(opts *options.Options, optionsFunc ...cliutils.OptionsFunc) *cobra.
 	cmd := &cobra.Command{
 		Use:   constants.CHECK_COMMAND.Use,
 		Short: constants.CHECK_COMMAND.Short,
-		Long:  "usage: glooctl check [-o FORMAT]",
+		Long:  constants.CHECK_COMMAND.Long,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			if !opts.Top.Output.IsTable() && !opts.Top.Output.IsJSON() {
-				return errors.New("Invalid output type. Only table (default) and json are supported.")
+			if !opts.Top.Output.IsTable() && !opts.Top.Output.IsJSON() && !opts.Top.Output.IsYAML() {
+				return errors.New("Invalid output type. Only table (default), json and yaml are supported.")
+			}
+			if err := validateCheckNames(opts); err != nil {
+				return err
 			}
 
 			printer = printers.P{OutputType: opts.Top.Output}
@@ -69,29 +72,39 @@ func RootCmd(opts *options.Options, optionsFunc ...cliutils.OptionsFunc) *cobra.
 			err := CheckResources(opts)
 
 			if err != nil {
-				// Not returning error here because this shouldn't propagate as a standard CLI error, which prints usage.
+				// the table output stops at the first failing checks, other outputs report all the checks below
 				if opts.Top.Output.IsTable() {
-					return err
+					return checkExitError(err, false)
 				}
 			} else {
 				printer.AppendMessage("No problems detected.")
 			}
 
-			CheckMulticlusterResources(opts)
+			if isCheckIncluded(opts, GlooInstancesCheck) {
+				if instancesErr := runCheck(GlooInstancesCheck, func() error {
+					return CheckMulticlusterResources(opts)
+				}); instancesErr != nil {
+					err = multierror.Append(err, instancesErr)
+				}
+			}
 
 			CheckVersionsMatch(opts)
 
-			if opts.Top.Output.IsJSON() {
-				printer.PrintChecks(new(bytes.Buffer))
+			if !opts.Top.Output.IsTable() {
+				if printErr := printer.PrintChecks(os.Stdout); printErr != nil {
+					return printErr
+				}
 			}
 
-			return nil
+			return checkExitError(err, true)
 		},
 	}
 	pflags := cmd.PersistentFlags()
 	flagutils.AddCheckOutputFlag(pflags, &opts.Top.Output)
 	flagutils.AddNamespaceFlag(pflags, &opts.Metadata.Namespace)
 	flagutils.AddExcludeCheckFlag(pflags, &opts.Top.CheckName)
+	flagutils.AddIncludeCheckFlag(pflags, &opts.Check.Include)
+	flagutils.AddCertificateExpiryWindowFlag(pflags, &opts.Check.CertificateExpiryWindow, DefaultCertificateExpiryWindow)
 	cliutils.ApplyOptions(cmd, optionsFunc)
 	return cmd
 }
@@ -106,16 +119,22 @@ func CheckResources(opts *options.Options) error {
 	}
 
 	var deployments *appsv1.DeploymentList
-	deploymentsIncluded := doesNotContain(opts.Top.CheckName, "deployments")
+	deploymentsIncluded := isCheckIncluded(opts, DeploymentsCheck)
 	if deploymentsIncluded {
-		deployments, err = getAndCheckDeployments(opts)
+		err := runCheck(DeploymentsCheck, func() error {
+			var err error
+			deployments, err = getAndCheckDeployments(opts)
+			return err
+		})
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 		}
 	}
 
-	if included := doesNotContain(opts.Top.CheckName, "pods"); included {
-		err := checkPods(opts)
+	if isCheckIncluded(opts, PodsCheck) {
+		err := runCheck(PodsCheck, func() error {
+			return checkPods(opts)
+		})
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 		}
@@ -132,76 +151,123 @@ func CheckResources(opts *options.Options) error {
 	}
 
 	var knownUpstreams []string
-	if included := doesNotContain(opts.Top.CheckName, "upstreams"); included {
-		knownUpstreams, err = checkUpstreams(opts, namespaces)
+	if isCheckIncluded(opts, UpstreamsCheck) {
+		err := runCheck(UpstreamsCheck, func() error {
+			var err error
+			knownUpstreams, err = checkUpstreams(opts, namespaces)
+			return err
+		})
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 		}
 	}
 
-	if included := doesNotContain(opts.Top.CheckName, "upstreamgroup"); included {
-		err := checkUpstreamGroups(opts, namespaces)
+	if isCheckIncluded(opts, UpstreamGroupsCheck) {
+		err := runCheck(UpstreamGroupsCheck, func() error {
+			return checkUpstreamGroups(opts, namespaces)
+		})
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 		}
 	}
 
 	var knownAuthConfigs []string
-	if included := doesNotContain(opts.Top.CheckName, "auth-configs"); included {
-		knownAuthConfigs, err = checkAuthConfigs(opts, namespaces)
+	if isCheckIncluded(opts, AuthConfigsCheck) {
+		err := runCheck(AuthConfigsCheck, func() error {
+			var err error
+			knownAuthConfigs, err = checkAuthConfigs(opts, namespaces)
+			return err
+		})
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 		}
 	}
 
 	var knownRateLimitConfigs []string
-	if included := doesNotContain(opts.Top.CheckName, "rate-limit-configs"); included {
-		knownRateLimitConfigs, err = checkRateLimitConfigs(opts, namespaces)
+	if isCheckIncluded(opts, RateLimitConfigsCheck) {
+		err := runCheck(RateLimitConfigsCheck, func() error {
+			var err error
+			knownRateLimitConfigs, err = checkRateLimitConfigs(opts, namespaces)
+			return err
+		})
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 		}
 	}
 
-	knownVirtualHostOptions, err := checkVirtualHostOptions(opts, namespaces)
-	if err != nil {
-		multiErr = multierror.Append(multiErr, err)
+	var knownVirtualHostOptions []string
+	if isCheckIncluded(opts, VirtualHostOptionsCheck) {
+		err := runCheck(VirtualHostOptionsCheck, func() error {
+			var err error
+			knownVirtualHostOptions, err = checkVirtualHostOptions(opts, namespaces)
+			return err
+		})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
 	}
 
-	knownRouteOptions, err := checkRouteOptions(opts, namespaces)
-	if err != nil {
-		multiErr = multierror.Append(multiErr, err)
+	var knownRouteOptions []string
+	if isCheckIncluded(opts, RouteOptionsCheck) {
+		err := runCheck(RouteOptionsCheck, func() error {
+			var err error
+			knownRouteOptions, err = checkRouteOptions(opts, namespaces)
+			return err
+		})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
+	}
+
+	if isCheckIncluded(opts, SecretsCheck) {
+		err := runCheck(SecretsCheck, func() error {
+			return checkSecrets(opts, namespaces)
+		})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
 	}
 
-	if included := doesNotContain(opts.Top.CheckName, "secrets"); included {
-		err := checkSecrets(opts, namespaces)
+	if isCheckIncluded(opts, CertificatesCheck) {
+		err := runCheck(CertificatesCheck, func() error {
+			return checkCertificates(opts, namespaces)
+		})
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 		}
 	}
 
-	if included := doesNotContain(opts.Top.CheckName, "virtual-services"); included {
-		err = checkVirtualServices(opts, namespaces, knownUpstreams, knownAuthConfigs, knownRateLimitConfigs, knownVirtualHostOptions, knownRouteOptions)
+	if isCheckIncluded(opts, VirtualServicesCheck) {
+		err := runCheck(VirtualServicesCheck, func() error {
+			return checkVirtualServices(opts, namespaces, knownUpstreams, knownAuthConfigs, knownRateLimitConfigs, knownVirtualHostOptions, knownRouteOptions)
+		})
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 		}
 	}
 
-	if included := doesNotContain(opts.Top.CheckName, "gateways"); included {
-		err := checkGateways(opts, namespaces)
+	if isCheckIncluded(opts, GatewaysCheck) {
+		err := runCheck(GatewaysCheck, func() error {
+			return checkGateways(opts, namespaces)
+		})
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 		}
 	}
 
-	if included := doesNotContain(opts.Top.CheckName, "proxies"); included {
-		err := checkProxies(opts, namespaces, opts.Metadata.GetNamespace(), deployments, deploymentsIncluded)
+	if isCheckIncluded(opts, ProxiesCheck) {
+		err := runCheck(ProxiesCheck, func() error {
+			return checkProxies(opts, namespaces, opts.Metadata.GetNamespace(), deployments, deploymentsIncluded)
+		})
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 		}
 	}
 
-	if included := doesNotContain(opts.Top.CheckName, "xds-metrics"); included {
-		err = checkXdsMetrics(opts, opts.Metadata.GetNamespace(), deployments)
+	if isCheckIncluded(opts, XdsMetricsCheck) {
+		err := runCheck(XdsMetricsCheck, func() error {
+			return checkXdsMetrics(opts, opts.Metadata.GetNamespace(), deployments)
+		})
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 		}
@@ -221,13 +287,13 @@ func getAndCheckDeployments(opts *options.Options) (*appsv1.DeploymentList, erro
 	client, err := helpers.KubeClient()
 	if err != nil {
 		errMessage := "error getting KubeClient"
-		fmt.Println(errMessage)
+		printer.AppendMessage(errMessage)
 		return nil, fmt.Errorf(errMessage+": %v", err)
 	}
 	_, err = client.CoreV1().Namespaces().Get(opts.Top.Ctx, opts.Metadata.GetNamespace(), metav1.GetOptions{})
 	if err != nil {
 		errMessage := "Gloo namespace does not exist"
-		fmt.Println(errMessage)
+		printer.AppendMessage(errMessage)
 		return nil, fmt.Errorf(errMessage)
 	}
 	deployments, err := client.AppsV1().Deployments(opts.Metadata.GetNamespace()).List(opts.Top.Ctx, metav1.ListOptions{})
@@ -236,7 +302,7 @@ func getAndCheckDeployments(opts *options.Options) (*appsv1.DeploymentList, erro
 	}
 	if len(deployments.Items) == 0 {
 		errMessage := "Gloo is not installed"
-		fmt.Println(errMessage)
+		printer.AppendMessage(errMessage)
 		return nil, fmt.Errorf(errMessage)
 	}
 	var multiErr *multierror.Error
@@ -254,7 +320,7 @@ func getAndCheckDeployments(opts *options.Options) (*appsv1.DeploymentList, erro
 		for _, condition := range deployment.Status.Conditions {
 			setMessage(condition)
 			if condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue {
-				err := fmt.Errorf("Deployment %s in namespace %s failed to create pods!%s", deployment.Name, deployment.Namespace, message)
+				err := deploymentError(deployment, fmt.Sprintf("Deployment %s in namespace %s failed to create pods!%s", deployment.Name, deployment.Namespace, message))
 				multiErr = multierror.Append(multiErr, err)
 			}
 		}
@@ -262,7 +328,7 @@ func getAndCheckDeployments(opts *options.Options) (*appsv1.DeploymentList, erro
 		for _, condition := range deployment.Status.Conditions {
 			setMessage(condition)
 			if condition.Type == appsv1.DeploymentProgressing && condition.Status != corev1.ConditionTrue {
-				err := fmt.Errorf("Deployment %s in namespace %s is not progressing!%s", deployment.Name, deployment.Namespace, message)
+				err := deploymentError(deployment, fmt.Sprintf("Deployment %s in namespace %s is not progressing!%s", deployment.Name, deployment.Namespace, message))
 				multiErr = multierror.Append(multiErr, err)
 			}
 		}
@@ -270,7 +336,7 @@ func getAndCheckDeployments(opts *options.Options) (*appsv1.DeploymentList, erro
 		for _, condition := range deployment.Status.Conditions {
 			setMessage(condition)
 			if condition.Type == appsv1.DeploymentAvailable && condition.Status != corev1.ConditionTrue {
-				err := fmt.Errorf("Deployment %s in namespace %s is not available!%s", deployment.Name, deployment.Namespace, message)
+				err := deploymentError(deployment, fmt.Sprintf("Deployment %s in namespace %s is not available!%s", deployment.Name, deployment.Namespace, message))
 				multiErr = multierror.Append(multiErr, err)
 			}
 
@@ -280,7 +346,7 @@ func getAndCheckDeployments(opts *options.Options) (*appsv1.DeploymentList, erro
 			if condition.Type != appsv1.DeploymentAvailable &&
 				condition.Type != appsv1.DeploymentReplicaFailure &&
 				condition.Type != appsv1.DeploymentProgressing {
-				err := fmt.Errorf("Deployment %s has an unhandled deployment condition %s", deployment.Name, condition.Type)
+				err := deploymentError(deployment, fmt.Sprintf("Deployment %s has an unhandled deployment condition %s", deployment.Name, condition.Type))
 				multiErr = multierror.Append(multiErr, err)
 			}
 		}
@@ -339,11 +405,11 @@ func checkPods(opts *options.Options) error {
 					errorToPrint = fmt.Sprintf("Not all containers in pod %s in namespace %s are ready!%s", pod.Name, pod.Namespace, message)
 				}
 			default:
-				fmt.Printf("Note: Unhandled pod condition %s", condition.Type)
+				printer.AppendMessage(fmt.Sprintf("Note: Unhandled pod condition %s", condition.Type))
 			}
 
 			if errorToPrint != "" {
-				multiErr = multierror.Append(multiErr, fmt.Errorf(errorToPrint))
+				multiErr = multierror.Append(multiErr, newResourceIssue(printers.CheckSeverityError, "Pod", pod.Namespace, pod.Name, errorToPrint))
 			}
 		}
 	}
@@ -373,7 +439,7 @@ func getNamespaces(ctx context.Context, settings *v1.Settings) ([]string, error)
 
 func checkUpstreams(opts *options.Options, namespaces []string) ([]string, error) {
 	printer.AppendCheck("Checking upstreams... ")
-	var knownUpstreams []string
+	knownUpstreams := []string{}
 	var multiErr *multierror.Error
 	for _, ns := range namespaces {
 		client, err := helpers.UpstreamClient(opts.Top.Ctx, []string{ns})
@@ -394,11 +460,11 @@ func checkUpstreams(opts *options.Options, namespaces []string) ([]string, error
 					case core.Status_Rejected:
 						errMessage := fmt.Sprintf("Found rejected upstream by '%s': %s ", reporter, renderMetadata(upstream.GetMetadata()))
 						errMessage += fmt.Sprintf("(Reason: %s)", status.GetReason())
-						multiErr = multierror.Append(multiErr, errors.New(errMessage))
+						multiErr = multierror.Append(multiErr, resourceError("Upstream", upstream.GetMetadata(), errMessage))
 					case core.Status_Warning:
 						errMessage := fmt.Sprintf("Found upstream with warnings by '%s': %s ", reporter, renderMetadata(upstream.GetMetadata()))
 						errMessage += fmt.Sprintf("(Reason: %s)", status.GetReason())
-						multiErr = multierror.Append(multiErr, errors.New(errMessage))
+						multiErr = multierror.Append(multiErr, resourceWarning("Upstream", upstream.GetMetadata(), errMessage))
 					}
 				}
 				knownUpstreams = append(knownUpstreams, renderMetadata(upstream.GetMetadata()))
@@ -438,11 +504,11 @@ func checkUpstreamGroups(opts *options.Options, namespaces []string) error {
 					case core.Status_Rejected:
 						errMessage := fmt.Sprintf("Found rejected upstream group by '%s': %s ", reporter, renderMetadata(upstreamGroup.GetMetadata()))
 						errMessage += fmt.Sprintf("(Reason: %s)", status.GetReason())
-						multiErr = multierror.Append(multiErr, errors.New(errMessage))
+						multiErr = multierror.Append(multiErr, resourceError("UpstreamGroup", upstreamGroup.GetMetadata(), errMessage))
 					case core.Status_Warning:
 						errMessage := fmt.Sprintf("Found upstream group with warnings by '%s': %s ", reporter, renderMetadata(upstreamGroup.GetMetadata()))
 						errMessage += fmt.Sprintf("(Reason: %s)", status.GetReason())
-						multiErr = multierror.Append(multiErr, errors.New(errMessage))
+						multiErr = multierror.Append(multiErr, resourceWarning("UpstreamGroup", upstreamGroup.GetMetadata(), errMessage))
 					}
 				}
 			}
@@ -458,7 +524,7 @@ func checkUpstreamGroups(opts *options.Options, namespaces []string) error {
 
 func checkAuthConfigs(opts *options.Options, namespaces []string) ([]string, error) {
 	printer.AppendCheck("Checking auth configs... ")
-	var knownAuthConfigs []string
+	knownAuthConfigs := []string{}
 	var multiErr *multierror.Error
 	for _, ns := range namespaces {
 		authConfigClient, err := helpers.AuthConfigClient(opts.Top.Ctx, []string{ns})
@@ -479,11 +545,11 @@ func checkAuthConfigs(opts *options.Options, namespaces []string) ([]string, err
 					case core.Status_Rejected:
 						errMessage := fmt.Sprintf("Found rejected auth config by '%s': %s ", reporter, renderMetadata(authConfig.GetMetadata()))
 						errMessage += fmt.Sprintf("(Reason: %s)", status.GetReason())
-						multiErr = multierror.Append(multiErr, errors.New(errMessage))
+						multiErr = multierror.Append(multiErr, resourceError("AuthConfig", authConfig.GetMetadata(), errMessage))
 					case core.Status_Warning:
 						errMessage := fmt.Sprintf("Found auth config with warnings by '%s': %s ", reporter, renderMetadata(authConfig.GetMetadata()))
 						errMessage += fmt.Sprintf("(Reason: %s)", status.GetReason())
-						multiErr = multierror.Append(multiErr, errors.New(errMessage))
+						multiErr = multierror.Append(multiErr, resourceWarning("AuthConfig", authConfig.GetMetadata(), errMessage))
 					}
 				}
 				knownAuthConfigs = append(knownAuthConfigs, renderMetadata(authConfig.GetMetadata()))
@@ -500,7 +566,7 @@ func checkAuthConfigs(opts *options.Options, namespaces []string) ([]string, err
 
 func checkRateLimitConfigs(opts *options.Options, namespaces []string) ([]string, error) {
 	printer.AppendCheck("Checking rate limit configs... ")
-	var knownConfigs []string
+	knownConfigs := []string{}
 	var multiErr *multierror.Error
 	for _, ns := range namespaces {
 
@@ -508,7 +574,7 @@ func checkRateLimitConfigs(opts *options.Options, namespaces []string) ([]string
 		if err != nil {
 			if isCrdNotFoundErr(ratelimit.RateLimitConfigCrd, err) {
 				// Just warn. If the CRD is required, the check would have failed on the crashing gloo/gloo-ee pod.
-				fmt.Printf("WARN: %s\n", CrdNotFoundErr(ratelimit.RateLimitConfigCrd.KindName).Error())
+				printer.AppendMessage(fmt.Sprintf("WARN: %s", CrdNotFoundErr(ratelimit.RateLimitConfigCrd.KindName).Error()))
 				return nil, nil
 			}
 			return nil, err
@@ -522,7 +588,7 @@ func checkRateLimitConfigs(opts *options.Options, namespaces []string) ([]string
 			if config.Status.GetState() == v1alpha1.RateLimitConfigStatus_REJECTED {
 				errMessage := fmt.Sprintf("Found rejected rate limit config: %s ", renderMetadata(config.GetMetadata()))
 				errMessage += fmt.Sprintf("(Reason: %s)", config.Status.GetMessage())
-				multiErr = multierror.Append(multiErr, fmt.Errorf(errMessage))
+				multiErr = multierror.Append(multiErr, resourceError("RateLimitConfig", config.GetMetadata(), errMessage))
 			}
 
 			knownConfigs = append(knownConfigs, renderMetadata(config.GetMetadata()))
@@ -540,14 +606,14 @@ func checkRateLimitConfigs(opts *options.Options, namespaces []string) ([]string
 
 func checkVirtualHostOptions(opts *options.Options, namespaces []string) ([]string, error) {
 	printer.AppendCheck("Checking VirtualHostOptions... ")
-	var knownVhOpts []string
+	knownVhOpts := []string{}
 	var multiErr *multierror.Error
 	for _, ns := range namespaces {
 		vhoptClient, err := helpers.VirtualHostOptionClient(opts.Top.Ctx, []string{ns})
 		if err != nil {
 			if isCrdNotFoundErr(gatewayv1.VirtualHostOptionCrd, err) {
 				// Just warn. If the CRD is required, the check would have failed on the crashing gloo/gloo-ee pod.
-				fmt.Printf("WARN: %s\n", CrdNotFoundErr(gatewayv1.VirtualHostOptionCrd.KindName).Error())
+				printer.AppendMessage(fmt.Sprintf("WARN: %s", CrdNotFoundErr(gatewayv1.VirtualHostOptionCrd.KindName).Error()))
 				return nil, nil
 			}
 			return nil, err
@@ -564,11 +630,11 @@ func checkVirtualHostOptions(opts *options.Options, namespaces []string) ([]stri
 					case core.Status_Rejected:
 						errMessage := fmt.Sprintf("Found rejected VirtualHostOption by '%s': %s ", reporter, renderMetadata(vhOpt.GetMetadata()))
 						errMessage += fmt.Sprintf("(Reason: %s)", status.GetReason())
-						multiErr = multierror.Append(multiErr, errors.New(errMessage))
+						multiErr = multierror.Append(multiErr, resourceError("VirtualHostOption", vhOpt.GetMetadata(), errMessage))
 					case core.Status_Warning:
 						errMessage := fmt.Sprintf("Found VirtualHostOption with warnings by '%s': %s ", reporter, renderMetadata(vhOpt.GetMetadata()))
 						errMessage += fmt.Sprintf("(Reason: %s)", status.GetReason())
-						multiErr = multierror.Append(multiErr, errors.New(errMessage))
+						multiErr = multierror.Append(multiErr, resourceWarning("VirtualHostOption", vhOpt.GetMetadata(), errMessage))
 					}
 				}
 				knownVhOpts = append(knownVhOpts, renderMetadata(vhOpt.GetMetadata()))
@@ -585,14 +651,14 @@ func checkVirtualHostOptions(opts *options.Options, namespaces []string) ([]stri
 
 func checkRouteOptions(opts *options.Options, namespaces []string) ([]string, error) {
 	printer.AppendCheck("Checking RouteOptions... ")
-	var knownVhOpts []string
+	knownVhOpts := []string{}
 	var multiErr *multierror.Error
 	for _, ns := range namespaces {
 		routeOptionClient, err := helpers.RouteOptionClient(opts.Top.Ctx, []string{ns})
 		if err != nil {
 			if isCrdNotFoundErr(gatewayv1.RouteOptionCrd, err) {
 				// Just warn. If the CRD is required, the check would have failed on the crashing gloo/gloo-ee pod.
-				fmt.Printf("WARN: %s\n", CrdNotFoundErr(gatewayv1.RouteOptionCrd.KindName).Error())
+				printer.AppendMessage(fmt.Sprintf("WARN: %s", CrdNotFoundErr(gatewayv1.RouteOptionCrd.KindName).Error()))
 				return nil, nil
 			}
 			return nil, err
@@ -609,11 +675,11 @@ func checkRouteOptions(opts *options.Options, namespaces []string) ([]string, er
 					case core.Status_Rejected:
 						errMessage := fmt.Sprintf("Found rejected RouteOption by '%s': %s ", reporter, renderMetadata(routeOpt.GetMetadata()))
 						errMessage += fmt.Sprintf("(Reason: %s)", status.GetReason())
-						multiErr = multierror.Append(multiErr, errors.New(errMessage))
+						multiErr = multierror.Append(multiErr, resourceError("RouteOption", routeOpt.GetMetadata(), errMessage))
 					case core.Status_Warning:
 						errMessage := fmt.Sprintf("Found RouteOption with warnings by '%s': %s ", reporter, renderMetadata(routeOpt.GetMetadata()))
 						errMessage += fmt.Sprintf("(Reason: %s)", status.GetReason())
-						multiErr = multierror.Append(multiErr, errors.New(errMessage))
+						multiErr = multierror.Append(multiErr, resourceWarning("RouteOption", routeOpt.GetMetadata(), errMessage))
 					}
 				}
 				knownVhOpts = append(knownVhOpts, renderMetadata(routeOpt.GetMetadata()))
@@ -651,11 +717,11 @@ func checkVirtualServices(opts *options.Options, namespaces, knownUpstreams, kno
 					case core.Status_Rejected:
 						errMessage := fmt.Sprintf("Found rejected virtual service by '%s': %s ", reporter, renderMetadata(virtualService.GetMetadata()))
 						errMessage += fmt.Sprintf("(Reason: %s)", status.GetReason())
-						multiErr = multierror.Append(multiErr, fmt.Errorf(errMessage))
+						multiErr = multierror.Append(multiErr, resourceError("VirtualService", virtualService.GetMetadata(), errMessage))
 					case core.Status_Warning:
 						errMessage := fmt.Sprintf("Found virtual service with warnings by '%s': %s ", reporter, renderMetadata(virtualService.GetMetadata()))
 						errMessage += fmt.Sprintf("(Reason: %s)", status.GetReason())
-						multiErr = multierror.Append(multiErr, fmt.Errorf(errMessage))
+						multiErr = multierror.Append(multiErr, resourceWarning("VirtualService", virtualService.GetMetadata(), errMessage))
 					}
 				}
 			}
@@ -664,13 +730,13 @@ func checkVirtualServices(opts *options.Options, namespaces, knownUpstreams, kno
 				if route.GetRouteAction() != nil {
 					if route.GetRouteAction().GetSingle() != nil {
 						us := route.GetRouteAction().GetSingle()
-						if us.GetUpstream() != nil {
+						if us.GetUpstream() != nil && knownUpstreams != nil {
 							if !cliutils.Contains(knownUpstreams, renderRef(us.GetUpstream())) {
 								// TODO warning message if using rejected or warning upstream
 								errMessage := "Virtual service references unknown upstream: "
 								errMessage += fmt.Sprintf("(Virtual service: %s", renderMetadata(virtualService.GetMetadata()))
 								errMessage += fmt.Sprintf(" | Upstream: %s)", renderRef(us.GetUpstream()))
-								multiErr = multierror.Append(multiErr, fmt.Errorf(errMessage))
+								multiErr = multierror.Append(multiErr, resourceError("VirtualService", virtualService.GetMetadata(), errMessage))
 							}
 						}
 					}
@@ -680,23 +746,23 @@ func checkVirtualServices(opts *options.Options, namespaces, knownUpstreams, kno
 			// Check references to auth configs
 			isAuthConfigRefValid := func(knownConfigs []string, ref *core.ResourceRef) error {
 				// If the virtual service points to a specific, non-existent authconfig, it is not valid.
-				if ref != nil && !cliutils.Contains(knownConfigs, renderRef(ref)) {
+				if ref != nil && knownConfigs != nil && !cliutils.Contains(knownConfigs, renderRef(ref)) {
 					// TODO: Virtual service references rejected or warning auth config
 					errMessage := "Virtual service references unknown auth config:\n"
 					errMessage += fmt.Sprintf("  Virtual service: %s\n", renderMetadata(virtualService.GetMetadata()))
 					errMessage += fmt.Sprintf("  Auth Config: %s\n", renderRef(ref))
-					return fmt.Errorf(errMessage)
+					return resourceError("VirtualService", virtualService.GetMetadata(), errMessage)
 				}
 				return nil
 			}
 			isOptionsRefValid := func(knownOptions []string, refs []*core.ResourceRef) error {
 				// If the virtual host points to a specifc, non-existent VirtualHostOption, it is not valid.
 				for _, ref := range refs {
-					if ref != nil && !cliutils.Contains(knownOptions, renderRef(ref)) {
+					if ref != nil && knownOptions != nil && !cliutils.Contains(knownOptions, renderRef(ref)) {
 						errMessage := "Virtual service references unknown VirtualHostOption:\n"
 						errMessage += fmt.Sprintf("  Virtual service: %s\n", renderMetadata(virtualService.GetMetadata()))
 						errMessage += fmt.Sprintf("  VirtualHostOption: %s\n", renderRef(ref))
-						return fmt.Errorf(errMessage)
+						return resourceError("VirtualService", virtualService.GetMetadata(), errMessage)
 					}
 				}
 				return nil
@@ -732,12 +798,12 @@ func checkVirtualServices(opts *options.Options, namespaces, knownUpstreams, kno
 					Name:      ref.GetName(),
 					Namespace: ref.GetNamespace(),
 				}
-				if !cliutils.Contains(knownConfigs, renderRef(resourceRef)) {
+				if knownConfigs != nil && !cliutils.Contains(knownConfigs, renderRef(resourceRef)) {
 					// TODO: check if references rate limit config with error or warning
 					errMessage := "Virtual service references unknown rate limit config:\n"
 					errMessage += fmt.Sprintf("  Virtual service: %s\n", renderMetadata(virtualService.GetMetadata()))
 					errMessage += fmt.Sprintf("  Rate Limit Config: %s\n", renderRef(resourceRef))
-					return fmt.Errorf(errMessage)
+					return resourceError("VirtualService", virtualService.GetMetadata(), errMessage)
 				}
 				return nil
 			}
@@ -788,11 +854,11 @@ func checkGateways(opts *options.Options, namespaces []string) error {
 					case core.Status_Rejected:
 						errMessage := fmt.Sprintf("Found rejected gateway by '%s': %s\n", reporter, renderMetadata(gateway.GetMetadata()))
 						errMessage += fmt.Sprintf("Reason: %s\n", status.GetReason())
-						multiErr = multierror.Append(multiErr, fmt.Errorf(errMessage))
+						multiErr = multierror.Append(multiErr, resourceError("Gateway", gateway.GetMetadata(), errMessage))
 					case core.Status_Warning:
 						errMessage := fmt.Sprintf("Found gateway with warnings by '%s': %s\n", reporter, renderMetadata(gateway.GetMetadata()))
 						errMessage += fmt.Sprintf("Reason: %s\n", status.GetReason())
-						multiErr = multierror.Append(multiErr, fmt.Errorf(errMessage))
+						multiErr = multierror.Append(multiErr, resourceWarning("Gateway", gateway.GetMetadata(), errMessage))
 					}
 				}
 			}
@@ -815,7 +881,7 @@ func checkProxies(opts *options.Options, namespaces []string, glooNamespace stri
 		return nil
 	}
 	if deployments == nil {
-		fmt.Println("Skipping due to an error in checking deployments")
+		printer.AppendMessage("Skipping due to an error in checking deployments")
 		return fmt.Errorf("proxy check was skipped due to an error in checking deployments")
 	}
 	var multiErr *multierror.Error
@@ -838,11 +904,11 @@ func checkProxies(opts *options.Options, namespaces []string, glooNamespace stri
 					case core.Status_Rejected:
 						errMessage := fmt.Sprintf("Found rejected proxy by '%s': %s\n", reporter, renderMetadata(proxy.GetMetadata()))
 						errMessage += fmt.Sprintf("Reason: %s\n", status.GetReason())
-						multiErr = multierror.Append(multiErr, fmt.Errorf(errMessage))
+						multiErr = multierror.Append(multiErr, resourceError("Proxy", proxy.GetMetadata(), errMessage))
 					case core.Status_Warning:
 						errMessage := fmt.Sprintf("Found proxy with warnings by '%s': %s\n", reporter, renderMetadata(proxy.GetMetadata()))
 						errMessage += fmt.Sprintf("Reason: %s\n", status.GetReason())
-						multiErr = multierror.Append(multiErr, fmt.Errorf(errMessage))
+						multiErr = multierror.Append(multiErr, resourceWarning("Proxy", proxy.GetMetadata(), errMessage))
 					}
 				}
 			}
@@ -886,6 +952,10 @@ func checkSecrets(opts *options.Options, namespaces []string) error {
 	return nil
 }
 
+func deploymentError(deployment appsv1.Deployment, message string) error {
+	return newResourceIssue(printers.CheckSeverityError, "Deployment", deployment.Namespace, deployment.Name, message)
+}
+
 func renderMetadata(metadata *core.Metadata) string {
 	return renderNamespaceName(metadata.GetNamespace(), metadata.GetName())
 }
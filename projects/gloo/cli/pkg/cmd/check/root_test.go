@@ -2,7 +2,9 @@ package check_test
 
 import (
 	"context"
+	"encoding/json"
 	"os"
+	"strings"
 
 	gloostatusutils "github.com/solo-io/gloo/pkg/utils/statusutils"
 
@@ -11,7 +13,10 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	v12 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/check"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmdutils"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/helpers"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/printers"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/testutils"
 	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
 	"github.com/solo-io/gloo/projects/gloo/pkg/defaults"
@@ -162,6 +167,57 @@ var _ = Describe("Root", func() {
 
 		})
 
+		It("reports the issues of each check as json and exits with the warnings exit code", func() {
+			client := helpers.MustKubeClient()
+			client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: defaults.GlooSystem,
+				},
+			}, metav1.CreateOptions{})
+
+			helpers.MustNamespacedSettingsClient(ctx, "gloo-system").Write(&v1.Settings{
+				Metadata: &core.Metadata{
+					Name:      "default",
+					Namespace: "gloo-system",
+				},
+			}, clients.WriteOpts{})
+
+			warningUpstream := &v1.Upstream{
+				Metadata: &core.Metadata{
+					Name:      "some-warning-upstream",
+					Namespace: "gloo-system",
+				},
+			}
+			statusClient.SetStatus(warningUpstream, &core.Status{
+				State:      core.Status_Warning,
+				Reason:     "I am an upstream with a warning",
+				ReportedBy: "gateway",
+			})
+			_, usErr := helpers.MustNamespacedUpstreamClient(ctx, "gloo-system").Write(warningUpstream, clients.WriteOpts{})
+			Expect(usErr).NotTo(HaveOccurred())
+
+			output, err := testutils.GlooctlOut("check --include upstreams,upstreamgroup -o json")
+			Expect(err).To(HaveOccurred())
+			Expect(cmdutils.ExitCode(err)).To(Equal(check.WarningsExitCode))
+
+			var result printers.CheckResult
+			Expect(json.Unmarshal([]byte(jsonLine(output)), &result)).NotTo(HaveOccurred())
+			Expect(result.Checks).To(HaveLen(2))
+			Expect(result.Checks[0].Id).To(Equal(check.UpstreamsCheck))
+			Expect(result.Checks[0].Status).To(Equal("warning"))
+			Expect(result.Checks[0].Issues).To(HaveLen(1))
+			issue := result.Checks[0].Issues[0]
+			Expect(issue.Severity).To(Equal(printers.CheckSeverityWarning))
+			Expect(issue.Message).To(ContainSubstring("Found upstream with warnings"))
+			Expect(issue.Resource).To(Equal(&printers.CheckResourceRef{Kind: "Upstream", Namespace: "gloo-system", Name: "some-warning-upstream"}))
+			Expect(result.Checks[1]).To(Equal(printers.CheckReport{Id: check.UpstreamGroupsCheck, Status: "ok"}))
+		})
+
+		It("rejects unknown checks", func() {
+			_, err := testutils.GlooctlOut("check --include upstream")
+			Expect(err).To(MatchError(check.UnknownCheckErr("upstream").Error()))
+		})
+
 	})
 
 	Context("With a custom namespace", func() {
@@ -376,6 +432,26 @@ var _ = Describe("Root", func() {
 			Expect(output).To(ContainSubstring("Checking proxies... OK"))
 		})
 
+		It("can include checks", func() {
+			output, err := testutils.GlooctlOut("check --include pods,upstreams")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(output).NotTo(ContainSubstring("Checking deployments..."))
+			Expect(output).To(ContainSubstring("Checking pods... OK"))
+			Expect(output).To(ContainSubstring("Checking upstreams... OK"))
+			Expect(output).NotTo(ContainSubstring("Checking upstream groups..."))
+			Expect(output).NotTo(ContainSubstring("Checking virtual services..."))
+			Expect(output).NotTo(ContainSubstring("Checking proxies..."))
+		})
+
+		It("excludes checks which are included", func() {
+			output, err := testutils.GlooctlOut("check --include pods,upstreams -x upstreams")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(output).To(ContainSubstring("Checking pods... OK"))
+			Expect(output).NotTo(ContainSubstring("Checking upstreams..."))
+		})
+
 		It("can exclude proxies", func() {
 			output, err := testutils.GlooctlOut("check -x xds-metrics,proxies")
 			Expect(err).NotTo(HaveOccurred())
@@ -395,3 +471,13 @@ var _ = Describe("Root", func() {
 	})
 
 })
+
+// the output also contains the error printed by the command, after the json output
+func jsonLine(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "{") {
+			return line
+		}
+	}
+	return ""
+}
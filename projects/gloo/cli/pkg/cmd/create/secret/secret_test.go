@@ -201,6 +201,38 @@ metadata:
 			Expect(*secret.GetTls()).To(Equal(tls))
 		})
 
+		It("should store the crl", func() {
+			rootca := mustWriteTestFile("foo")
+			defer os.Remove(rootca)
+			crl := mustWriteTestFile("crl")
+			defer os.Remove(crl)
+			err := testutils.Glooctl("create secret tls revoked --namespace gloo-system --rootca " + rootca + " --crl " + crl)
+			Expect(err).NotTo(HaveOccurred())
+
+			secret, err := helpers.MustSecretClient(ctx).Read("gloo-system", "revoked", clients.ReadOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.GetTls().GetCrl()).To(Equal("crl"))
+		})
+
+		It("should store the ocsp staple", func() {
+			privatekey := mustWriteTestFile(privateKey1)
+			defer os.Remove(privatekey)
+			certchain := mustWriteTestFile(privateKey1Cert)
+			defer os.Remove(certchain)
+			ocspstaple := mustWriteTestFile("staple")
+			defer os.Remove(ocspstaple)
+			err := testutils.Glooctl(fmt.Sprintf(
+				"create secret tls stapled --namespace gloo-system --privatekey %s --certchain %s --ocspstaple %s",
+				privatekey,
+				certchain,
+				ocspstaple))
+			Expect(err).NotTo(HaveOccurred())
+
+			secret, err := helpers.MustSecretClient(ctx).Read("gloo-system", "stapled", clients.ReadOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.GetTls().GetOcspStaple()).To(Equal([]byte("staple")))
+		})
+
 		It("should work as expected with valid and invalid input", func() {
 			type keyPair struct {
 				shouldPass   bool
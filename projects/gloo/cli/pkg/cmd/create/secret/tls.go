@@ -45,6 +45,8 @@ func tlsCmd(opts *options.Options) *cobra.Command {
 	flags.StringVar(&input.RootCaFilename, "rootca", "", "filename of rootca for secret")
 	flags.StringVar(&input.PrivateKeyFilename, "privatekey", "", "filename of privatekey for secret")
 	flags.StringVar(&input.CertChainFilename, "certchain", "", "filename of certchain for secret")
+	flags.StringVar(&input.OcspStapleFilename, "ocspstaple", "", "filename of the DER encoded OCSP response to staple to the certificate (optional)")
+	flags.StringVar(&input.CrlFilename, "crl", "", "filename of the PEM encoded certificate revocation list to check the peer certificates against, along with the rootca (optional)")
 
 	return cmd
 }
@@ -77,6 +79,14 @@ func createTlsSecret(ctx context.Context, meta *core.Metadata, input options.Tls
 	if err != nil {
 		return err
 	}
+	ocspStaple, err := input.ReadOcspStaple()
+	if err != nil {
+		return err
+	}
+	crl, err := input.ReadCrl()
+	if err != nil {
+		return err
+	}
 
 	secret := &gloov1.Secret{
 		Metadata: meta,
@@ -85,6 +95,8 @@ func createTlsSecret(ctx context.Context, meta *core.Metadata, input options.Tls
 				CertChain:  string(certChain),
 				PrivateKey: string(privateKey),
 				RootCa:     string(rootCa),
+				OcspStaple: ocspStaple,
+				Crl:        crl,
 			},
 		},
 	}
@@ -0,0 +1,594 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	envoycore "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoyquic "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/quic/v3"
+	envoyauth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rotisserie/eris"
+	"github.com/solo-io/gloo/pkg/cliutil"
+	"github.com/solo-io/gloo/pkg/cliutil/install"
+	installcmd "github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/install"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/version"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/helpers"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/printers"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/xdsinspection"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/compress"
+	envoyaws "github.com/solo-io/gloo/projects/gloo/pkg/api/external/envoy/extensions/aws"
+	envoytransformation "github.com/solo-io/gloo/projects/gloo/pkg/api/external/envoy/extensions/transformation"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/defaults"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins/transformation"
+	"github.com/solo-io/gloo/projects/gloo/pkg/utils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	BundleFilename = "/tmp/gloo-debug-bundle.tgz"
+
+	// the value of the redacted secret data in the bundle
+	RedactedValue = "<redacted>"
+
+	// the file of the bundle listing what could not be collected
+	bundleErrorsFile = "errors.txt"
+
+	proxyPodSelector = "gloo in (gateway-proxy,ingress-proxy,knative-external-proxy,knative-internal-proxy)"
+	glooPodSelector  = "gloo=gloo"
+	glooMetricsPath  = "/metrics"
+	dirPermissions   = 0755
+)
+
+// visible for testing
+var getXdsDump = func(ctx context.Context, proxyName, namespace string) (*xdsinspection.XdsDump, error) {
+	return xdsinspection.GetGlooXdsDump(ctx, proxyName, namespace, false)
+}
+
+// The Envoy admin endpoints collected from each proxy pod, by file name.
+var envoyAdminPaths = map[string]string{
+	"stats.txt":    "/stats",
+	"clusters.txt": "/clusters",
+}
+
+// The config dump of Envoy is only collected with the secret values: Envoy only redacts the private keys in it, not
+// the AWS credentials of the clusters nor the tokens and keys added by the routes. The xDS configuration collected
+// from Gloo holds the same configuration, redacted.
+const envoyConfigDumpFile, envoyConfigDumpPath = "config_dump.json", "/config_dump"
+
+// the function keys of the routes to Azure upstreams are set in the code query parameter of their path
+var azureFunctionKeyRegex = regexp.MustCompile(`([?&]code=)[^&]*`)
+
+func DebugBundle(opts *options.Options, w io.Writer) error {
+	file := opts.Top.File
+	if file == "" {
+		file = BundleFilename
+	}
+	collectErrs, err := WriteBundle(opts, &install.CmdKubectl{}, file)
+	if err != nil {
+		return err
+	}
+	if len(collectErrs) > 0 {
+		fmt.Fprintf(w, "%d items could not be collected, see %s in the bundle\n", len(collectErrs), bundleErrorsFile)
+	}
+	_, err = fmt.Fprintf(w, "wrote debug bundle to %s\n", file)
+	return err
+}
+
+// Collects the state of Gloo into a tarball written to the given file: the versions, the Kubernetes resources of the
+// installation with their events and logs, all the Gloo resources with their statuses, the decompressed proxies and
+// the xDS configuration Gloo serves for them, the configuration and stats of each Envoy, and the controller metrics.
+// The values of the secrets, the credentials in the Settings and the private keys, AWS credentials, tokens and function
+// keys in the xDS configuration are redacted, and the Envoy config dumps are left out, unless
+// opts.Debug.IncludeSecretValues is set.
+// Collection is best effort: the errors of what could not be collected are listed in the bundle and returned, and
+// only failing to write the bundle fails.
+// visible for testing
+func WriteBundle(opts *options.Options, kubeCli install.KubeCli, file string) ([]error, error) {
+	fs := afero.NewOsFs()
+	dir, err := afero.TempDir(fs, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer fs.RemoveAll(dir)
+
+	b := &bundle{
+		ctx:       opts.Top.Ctx,
+		namespace: opts.Metadata.GetNamespace(),
+		fs:        fs,
+		dir:       dir,
+		kubeCli:   kubeCli,
+	}
+	if b.ctx == nil {
+		b.ctx = context.Background()
+	}
+	b.collectVersions()
+	b.collectKubeResources()
+	b.collectGlooResources(opts.Debug.IncludeSecretValues)
+	b.collectProxies(opts.Debug.IncludeSecretValues)
+	b.collectSecrets(opts.Debug.IncludeSecretValues)
+	b.collectEvents()
+	b.collectLogs(opts)
+	b.collectEnvoyAdmin(opts.Debug.IncludeSecretValues)
+	b.collectControllerMetrics()
+
+	collectErrs := b.errs.WrappedErrors()
+	if len(collectErrs) > 0 {
+		var lines []string
+		for _, err := range collectErrs {
+			lines = append(lines, err.Error())
+		}
+		b.write(bundleErrorsFile, []byte(strings.Join(lines, "\n")+"\n"))
+	}
+	return collectErrs, zip(fs, dir, file)
+}
+
+type bundle struct {
+	ctx       context.Context
+	namespace string
+	fs        afero.Fs
+	dir       string
+	kubeCli   install.KubeCli
+	errs      *multierror.Error
+}
+
+func (b *bundle) write(name string, content []byte) {
+	path := filepath.Join(b.dir, name)
+	if err := b.fs.MkdirAll(filepath.Dir(path), dirPermissions); err != nil {
+		b.addError(name, err)
+		return
+	}
+	if err := afero.WriteFile(b.fs, path, content, filePermissions); err != nil {
+		b.addError(name, err)
+	}
+}
+
+func (b *bundle) writeYaml(name string, in interface{}) {
+	out, err := yaml.Marshal(in)
+	if err != nil {
+		b.addError(name, err)
+		return
+	}
+	b.write(name, out)
+}
+
+func (b *bundle) addError(what string, err error) {
+	b.errs = multierror.Append(b.errs, eris.Wrapf(err, "collecting %s", what))
+}
+
+func (b *bundle) collectVersions() {
+	// the client version is known even if the server versions are not
+	vrs, err := version.GetClientServerVersions(b.ctx, version.NewKube(b.namespace))
+	if err != nil {
+		b.addError("server versions", err)
+	}
+	b.write("versions.yaml", version.GetYaml(vrs))
+}
+
+func (b *bundle) collectKubeResources() {
+	for _, kind := range append(append([]string{}, installcmd.GlooNamespacedKinds...), "Pod") {
+		out, err := b.kubeCli.KubectlOut(nil, "get", kind, "-oyaml", "-n", b.namespace)
+		if err != nil {
+			b.addError(kind, err)
+			continue
+		}
+		b.write(filepath.Join("kubernetes", strings.ToLower(kind)+".yaml"), out)
+	}
+}
+
+// collects the resources of the Gloo CRDs installed in the cluster, in all namespaces, but the proxies which are
+// collected decompressed
+func (b *bundle) collectGlooResources(includeSecretValues bool) {
+	out, err := b.kubeCli.KubectlOut(nil, "get", "crd", "-o", "name")
+	if err != nil {
+		b.addError("CRDs", err)
+		return
+	}
+	installed := map[string]bool{}
+	for _, name := range strings.Fields(string(out)) {
+		installed[strings.TrimPrefix(name, "customresourcedefinition.apiextensions.k8s.io/")] = true
+	}
+	for _, crdName := range installcmd.GlooCrdNames {
+		if !installed[crdName] || crdName == v1.ProxyCrd.FullName() {
+			continue
+		}
+		out, err := b.kubeCli.KubectlOut(nil, "get", crdName, "--all-namespaces", "-oyaml")
+		if err != nil {
+			b.addError(crdName, err)
+			continue
+		}
+		if crdName == v1.SettingsCrd.FullName() && !includeSecretValues {
+			out, err = RedactSettings(out)
+			if err != nil {
+				b.addError(crdName, err)
+				continue
+			}
+		}
+		b.write(filepath.Join("resources", crdName+".yaml"), out)
+	}
+}
+
+// the fields of the Settings spec which hold credentials
+var settingsCredentialFields = [][]string{
+	{"vaultSecretSource", "token"},
+	{"consul", "password"},
+	{"consul", "token"},
+}
+
+// RedactSettings replaces the credentials in the given yaml list of Settings by RedactedValue
+func RedactSettings(settingsList []byte) ([]byte, error) {
+	var list map[string]interface{}
+	if err := yaml.Unmarshal(settingsList, &list); err != nil {
+		return nil, err
+	}
+	items, _, err := unstructured.NestedSlice(list, "items")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		settings, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, field := range settingsCredentialFields {
+			path := append([]string{"spec"}, field...)
+			if value, found, _ := unstructured.NestedString(settings, path...); found && value != "" {
+				if err := unstructured.SetNestedField(settings, RedactedValue, path...); err != nil {
+					return nil, err
+				}
+			}
+		}
+		// the last applied configuration holds the credentials as well
+		unstructured.RemoveNestedField(settings, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+	}
+	if err := unstructured.SetNestedSlice(list, items, "items"); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(list)
+}
+
+func (b *bundle) collectProxies(includeSecretValues bool) {
+	proxyClient, err := helpers.ProxyClient(b.ctx, []string{b.namespace})
+	if err != nil {
+		b.addError("proxies", err)
+		return
+	}
+	proxies, err := proxyClient.List(b.namespace, clients.ListOpts{Ctx: b.ctx})
+	if err != nil {
+		b.addError("proxies", err)
+		return
+	}
+	for _, proxy := range proxies {
+		name := proxy.GetMetadata().GetName()
+		// the proxies are read decompressed, and would be compressed again if they kept the annotation
+		delete(proxy.GetMetadata().GetAnnotations(), compress.CompressedKey)
+		out, err := printers.GenerateKubeCrdString(proxy, v1.ProxyCrd)
+		if err != nil {
+			b.addError("proxy "+name, err)
+		} else {
+			b.write(filepath.Join("proxies", name+".yaml"), []byte(out))
+		}
+
+		xdsDump, err := getXdsDump(b.ctx, name, b.namespace)
+		if err != nil {
+			b.addError("xds configuration of proxy "+name, err)
+			continue
+		}
+		if !includeSecretValues {
+			if err := RedactXdsDump(xdsDump); err != nil {
+				b.addError("xds configuration of proxy "+name, err)
+				continue
+			}
+		}
+		b.write(filepath.Join("xds", name+".yaml"), []byte(xdsDump.String()))
+	}
+}
+
+// RedactXdsDump replaces the private keys, their passwords and the session ticket keys inlined in the TLS contexts of
+// the listeners and clusters, the AWS credentials of the clusters, the values of the authorization headers added by the
+// routes and their transformations, and the Azure function keys in the paths set by the transformations, by
+// RedactedValue
+func RedactXdsDump(xdsDump *xdsinspection.XdsDump) error {
+	for i := range xdsDump.Listeners {
+		listener := &xdsDump.Listeners[i]
+		filterChains := listener.GetFilterChains()
+		if listener.GetDefaultFilterChain() != nil {
+			filterChains = append(filterChains, listener.GetDefaultFilterChain())
+		}
+		for _, filterChain := range filterChains {
+			if err := redactTransportSocket(filterChain.GetTransportSocket()); err != nil {
+				return eris.Wrapf(err, "redacting listener %s", listener.GetName())
+			}
+		}
+	}
+	for i := range xdsDump.Clusters {
+		cluster := &xdsDump.Clusters[i]
+		transportSockets := []*envoycore.TransportSocket{cluster.GetTransportSocket()}
+		for _, match := range cluster.GetTransportSocketMatches() {
+			transportSockets = append(transportSockets, match.GetTransportSocket())
+		}
+		for _, transportSocket := range transportSockets {
+			if err := redactTransportSocket(transportSocket); err != nil {
+				return eris.Wrapf(err, "redacting cluster %s", cluster.GetName())
+			}
+		}
+		if err := redactAwsCredentials(cluster.GetTypedExtensionProtocolOptions()); err != nil {
+			return eris.Wrapf(err, "redacting cluster %s", cluster.GetName())
+		}
+	}
+	for i := range xdsDump.Routes {
+		routeConfig := &xdsDump.Routes[i]
+		redactHeaders(routeConfig.GetRequestHeadersToAdd())
+		for _, virtualHost := range routeConfig.GetVirtualHosts() {
+			redactHeaders(virtualHost.GetRequestHeadersToAdd())
+			for _, route := range virtualHost.GetRoutes() {
+				redactHeaders(route.GetRequestHeadersToAdd())
+				if err := redactTransformations(route.GetTypedPerFilterConfig()); err != nil {
+					return eris.Wrapf(err, "redacting route %s", route.GetName())
+				}
+				for _, weightedCluster := range route.GetRoute().GetWeightedClusters().GetClusters() {
+					redactHeaders(weightedCluster.GetRequestHeadersToAdd())
+					if err := redactTransformations(weightedCluster.GetTypedPerFilterConfig()); err != nil {
+						return eris.Wrapf(err, "redacting route %s", route.GetName())
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// the routes to GCP upstreams add ID tokens to the authorization header of their requests
+func redactHeaders(headers []*envoycore.HeaderValueOption) {
+	for _, header := range headers {
+		if strings.EqualFold(header.GetHeader().GetKey(), "authorization") {
+			header.GetHeader().Value = RedactedValue
+		}
+	}
+}
+
+// the clusters of AWS upstreams hold the credentials Envoy signs the requests to Lambda with
+func redactAwsCredentials(extensionProtocolOptions map[string]*any.Any) error {
+	for name, config := range extensionProtocolOptions {
+		if !ptypes.Is(config, &envoyaws.AWSLambdaProtocolExtension{}) {
+			continue
+		}
+		var lpe envoyaws.AWSLambdaProtocolExtension
+		if err := ptypes.UnmarshalAny(config, &lpe); err != nil {
+			return err
+		}
+		lpe.AccessKey = redactValue(lpe.GetAccessKey())
+		lpe.SecretKey = redactValue(lpe.GetSecretKey())
+		lpe.SessionToken = redactValue(lpe.GetSessionToken())
+		redacted, err := utils.MessageToAny(&lpe)
+		if err != nil {
+			return err
+		}
+		extensionProtocolOptions[name] = redacted
+	}
+	return nil
+}
+
+// the unset values are left empty, so that the bundle shows which credentials were set
+func redactValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return RedactedValue
+}
+
+// the transformations of the routes to Azure upstreams add access tokens to the authorization header of their requests,
+// and function keys to their path
+func redactTransformations(perFilterConfig map[string]*any.Any) error {
+	config, ok := perFilterConfig[transformation.FilterName]
+	if !ok {
+		return nil
+	}
+	var transformations envoytransformation.RouteTransformations
+	if err := ptypes.UnmarshalAny(config, &transformations); err != nil {
+		return err
+	}
+	requestTransformations := []*envoytransformation.Transformation{transformations.GetRequestTransformation()}
+	for _, routeTransformation := range transformations.GetTransformations() {
+		requestTransformations = append(requestTransformations, routeTransformation.GetRequestMatch().GetRequestTransformation())
+	}
+	for _, requestTransformation := range requestTransformations {
+		for name, header := range requestTransformation.GetTransformationTemplate().GetHeaders() {
+			if strings.EqualFold(name, "authorization") {
+				header.Text = RedactedValue
+			}
+			if name == ":path" {
+				header.Text = azureFunctionKeyRegex.ReplaceAllString(header.GetText(), "${1}"+RedactedValue)
+			}
+		}
+	}
+	redacted, err := utils.MessageToAny(&transformations)
+	if err != nil {
+		return err
+	}
+	perFilterConfig[transformation.FilterName] = redacted
+	return nil
+}
+
+func redactTransportSocket(transportSocket *envoycore.TransportSocket) error {
+	if transportSocket.GetTypedConfig() == nil {
+		return nil
+	}
+	// transport sockets of unknown types may hold secrets, so they fail the redaction
+	var config ptypes.DynamicAny
+	if err := ptypes.UnmarshalAny(transportSocket.GetTypedConfig(), &config); err != nil {
+		return err
+	}
+	switch tlsContext := config.Message.(type) {
+	case *envoyauth.DownstreamTlsContext:
+		redactDownstreamTlsContext(tlsContext)
+	case *envoyauth.UpstreamTlsContext:
+		redactCommonTlsContext(tlsContext.GetCommonTlsContext())
+	case *envoyquic.QuicDownstreamTransport:
+		redactDownstreamTlsContext(tlsContext.GetDownstreamTlsContext())
+	case *envoyquic.QuicUpstreamTransport:
+		redactCommonTlsContext(tlsContext.GetUpstreamTlsContext().GetCommonTlsContext())
+	default:
+		return nil
+	}
+	redacted, err := utils.MessageToAny(config.Message)
+	if err != nil {
+		return err
+	}
+	transportSocket.ConfigType = &envoycore.TransportSocket_TypedConfig{TypedConfig: redacted}
+	return nil
+}
+
+func redactDownstreamTlsContext(tlsContext *envoyauth.DownstreamTlsContext) {
+	redactCommonTlsContext(tlsContext.GetCommonTlsContext())
+	for i, key := range tlsContext.GetSessionTicketKeys().GetKeys() {
+		tlsContext.GetSessionTicketKeys().Keys[i] = redactDataSource(key)
+	}
+}
+
+func redactCommonTlsContext(tlsContext *envoyauth.CommonTlsContext) {
+	for _, certificate := range tlsContext.GetTlsCertificates() {
+		certificate.PrivateKey = redactDataSource(certificate.GetPrivateKey())
+		certificate.Password = redactDataSource(certificate.GetPassword())
+	}
+}
+
+// only the inline data is redacted, files are referenced by their path
+func redactDataSource(dataSource *envoycore.DataSource) *envoycore.DataSource {
+	switch dataSource.GetSpecifier().(type) {
+	case *envoycore.DataSource_InlineBytes, *envoycore.DataSource_InlineString:
+		return &envoycore.DataSource{Specifier: &envoycore.DataSource_InlineString{InlineString: RedactedValue}}
+	}
+	return dataSource
+}
+
+func (b *bundle) collectSecrets(includeValues bool) {
+	secrets, err := helpers.MustKubeClient().CoreV1().Secrets(b.namespace).List(b.ctx, metav1.ListOptions{})
+	if err != nil {
+		b.addError("secrets", err)
+		return
+	}
+	if !includeValues {
+		for i := range secrets.Items {
+			RedactSecret(&secrets.Items[i])
+		}
+	}
+	b.writeYaml("secrets.yaml", secrets)
+}
+
+// RedactSecret replaces the values of the secret by RedactedValue, keeping their keys
+func RedactSecret(secret *corev1.Secret) {
+	redacted := map[string]string{}
+	for key := range secret.Data {
+		redacted[key] = RedactedValue
+	}
+	for key := range secret.StringData {
+		redacted[key] = RedactedValue
+	}
+	secret.Data = nil
+	secret.StringData = redacted
+	// the last applied configuration holds the values as well
+	delete(secret.GetAnnotations(), corev1.LastAppliedConfigAnnotation)
+}
+
+func (b *bundle) collectEvents() {
+	events, err := helpers.MustKubeClient().CoreV1().Events(b.namespace).List(b.ctx, metav1.ListOptions{})
+	if err != nil {
+		b.addError("events", err)
+		return
+	}
+	b.writeYaml("events.yaml", events)
+}
+
+func (b *bundle) collectLogs(opts *options.Options) {
+	responses, err := setup(opts)
+	if err != nil {
+		b.addError("logs", err)
+		return
+	}
+	for _, response := range responses {
+		logs, err := ioutil.ReadAll(response.Response)
+		response.Response.Close()
+		if err != nil {
+			b.addError("logs of "+response.ResourceId(), err)
+			continue
+		}
+		b.write(filepath.Join("logs", response.ResourceId()+".log"), logs)
+	}
+}
+
+func (b *bundle) collectEnvoyAdmin(includeSecretValues bool) {
+	pods, err := helpers.MustKubeClient().CoreV1().Pods(b.namespace).List(b.ctx, metav1.ListOptions{
+		LabelSelector: proxyPodSelector,
+	})
+	if err != nil {
+		b.addError("proxy pods", err)
+		return
+	}
+	paths := map[string]string{}
+	for file, path := range envoyAdminPaths {
+		paths[file] = path
+	}
+	if includeSecretValues {
+		paths[envoyConfigDumpFile] = envoyConfigDumpPath
+	}
+	for _, pod := range pods.Items {
+		for file, path := range paths {
+			out, err := portForwardGet(b.ctx, b.namespace, "pod/"+pod.Name, defaults.EnvoyAdminPort, path)
+			if err != nil {
+				b.addError(fmt.Sprintf("%s of pod %s", path, pod.Name), err)
+				continue
+			}
+			b.write(filepath.Join("envoy", pod.Name, file), []byte(out))
+		}
+	}
+}
+
+func (b *bundle) collectControllerMetrics() {
+	pods, err := helpers.MustKubeClient().CoreV1().Pods(b.namespace).List(b.ctx, metav1.ListOptions{
+		LabelSelector: glooPodSelector,
+	})
+	if err != nil {
+		b.addError("gloo pods", err)
+		return
+	}
+	for _, pod := range pods.Items {
+		out, err := portForwardGet(b.ctx, b.namespace, "pod/"+pod.Name, defaults.GlooAdminPort, glooMetricsPath)
+		if err != nil {
+			b.addError("metrics of pod "+pod.Name, err)
+			continue
+		}
+		b.write(filepath.Join("metrics", pod.Name+".txt"), []byte(out))
+	}
+}
+
+func portForwardGet(ctx context.Context, namespace, resource string, port uint32, path string) (string, error) {
+	freePort, err := cliutil.GetFreePort()
+	if err != nil {
+		return "", err
+	}
+	out, portFwdCmd, err := cliutil.PortForwardGet(ctx, namespace, resource, strconv.Itoa(freePort), strconv.Itoa(int(port)), false, path)
+	if err != nil {
+		return "", err
+	}
+	if portFwdCmd.Process != nil {
+		portFwdCmd.Process.Kill()
+		portFwdCmd.Process.Release()
+	}
+	return out, nil
+}
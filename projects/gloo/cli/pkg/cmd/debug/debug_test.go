@@ -3,12 +3,34 @@ package debug
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 
+	envoycluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycore "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoylistener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoyroute "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoyauth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/xdsinspection"
+	envoyaws "github.com/solo-io/gloo/projects/gloo/pkg/api/external/envoy/extensions/aws"
+	envoytransformation "github.com/solo-io/gloo/projects/gloo/pkg/api/external/envoy/extensions/transformation"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins/transformation"
+	"github.com/solo-io/gloo/projects/gloo/pkg/utils"
+	"github.com/solo-io/go-utils/tarutils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	installcmd "github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/install"
 
 	"github.com/solo-io/gloo/pkg/cliutil/install"
@@ -120,4 +142,256 @@ var _ = Describe("Debug", func() {
 			Expect(manifests).To(HaveLen(len(cmds)), "Should have written the same number of manifests as commands")
 		})
 	})
+
+	Context("bundle", func() {
+		var (
+			dir     string
+			opts    *options.Options
+			kubeCli *install.MockKubectl
+
+			settingsYaml = `apiVersion: v1
+items:
+- apiVersion: gloo.solo.io/v1
+  kind: Settings
+  metadata:
+    name: default
+    namespace: gloo-system
+  spec:
+    consul:
+      token: consul-token-value
+    vaultSecretSource:
+      address: http://vault:8200
+      token: vault-token-value
+kind: List
+`
+		)
+
+		inlineDataSource := func(value string) *envoycore.DataSource {
+			return &envoycore.DataSource{Specifier: &envoycore.DataSource_InlineString{InlineString: value}}
+		}
+
+		tlsTransportSocket := func(tlsContext proto.Message) *envoycore.TransportSocket {
+			return &envoycore.TransportSocket{
+				Name:       wellknown.TransportSocketTls,
+				ConfigType: &envoycore.TransportSocket_TypedConfig{TypedConfig: utils.MustMessageToAny(tlsContext)},
+			}
+		}
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "bundle")
+			Expect(err).NotTo(HaveOccurred())
+
+			opts = &options.Options{}
+			opts.Top.Ctx = context.Background()
+			opts.Metadata.Namespace = "gloo-system"
+
+			kubeClient := helpers.MustKubeClient()
+			_, err = kubeClient.CoreV1().Secrets("gloo-system").Create(opts.Top.Ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "tls",
+					Namespace:   "gloo-system",
+					Annotations: map[string]string{corev1.LastAppliedConfigAnnotation: "secret-value"},
+				},
+				Data: map[string][]byte{"tls.key": []byte("secret-value")},
+			}, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = kubeClient.CoreV1().Events("gloo-system").Create(opts.Top.Ctx, &corev1.Event{
+				ObjectMeta: metav1.ObjectMeta{Name: "event", Namespace: "gloo-system"},
+				Reason:     "SomethingHappened",
+			}, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			var cmds, outputs []string
+			for _, kind := range append(append([]string{}, installcmd.GlooNamespacedKinds...), "Pod") {
+				cmds = append(cmds, fmt.Sprintf("get %s -oyaml -n gloo-system", kind))
+				outputs = append(outputs, kind+"-yaml")
+			}
+			cmds = append(cmds, "get crd -o name", "get settings.gloo.solo.io --all-namespaces -oyaml")
+			outputs = append(outputs,
+				"customresourcedefinition.apiextensions.k8s.io/settings.gloo.solo.io customresourcedefinition.apiextensions.k8s.io/proxies.gloo.solo.io",
+				settingsYaml)
+			kubeCli = install.NewMockKubectl(cmds, outputs)
+
+			proxyClient, err := helpers.ProxyClient(opts.Top.Ctx, []string{"gloo-system"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = proxyClient.Write(&v1.Proxy{
+				Metadata: &core.Metadata{Name: "gateway-proxy", Namespace: "gloo-system"},
+			}, clients.WriteOpts{})
+			Expect(err).NotTo(HaveOccurred())
+
+			getXdsDump = func(_ context.Context, proxyName, _ string) (*xdsinspection.XdsDump, error) {
+				return &xdsinspection.XdsDump{
+					Role: "gloo-system~" + proxyName,
+					Listeners: []envoylistener.Listener{{
+						Name: "listener-::-8443",
+						FilterChains: []*envoylistener.FilterChain{{
+							TransportSocket: tlsTransportSocket(&envoyauth.DownstreamTlsContext{
+								CommonTlsContext: &envoyauth.CommonTlsContext{
+									TlsCertificates: []*envoyauth.TlsCertificate{{
+										CertificateChain: inlineDataSource("certificate-chain-value"),
+										PrivateKey:       inlineDataSource("private-key-value"),
+										Password:         inlineDataSource("key-password-value"),
+									}},
+								},
+								SessionTicketKeysType: &envoyauth.DownstreamTlsContext_SessionTicketKeys{
+									SessionTicketKeys: &envoyauth.TlsSessionTicketKeys{
+										Keys: []*envoycore.DataSource{inlineDataSource("session-ticket-key-value")},
+									},
+								},
+							}),
+						}},
+					}},
+					Clusters: []envoycluster.Cluster{{
+						Name: "upstream",
+						TransportSocket: tlsTransportSocket(&envoyauth.UpstreamTlsContext{
+							CommonTlsContext: &envoyauth.CommonTlsContext{
+								TlsCertificates: []*envoyauth.TlsCertificate{{
+									PrivateKey: inlineDataSource("client-key-value"),
+								}},
+							},
+						}),
+					}},
+				}, nil
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+			getXdsDump = func(ctx context.Context, proxyName, namespace string) (*xdsinspection.XdsDump, error) {
+				return xdsinspection.GetGlooXdsDump(ctx, proxyName, namespace, false)
+			}
+		})
+
+		readBundle := func(file string) map[string]string {
+			fs := afero.NewOsFs()
+			untarred := filepath.Join(dir, "untarred")
+			Expect(fs.MkdirAll(untarred, 0755)).NotTo(HaveOccurred())
+			Expect(tarutils.Untar(untarred, file, fs)).NotTo(HaveOccurred())
+			files := map[string]string{}
+			err := afero.Walk(fs, untarred, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+				content, err := ioutil.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				rel, err := filepath.Rel(untarred, path)
+				files[rel] = string(content)
+				return err
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return files
+		}
+
+		It("collects the resources into a tarball with the secret values redacted", func() {
+			file := filepath.Join(dir, "bundle.tgz")
+			_, err := WriteBundle(opts, kubeCli, file)
+			Expect(err).NotTo(HaveOccurred())
+
+			files := readBundle(file)
+			Expect(files).To(HaveKey("versions.yaml"))
+			Expect(files).To(HaveKeyWithValue(filepath.Join("kubernetes", "deployment.yaml"), "Deployment-yaml"))
+			Expect(files).To(HaveKeyWithValue(filepath.Join("kubernetes", "pod.yaml"), "Pod-yaml"))
+			Expect(files).To(HaveKey(filepath.Join("resources", "settings.gloo.solo.io.yaml")))
+			Expect(files).NotTo(HaveKey(filepath.Join("resources", "proxies.gloo.solo.io.yaml")))
+			Expect(files["events.yaml"]).To(ContainSubstring("SomethingHappened"))
+			Expect(files["secrets.yaml"]).To(ContainSubstring("tls.key: " + RedactedValue))
+			Expect(files["secrets.yaml"]).NotTo(ContainSubstring("secret-value"))
+		})
+
+		It("redacts the credentials of the settings", func() {
+			file := filepath.Join(dir, "bundle.tgz")
+			_, err := WriteBundle(opts, kubeCli, file)
+			Expect(err).NotTo(HaveOccurred())
+
+			settings := readBundle(file)[filepath.Join("resources", "settings.gloo.solo.io.yaml")]
+			Expect(settings).To(ContainSubstring("address: http://vault:8200"))
+			Expect(settings).To(ContainSubstring("token: " + RedactedValue))
+			Expect(settings).NotTo(ContainSubstring("consul-token-value"))
+			Expect(settings).NotTo(ContainSubstring("vault-token-value"))
+		})
+
+		It("redacts the private keys inlined in the xds configuration", func() {
+			file := filepath.Join(dir, "bundle.tgz")
+			_, err := WriteBundle(opts, kubeCli, file)
+			Expect(err).NotTo(HaveOccurred())
+
+			xds := readBundle(file)[filepath.Join("xds", "gateway-proxy.yaml")]
+			Expect(xds).To(ContainSubstring("listener-::-8443"))
+			Expect(xds).To(ContainSubstring("certificate-chain-value"))
+			Expect(xds).To(ContainSubstring(RedactedValue))
+			Expect(xds).NotTo(ContainSubstring("private-key-value"))
+			Expect(xds).NotTo(ContainSubstring("key-password-value"))
+			Expect(xds).NotTo(ContainSubstring("session-ticket-key-value"))
+			Expect(xds).NotTo(ContainSubstring("client-key-value"))
+		})
+
+		It("redacts the aws credentials and azure function keys in the xds configuration", func() {
+			getXdsDump = func(_ context.Context, proxyName, _ string) (*xdsinspection.XdsDump, error) {
+				return &xdsinspection.XdsDump{
+					Role: "gloo-system~" + proxyName,
+					Clusters: []envoycluster.Cluster{{
+						Name: "lambda",
+						TypedExtensionProtocolOptions: map[string]*any.Any{
+							"io.solo.aws_lambda": utils.MustMessageToAny(&envoyaws.AWSLambdaProtocolExtension{
+								Host:         "lambda.us-east-1.amazonaws.com",
+								AccessKey:    "access-key-value",
+								SecretKey:    "secret-key-value",
+								SessionToken: "session-token-value",
+							}),
+						},
+					}},
+					Routes: []envoyroute.RouteConfiguration{{
+						Name: "listener-::-8080-routes",
+						VirtualHosts: []*envoyroute.VirtualHost{{
+							Name: "azure",
+							Routes: []*envoyroute.Route{{
+								Name: "function",
+								TypedPerFilterConfig: map[string]*any.Any{
+									transformation.FilterName: utils.MustMessageToAny(&envoytransformation.RouteTransformations{
+										RequestTransformation: &envoytransformation.Transformation{
+											TransformationType: &envoytransformation.Transformation_TransformationTemplate{
+												TransformationTemplate: &envoytransformation.TransformationTemplate{
+													Headers: map[string]*envoytransformation.InjaTemplate{
+														":path": {Text: "/api/uppercase?code=function-key-value"},
+													},
+												},
+											},
+										},
+									}),
+								},
+							}},
+						}},
+					}},
+				}, nil
+			}
+			file := filepath.Join(dir, "bundle.tgz")
+			_, err := WriteBundle(opts, kubeCli, file)
+			Expect(err).NotTo(HaveOccurred())
+
+			xds := readBundle(file)[filepath.Join("xds", "gateway-proxy.yaml")]
+			Expect(xds).To(ContainSubstring("lambda.us-east-1.amazonaws.com"))
+			Expect(xds).To(ContainSubstring("/api/uppercase?code=" + RedactedValue))
+			Expect(xds).NotTo(ContainSubstring("access-key-value"))
+			Expect(xds).NotTo(ContainSubstring("secret-key-value"))
+			Expect(xds).NotTo(ContainSubstring("session-token-value"))
+			Expect(xds).NotTo(ContainSubstring("function-key-value"))
+		})
+
+		It("includes the secret values when asked to", func() {
+			opts.Debug.IncludeSecretValues = true
+			file := filepath.Join(dir, "bundle.tgz")
+			_, err := WriteBundle(opts, kubeCli, file)
+			Expect(err).NotTo(HaveOccurred())
+
+			files := readBundle(file)
+			Expect(files["secrets.yaml"]).NotTo(ContainSubstring(RedactedValue))
+			// the data is base64 encoded
+			Expect(files["secrets.yaml"]).To(ContainSubstring("c2VjcmV0LXZhbHVl"))
+			Expect(files[filepath.Join("resources", "settings.gloo.solo.io.yaml")]).To(ContainSubstring("vault-token-value"))
+			Expect(files[filepath.Join("xds", "gateway-proxy.yaml")]).To(ContainSubstring("private-key-value"))
+		})
+	})
 })
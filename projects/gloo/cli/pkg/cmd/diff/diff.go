@@ -0,0 +1,272 @@
+package diff
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	errors "github.com/rotisserie/eris"
+	"github.com/solo-io/gloo/pkg/cliutil"
+	gatewayv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	gatewaydefaults "github.com/solo-io/gloo/projects/gateway/pkg/defaults"
+	"github.com/solo-io/gloo/projects/gateway/pkg/services/k8sadmission"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/helpers"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/proxydiff"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/resourcefiles"
+	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	skerrors "github.com/solo-io/solo-kit/pkg/errors"
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// the deployment serving the validation webhook
+	validationDeployment = "deploy/gateway"
+	validationTimeout    = 30 * time.Second
+)
+
+// the kinds of the resources the validation webhook validates in a list
+var validatedKinds = map[schema.GroupVersionKind]bool{
+	gatewayv1.GatewayGVK:        true,
+	gatewayv1.VirtualServiceGVK: true,
+	gatewayv1.RouteTableGVK:     true,
+}
+
+func Diff(opts *options.Options, w io.Writer) error {
+	namespace := opts.Metadata.GetNamespace()
+	if len(opts.Diff.Files) == 0 {
+		return errors.Errorf("at least one file must be provided with --file")
+	}
+	objects, err := resourcefiles.ReadObjects(namespace, opts.Diff.Files...)
+	if err != nil {
+		return err
+	}
+
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+	list.SetGroupVersionKind(k8sadmission.ListGVK)
+	var skipped []string
+	for _, obj := range objects {
+		if !validatedKinds[obj.GroupVersionKind()] {
+			skipped = append(skipped, fmt.Sprintf("%v %v.%v", obj.GetKind(), obj.GetNamespace(), obj.GetName()))
+			continue
+		}
+		list.Items = append(list.Items, obj)
+	}
+	if len(skipped) > 0 {
+		fmt.Fprintf(w, "Skipping %v: only Gateways, Virtual Services and Route Tables are validated\n", strings.Join(skipped, ", "))
+	}
+	if len(list.Items) == 0 {
+		return errors.Errorf("no Gateways, Virtual Services or Route Tables were found in the files")
+	}
+
+	review, err := requestDryRun(opts.Top.Ctx, namespace, list)
+	if err != nil {
+		return err
+	}
+	proxyClient, err := helpers.ProxyClient(opts.Top.Ctx, []string{namespace})
+	if err != nil {
+		return err
+	}
+	return PrintDiff(opts.Top.Ctx, proxyClient, review, w)
+}
+
+// Prints the differences between the proxies of the response of the validation webhook and the current ones, along
+// with the errors and warnings of the response the current proxies do not have. Returns an error if the resources were
+// rejected.
+// visible for testing
+func PrintDiff(ctx context.Context, proxyClient gloov1.ProxyClient, review *k8sadmission.AdmissionReviewWithProxies, w io.Writer) error {
+	response := review.Response
+	if response == nil {
+		return errors.Errorf("the validation webhook returned no response")
+	}
+
+	// a proxy is returned for each resource which changes it, the last one includes the changes of all the resources
+	var proposed gloov1.ProxyList
+	for _, proxy := range review.Proxies {
+		if i := indexOf(proposed, proxy.GetMetadata().Ref()); i >= 0 {
+			proposed[i] = proxy
+		} else {
+			proposed = append(proposed, proxy)
+		}
+	}
+
+	var changes []proxydiff.Change
+	var currentStatuses []*core.Status
+	for _, proxy := range proposed {
+		current, err := proxyClient.Read(proxy.GetMetadata().GetNamespace(), proxy.GetMetadata().GetName(), clients.ReadOpts{Ctx: ctx})
+		if err != nil && !skerrors.IsNotExist(err) {
+			return err
+		}
+		for _, status := range current.GetNamespacedStatuses().GetStatuses() {
+			currentStatuses = append(currentStatuses, status)
+		}
+		proxyChanges, err := proxydiff.Diff(current, proxy)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, proxyChanges...)
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes to the proxies")
+	} else {
+		proxydiff.Print(w, changes)
+	}
+
+	var errs []string
+	if !response.Allowed && response.Result != nil {
+		if details := response.Result.Details; details != nil {
+			for _, cause := range details.Causes {
+				errs = append(errs, cause.Message)
+			}
+		}
+		if len(errs) == 0 {
+			errs = append(errs, response.Result.Message)
+		}
+	}
+	printFindings(w, "New errors:", newFindings(errs, currentStatuses))
+	printFindings(w, "New warnings:", newFindings(response.Warnings, currentStatuses))
+
+	if !response.Allowed {
+		return errors.Errorf("the changes would be rejected by the validation webhook")
+	}
+	return nil
+}
+
+func indexOf(proxies gloov1.ProxyList, ref *core.ResourceRef) int {
+	for i, proxy := range proxies {
+		if proxy.GetMetadata().Ref().Equal(ref) {
+			return i
+		}
+	}
+	return -1
+}
+
+// the errors and warnings which are not reported on the current proxies already. The messages of the webhook wrap
+// the reasons of the proxy statuses, or are part of them when a status reports several.
+func newFindings(findings []string, currentStatuses []*core.Status) []string {
+	var newOnes []string
+	for _, finding := range findings {
+		found := false
+		for _, status := range currentStatuses {
+			reason := status.GetReason()
+			if reason != "" && (strings.Contains(reason, finding) || strings.Contains(finding, reason)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			newOnes = append(newOnes, finding)
+		}
+	}
+	return newOnes
+}
+
+func printFindings(w io.Writer, title string, findings []string) {
+	if len(findings) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s\n", title)
+	for _, finding := range findings {
+		fmt.Fprintf(w, "- %s\n", finding)
+	}
+}
+
+// Sends the resources of the list to the validation webhook as a dry run, through a port-forward to the deployment
+// serving it, and returns its response with the resulting proxies.
+func requestDryRun(ctx context.Context, namespace string, list *unstructured.UnstructuredList) (*k8sadmission.AdmissionReviewWithProxies, error) {
+	raw, err := list.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	dryRun := true
+	body, err := json.Marshal(k8sadmission.AdmissionReviewWithProxies{
+		AdmissionRequestWithProxies: k8sadmission.AdmissionRequestWithProxies{
+			AdmissionReview: v1beta1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "AdmissionReview"},
+				Request: &v1beta1.AdmissionRequest{
+					UID:       "glooctl-diff",
+					Kind:      metav1.GroupVersionKind{Version: k8sadmission.ListGVK.Version, Kind: k8sadmission.ListGVK.Kind},
+					Namespace: namespace,
+					Operation: v1beta1.Create,
+					DryRun:    &dryRun,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			},
+			ReturnProxies: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	localPort, err := cliutil.GetFreePort()
+	if err != nil {
+		return nil, err
+	}
+	portFwd, err := cliutil.PortForward(namespace, validationDeployment, strconv.Itoa(localPort),
+		strconv.Itoa(gatewaydefaults.ValidationWebhookBindPort), false)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if portFwd.Process != nil {
+			portFwd.Process.Kill()
+			portFwd.Process.Release()
+		}
+	}()
+
+	// the webhook serves a self-signed certificate for the name of its service
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	url := fmt.Sprintf("https://localhost:%v%v", localPort, k8sadmission.ValidationPath)
+
+	// retry until the port-forward is ready
+	timeout := time.After(validationTimeout)
+	var multiErr *multierror.Error
+	for {
+		review, err := postReview(ctx, client, url, body)
+		if err == nil {
+			return review, nil
+		}
+		multiErr = multierror.Append(multiErr, err)
+		select {
+		case <-timeout:
+			return nil, errors.Errorf("timed out sending the resources to the validation webhook, errors: %v", multiErr)
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+func postReview(ctx context.Context, client *http.Client, url string, body []byte) (*k8sadmission.AdmissionReviewWithProxies, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", k8sadmission.ApplicationJson)
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("invalid status code: %v %v", res.StatusCode, res.Status)
+	}
+	var review k8sadmission.AdmissionReviewWithProxies
+	if err := json.NewDecoder(res.Body).Decode(&review); err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
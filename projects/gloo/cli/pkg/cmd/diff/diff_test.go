@@ -0,0 +1,112 @@
+package diff_test
+
+import (
+	"bytes"
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/projects/gateway/pkg/services/k8sadmission"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/diff"
+	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/factory"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/memory"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("PrintDiff", func() {
+
+	var (
+		ctx         context.Context
+		proxyClient gloov1.ProxyClient
+	)
+
+	proxy := func(domains ...string) *gloov1.Proxy {
+		return &gloov1.Proxy{
+			Metadata: &core.Metadata{Name: "gateway-proxy", Namespace: "gloo-system"},
+			Listeners: []*gloov1.Listener{{
+				Name:     "listener-::-8080",
+				BindPort: 8080,
+				ListenerType: &gloov1.Listener_HttpListener{
+					HttpListener: &gloov1.HttpListener{
+						VirtualHosts: []*gloov1.VirtualHost{{Name: "gloo-system.default", Domains: domains}},
+					},
+				},
+			}},
+		}
+	}
+
+	review := func(response *v1beta1.AdmissionResponse, proxies ...*gloov1.Proxy) *k8sadmission.AdmissionReviewWithProxies {
+		r := &k8sadmission.AdmissionReviewWithProxies{}
+		r.Response = response
+		r.Proxies = proxies
+		return r
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		proxyClient, err = gloov1.NewProxyClient(ctx, &factory.MemoryResourceClientFactory{Cache: memory.NewInMemoryResourceCache()})
+		Expect(err).NotTo(HaveOccurred())
+
+		current := proxy("a.com")
+		current.SetNamespacedStatuses(&core.NamespacedStatuses{Statuses: map[string]*core.Status{
+			"gloo-system": {State: core.Status_Warning, Reason: "Route Warning: InvalidDestinationWarning. Reason: existing"},
+		}})
+		_, err = proxyClient.Write(current, clients.WriteOpts{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("prints the changes to the proxies and the new warnings", func() {
+		var out bytes.Buffer
+		err := diff.PrintDiff(ctx, proxyClient, review(
+			&v1beta1.AdmissionResponse{
+				Allowed: true,
+				Warnings: []string{
+					"Route Warning: InvalidDestinationWarning. Reason: existing",
+					"Route Warning: InvalidDestinationWarning. Reason: new",
+				},
+			},
+			// the last proxy returned for the proxy includes all the changes
+			proxy("a.com", "b.com"),
+			proxy("a.com", "b.com", "c.com"),
+		), &out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(Equal(`~ proxy gloo-system.gateway-proxy > listener listener-::-8080 > virtual host gloo-system.default (modified)
+      domains:
+      - a.com
+    + - b.com
+    + - c.com
+      name: gloo-system.default
+
+New warnings:
+- Route Warning: InvalidDestinationWarning. Reason: new
+`))
+	})
+
+	It("prints new proxies", func() {
+		newProxy := proxy("a.com")
+		newProxy.Metadata.Name = "other"
+
+		var out bytes.Buffer
+		err := diff.PrintDiff(ctx, proxyClient, review(&v1beta1.AdmissionResponse{Allowed: true}, newProxy), &out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(Equal("+ proxy gloo-system.other (added)\n"))
+	})
+
+	It("prints the errors and fails when the changes are rejected", func() {
+		var out bytes.Buffer
+		err := diff.PrintDiff(ctx, proxyClient, review(&v1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: "resource incompatible with current Gloo snapshot",
+				Details: &metav1.StatusDetails{Causes: []metav1.StatusCause{{Message: "Error could not render proxy"}}},
+			},
+		}), &out)
+		Expect(err).To(MatchError("the changes would be rejected by the validation webhook"))
+		Expect(out.String()).To(Equal("No changes to the proxies\n\nNew errors:\n- Error could not render proxy\n"))
+	})
+})
@@ -0,0 +1,28 @@
+package diff
+
+import (
+	"os"
+
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/constants"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/flagutils"
+	"github.com/solo-io/go-utils/cliutils"
+	"github.com/spf13/cobra"
+)
+
+func RootCmd(opts *options.Options, optionsFunc ...cliutils.OptionsFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   constants.DIFF_COMMAND.Use,
+		Short: constants.DIFF_COMMAND.Short,
+		Long:  constants.DIFF_COMMAND.Long,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Diff(opts, os.Stdout)
+		},
+	}
+
+	pflags := cmd.PersistentFlags()
+	flagutils.AddNamespaceFlag(pflags, &opts.Metadata.Namespace)
+	flagutils.AddDiffFlags(pflags, &opts.Diff)
+	cliutils.ApplyOptions(cmd, optionsFunc)
+	return cmd
+}
@@ -0,0 +1,110 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/solo-io/gloo/pkg/cliutil/install"
+	gatewayv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	migration "github.com/solo-io/gloo/projects/gloo/cli/pkg/migrate"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/printers"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/resourcefiles"
+	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/kube/crd"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// the kinds the conversion reads from the cluster
+	clusterKinds            = "ingresses.networking.k8s.io,services,upstreams.gloo.solo.io"
+	istioVirtualServiceKind = "virtualservices.networking.istio.io"
+)
+
+func Migrate(opts *options.Options, kubeCli install.KubeCli, w io.Writer) error {
+	var objects []unstructured.Unstructured
+	var err error
+	if len(opts.Migrate.Files) > 0 {
+		objects, err = resourcefiles.ReadObjects(opts.Metadata.GetNamespace(), opts.Migrate.Files...)
+	} else {
+		objects, err = readClusterObjects(kubeCli)
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := migration.Migrate(opts.Top.Ctx, objects, migration.Options{
+		WriteNamespace: opts.Metadata.GetNamespace(),
+		IngressClass:   opts.Migrate.IngressClass,
+	})
+	if err != nil {
+		return err
+	}
+	return PrintResult(result, w)
+}
+
+// Reads the Ingresses, Services and Upstreams of all the namespaces, along with the Istio VirtualServices if Istio
+// is installed.
+func readClusterObjects(kubeCli install.KubeCli) ([]unstructured.Unstructured, error) {
+	out, err := kubeCli.KubectlOut(nil, "get", clusterKinds, "--all-namespaces", "-o", "yaml")
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading the resources of the cluster: %s", out)
+	}
+	objects, err := resourcefiles.DecodeObjects(bytes.NewReader(out), "")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := kubeCli.KubectlOut(nil, "get", "crd", istioVirtualServiceKind); err != nil {
+		return objects, nil
+	}
+	out, err = kubeCli.KubectlOut(nil, "get", istioVirtualServiceKind, "--all-namespaces", "-o", "yaml")
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading the Istio VirtualServices of the cluster: %s", out)
+	}
+	istioObjects, err := resourcefiles.DecodeObjects(bytes.NewReader(out), "")
+	if err != nil {
+		return nil, err
+	}
+	return append(objects, istioObjects...), nil
+}
+
+// Prints what could not be converted as comments, followed by the converted resources as YAML documents, so that the
+// output can be reviewed and applied.
+// visible for testing
+func PrintResult(result *migration.Result, w io.Writer) error {
+	if len(result.Issues) > 0 {
+		fmt.Fprintln(w, "# The following could not be converted, or were converted with a different behavior:")
+		for _, issue := range result.Issues {
+			fmt.Fprintf(w, "# - %v\n", issue)
+		}
+	}
+
+	var docs []string
+	addDocs := func(in resources.InputResourceList, resourceCrd crd.Crd) error {
+		for _, res := range in {
+			doc, err := printers.GenerateKubeCrdString(res, resourceCrd)
+			if err != nil {
+				return err
+			}
+			docs = append(docs, doc)
+		}
+		return nil
+	}
+	if err := addDocs(result.Upstreams.AsInputResources(), gloov1.UpstreamCrd); err != nil {
+		return err
+	}
+	if err := addDocs(result.RouteTables.AsInputResources(), gatewayv1.RouteTableCrd); err != nil {
+		return err
+	}
+	if err := addDocs(result.VirtualServices.AsInputResources(), gatewayv1.VirtualServiceCrd); err != nil {
+		return err
+	}
+	for _, doc := range docs {
+		fmt.Fprintf(w, "---\n%s", doc)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package migrate_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	gatewayv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/migrate"
+	migration "github.com/solo-io/gloo/projects/gloo/cli/pkg/migrate"
+	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+)
+
+var _ = Describe("PrintResult", func() {
+
+	It("prints the issues as comments followed by the resources", func() {
+		result := &migration.Result{
+			VirtualServices: gatewayv1.VirtualServiceList{{
+				Metadata:    &core.Metadata{Name: "wildcard", Namespace: "gloo-system"},
+				VirtualHost: &gatewayv1.VirtualHost{Domains: []string{"*"}},
+			}},
+			Upstreams: gloov1.UpstreamList{{
+				Metadata: &core.Metadata{Name: "default-petstore-8080", Namespace: "gloo-system"},
+			}},
+			Issues: []migration.Issue{{
+				Kind:      "Ingress",
+				Namespace: "default",
+				Name:      "petstore",
+				Message:   "annotation nginx.ingress.kubernetes.io/limit-rps is not converted",
+			}},
+		}
+
+		var out bytes.Buffer
+		Expect(migrate.PrintResult(result, &out)).NotTo(HaveOccurred())
+		Expect(out.String()).To(Equal(`# The following could not be converted, or were converted with a different behavior:
+# - Ingress default.petstore: annotation nginx.ingress.kubernetes.io/limit-rps is not converted
+---
+apiVersion: gloo.solo.io/v1
+kind: Upstream
+metadata:
+  creationTimestamp: null
+  name: default-petstore-8080
+  namespace: gloo-system
+spec: {}
+status: {}
+---
+apiVersion: gateway.solo.io/v1
+kind: VirtualService
+metadata:
+  creationTimestamp: null
+  name: wildcard
+  namespace: gloo-system
+spec:
+  virtualHost:
+    domains:
+    - '*'
+status: {}
+`))
+	})
+
+	It("prints nothing when there is nothing to convert", func() {
+		var out bytes.Buffer
+		Expect(migrate.PrintResult(&migration.Result{}, &out)).NotTo(HaveOccurred())
+		Expect(out.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,29 @@
+package migrate
+
+import (
+	"os"
+
+	"github.com/solo-io/gloo/pkg/cliutil/install"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/constants"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/flagutils"
+	"github.com/solo-io/go-utils/cliutils"
+	"github.com/spf13/cobra"
+)
+
+func RootCmd(opts *options.Options, optionsFunc ...cliutils.OptionsFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   constants.MIGRATE_COMMAND.Use,
+		Short: constants.MIGRATE_COMMAND.Short,
+		Long:  constants.MIGRATE_COMMAND.Long,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Migrate(opts, &install.CmdKubectl{}, os.Stdout)
+		},
+	}
+
+	pflags := cmd.PersistentFlags()
+	flagutils.AddNamespaceFlag(pflags, &opts.Metadata.Namespace)
+	flagutils.AddMigrateFlags(pflags, &opts.Migrate)
+	cliutils.ApplyOptions(cmd, optionsFunc)
+	return cmd
+}
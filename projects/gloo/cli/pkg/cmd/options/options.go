@@ -32,6 +32,10 @@ type Options struct {
 	Remove    Remove
 	Cluster   Cluster
 	Check     Check
+	Translate Translate
+	Debug     Debug
+	Diff      Diff
+	Migrate   Migrate
 }
 
 type Top struct {
@@ -114,6 +118,37 @@ type Edit struct {
 }
 
 type Route struct {
+	Test RouteTest
+}
+
+type RouteTest struct {
+	Method      string
+	Host        string
+	Path        string
+	Headers     InputMapStringString
+	QueryParams InputMapStringString
+	Port        uint32
+	ProxyName   string
+	Files       []string
+}
+
+type Translate struct {
+	Files   []string
+	Proxies []string
+}
+
+type Diff struct {
+	Files []string
+}
+
+type Migrate struct {
+	Files        []string
+	IngressClass string
+}
+
+type Debug struct {
+	// Include the values of the secrets in the debug bundle, which are redacted otherwise
+	IncludeSecretValues bool
 }
 
 type Consul struct {
@@ -437,4 +472,8 @@ type Register struct {
 type Check struct {
 	// The maximum length of time to wait before giving up on a secret request. A value of zero means no timeout.
 	SecretClientTimeout time.Duration
+	// The checks to run, all of them if empty. The checks excluded with Top.CheckName are skipped regardless.
+	Include []string
+	// Certificates expiring within this window are reported by the certificates check
+	CertificateExpiryWindow time.Duration
 }
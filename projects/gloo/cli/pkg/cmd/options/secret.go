@@ -32,6 +32,8 @@ type TlsSecret struct {
 	RootCaFilename     string
 	PrivateKeyFilename string
 	CertChainFilename  string
+	OcspStapleFilename string
+	CrlFilename        string
 	// non-user facing value for test purposes
 	// if set, Read() will just return the filenames
 	Mock bool
@@ -75,6 +77,31 @@ func (t *TlsSecret) ReadFiles() (string, string, string, error) {
 	return string(rootCa), string(privateKey), string(certChain), nil
 }
 
+// ReadOcspStaple reads the optional OCSP response, sidestepping file io during testing like ReadFiles
+func (t *TlsSecret) ReadOcspStaple() ([]byte, error) {
+	return t.readOptionalFile(t.OcspStapleFilename, "ocsp staple")
+}
+
+// ReadCrl reads the optional certificate revocation list, sidestepping file io during testing like ReadFiles
+func (t *TlsSecret) ReadCrl() (string, error) {
+	crl, err := t.readOptionalFile(t.CrlFilename, "crl")
+	return string(crl), err
+}
+
+func (t *TlsSecret) readOptionalFile(filename, description string) ([]byte, error) {
+	if filename == "" {
+		return nil, nil
+	}
+	if t.Mock {
+		return []byte(filename), nil
+	}
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %v file: %v", description, filename)
+	}
+	return content, nil
+}
+
 func (t *TlsSecret) keyPairExists() bool {
 	return !(t.CertChainFilename == "" && t.PrivateKeyFilename == "")
 }
@@ -8,8 +8,10 @@ import (
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/dashboard"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/debug"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/demo"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/diff"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/federation"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/istio"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/migrate"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/plugin"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/constants"
 	"k8s.io/kubectl/pkg/cmd"
@@ -24,6 +26,7 @@ import (
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/install"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/remove"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/route"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/translate"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/upgrade"
 	versioncmd "github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/version"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/flagutils"
@@ -121,6 +124,9 @@ func GlooCli() *cobra.Command {
 			federation.RootCmd(opts),
 			plugin.RootCmd(opts),
 			istio.RootCmd(opts),
+			translate.RootCmd(opts),
+			diff.RootCmd(opts),
+			migrate.RootCmd(opts),
 			initpluginmanager.Command(context.Background()),
 			completionCmd(),
 		)
@@ -26,7 +26,7 @@ func RootCmd(opts *options.Options, optionsFunc ...cliutils.OptionsFunc) *cobra.
 	pflags := cmd.PersistentFlags()
 	flagutils.AddMetadataFlags(pflags, &opts.Metadata)
 
-	cmd.AddCommand(Sort(opts))
+	cmd.AddCommand(Sort(opts), Test(opts))
 	cliutils.ApplyOptions(cmd, optionsFunc)
 	return cmd
 }
@@ -0,0 +1,334 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	errors "github.com/rotisserie/eris"
+	gatewayv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	gatewaytranslator "github.com/solo-io/gloo/projects/gateway/pkg/translator"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/flagutils"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/helpers"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/printers"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/resourcefiles"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/routematch"
+	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/core/matchers"
+	"github.com/solo-io/go-utils/cliutils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources"
+	"github.com/solo-io/solo-kit/pkg/utils/protoutils"
+	"github.com/spf13/cobra"
+)
+
+func Test(opts *options.Options, optionsFunc ...cliutils.OptionsFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "show which route a request would be routed to",
+		Long: "Simulates the routing of a request by Envoy: selects the virtual host of each HTTP listener of the proxy " +
+			"by the host of the request, then the first route whose matchers match the method, path, headers and query " +
+			"parameters of the request. Prints the matched route along with the Virtual Service and Route Tables it " +
+			"comes from, its destination and its options." +
+			"\n\n" +
+			"The proxy is read from the cluster, unless files are given, in which case the Gateways, Virtual Services, " +
+			"Route Tables and options they contain are translated locally. The default Gateways are used if the files " +
+			"do not contain any." +
+			"\n\n" +
+			"Usage: `glooctl route test --host example.com --path /api/v1/pets?limit=10 [--method GET] [--header x-version=2] [--proxy gateway-proxy] [-f resources.yaml]`",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return testRoute(opts)
+		},
+	}
+	pflags := cmd.PersistentFlags()
+	flagutils.AddOutputFlag(pflags, &opts.Top.Output)
+	flagutils.AddRouteTestFlags(pflags, &opts.Route.Test)
+	cliutils.ApplyOptions(cmd, optionsFunc)
+	return cmd
+}
+
+func testRoute(opts *options.Options) error {
+	test := opts.Route.Test
+	headers, err := parseEntries(test.Headers.Entries)
+	if err != nil {
+		return errors.Wrapf(err, "parsing headers")
+	}
+	queryParams, err := parseEntries(test.QueryParams.Entries)
+	if err != nil {
+		return errors.Wrapf(err, "parsing query parameters")
+	}
+
+	proxy, err := getProxyToTest(opts)
+	if err != nil {
+		return err
+	}
+
+	matches, err := routematch.MatchProxy(proxy, routematch.Request{
+		Method:      test.Method,
+		Host:        test.Host,
+		Path:        test.Path,
+		Headers:     http.Header(headers),
+		QueryParams: url.Values(queryParams),
+		Port:        test.Port,
+	})
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		return errors.Errorf("proxy %v has no http listener", proxy.GetMetadata().Ref().Key())
+	}
+
+	return printRouteTestResults(proxy, matches, opts.Top.Output, os.Stdout)
+}
+
+func parseEntries(entries []string) (map[string][]string, error) {
+	values := map[string][]string{}
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("'%v': invalid key-value format. must be KEY=VALUE", entry)
+		}
+		values[parts[0]] = append(values[parts[0]], parts[1])
+	}
+	return values, nil
+}
+
+// reads the proxy from the cluster, or translates it from the files of the options
+func getProxyToTest(opts *options.Options) (*gloov1.Proxy, error) {
+	test := opts.Route.Test
+	namespace := opts.Metadata.GetNamespace()
+	if len(test.Files) == 0 {
+		proxy, err := helpers.MustNamespacedProxyClient(opts.Top.Ctx, namespace).Read(namespace, test.ProxyName,
+			clients.ReadOpts{Ctx: opts.Top.Ctx})
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading proxy %v.%v", namespace, test.ProxyName)
+		}
+		return proxy, nil
+	}
+
+	res, err := resourcefiles.ReadFiles(namespace, test.Files...)
+	if err != nil {
+		return nil, err
+	}
+	proxies, reports := res.TranslateProxies(opts.Top.Ctx, namespace)
+	// the request may still be routed by the resources that are valid
+	if err := reports.Validate(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: the resources have errors: %v\n", err)
+	}
+	for _, proxy := range proxies {
+		if proxy.GetMetadata().GetName() == test.ProxyName {
+			return proxy, nil
+		}
+	}
+	return nil, errors.Errorf("the resources do not define a proxy named %v", test.ProxyName)
+}
+
+type routeTestResult struct {
+	Listener       string                 `json:"listener"`
+	VirtualHost    string                 `json:"virtualHost,omitempty"`
+	RouteIndex     *int                   `json:"routeIndex,omitempty"`
+	RouteName      string                 `json:"routeName,omitempty"`
+	Matcher        map[string]interface{} `json:"matcher,omitempty"`
+	VirtualService string                 `json:"virtualService,omitempty"`
+	RouteTables    []string               `json:"routeTables,omitempty"`
+	Destination    string                 `json:"destination,omitempty"`
+	Options        map[string]interface{} `json:"options,omitempty"`
+}
+
+func printRouteTestResults(proxy *gloov1.Proxy, matches []*routematch.Match, outputType printers.OutputType, w io.Writer) error {
+	results := make([]*routeTestResult, 0, len(matches))
+	for _, match := range matches {
+		result, err := toRouteTestResult(match)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+	}
+
+	switch outputType {
+	case printers.JSON:
+		out, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	case printers.YAML, printers.KUBE_YAML:
+		out, err := yaml.Marshal(results)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(w, string(out))
+		return err
+	}
+
+	for i, result := range results {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		_, _ = fmt.Fprintf(w, "proxy %v, listener %v:\n", proxy.GetMetadata().Ref().Key(), result.Listener)
+		if result.VirtualHost == "" {
+			_, _ = fmt.Fprintln(w, "  no virtual host matches the host of the request")
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "  virtual host:     %v\n", result.VirtualHost)
+		if result.RouteIndex == nil {
+			_, _ = fmt.Fprintln(w, "  no route matches the request")
+			continue
+		}
+		routeName := result.RouteName
+		if routeName == "" {
+			routeName = "<unnamed>"
+		}
+		_, _ = fmt.Fprintf(w, "  route:            #%v %v\n", *result.RouteIndex, routeName)
+		_, _ = fmt.Fprintf(w, "  matcher:          %v\n", describeMatcher(matches[i].Matcher))
+		if result.VirtualService != "" {
+			_, _ = fmt.Fprintf(w, "  virtual service:  %v\n", result.VirtualService)
+		}
+		if len(result.RouteTables) > 0 {
+			_, _ = fmt.Fprintf(w, "  route tables:     %v\n", strings.Join(result.RouteTables, " -> "))
+		}
+		_, _ = fmt.Fprintf(w, "  destination:      %v\n", result.Destination)
+		if len(result.Options) > 0 {
+			out, err := yaml.Marshal(result.Options)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(w, "  options:")
+			for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+				_, _ = fmt.Fprintf(w, "    %v\n", line)
+			}
+		}
+	}
+	return nil
+}
+
+func toRouteTestResult(match *routematch.Match) (*routeTestResult, error) {
+	result := &routeTestResult{
+		Listener: match.Listener.GetName(),
+	}
+	if match.VirtualHost == nil {
+		return result, nil
+	}
+	result.VirtualHost = match.VirtualHost.GetName()
+	if match.Route == nil {
+		return result, nil
+	}
+
+	routeIndex := match.RouteIndex
+	result.RouteIndex = &routeIndex
+	result.RouteName = match.Route.GetName()
+	result.Destination = describeAction(match.Route)
+
+	var err error
+	if result.Matcher, err = protoutils.MarshalMapFromProto(match.Matcher); err != nil {
+		return nil, err
+	}
+	if match.Route.GetOptions() != nil {
+		if result.Options, err = protoutils.MarshalMapFromProto(match.Route.GetOptions()); err != nil {
+			return nil, err
+		}
+	}
+
+	// the sources of a route are the virtual service it is defined on, followed by the route tables it was
+	// delegated to
+	err = gatewaytranslator.ForEachSource(match.Route, func(source gatewaytranslator.SourceRef) error {
+		switch source.ResourceKind {
+		case resources.Kind(&gatewayv1.VirtualService{}):
+			result.VirtualService = source.ResourceRef.Key()
+		case resources.Kind(&gatewayv1.RouteTable{}):
+			result.RouteTables = append(result.RouteTables, source.ResourceRef.Key())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func describeMatcher(matcher *matchers.Matcher) string {
+	var parts []string
+	switch path := matcher.GetPathSpecifier().(type) {
+	case *matchers.Matcher_Prefix:
+		parts = append(parts, "prefix "+path.Prefix)
+	case *matchers.Matcher_Exact:
+		parts = append(parts, "exact "+path.Exact)
+	case *matchers.Matcher_Regex:
+		parts = append(parts, "regex "+path.Regex)
+	}
+	if matcher.GetCaseSensitive() != nil && !matcher.GetCaseSensitive().GetValue() {
+		parts = append(parts, "case insensitive")
+	}
+	if len(matcher.GetMethods()) > 0 {
+		parts = append(parts, "methods "+strings.Join(matcher.GetMethods(), "|"))
+	}
+	for _, header := range matcher.GetHeaders() {
+		parts = append(parts, "header "+describeValueMatcher(header.GetName(), header.GetValue(), header.GetRegex(), header.GetInvertMatch()))
+	}
+	for _, param := range matcher.GetQueryParameters() {
+		parts = append(parts, "query "+describeValueMatcher(param.GetName(), param.GetValue(), param.GetRegex(), false))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func describeValueMatcher(name, value string, regex, invert bool) string {
+	operator := "="
+	switch {
+	case value == "":
+		operator, value = " present", ""
+	case regex:
+		operator = "=~"
+	}
+	if invert {
+		return "not " + name + operator + value
+	}
+	return name + operator + value
+}
+
+func describeAction(route *gloov1.Route) string {
+	switch action := route.GetAction().(type) {
+	case *gloov1.Route_RouteAction:
+		switch dest := action.RouteAction.GetDestination().(type) {
+		case *gloov1.RouteAction_Single:
+			return describeDestination(dest.Single)
+		case *gloov1.RouteAction_Multi:
+			var destinations []string
+			for _, weighted := range dest.Multi.GetDestinations() {
+				destinations = append(destinations, fmt.Sprintf("%v (weight %v)", describeDestination(weighted.GetDestination()), weighted.GetWeight()))
+			}
+			return strings.Join(destinations, ", ")
+		case *gloov1.RouteAction_UpstreamGroup:
+			return "upstream group " + dest.UpstreamGroup.Key()
+		case *gloov1.RouteAction_ClusterHeader:
+			return "the cluster named by header " + dest.ClusterHeader
+		case *gloov1.RouteAction_DynamicForwardProxy:
+			return "dynamic forward proxy"
+		}
+	case *gloov1.Route_RedirectAction:
+		return fmt.Sprintf("redirect to host %q, path %q", action.RedirectAction.GetHostRedirect(), action.RedirectAction.GetPathRedirect())
+	case *gloov1.Route_DirectResponseAction:
+		return fmt.Sprintf("direct response with status %v", action.DirectResponseAction.GetStatus())
+	case *gloov1.Route_GraphqlApiRef:
+		return "graphql api " + action.GraphqlApiRef.Key()
+	}
+	return "none"
+}
+
+func describeDestination(dest *gloov1.Destination) string {
+	switch destType := dest.GetDestinationType().(type) {
+	case *gloov1.Destination_Upstream:
+		return "upstream " + destType.Upstream.Key()
+	case *gloov1.Destination_Kube:
+		return fmt.Sprintf("kubernetes service %v port %v", destType.Kube.GetRef().Key(), destType.Kube.GetPort())
+	case *gloov1.Destination_Consul:
+		return "consul service " + destType.Consul.GetServiceName()
+	}
+	return "none"
+}
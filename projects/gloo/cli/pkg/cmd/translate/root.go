@@ -0,0 +1,29 @@
+package translate
+
+import (
+	"os"
+
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/constants"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/flagutils"
+	"github.com/solo-io/go-utils/cliutils"
+	"github.com/spf13/cobra"
+)
+
+func RootCmd(opts *options.Options, optionsFunc ...cliutils.OptionsFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   constants.TRANSLATE_COMMAND.Use,
+		Short: constants.TRANSLATE_COMMAND.Short,
+		Long:  constants.TRANSLATE_COMMAND.Long,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Translate(opts, os.Stdout)
+		},
+	}
+
+	pflags := cmd.PersistentFlags()
+	flagutils.AddNamespaceFlag(pflags, &opts.Metadata.Namespace)
+	flagutils.AddOutputFlag(pflags, &opts.Top.Output)
+	flagutils.AddTranslateFlags(pflags, &opts.Translate)
+	cliutils.ApplyOptions(cmd, optionsFunc)
+	return cmd
+}
@@ -0,0 +1,250 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/hashicorp/go-multierror"
+	errors "github.com/rotisserie/eris"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/printers"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/resourcefiles"
+	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/bootstrap"
+	"github.com/solo-io/gloo/projects/gloo/pkg/defaults"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins/registry"
+	"github.com/solo-io/gloo/projects/gloo/pkg/syncer/sanitizer"
+	"github.com/solo-io/gloo/projects/gloo/pkg/translator"
+	sslutils "github.com/solo-io/gloo/projects/gloo/pkg/utils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/factory"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/memory"
+	envoycache "github.com/solo-io/solo-kit/pkg/api/v1/control-plane/cache"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/resource"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	"github.com/solo-io/solo-kit/pkg/api/v2/reporter"
+	"github.com/solo-io/solo-kit/pkg/utils/protoutils"
+)
+
+// The translation of the resources read from files, as printed by glooctl translate
+type Output struct {
+	Proxies []ProxyOutput     `json:"proxies"`
+	Reports []ResourceOutcome `json:"reports,omitempty"`
+}
+
+// A proxy and the Envoy configuration Gloo would serve for it
+type ProxyOutput struct {
+	Proxy               map[string]interface{}   `json:"proxy"`
+	Listeners           []map[string]interface{} `json:"listeners,omitempty"`
+	RouteConfigurations []map[string]interface{} `json:"routeConfigurations,omitempty"`
+	Clusters            []map[string]interface{} `json:"clusters,omitempty"`
+	Endpoints           []map[string]interface{} `json:"endpoints,omitempty"`
+}
+
+// The errors and warnings reported on a resource
+type ResourceOutcome struct {
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Errors    []string `json:"errors,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
+}
+
+func Translate(opts *options.Options, w io.Writer) error {
+	namespace := opts.Metadata.GetNamespace()
+	if len(opts.Translate.Files) == 0 {
+		return errors.Errorf("at least one file must be provided with --file")
+	}
+	res, err := resourcefiles.ReadFiles(namespace, opts.Translate.Files...)
+	if err != nil {
+		return err
+	}
+
+	output, err := TranslateResources(opts.Top.Ctx, res, namespace, opts.Translate.Proxies)
+	if err != nil {
+		return err
+	}
+	if err := printOutput(output, opts.Top.Output, w); err != nil {
+		return err
+	}
+
+	var errCount int
+	for _, report := range output.Reports {
+		errCount += len(report.Errors)
+	}
+	if errCount > 0 {
+		return errors.Errorf("translation reported %v errors", errCount)
+	}
+	return nil
+}
+
+// Translates the resources as Gloo would: the Gateways are translated to Proxies, which are translated to Envoy
+// configuration along with the Proxies of the resources, using the standard plugins. Only the proxies with the given
+// names are part of the output, if any are given, but the reports cover the translation of all the proxies.
+func TranslateResources(ctx context.Context, res *resourcefiles.Resources, namespace string, proxyNames []string) (*Output, error) {
+	proxies, reports := res.TranslateProxies(ctx, namespace)
+	for _, proxy := range res.Proxies {
+		// the proxies generated from the gateways replace the ones of the resources, as they do in a cluster
+		if _, err := proxies.Find(proxy.GetMetadata().GetNamespace(), proxy.GetMetadata().GetName()); err != nil {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	settings := settingsOf(res, namespace)
+	memoryClientFactory := &factory.MemoryResourceClientFactory{
+		Cache: memory.NewInMemoryResourceCache(),
+	}
+	bootstrapOpts := bootstrap.Opts{
+		WriteNamespace: namespace,
+		Settings:       settings,
+		Secrets:        memoryClientFactory,
+		Upstreams:      memoryClientFactory,
+		WatchOpts:      clients.WatchOpts{Ctx: ctx},
+	}
+	glooTranslator := translator.NewTranslator(sslutils.NewSslConfigTranslator(), settings, registry.GetPluginRegistryFactory(bootstrapOpts))
+	routeReplacingSanitizer, err := sanitizer.NewRouteReplacingSanitizer(settings.GetGloo().GetInvalidConfigPolicy())
+	if err != nil {
+		return nil, err
+	}
+	xdsSanitizer := sanitizer.XdsSanitizers{
+		sanitizer.NewUpstreamRemovingSanitizer(),
+		routeReplacingSanitizer,
+	}
+
+	snap := res.GlooSnapshot(proxies)
+	reports.Accept(snap.Upstreams.AsInputResources()...)
+	reports.Accept(snap.UpstreamGroups.AsInputResources()...)
+	reports.Accept(snap.Proxies.AsInputResources()...)
+
+	output := &Output{}
+	for _, proxy := range proxies {
+		params := plugins.Params{Ctx: ctx, Snapshot: snap}
+		xdsSnapshot, proxyReports, _, err := glooTranslator.Translate(params, proxy)
+		if err != nil {
+			return nil, errors.Wrapf(err, "translating proxy %v", proxy.GetMetadata().Ref().Key())
+		}
+		xdsSnapshot = xdsSanitizer.SanitizeSnapshot(ctx, snap, xdsSnapshot, proxyReports)
+		reports.Merge(proxyReports)
+
+		if len(proxyNames) > 0 && !contains(proxyNames, proxy.GetMetadata().GetName()) {
+			continue
+		}
+		proxyOutput, err := makeProxyOutput(proxy, xdsSnapshot)
+		if err != nil {
+			return nil, err
+		}
+		output.Proxies = append(output.Proxies, *proxyOutput)
+	}
+	output.Reports = makeResourceOutcomes(reports)
+	return output, nil
+}
+
+// the settings named default are the ones Gloo runs with by default
+func settingsOf(res *resourcefiles.Resources, namespace string) *gloov1.Settings {
+	if settings, err := res.Settings.Find(namespace, defaults.SettingsName); err == nil {
+		return settings
+	}
+	if len(res.Settings) > 0 {
+		return res.Settings[0]
+	}
+	return &gloov1.Settings{
+		Metadata: &core.Metadata{Name: defaults.SettingsName, Namespace: namespace},
+	}
+}
+
+func makeProxyOutput(proxy *gloov1.Proxy, xdsSnapshot envoycache.Snapshot) (*ProxyOutput, error) {
+	proxyMap, err := protoutils.MarshalMap(proxy)
+	if err != nil {
+		return nil, err
+	}
+	out := &ProxyOutput{Proxy: proxyMap}
+	for _, xdsResources := range []struct {
+		typeURL string
+		out     *[]map[string]interface{}
+	}{
+		{typeURL: resource.ListenerTypeV3, out: &out.Listeners},
+		{typeURL: resource.RouteTypeV3, out: &out.RouteConfigurations},
+		{typeURL: resource.ClusterTypeV3, out: &out.Clusters},
+		{typeURL: resource.EndpointTypeV3, out: &out.Endpoints},
+	} {
+		items := xdsSnapshot.GetResources(xdsResources.typeURL).Items
+		names := make([]string, 0, len(items))
+		for name := range items {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			resourceMap, err := protoutils.MarshalMapFromProto(items[name].ResourceProto())
+			if err != nil {
+				return nil, errors.Wrapf(err, "marshalling %v", name)
+			}
+			*xdsResources.out = append(*xdsResources.out, resourceMap)
+		}
+	}
+	return out, nil
+}
+
+func makeResourceOutcomes(reports reporter.ResourceReports) []ResourceOutcome {
+	var outcomes []ResourceOutcome
+	for res, report := range reports {
+		if report.Errors == nil && len(report.Warnings) == 0 {
+			continue
+		}
+		outcome := ResourceOutcome{
+			Kind:      resources.Kind(res),
+			Namespace: res.GetMetadata().GetNamespace(),
+			Name:      res.GetMetadata().GetName(),
+			Warnings:  report.Warnings,
+		}
+		if multiErr, ok := report.Errors.(*multierror.Error); ok {
+			for _, err := range multiErr.WrappedErrors() {
+				outcome.Errors = append(outcome.Errors, err.Error())
+			}
+		} else if report.Errors != nil {
+			outcome.Errors = append(outcome.Errors, report.Errors.Error())
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	sort.Slice(outcomes, func(i, j int) bool {
+		if outcomes[i].Kind != outcomes[j].Kind {
+			return outcomes[i].Kind < outcomes[j].Kind
+		}
+		if outcomes[i].Namespace != outcomes[j].Namespace {
+			return outcomes[i].Namespace < outcomes[j].Namespace
+		}
+		return outcomes[i].Name < outcomes[j].Name
+	})
+	return outcomes
+}
+
+func printOutput(output *Output, outputType printers.OutputType, w io.Writer) error {
+	var (
+		out []byte
+		err error
+	)
+	if outputType == printers.JSON {
+		out, err = json.MarshalIndent(output, "", "  ")
+	} else {
+		out, err = yaml.Marshal(output)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
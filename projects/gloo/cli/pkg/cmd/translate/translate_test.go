@@ -0,0 +1,177 @@
+package translate_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/translate"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/resourcefiles"
+	"github.com/solo-io/solo-kit/pkg/utils/statusutils"
+)
+
+var _ = Describe("Translate", func() {
+
+	const resourcesYaml = `
+apiVersion: gateway.solo.io/v1
+kind: VirtualService
+metadata:
+  name: default
+spec:
+  virtualHost:
+    domains:
+    - '*'
+    routes:
+    - matchers:
+      - prefix: /api
+      routeAction:
+        single:
+          upstream:
+            name: petstore
+            namespace: gloo-system
+---
+apiVersion: gloo.solo.io/v1
+kind: Upstream
+metadata:
+  name: petstore
+spec:
+  static:
+    hosts:
+    - addr: petstore.example.com
+      port: 8080
+`
+
+	// a route to an upstream and a virtual service with a secret which are not part of the resources
+	const unresolvedYaml = `
+apiVersion: gateway.solo.io/v1
+kind: VirtualService
+metadata:
+  name: default
+spec:
+  virtualHost:
+    domains:
+    - '*'
+    routes:
+    - matchers:
+      - prefix: /api
+      routeAction:
+        single:
+          upstream:
+            name: missing
+            namespace: gloo-system
+---
+apiVersion: gateway.solo.io/v1
+kind: VirtualService
+metadata:
+  name: secure
+spec:
+  sslConfig:
+    secretRef:
+      name: missing-tls
+      namespace: gloo-system
+  virtualHost:
+    domains:
+    - secure.example.com
+    routes:
+    - matchers:
+      - prefix: /
+      routeAction:
+        single:
+          upstream:
+            name: missing
+            namespace: gloo-system
+`
+
+	names := func(resources []map[string]interface{}) []string {
+		var names []string
+		for _, resource := range resources {
+			names = append(names, resource["name"].(string))
+		}
+		return names
+	}
+
+	translateYaml := func(resourcesYaml string, proxyNames ...string) *translate.Output {
+		res, err := resourcefiles.Read(strings.NewReader(resourcesYaml), "gloo-system")
+		Expect(err).NotTo(HaveOccurred())
+		output, err := translate.TranslateResources(context.Background(), res, "gloo-system", proxyNames)
+		Expect(err).NotTo(HaveOccurred())
+		return output
+	}
+
+	BeforeEach(func() {
+		Expect(os.Setenv(statusutils.PodNamespaceEnvName, "gloo-system")).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv(statusutils.PodNamespaceEnvName)).NotTo(HaveOccurred())
+	})
+
+	It("translates the resources to proxies and their xds configuration", func() {
+		output := translateYaml(resourcesYaml)
+		Expect(output.Reports).To(BeEmpty())
+		Expect(output.Proxies).To(HaveLen(1))
+
+		proxy := output.Proxies[0]
+		Expect(proxy.Proxy["metadata"]).To(HaveKeyWithValue("name", "gateway-proxy"))
+		Expect(proxy.Proxy["metadata"]).To(HaveKeyWithValue("namespace", "gloo-system"))
+
+		Expect(names(proxy.Listeners)).To(ContainElement("listener-::-8080"))
+		Expect(names(proxy.RouteConfigurations)).To(ContainElement("listener-::-8080-routes"))
+		Expect(names(proxy.Clusters)).To(ContainElement("petstore_gloo-system"))
+
+		var routeConfig map[string]interface{}
+		for _, routeConfiguration := range proxy.RouteConfigurations {
+			if routeConfiguration["name"] == "listener-::-8080-routes" {
+				routeConfig = routeConfiguration
+			}
+		}
+		virtualHosts := routeConfig["virtualHosts"].([]interface{})
+		Expect(virtualHosts).To(HaveLen(1))
+		routes := virtualHosts[0].(map[string]interface{})["routes"].([]interface{})
+		Expect(routes).To(HaveLen(1))
+		route := routes[0].(map[string]interface{})
+		Expect(route["match"]).To(HaveKeyWithValue("prefix", "/api"))
+		Expect(route["route"]).To(HaveKeyWithValue("cluster", "petstore_gloo-system"))
+	})
+
+	It("only outputs the proxies with the given names", func() {
+		output := translateYaml(resourcesYaml, "other-proxy")
+		Expect(output.Proxies).To(BeEmpty())
+		Expect(output.Reports).To(BeEmpty())
+	})
+
+	It("reports the references that cannot be resolved", func() {
+		output := translateYaml(unresolvedYaml)
+		Expect(output.Proxies).To(HaveLen(1))
+
+		Expect(output.Reports).To(HaveLen(1))
+		report := output.Reports[0]
+		Expect(report.Kind).To(Equal("*v1.Proxy"))
+		Expect(report.Namespace).To(Equal("gloo-system"))
+		Expect(report.Name).To(Equal("gateway-proxy"))
+		Expect(report.Errors).To(ContainElement(ContainSubstring("SSL secret not found")))
+		Expect(report.Warnings).To(ContainElement(ContainSubstring("*v1.Upstream { gloo-system.missing } not found")))
+	})
+
+	It("returns an error if the translation reports errors", func() {
+		dir, err := ioutil.TempDir("", "translate")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		file := filepath.Join(dir, "resources.yaml")
+		Expect(ioutil.WriteFile(file, []byte(unresolvedYaml), 0644)).NotTo(HaveOccurred())
+
+		opts := &options.Options{}
+		opts.Top.Ctx = context.Background()
+		opts.Metadata.Namespace = "gloo-system"
+		opts.Translate.Files = []string{file}
+		var out strings.Builder
+		err = translate.Translate(opts, &out)
+		Expect(err).To(MatchError(ContainSubstring("translation reported")))
+		Expect(out.String()).To(ContainSubstring("SSL secret not found"))
+	})
+})
@@ -0,0 +1,31 @@
+package cmdutils
+
+import (
+	"errors"
+)
+
+// ExitError is returned by commands which exit with a specific code, e.g. to tell apart failures from warnings
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the code glooctl exits with when a command returns the given error
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return 1
+}
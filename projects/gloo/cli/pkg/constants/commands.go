@@ -64,6 +64,10 @@ var (
 	CHECK_COMMAND = cobra.Command{
 		Use:   "check",
 		Short: "Checks Gloo resources for errors (requires Gloo running on Kubernetes)",
+		Long: "usage: glooctl check [-o FORMAT]\n\n" +
+			"Runs each check (unless excluded with --exclude, or not included with --include) and reports the issues " +
+			"found, along with the resources they were found on for json and yaml output. Exits with code 1 if any " +
+			"check found errors, and with code 2 if the checks only found warnings.",
 	}
 
 	CREATE_COMMAND = cobra.Command{
@@ -89,6 +93,18 @@ var (
 		Short: "Dump YAML representing the current Gloo state (requires Gloo running on Kubernetes)",
 	}
 
+	DEBUG_BUNDLE_COMMAND = cobra.Command{
+		Use:   "bundle",
+		Short: "Collect the state of Gloo into a tarball for support cases (requires Gloo running on Kubernetes)",
+		Long: "Collects the versions, the Kubernetes resources of the installation with their events and logs, all the " +
+			"Gloo resources with their statuses, the proxies and the xDS configuration Gloo serves for them, the " +
+			"stats and clusters of each Envoy, and the metrics of the controller into a tarball, written to " +
+			"/tmp/gloo-debug-bundle.tgz unless set with --file. The values of the secrets, the credentials in the " +
+			"Settings, and the private keys, AWS credentials, authorization headers and Azure function keys in the xDS " +
+			"configuration are redacted, and the config dump of each Envoy is left out, unless --include-secret-values " +
+			"is set.",
+	}
+
 	DELETE_COMMAND = cobra.Command{
 		Use:     "delete",
 		Aliases: []string{"d"},
@@ -131,6 +147,38 @@ var (
 		Short: "uninstall gloo federation",
 	}
 
+	TRANSLATE_COMMAND = cobra.Command{
+		Use:   "translate",
+		Short: "Translate Gloo resources from files to Proxies and Envoy configuration, without a cluster",
+		Long: "Reads Gateways, Virtual Services, Route Tables, options, Upstreams, Upstream Groups, Settings and Secrets " +
+			"from files and runs the Gateway and Gloo translators on them, as Gloo would. Prints the resulting Proxies " +
+			"along with the Envoy listeners, route configurations, clusters and endpoints generated for them, and the " +
+			"errors and warnings reported on the resources. Fails if any resource has errors. Endpoints are not discovered, so the clusters of " +
+			"Upstreams which rely on discovery, such as Kubernetes Upstreams, have no endpoints.",
+	}
+
+	DIFF_COMMAND = cobra.Command{
+		Use:   "diff",
+		Short: "Preview the changes to the Proxies of changes to Gloo resources",
+		Long: "Sends the Gateways, Virtual Services and Route Tables read from files to the validation webhook of the " +
+			"gateway as a dry run, and prints the differences between the Proxies they would result in and the deployed " +
+			"ones: the listeners, virtual hosts and routes which would be added, removed, modified or reordered. Also " +
+			"prints the errors and warnings the changes would introduce. Fails if the changes would be rejected. " +
+			"Nothing is written to the cluster.",
+	}
+
+	MIGRATE_COMMAND = cobra.Command{
+		Use:   "migrate",
+		Short: "Convert Kubernetes Ingresses and Istio VirtualServices to Gloo resources",
+		Long: "Reads Ingresses, Istio VirtualServices, Services and Upstreams from files, or from the cluster when no " +
+			"file is provided, and prints the Gloo Virtual Services, Route Tables and Upstreams they convert to. " +
+			"The backends of Ingresses and the destinations of Istio VirtualServices are routed to the Upstreams of " +
+			"their services, which are created when they do not exist yet. The common annotations of ingress-nginx " +
+			"(rewrite-target, use-regex, ssl-redirect, force-ssl-redirect, proxy-read-timeout and CORS) are converted " +
+			"to route options. Everything which could not be converted is listed in comments at the top of the output. " +
+			"Nothing is written to the cluster.",
+	}
+
 	UPGRADE_COMMAND = cobra.Command{
 		Use:     "upgrade",
 		Aliases: []string{"ug"},
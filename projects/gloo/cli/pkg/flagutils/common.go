@@ -18,7 +18,7 @@ const (
 )
 
 func AddCheckOutputFlag(set *pflag.FlagSet, outputType *printers.OutputType) {
-	set.VarP(outputType, OutputFlag, "o", "output format: (json, table)")
+	set.VarP(outputType, OutputFlag, "o", "output format: (json, yaml, table)")
 }
 
 func AddOutputFlag(set *pflag.FlagSet, outputType *printers.OutputType) {
@@ -9,3 +9,9 @@ func AddDebugFlags(set *pflag.FlagSet, top *options.Top) {
 	set.BoolVar(&top.Zip, "zip", false, "save logs to a tar file (specify location with -f)")
 	set.BoolVar(&top.ErrorsOnly, "errors-only", false, "filter for error logs only")
 }
+
+func AddDebugBundleFlags(set *pflag.FlagSet, debug *options.Debug) {
+	set.BoolVar(&debug.IncludeSecretValues, "include-secret-values", false, "include the values of the secrets, the Settings credentials "+
+		"and the xDS private keys, AWS credentials, authorization headers and Azure function keys in the bundle, which are "+
+		"redacted by default, and the config dump of each Envoy")
+}
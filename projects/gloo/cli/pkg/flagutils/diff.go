@@ -0,0 +1,11 @@
+package flagutils
+
+import (
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/spf13/pflag"
+)
+
+func AddDiffFlags(set *pflag.FlagSet, diff *options.Diff) {
+	set.StringSliceVarP(&diff.Files, "file", "f", []string{},
+		"YAML files or directories to read the resources from. may be repeated")
+}
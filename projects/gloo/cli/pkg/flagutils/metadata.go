@@ -1,6 +1,8 @@
 package flagutils
 
 import (
+	"time"
+
 	"github.com/solo-io/gloo/projects/gloo/pkg/defaults"
 	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
 	"github.com/spf13/pflag"
@@ -22,6 +24,17 @@ func AddNamespaceFlag(set *pflag.FlagSet, strptr *string) {
 	set.StringVarP(strptr, "namespace", "n", DefaultNamespace, "namespace for reading or writing resources")
 }
 
+const checkNames = "(deployments, pods, upstreams, upstreamgroup, auth-configs, rate-limit-configs, virtual-host-options, " +
+	"route-options, secrets, certificates, virtual-services, gateways, proxies, xds-metrics, gloo-instances)"
+
 func AddExcludeCheckFlag(set *pflag.FlagSet, strarrptr *[]string) {
-	set.StringSliceVarP(strarrptr, "exclude", "x", []string{}, "check to exclude: (deployments, pods, upstreams, upstreamgroup, auth-configs, rate-limit-configs, secrets, virtual-services, gateways, proxies, xds-metrics)")
+	set.StringSliceVarP(strarrptr, "exclude", "x", []string{}, "check to exclude: "+checkNames)
+}
+
+func AddIncludeCheckFlag(set *pflag.FlagSet, strarrptr *[]string) {
+	set.StringSliceVar(strarrptr, "include", []string{}, "check to run, all of them if not set: "+checkNames)
+}
+
+func AddCertificateExpiryWindowFlag(set *pflag.FlagSet, durationptr *time.Duration, defaultWindow time.Duration) {
+	set.DurationVar(durationptr, "certificate-expiry-window", defaultWindow, "report the certificates expiring within this window")
 }
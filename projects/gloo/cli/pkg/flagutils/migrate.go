@@ -0,0 +1,13 @@
+package flagutils
+
+import (
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/spf13/pflag"
+)
+
+func AddMigrateFlags(set *pflag.FlagSet, migrate *options.Migrate) {
+	set.StringSliceVarP(&migrate.Files, "file", "f", []string{},
+		"YAML files or directories to read the resources from, instead of the cluster. may be repeated")
+	set.StringVar(&migrate.IngressClass, "ingress-class", "",
+		"only convert the Ingresses of this class. all the Ingresses are converted if not set")
+}
@@ -1,6 +1,7 @@
 package flagutils
 
 import (
+	gatewaydefaults "github.com/solo-io/gloo/projects/gateway/pkg/defaults"
 	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
 	"github.com/solo-io/gloo/projects/gloo/pkg/defaults"
 	"github.com/spf13/pflag"
@@ -63,3 +64,18 @@ func RemoveRouteFlags(set *pflag.FlagSet, route *options.RemoveRoute) {
 	set.Uint32VarP(&route.RemoveIndex, "index", "x", 0, "remove the route with this index in the virtual service "+
 		"route list")
 }
+
+func AddRouteTestFlags(set *pflag.FlagSet, test *options.RouteTest) {
+	set.StringVarP(&test.Method, "method", "m", "GET", "the HTTP method of the request")
+	set.StringVar(&test.Host, "host", "", "the host (authority) of the request")
+	set.StringVarP(&test.Path, "path", "p", "/", "the path of the request, which may contain a query string")
+	set.StringSliceVarP(&test.Headers.Entries, "header", "d", []string{},
+		"headers of the request, in the format NAME=VALUE. may be repeated")
+	set.StringSliceVarP(&test.QueryParams.Entries, "query", "q", []string{},
+		"query parameters of the request, in the format NAME=VALUE. may be repeated")
+	set.Uint32Var(&test.Port, "port", 0, "only consider the listeners bound to this port")
+	set.StringVar(&test.ProxyName, "proxy", gatewaydefaults.GatewayProxyName, "name of the proxy to route the request with")
+	set.StringSliceVarP(&test.Files, "file", "f", []string{},
+		"read Gateways, VirtualServices, RouteTables and options from these YAML files or directories and translate "+
+			"them locally, rather than reading the proxy from the cluster. may be repeated")
+}
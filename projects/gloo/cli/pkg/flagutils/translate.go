@@ -0,0 +1,13 @@
+package flagutils
+
+import (
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/cmd/options"
+	"github.com/spf13/pflag"
+)
+
+func AddTranslateFlags(set *pflag.FlagSet, translate *options.Translate) {
+	set.StringSliceVarP(&translate.Files, "file", "f", []string{},
+		"YAML files or directories to read the resources from. may be repeated")
+	set.StringSliceVar(&translate.Proxies, "proxy", []string{},
+		"only print the translation of the proxies with these names. may be repeated")
+}
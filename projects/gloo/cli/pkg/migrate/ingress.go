@@ -0,0 +1,328 @@
+package migrate
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/rotisserie/eris"
+	gatewayv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	envoymatcher "github.com/solo-io/gloo/projects/gloo/pkg/api/external/envoy/type/matcher/v3"
+	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/core/matchers"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/cors"
+	glooutils "github.com/solo-io/gloo/projects/gloo/pkg/utils"
+	"github.com/solo-io/gloo/projects/ingress/pkg/translator"
+	"github.com/solo-io/k8s-utils/kubeutils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// The annotations of ingress-nginx which are converted
+const (
+	nginxAnnotationPrefix = "nginx.ingress.kubernetes.io/"
+
+	rewriteTargetAnnotation        = nginxAnnotationPrefix + "rewrite-target"
+	useRegexAnnotation             = nginxAnnotationPrefix + "use-regex"
+	sslRedirectAnnotation          = nginxAnnotationPrefix + "ssl-redirect"
+	forceSslRedirectAnnotation     = nginxAnnotationPrefix + "force-ssl-redirect"
+	proxyReadTimeoutAnnotation     = nginxAnnotationPrefix + "proxy-read-timeout"
+	enableCorsAnnotation           = nginxAnnotationPrefix + "enable-cors"
+	corsAllowOriginAnnotation      = nginxAnnotationPrefix + "cors-allow-origin"
+	corsAllowMethodsAnnotation     = nginxAnnotationPrefix + "cors-allow-methods"
+	corsAllowHeadersAnnotation     = nginxAnnotationPrefix + "cors-allow-headers"
+	corsExposeHeadersAnnotation    = nginxAnnotationPrefix + "cors-expose-headers"
+	corsAllowCredentialsAnnotation = nginxAnnotationPrefix + "cors-allow-credentials"
+	corsMaxAgeAnnotation           = nginxAnnotationPrefix + "cors-max-age"
+)
+
+var convertedAnnotations = map[string]bool{
+	rewriteTargetAnnotation:        true,
+	useRegexAnnotation:             true,
+	sslRedirectAnnotation:          true,
+	forceSslRedirectAnnotation:     true,
+	proxyReadTimeoutAnnotation:     true,
+	enableCorsAnnotation:           true,
+	corsAllowOriginAnnotation:      true,
+	corsAllowMethodsAnnotation:     true,
+	corsAllowHeadersAnnotation:     true,
+	corsExposeHeadersAnnotation:    true,
+	corsAllowCredentialsAnnotation: true,
+	corsMaxAgeAnnotation:           true,
+}
+
+// The CORS policy of ingress-nginx when CORS is enabled, which the cors annotations override
+const (
+	defaultCorsAllowOrigin  = "*"
+	defaultCorsAllowMethods = "GET, PUT, POST, DELETE, PATCH, OPTIONS"
+	defaultCorsAllowHeaders = "DNT,X-CustomHeader,Keep-Alive,User-Agent,X-Requested-With,If-Modified-Since,Cache-Control,Content-Type,Authorization"
+	defaultCorsMaxAge       = "1728000"
+)
+
+var rewriteCaptureGroup = regexp.MustCompile(`\$(\d)`)
+
+// The routes of a host, merged from all the Ingresses which define it, as the ingress translator does
+type ingressHost struct {
+	host   string
+	routes []*gatewayv1.Route
+	secret *core.ResourceRef
+	// ingress-nginx redirects HTTP requests to HTTPS by default when the host has TLS
+	sslRedirectDisabled bool
+	forceSslRedirect    bool
+}
+
+// Converts the Ingresses to one Virtual Service per host in the write namespace, as Gloo rejects Virtual Services
+// which share domains. The TLS hosts have a Virtual Service for HTTPS, and one for HTTP which either redirects to
+// HTTPS or serves the same routes.
+func (m *migrator) convertIngresses(ingresses []*networkingv1.Ingress) {
+	hosts := map[string]*ingressHost{}
+	hostFor := func(host string) *ingressHost {
+		if host == "" {
+			host = "*"
+		}
+		if _, ok := hosts[host]; !ok {
+			hosts[host] = &ingressHost{host: host}
+		}
+		return hosts[host]
+	}
+
+	for _, ing := range ingresses {
+		if !m.isIngressClass(ing) {
+			continue
+		}
+		ing := ing
+		issue := func(format string, args ...interface{}) {
+			m.addIssue(ingressKind, ing.Namespace, ing.Name, format, args...)
+		}
+
+		var annotations []string
+		for key := range ing.Annotations {
+			if strings.HasPrefix(key, nginxAnnotationPrefix) && !convertedAnnotations[key] {
+				annotations = append(annotations, key)
+			}
+		}
+		sort.Strings(annotations)
+		for _, key := range annotations {
+			issue("annotation %v is not converted", key)
+		}
+		options, err := ingressRouteOptions(ing.Annotations)
+		if err != nil {
+			issue("%v", err)
+		}
+
+		for _, tls := range ing.Spec.TLS {
+			if len(tls.Hosts) == 0 {
+				issue("TLS without hosts, which uses the default certificate of the controller, is not converted")
+			}
+			for _, host := range tls.Hosts {
+				h := hostFor(host)
+				ref := &core.ResourceRef{Name: tls.SecretName, Namespace: ing.Namespace}
+				if h.secret != nil && !h.secret.Equal(ref) {
+					issue("the TLS secret of host %v is defined by another Ingress already, ignoring it", host)
+					continue
+				}
+				h.secret = ref
+			}
+		}
+
+		addRoutes := func(host string, paths []networkingv1.HTTPIngressPath) {
+			h := hostFor(host)
+			if ing.Annotations[sslRedirectAnnotation] == "false" {
+				h.sslRedirectDisabled = true
+			}
+			if ing.Annotations[forceSslRedirectAnnotation] == "true" {
+				h.forceSslRedirect = true
+			}
+			for _, path := range paths {
+				route, err := m.ingressRoute(ing, path, options)
+				if err != nil {
+					issue("path %v of host %v is not converted: %v", path.Path, h.host, err)
+					continue
+				}
+				h.routes = append(h.routes, route)
+			}
+		}
+		if backend := ing.Spec.DefaultBackend; backend != nil {
+			prefix := networkingv1.PathTypePrefix
+			addRoutes("*", []networkingv1.HTTPIngressPath{{Path: "/", PathType: &prefix, Backend: *backend}})
+		}
+		for _, rule := range ing.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			addRoutes(rule.Host, rule.HTTP.Paths)
+		}
+	}
+
+	hostNames := make([]string, 0, len(hosts))
+	for host := range hosts {
+		hostNames = append(hostNames, host)
+	}
+	sort.Strings(hostNames)
+	for _, host := range hostNames {
+		m.addIngressHost(hosts[host])
+	}
+}
+
+func (m *migrator) isIngressClass(ing *networkingv1.Ingress) bool {
+	if m.opts.IngressClass == "" {
+		return true
+	}
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName == m.opts.IngressClass
+	}
+	return ing.Annotations[translator.IngressClassKey] == m.opts.IngressClass
+}
+
+func (m *migrator) addIngressHost(h *ingressHost) {
+	if len(h.routes) == 0 {
+		return
+	}
+	glooutils.SortGatewayRoutesByPath(h.routes)
+
+	name := kubeutils.SanitizeNameV2(strings.ReplaceAll(h.host, "*", "wildcard"))
+	redirect := h.forceSslRedirect || (h.secret != nil && !h.sslRedirectDisabled)
+	newVirtualService := func(name string, routes []*gatewayv1.Route) *gatewayv1.VirtualService {
+		return &gatewayv1.VirtualService{
+			Metadata: &core.Metadata{Name: name, Namespace: m.opts.WriteNamespace},
+			VirtualHost: &gatewayv1.VirtualHost{
+				Domains: []string{h.host},
+				Routes:  routes,
+			},
+		}
+	}
+	redirectRoutes := []*gatewayv1.Route{{
+		Matchers: []*matchers.Matcher{{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/"}}},
+		Action: &gatewayv1.Route_RedirectAction{
+			RedirectAction: &gloov1.RedirectAction{HttpsRedirect: true},
+		},
+	}}
+
+	if h.secret == nil {
+		routes := h.routes
+		if redirect {
+			routes = redirectRoutes
+		}
+		m.result.VirtualServices = append(m.result.VirtualServices, newVirtualService(name, routes))
+		return
+	}
+
+	vs := newVirtualService(name, h.routes)
+	vs.SslConfig = &gloov1.SslConfig{SslSecrets: &gloov1.SslConfig_SecretRef{SecretRef: h.secret}}
+	if h.host != "*" {
+		vs.GetSslConfig().SniDomains = []string{h.host}
+	}
+	m.result.VirtualServices = append(m.result.VirtualServices, vs)
+	if redirect {
+		m.result.VirtualServices = append(m.result.VirtualServices, newVirtualService(name+"-http-redirect", redirectRoutes))
+	} else {
+		var routes []*gatewayv1.Route
+		for _, route := range h.routes {
+			routes = append(routes, route.Clone().(*gatewayv1.Route))
+		}
+		m.result.VirtualServices = append(m.result.VirtualServices, newVirtualService(name+"-http", routes))
+	}
+}
+
+// ingress-nginx matches regular expressions as prefixes of the path, and enables them with a rewrite target
+func (m *migrator) ingressRoute(ing *networkingv1.Ingress, path networkingv1.HTTPIngressPath, options *gloov1.RouteOptions) (*gatewayv1.Route, error) {
+	if path.Backend.Service == nil {
+		return nil, eris.New("only service backends are converted")
+	}
+	ref, err := m.upstreamFor(ing.Namespace, path.Backend.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	p := path.Path
+	if p == "" {
+		p = "/"
+	}
+	rewriteTarget, rewrite := ing.Annotations[rewriteTargetAnnotation]
+	useRegex := ing.Annotations[useRegexAnnotation] == "true" || rewrite
+	regex := strings.TrimPrefix(p, "^")
+	if !strings.HasSuffix(regex, "$") {
+		regex += ".*"
+	}
+
+	matcher := &matchers.Matcher{}
+	switch {
+	case path.PathType != nil && *path.PathType == networkingv1.PathTypeExact:
+		matcher.PathSpecifier = &matchers.Matcher_Exact{Exact: p}
+	case useRegex:
+		matcher.PathSpecifier = &matchers.Matcher_Regex{Regex: regex}
+	default:
+		matcher.PathSpecifier = &matchers.Matcher_Prefix{Prefix: p}
+	}
+
+	route := &gatewayv1.Route{
+		Matchers: []*matchers.Matcher{matcher},
+		Action: &gatewayv1.Route_RouteAction{
+			RouteAction: &gloov1.RouteAction{
+				Destination: &gloov1.RouteAction_Single{Single: upstreamDestination(ref)},
+			},
+		},
+	}
+	if options != nil {
+		route.Options = options.Clone().(*gloov1.RouteOptions)
+	}
+	if rewrite {
+		if route.GetOptions() == nil {
+			route.Options = &gloov1.RouteOptions{}
+		}
+		// ingress-nginx replaces the whole path by the target, in which $n are the groups captured by the path
+		route.GetOptions().RegexRewrite = &envoymatcher.RegexMatchAndSubstitute{
+			Pattern:      &envoymatcher.RegexMatcher{Regex: "^" + regex},
+			Substitution: rewriteCaptureGroup.ReplaceAllString(rewriteTarget, `\$1`),
+		}
+	}
+	return route, nil
+}
+
+// Returns the route options of the annotations shared by all the paths of an Ingress
+func ingressRouteOptions(annotations map[string]string) (*gloov1.RouteOptions, error) {
+	var options *gloov1.RouteOptions
+	if value, ok := annotations[proxyReadTimeoutAnnotation]; ok {
+		seconds, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, eris.Wrapf(err, "invalid %v", proxyReadTimeoutAnnotation)
+		}
+		options = &gloov1.RouteOptions{Timeout: ptypes.DurationProto(time.Duration(seconds) * time.Second)}
+	}
+
+	if annotations[enableCorsAnnotation] != "true" {
+		return options, nil
+	}
+	annotationOrDefault := func(key, defaultValue string) string {
+		if value, ok := annotations[key]; ok {
+			return value
+		}
+		return defaultValue
+	}
+	policy := &cors.CorsPolicy{
+		AllowOrigin:      splitList(annotationOrDefault(corsAllowOriginAnnotation, defaultCorsAllowOrigin)),
+		AllowMethods:     splitList(annotationOrDefault(corsAllowMethodsAnnotation, defaultCorsAllowMethods)),
+		AllowHeaders:     splitList(annotationOrDefault(corsAllowHeadersAnnotation, defaultCorsAllowHeaders)),
+		ExposeHeaders:    splitList(annotations[corsExposeHeadersAnnotation]),
+		MaxAge:           annotationOrDefault(corsMaxAgeAnnotation, defaultCorsMaxAge),
+		AllowCredentials: annotationOrDefault(corsAllowCredentialsAnnotation, "true") == "true",
+	}
+	if options == nil {
+		options = &gloov1.RouteOptions{}
+	}
+	options.Cors = policy
+	return options, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
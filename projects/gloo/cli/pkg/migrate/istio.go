@@ -0,0 +1,519 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/rotisserie/eris"
+	gatewayv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/core/matchers"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/cors"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/headers"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/retries"
+	envoycore "github.com/solo-io/solo-kit/pkg/api/external/envoy/api/v2/core"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// the gateway of the sidecars of the mesh, whose routes apply to the traffic between services
+const istioMeshGateway = "mesh"
+
+// The fields of the Istio VirtualService which are converted, or reported when they are not. Istio types are not
+// imported to keep the dependencies of glooctl down.
+type istioVirtualServiceSpec struct {
+	Hosts    []string          `json:"hosts"`
+	Gateways []string          `json:"gateways"`
+	Http     []istioHTTPRoute  `json:"http"`
+	Tls      []json.RawMessage `json:"tls"`
+	Tcp      []json.RawMessage `json:"tcp"`
+}
+
+type istioHTTPRoute struct {
+	Name       string                  `json:"name"`
+	Match      []istioHTTPMatchRequest `json:"match"`
+	Route      []istioRouteDestination `json:"route"`
+	Redirect   *istioHTTPRedirect      `json:"redirect"`
+	Delegate   *istioDelegate          `json:"delegate"`
+	Rewrite    *istioHTTPRewrite       `json:"rewrite"`
+	Timeout    string                  `json:"timeout"`
+	Retries    *istioHTTPRetry         `json:"retries"`
+	Fault      json.RawMessage         `json:"fault"`
+	Mirror     json.RawMessage         `json:"mirror"`
+	CorsPolicy *istioCorsPolicy        `json:"corsPolicy"`
+	Headers    *istioHeaders           `json:"headers"`
+}
+
+type istioStringMatch struct {
+	Exact  string `json:"exact"`
+	Prefix string `json:"prefix"`
+	Regex  string `json:"regex"`
+}
+
+type istioHTTPMatchRequest struct {
+	Uri            *istioStringMatch           `json:"uri"`
+	Method         *istioStringMatch           `json:"method"`
+	Headers        map[string]istioStringMatch `json:"headers"`
+	WithoutHeaders map[string]istioStringMatch `json:"withoutHeaders"`
+	QueryParams    map[string]istioStringMatch `json:"queryParams"`
+	IgnoreUriCase  bool                        `json:"ignoreUriCase"`
+
+	Scheme          *istioStringMatch `json:"scheme"`
+	Authority       *istioStringMatch `json:"authority"`
+	Port            uint32            `json:"port"`
+	SourceLabels    map[string]string `json:"sourceLabels"`
+	Gateways        []string          `json:"gateways"`
+	SourceNamespace string            `json:"sourceNamespace"`
+}
+
+type istioRouteDestination struct {
+	Destination istioDestination `json:"destination"`
+	Weight      uint32           `json:"weight"`
+}
+
+type istioDestination struct {
+	Host   string `json:"host"`
+	Subset string `json:"subset"`
+	Port   *struct {
+		Number int32 `json:"number"`
+	} `json:"port"`
+}
+
+type istioHTTPRedirect struct {
+	Uri          string `json:"uri"`
+	Authority    string `json:"authority"`
+	RedirectCode uint32 `json:"redirectCode"`
+}
+
+type istioDelegate struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type istioHTTPRewrite struct {
+	Uri       string `json:"uri"`
+	Authority string `json:"authority"`
+}
+
+type istioHTTPRetry struct {
+	Attempts      uint32 `json:"attempts"`
+	PerTryTimeout string `json:"perTryTimeout"`
+	RetryOn       string `json:"retryOn"`
+}
+
+type istioCorsPolicy struct {
+	AllowOrigins     []istioStringMatch `json:"allowOrigins"`
+	AllowOrigin      []string           `json:"allowOrigin"`
+	AllowMethods     []string           `json:"allowMethods"`
+	AllowHeaders     []string           `json:"allowHeaders"`
+	ExposeHeaders    []string           `json:"exposeHeaders"`
+	MaxAge           string             `json:"maxAge"`
+	AllowCredentials bool               `json:"allowCredentials"`
+}
+
+type istioHeaderOperations struct {
+	Set    map[string]string `json:"set"`
+	Add    map[string]string `json:"add"`
+	Remove []string          `json:"remove"`
+}
+
+type istioHeaders struct {
+	Request  *istioHeaderOperations `json:"request"`
+	Response *istioHeaderOperations `json:"response"`
+}
+
+var istioRedirectCodes = map[uint32]gloov1.RedirectAction_RedirectResponseCode{
+	301: gloov1.RedirectAction_MOVED_PERMANENTLY,
+	302: gloov1.RedirectAction_FOUND,
+	303: gloov1.RedirectAction_SEE_OTHER,
+	307: gloov1.RedirectAction_TEMPORARY_REDIRECT,
+	308: gloov1.RedirectAction_PERMANENT_REDIRECT,
+}
+
+// the hosts of kube services: name, name.namespace, name.namespace.svc or name.namespace.svc.cluster.local
+var istioServiceHost = regexp.MustCompile(`^([a-z0-9-]+)(?:\.([a-z0-9-]+)(?:\.svc(?:\.cluster\.local)?)?)?$`)
+
+// Converts an Istio VirtualService to a Gloo Virtual Service with the same name, or to a Route Table when it has no
+// hosts, as it is then the target of the delegation of another VirtualService.
+func (m *migrator) convertIstioVirtualService(obj unstructured.Unstructured) error {
+	namespace, name := obj.GetNamespace(), obj.GetName()
+	issue := func(format string, args ...interface{}) {
+		m.addIssue("Istio "+istioVirtualServiceKind, namespace, name, format, args...)
+	}
+
+	jsn, err := json.Marshal(obj.Object["spec"])
+	if err != nil {
+		return err
+	}
+	var spec istioVirtualServiceSpec
+	if err := json.Unmarshal(jsn, &spec); err != nil {
+		return eris.Wrapf(err, "parsing Istio VirtualService %v.%v", namespace, name)
+	}
+
+	if len(spec.Hosts) > 0 && !hasIngressGateway(spec.Gateways) {
+		issue("only the routes of ingress gateways are converted, not the routes of the mesh")
+		return nil
+	}
+	if len(spec.Tls) > 0 {
+		issue("tls routes are not converted")
+	}
+	if len(spec.Tcp) > 0 {
+		issue("tcp routes are not converted")
+	}
+
+	var routes []*gatewayv1.Route
+	for i, httpRoute := range spec.Http {
+		routeName := httpRoute.Name
+		if routeName == "" {
+			routeName = fmt.Sprintf("#%d", i)
+		}
+		route, err := m.istioRoute(namespace, httpRoute)
+		if err != nil {
+			issue("http route %v is not converted: %v", routeName, err)
+			continue
+		}
+		for _, unsupported := range unsupportedIstioRouteFields(httpRoute) {
+			issue("%v of http route %v is not converted", unsupported, routeName)
+		}
+		routes = append(routes, route)
+	}
+
+	metadata := &core.Metadata{Name: name, Namespace: namespace}
+	if len(spec.Hosts) == 0 {
+		m.result.RouteTables = append(m.result.RouteTables, &gatewayv1.RouteTable{
+			Metadata: metadata,
+			Routes:   routes,
+		})
+		return nil
+	}
+	m.result.VirtualServices = append(m.result.VirtualServices, &gatewayv1.VirtualService{
+		Metadata: metadata,
+		VirtualHost: &gatewayv1.VirtualHost{
+			Domains: spec.Hosts,
+			Routes:  routes,
+		},
+	})
+	return nil
+}
+
+// VirtualServices without gateways apply to the mesh only
+func hasIngressGateway(gateways []string) bool {
+	for _, gateway := range gateways {
+		if gateway != istioMeshGateway {
+			return true
+		}
+	}
+	return false
+}
+
+func unsupportedIstioRouteFields(httpRoute istioHTTPRoute) []string {
+	var fields []string
+	if len(httpRoute.Fault) > 0 {
+		fields = append(fields, "fault")
+	}
+	if len(httpRoute.Mirror) > 0 {
+		fields = append(fields, "mirror")
+	}
+	for _, match := range httpRoute.Match {
+		if match.Scheme != nil {
+			fields = append(fields, "match scheme")
+		}
+		if match.Authority != nil {
+			fields = append(fields, "match authority")
+		}
+		if match.Port != 0 {
+			fields = append(fields, "match port")
+		}
+		if len(match.SourceLabels) > 0 {
+			fields = append(fields, "match sourceLabels")
+		}
+		if len(match.Gateways) > 0 {
+			fields = append(fields, "match gateways")
+		}
+		if match.SourceNamespace != "" {
+			fields = append(fields, "match sourceNamespace")
+		}
+	}
+	return fields
+}
+
+func (m *migrator) istioRoute(namespace string, httpRoute istioHTTPRoute) (*gatewayv1.Route, error) {
+	route := &gatewayv1.Route{Name: httpRoute.Name}
+	for _, match := range httpRoute.Match {
+		matcher, err := istioMatcher(match)
+		if err != nil {
+			return nil, err
+		}
+		route.Matchers = append(route.Matchers, matcher)
+	}
+
+	switch {
+	case httpRoute.Redirect != nil:
+		action := &gloov1.RedirectAction{
+			HostRedirect: httpRoute.Redirect.Authority,
+		}
+		if httpRoute.Redirect.Uri != "" {
+			action.PathRewriteSpecifier = &gloov1.RedirectAction_PathRedirect{PathRedirect: httpRoute.Redirect.Uri}
+		}
+		if httpRoute.Redirect.RedirectCode != 0 {
+			code, ok := istioRedirectCodes[httpRoute.Redirect.RedirectCode]
+			if !ok {
+				return nil, eris.Errorf("unsupported redirect code %v", httpRoute.Redirect.RedirectCode)
+			}
+			action.ResponseCode = code
+		}
+		route.Action = &gatewayv1.Route_RedirectAction{RedirectAction: action}
+	case httpRoute.Delegate != nil:
+		delegateNamespace := httpRoute.Delegate.Namespace
+		if delegateNamespace == "" {
+			delegateNamespace = namespace
+		}
+		route.Action = &gatewayv1.Route_DelegateAction{
+			DelegateAction: &gatewayv1.DelegateAction{
+				DelegationType: &gatewayv1.DelegateAction_Ref{
+					Ref: &core.ResourceRef{Name: httpRoute.Delegate.Name, Namespace: delegateNamespace},
+				},
+			},
+		}
+	case len(httpRoute.Route) > 0:
+		action, err := m.istioRouteAction(namespace, httpRoute.Route)
+		if err != nil {
+			return nil, err
+		}
+		route.Action = &gatewayv1.Route_RouteAction{RouteAction: action}
+	default:
+		return nil, eris.New("it has no route, redirect or delegate")
+	}
+
+	options, err := istioRouteOptions(httpRoute)
+	if err != nil {
+		return nil, err
+	}
+	route.Options = options
+	return route, nil
+}
+
+func istioMatcher(match istioHTTPMatchRequest) (*matchers.Matcher, error) {
+	matcher := &matchers.Matcher{}
+	if uri := match.Uri; uri != nil {
+		switch {
+		case uri.Exact != "":
+			matcher.PathSpecifier = &matchers.Matcher_Exact{Exact: uri.Exact}
+		case uri.Regex != "":
+			matcher.PathSpecifier = &matchers.Matcher_Regex{Regex: uri.Regex}
+		default:
+			matcher.PathSpecifier = &matchers.Matcher_Prefix{Prefix: uri.Prefix}
+		}
+	}
+	if match.IgnoreUriCase {
+		matcher.CaseSensitive = &wrappers.BoolValue{Value: false}
+	}
+	if method := match.Method; method != nil {
+		if method.Exact == "" {
+			return nil, eris.New("only exact methods are converted")
+		}
+		matcher.Methods = []string{method.Exact}
+	}
+
+	for _, name := range sortedKeys(match.Headers) {
+		value, regex := istioStringMatchValue(match.Headers[name])
+		matcher.Headers = append(matcher.Headers, &matchers.HeaderMatcher{Name: name, Value: value, Regex: regex})
+	}
+	for _, name := range sortedKeys(match.WithoutHeaders) {
+		value, regex := istioStringMatchValue(match.WithoutHeaders[name])
+		matcher.Headers = append(matcher.Headers, &matchers.HeaderMatcher{Name: name, Value: value, Regex: regex, InvertMatch: true})
+	}
+	for _, name := range sortedKeys(match.QueryParams) {
+		value, regex := istioStringMatchValue(match.QueryParams[name])
+		matcher.QueryParameters = append(matcher.QueryParameters, &matchers.QueryParameterMatcher{Name: name, Value: value, Regex: regex})
+	}
+	return matcher, nil
+}
+
+// Gloo matches headers and query parameters either exactly or with a regex
+func istioStringMatchValue(match istioStringMatch) (string, bool) {
+	switch {
+	case match.Regex != "":
+		return match.Regex, true
+	case match.Prefix != "":
+		return regexp.QuoteMeta(match.Prefix) + ".*", true
+	default:
+		return match.Exact, false
+	}
+}
+
+func (m *migrator) istioRouteAction(namespace string, destinations []istioRouteDestination) (*gloov1.RouteAction, error) {
+	var weighted []*gloov1.WeightedDestination
+	for _, routeDestination := range destinations {
+		ref, err := m.istioDestinationUpstream(namespace, routeDestination.Destination)
+		if err != nil {
+			return nil, err
+		}
+		weighted = append(weighted, &gloov1.WeightedDestination{
+			Destination: upstreamDestination(ref),
+			Weight:      routeDestination.Weight,
+		})
+	}
+	if len(weighted) == 1 {
+		return &gloov1.RouteAction{
+			Destination: &gloov1.RouteAction_Single{Single: weighted[0].GetDestination()},
+		}, nil
+	}
+	return &gloov1.RouteAction{
+		Destination: &gloov1.RouteAction_Multi{Multi: &gloov1.MultiDestination{Destinations: weighted}},
+	}, nil
+}
+
+func (m *migrator) istioDestinationUpstream(namespace string, destination istioDestination) (*core.ResourceRef, error) {
+	if destination.Subset != "" {
+		return nil, eris.Errorf("subset %v of destination %v is not supported", destination.Subset, destination.Host)
+	}
+	parts := istioServiceHost.FindStringSubmatch(destination.Host)
+	if parts == nil {
+		return nil, eris.Errorf("destination %v is not a kube service", destination.Host)
+	}
+	serviceName, serviceNamespace := parts[1], parts[2]
+	if serviceNamespace == "" {
+		serviceNamespace = namespace
+	}
+
+	backend := &networkingv1.IngressServiceBackend{Name: serviceName}
+	if destination.Port != nil {
+		backend.Port.Number = destination.Port.Number
+	} else {
+		// the port can be omitted when the service has a single one
+		svc := m.service(serviceNamespace, serviceName)
+		if svc == nil || len(svc.Spec.Ports) != 1 {
+			return nil, eris.Errorf("destination %v has no port", destination.Host)
+		}
+		backend.Port.Number = svc.Spec.Ports[0].Port
+	}
+	return m.upstreamFor(serviceNamespace, backend)
+}
+
+func istioRouteOptions(httpRoute istioHTTPRoute) (*gloov1.RouteOptions, error) {
+	options := &gloov1.RouteOptions{}
+	if rewrite := httpRoute.Rewrite; rewrite != nil {
+		if rewrite.Uri != "" {
+			options.PrefixRewrite = &wrappers.StringValue{Value: rewrite.Uri}
+		}
+		if rewrite.Authority != "" {
+			options.HostRewriteType = &gloov1.RouteOptions_HostRewrite{HostRewrite: rewrite.Authority}
+		}
+	}
+	if httpRoute.Timeout != "" {
+		timeout, err := time.ParseDuration(httpRoute.Timeout)
+		if err != nil {
+			return nil, eris.Wrapf(err, "invalid timeout")
+		}
+		options.Timeout = ptypes.DurationProto(timeout)
+	}
+	if retry := httpRoute.Retries; retry != nil {
+		policy := &retries.RetryPolicy{RetryOn: retry.RetryOn, NumRetries: retry.Attempts}
+		if retry.PerTryTimeout != "" {
+			perTryTimeout, err := time.ParseDuration(retry.PerTryTimeout)
+			if err != nil {
+				return nil, eris.Wrapf(err, "invalid perTryTimeout")
+			}
+			policy.PerTryTimeout = ptypes.DurationProto(perTryTimeout)
+		}
+		options.Retries = policy
+	}
+	if corsPolicy := httpRoute.CorsPolicy; corsPolicy != nil {
+		policy, err := istioCors(corsPolicy)
+		if err != nil {
+			return nil, err
+		}
+		options.Cors = policy
+	}
+	if httpRoute.Headers != nil {
+		options.HeaderManipulation = istioHeaderManipulation(httpRoute.Headers)
+	}
+
+	if options.Equal(&gloov1.RouteOptions{}) {
+		return nil, nil
+	}
+	return options, nil
+}
+
+func istioCors(corsPolicy *istioCorsPolicy) (*cors.CorsPolicy, error) {
+	policy := &cors.CorsPolicy{
+		AllowOrigin:      corsPolicy.AllowOrigin,
+		AllowMethods:     corsPolicy.AllowMethods,
+		AllowHeaders:     corsPolicy.AllowHeaders,
+		ExposeHeaders:    corsPolicy.ExposeHeaders,
+		AllowCredentials: corsPolicy.AllowCredentials,
+	}
+	for _, origin := range corsPolicy.AllowOrigins {
+		if value, regex := istioStringMatchValue(origin); regex {
+			policy.AllowOriginRegex = append(policy.AllowOriginRegex, value)
+		} else {
+			policy.AllowOrigin = append(policy.AllowOrigin, value)
+		}
+	}
+	if corsPolicy.MaxAge != "" {
+		maxAge, err := time.ParseDuration(corsPolicy.MaxAge)
+		if err != nil {
+			return nil, eris.Wrapf(err, "invalid maxAge")
+		}
+		policy.MaxAge = strconv.FormatInt(int64(maxAge.Seconds()), 10)
+	}
+	return policy, nil
+}
+
+// Istio replaces the headers it sets, and appends the ones it adds
+func istioHeaderManipulation(istioHeaders *istioHeaders) *headers.HeaderManipulation {
+	manipulation := &headers.HeaderManipulation{}
+	if request := istioHeaders.Request; request != nil {
+		addRequestHeader := func(values map[string]string, appendValue bool) {
+			for _, key := range sortedKeys(values) {
+				manipulation.RequestHeadersToAdd = append(manipulation.RequestHeadersToAdd, &envoycore.HeaderValueOption{
+					HeaderOption: &envoycore.HeaderValueOption_Header{
+						Header: &envoycore.HeaderValue{Key: key, Value: values[key]},
+					},
+					Append: &wrappers.BoolValue{Value: appendValue},
+				})
+			}
+		}
+		addRequestHeader(request.Set, false)
+		addRequestHeader(request.Add, true)
+		manipulation.RequestHeadersToRemove = request.Remove
+	}
+	if response := istioHeaders.Response; response != nil {
+		addResponseHeader := func(values map[string]string, appendValue bool) {
+			for _, key := range sortedKeys(values) {
+				manipulation.ResponseHeadersToAdd = append(manipulation.ResponseHeadersToAdd, &headers.HeaderValueOption{
+					Header: &headers.HeaderValue{Key: key, Value: values[key]},
+					Append: &wrappers.BoolValue{Value: appendValue},
+				})
+			}
+		}
+		addResponseHeader(response.Set, false)
+		addResponseHeader(response.Add, true)
+		manipulation.ResponseHeadersToRemove = response.Remove
+	}
+	return manipulation
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch typed := m.(type) {
+	case map[string]string:
+		for key := range typed {
+			keys = append(keys, key)
+		}
+	case map[string]istioStringMatch:
+		for key := range typed {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
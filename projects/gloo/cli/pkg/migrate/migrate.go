@@ -0,0 +1,187 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/rotisserie/eris"
+	gatewayv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins/kubernetes"
+	"github.com/solo-io/gloo/projects/ingress/pkg/translator"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	"github.com/solo-io/solo-kit/pkg/utils/protoutils"
+	kubev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	ingressKind             = "Ingress"
+	istioVirtualServiceKind = "VirtualService"
+	istioGroup              = "networking.istio.io"
+)
+
+var (
+	ingressGVK = networkingv1.SchemeGroupVersion.WithKind(ingressKind)
+	serviceGVK = kubev1.SchemeGroupVersion.WithKind("Service")
+)
+
+type Options struct {
+	// The namespace of the Virtual Services converted from Ingresses and of the new Upstreams
+	WriteNamespace string
+	// If set, only the Ingresses of this class are converted
+	IngressClass string
+}
+
+// Result holds the Gloo resources converted from Ingresses and Istio VirtualServices, along with what could not be
+// converted.
+type Result struct {
+	VirtualServices gatewayv1.VirtualServiceList
+	RouteTables     gatewayv1.RouteTableList
+	// The Upstreams the converted routes need which were not part of the input, as discovery would create them
+	Upstreams gloov1.UpstreamList
+	Issues    []Issue
+}
+
+// Issue is a part of a resource which could not be converted, or was converted with a different behavior.
+type Issue struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Message   string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%v %v.%v: %v", i.Kind, i.Namespace, i.Name, i.Message)
+}
+
+// Migrate converts the Ingresses and Istio VirtualServices of the objects to Gloo Virtual Services, Route Tables and
+// Upstreams. The Services and Upstreams of the objects are used to find the Upstreams of the backends and
+// destinations, and Upstreams are created for the Services which have none, the way discovery would.
+func Migrate(ctx context.Context, objects []unstructured.Unstructured, opts Options) (*Result, error) {
+	m := &migrator{ctx: ctx, opts: opts, result: &Result{}}
+	var ingresses []*networkingv1.Ingress
+	var istioVirtualServices []unstructured.Unstructured
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		switch {
+		case gvk == ingressGVK:
+			var ingress networkingv1.Ingress
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &ingress); err != nil {
+				return nil, eris.Wrapf(err, "parsing Ingress %v.%v", obj.GetNamespace(), obj.GetName())
+			}
+			ingresses = append(ingresses, &ingress)
+		case gvk.Kind == ingressKind:
+			m.addIssue(ingressKind, obj.GetNamespace(), obj.GetName(), "only networking.k8s.io/v1 Ingresses are converted, not %v", gvk.GroupVersion())
+		case gvk == serviceGVK:
+			var svc kubev1.Service
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &svc); err != nil {
+				return nil, eris.Wrapf(err, "parsing Service %v.%v", obj.GetNamespace(), obj.GetName())
+			}
+			m.services = append(m.services, &svc)
+		case gvk == gloov1.UpstreamGVK:
+			us := &gloov1.Upstream{}
+			jsn, err := obj.MarshalJSON()
+			if err != nil {
+				return nil, err
+			}
+			if err := protoutils.UnmarshalResource(jsn, us); err != nil {
+				return nil, eris.Wrapf(err, "parsing Upstream %v.%v", obj.GetNamespace(), obj.GetName())
+			}
+			m.upstreams = append(m.upstreams, us)
+		case isIstioVirtualService(gvk):
+			istioVirtualServices = append(istioVirtualServices, obj)
+		}
+	}
+
+	m.convertIngresses(ingresses)
+	for _, obj := range istioVirtualServices {
+		if err := m.convertIstioVirtualService(obj); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(m.result.VirtualServices, func(i, j int) bool {
+		return m.result.VirtualServices[i].GetMetadata().Ref().Key() < m.result.VirtualServices[j].GetMetadata().Ref().Key()
+	})
+	sort.SliceStable(m.result.RouteTables, func(i, j int) bool {
+		return m.result.RouteTables[i].GetMetadata().Ref().Key() < m.result.RouteTables[j].GetMetadata().Ref().Key()
+	})
+	sort.SliceStable(m.result.Upstreams, func(i, j int) bool {
+		return m.result.Upstreams[i].GetMetadata().Ref().Key() < m.result.Upstreams[j].GetMetadata().Ref().Key()
+	})
+	return m.result, nil
+}
+
+type migrator struct {
+	ctx       context.Context
+	opts      Options
+	services  []*kubev1.Service
+	upstreams gloov1.UpstreamList
+	result    *Result
+}
+
+func (m *migrator) addIssue(kind, namespace, name, format string, args ...interface{}) {
+	m.result.Issues = append(m.result.Issues, Issue{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// Returns the reference to the upstream of the port of the service, creating the upstream if there is none.
+// The port is either a number or the name of a port of the service.
+func (m *migrator) upstreamFor(serviceNamespace string, serviceBackend *networkingv1.IngressServiceBackend) (*core.ResourceRef, error) {
+	backend := networkingv1.IngressBackend{Service: serviceBackend}
+	if us, err := translator.UpstreamForBackend(m.upstreams, m.services, serviceNamespace, backend); err == nil {
+		return us.GetMetadata().Ref(), nil
+	}
+	serviceName, servicePort, err := translator.ServiceNameAndPort(m.services, serviceNamespace, serviceBackend)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := m.service(serviceNamespace, serviceName)
+	if svc == nil {
+		// the selector of the upstream is only known from the service
+		svc = &kubev1.Service{}
+		svc.Name = serviceName
+		svc.Namespace = serviceNamespace
+	}
+	port := kubev1.ServicePort{Port: servicePort}
+	for _, svcPort := range svc.Spec.Ports {
+		if svcPort.Port == servicePort {
+			port = svcPort
+		}
+	}
+	us := kubernetes.DefaultUpstreamConverter().CreateUpstream(m.ctx, svc, port)
+	us.GetMetadata().Namespace = m.opts.WriteNamespace
+	us.GetMetadata().Annotations = nil
+	m.upstreams = append(m.upstreams, us)
+	m.result.Upstreams = append(m.result.Upstreams, us)
+	return us.GetMetadata().Ref(), nil
+}
+
+func (m *migrator) service(namespace, name string) *kubev1.Service {
+	for _, svc := range m.services {
+		if svc.Namespace == namespace && svc.Name == name {
+			return svc
+		}
+	}
+	return nil
+}
+
+func upstreamDestination(ref *core.ResourceRef) *gloov1.Destination {
+	return &gloov1.Destination{
+		DestinationType: &gloov1.Destination_Upstream{Upstream: ref},
+	}
+}
+
+func isIstioVirtualService(gvk schema.GroupVersionKind) bool {
+	return gvk.Group == istioGroup && gvk.Kind == istioVirtualServiceKind
+}
@@ -0,0 +1,472 @@
+package migrate_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	gatewayv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/migrate"
+	envoymatcher "github.com/solo-io/gloo/projects/gloo/pkg/api/external/envoy/type/matcher/v3"
+	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/core/matchers"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/cors"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	"github.com/solo-io/solo-kit/pkg/utils/statusutils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Migrate", func() {
+
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		Expect(os.Setenv(statusutils.PodNamespaceEnvName, "gloo-system")).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv(statusutils.PodNamespaceEnvName)).NotTo(HaveOccurred())
+	})
+
+	objects := func(docs ...string) []unstructured.Unstructured {
+		var objs []unstructured.Unstructured
+		for _, doc := range docs {
+			var obj map[string]interface{}
+			Expect(yaml.Unmarshal([]byte(doc), &obj)).NotTo(HaveOccurred())
+			objs = append(objs, unstructured.Unstructured{Object: obj})
+		}
+		return objs
+	}
+
+	const service = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: petstore
+  namespace: default
+spec:
+  selector:
+    app: petstore
+  ports:
+  - name: http
+    port: 8080
+`
+
+	upstreamRef := func(name string) *core.ResourceRef {
+		return &core.ResourceRef{Name: name, Namespace: "gloo-system"}
+	}
+
+	singleDestination := func(ref *core.ResourceRef) *gatewayv1.Route_RouteAction {
+		return &gatewayv1.Route_RouteAction{
+			RouteAction: &gloov1.RouteAction{
+				Destination: &gloov1.RouteAction_Single{
+					Single: &gloov1.Destination{DestinationType: &gloov1.Destination_Upstream{Upstream: ref}},
+				},
+			},
+		}
+	}
+
+	issueMessages := func(result *migrate.Result) []string {
+		var messages []string
+		for _, issue := range result.Issues {
+			messages = append(messages, issue.String())
+		}
+		return messages
+	}
+
+	Context("Ingresses", func() {
+
+		It("converts the rules to a virtual service per host, creating the missing upstreams", func() {
+			result, err := migrate.Migrate(ctx, objects(service, `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: petstore
+  namespace: default
+spec:
+  rules:
+  - host: petstore.example.com
+    http:
+      paths:
+      - path: /api
+        pathType: Prefix
+        backend:
+          service:
+            name: petstore
+            port:
+              name: http
+      - path: /api/pets
+        pathType: Exact
+        backend:
+          service:
+            name: petstore
+            port:
+              number: 8080
+`), migrate.Options{WriteNamespace: "gloo-system"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Issues).To(BeEmpty())
+
+			Expect(result.Upstreams).To(HaveLen(1))
+			us := result.Upstreams[0]
+			Expect(us.GetMetadata().GetNamespace()).To(Equal("gloo-system"))
+			Expect(us.GetKube().GetServiceName()).To(Equal("petstore"))
+			Expect(us.GetKube().GetServiceNamespace()).To(Equal("default"))
+			Expect(us.GetKube().GetServicePort()).To(BeEquivalentTo(8080))
+			Expect(us.GetKube().GetSelector()).To(Equal(map[string]string{"app": "petstore"}))
+
+			Expect(result.VirtualServices).To(HaveLen(1))
+			vs := result.VirtualServices[0]
+			Expect(vs.GetMetadata().Ref()).To(Equal(&core.ResourceRef{Name: "petstore-example-com", Namespace: "gloo-system"}))
+			Expect(vs.GetVirtualHost().GetDomains()).To(Equal([]string{"petstore.example.com"}))
+			// the exact path is more specific than the prefix
+			Expect(vs.GetVirtualHost().GetRoutes()).To(Equal([]*gatewayv1.Route{
+				{
+					Matchers: []*matchers.Matcher{{PathSpecifier: &matchers.Matcher_Exact{Exact: "/api/pets"}}},
+					Action:   singleDestination(us.GetMetadata().Ref()),
+				},
+				{
+					Matchers: []*matchers.Matcher{{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/api"}}},
+					Action:   singleDestination(us.GetMetadata().Ref()),
+				},
+			}))
+		})
+
+		It("uses the existing upstreams of the services", func() {
+			result, err := migrate.Migrate(ctx, objects(service, `
+apiVersion: gloo.solo.io/v1
+kind: Upstream
+metadata:
+  name: existing
+  namespace: gloo-system
+spec:
+  kube:
+    serviceName: petstore
+    serviceNamespace: default
+    servicePort: 8080
+`, `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: petstore
+  namespace: default
+spec:
+  defaultBackend:
+    service:
+      name: petstore
+      port:
+        number: 8080
+`), migrate.Options{WriteNamespace: "gloo-system"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Upstreams).To(BeEmpty())
+			Expect(result.VirtualServices).To(HaveLen(1))
+			vs := result.VirtualServices[0]
+			Expect(vs.GetMetadata().GetName()).To(Equal("wildcard"))
+			Expect(vs.GetVirtualHost().GetDomains()).To(Equal([]string{"*"}))
+			Expect(vs.GetVirtualHost().GetRoutes()).To(Equal([]*gatewayv1.Route{{
+				Matchers: []*matchers.Matcher{{PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/"}}},
+				Action:   singleDestination(upstreamRef("existing")),
+			}}))
+		})
+
+		It("converts the nginx annotations and reports the others", func() {
+			result, err := migrate.Migrate(ctx, objects(service, `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: petstore
+  namespace: default
+  annotations:
+    nginx.ingress.kubernetes.io/rewrite-target: /$2
+    nginx.ingress.kubernetes.io/proxy-read-timeout: "30"
+    nginx.ingress.kubernetes.io/enable-cors: "true"
+    nginx.ingress.kubernetes.io/cors-allow-origin: "https://a.com, https://b.com"
+    nginx.ingress.kubernetes.io/limit-rps: "5"
+spec:
+  rules:
+  - http:
+      paths:
+      - path: /pets(/|$)(.*)
+        pathType: ImplementationSpecific
+        backend:
+          service:
+            name: petstore
+            port:
+              number: 8080
+`), migrate.Options{WriteNamespace: "gloo-system"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issueMessages(result)).To(Equal([]string{
+				"Ingress default.petstore: annotation nginx.ingress.kubernetes.io/limit-rps is not converted",
+			}))
+
+			Expect(result.VirtualServices).To(HaveLen(1))
+			routes := result.VirtualServices[0].GetVirtualHost().GetRoutes()
+			Expect(routes).To(HaveLen(1))
+			Expect(routes[0].GetMatchers()).To(Equal([]*matchers.Matcher{{
+				PathSpecifier: &matchers.Matcher_Regex{Regex: "/pets(/|$)(.*).*"},
+			}}))
+			Expect(routes[0].GetOptions().GetRegexRewrite()).To(Equal(&envoymatcher.RegexMatchAndSubstitute{
+				Pattern:      &envoymatcher.RegexMatcher{Regex: "^/pets(/|$)(.*).*"},
+				Substitution: `/\2`,
+			}))
+			Expect(routes[0].GetOptions().GetTimeout()).To(Equal(ptypes.DurationProto(30 * time.Second)))
+			Expect(routes[0].GetOptions().GetCors()).To(Equal(&cors.CorsPolicy{
+				AllowOrigin:      []string{"https://a.com", "https://b.com"},
+				AllowMethods:     []string{"GET", "PUT", "POST", "DELETE", "PATCH", "OPTIONS"},
+				AllowHeaders:     strings.Split("DNT,X-CustomHeader,Keep-Alive,User-Agent,X-Requested-With,If-Modified-Since,Cache-Control,Content-Type,Authorization", ","),
+				MaxAge:           "1728000",
+				AllowCredentials: true,
+			}))
+		})
+
+		It("serves TLS hosts over HTTPS and redirects HTTP to HTTPS unless disabled", func() {
+			ingress := func(name, host, sslRedirect string) string {
+				return `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: ` + name + `
+  namespace: default
+  annotations:
+    nginx.ingress.kubernetes.io/ssl-redirect: "` + sslRedirect + `"
+spec:
+  tls:
+  - hosts: [` + host + `]
+    secretName: ` + name + `-tls
+  rules:
+  - host: ` + host + `
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: petstore
+            port:
+              number: 8080
+`
+			}
+			result, err := migrate.Migrate(ctx, objects(service, ingress("a", "a.com", "true"), ingress("b", "b.com", "false")),
+				migrate.Options{WriteNamespace: "gloo-system"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Issues).To(BeEmpty())
+
+			var names []string
+			for _, vs := range result.VirtualServices {
+				names = append(names, vs.GetMetadata().GetName())
+			}
+			Expect(names).To(Equal([]string{"a-com", "a-com-http-redirect", "b-com", "b-com-http"}))
+
+			https := result.VirtualServices[0]
+			Expect(https.GetSslConfig()).To(Equal(&gloov1.SslConfig{
+				SslSecrets: &gloov1.SslConfig_SecretRef{SecretRef: &core.ResourceRef{Name: "a-tls", Namespace: "default"}},
+				SniDomains: []string{"a.com"},
+			}))
+			Expect(result.VirtualServices[1].GetSslConfig()).To(BeNil())
+			Expect(result.VirtualServices[1].GetVirtualHost().GetRoutes()[0].GetRedirectAction()).To(Equal(&gloov1.RedirectAction{HttpsRedirect: true}))
+			Expect(result.VirtualServices[3].GetSslConfig()).To(BeNil())
+			Expect(result.VirtualServices[3].GetVirtualHost().GetRoutes()).To(Equal(result.VirtualServices[2].GetVirtualHost().GetRoutes()))
+		})
+
+		It("filters the ingresses by class and reports the unsupported ones", func() {
+			result, err := migrate.Migrate(ctx, objects(service, `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: other
+  namespace: default
+spec:
+  ingressClassName: other
+  defaultBackend:
+    service:
+      name: petstore
+      port:
+        number: 8080
+`, `
+apiVersion: extensions/v1beta1
+kind: Ingress
+metadata:
+  name: old
+  namespace: default
+spec: {}
+`, `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: resource
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: gloo
+spec:
+  rules:
+  - http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          resource:
+            kind: StorageBucket
+            name: bucket
+`), migrate.Options{WriteNamespace: "gloo-system", IngressClass: "gloo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.VirtualServices).To(BeEmpty())
+			Expect(issueMessages(result)).To(Equal([]string{
+				"Ingress default.old: only networking.k8s.io/v1 Ingresses are converted, not extensions/v1beta1",
+				"Ingress default.resource: path / of host * is not converted: only service backends are converted",
+			}))
+		})
+	})
+
+	Context("Istio VirtualServices", func() {
+
+		It("converts the http routes of gateways", func() {
+			result, err := migrate.Migrate(ctx, objects(service, `
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: petstore
+  namespace: default
+spec:
+  hosts: [petstore.example.com]
+  gateways: [istio-system/ingressgateway]
+  http:
+  - name: pets
+    match:
+    - uri:
+        prefix: /pets
+      headers:
+        version:
+          exact: v2
+      withoutHeaders:
+        debug:
+          prefix: "1"
+    rewrite:
+      uri: /api/pets
+    timeout: 5s
+    retries:
+      attempts: 3
+      retryOn: 5xx
+    route:
+    - destination:
+        host: petstore.default.svc.cluster.local
+        port:
+          number: 8080
+      weight: 90
+    - destination:
+        host: petstore
+      weight: 10
+  - name: old
+    match:
+    - uri:
+        exact: /old
+    redirect:
+      uri: /pets
+      redirectCode: 302
+  - name: reviews
+    delegate:
+      name: reviews
+  - name: mirrored
+    mirror:
+      host: petstore
+    route:
+    - destination:
+        host: petstore
+  tcp:
+  - route:
+    - destination:
+        host: petstore
+`), migrate.Options{WriteNamespace: "gloo-system"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issueMessages(result)).To(Equal([]string{
+				"Istio VirtualService default.petstore: tcp routes are not converted",
+				"Istio VirtualService default.petstore: mirror of http route mirrored is not converted",
+			}))
+
+			Expect(result.Upstreams).To(HaveLen(1))
+			usRef := result.Upstreams[0].GetMetadata().Ref()
+
+			Expect(result.VirtualServices).To(HaveLen(1))
+			vs := result.VirtualServices[0]
+			Expect(vs.GetMetadata().Ref()).To(Equal(&core.ResourceRef{Name: "petstore", Namespace: "default"}))
+			Expect(vs.GetVirtualHost().GetDomains()).To(Equal([]string{"petstore.example.com"}))
+			routes := vs.GetVirtualHost().GetRoutes()
+			Expect(routes).To(HaveLen(4))
+
+			Expect(routes[0].GetMatchers()).To(Equal([]*matchers.Matcher{{
+				PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/pets"},
+				Headers: []*matchers.HeaderMatcher{
+					{Name: "version", Value: "v2"},
+					{Name: "debug", Value: "1.*", Regex: true, InvertMatch: true},
+				},
+			}}))
+			Expect(routes[0].GetRouteAction().GetMulti().GetDestinations()).To(Equal([]*gloov1.WeightedDestination{
+				{Destination: &gloov1.Destination{DestinationType: &gloov1.Destination_Upstream{Upstream: usRef}}, Weight: 90},
+				{Destination: &gloov1.Destination{DestinationType: &gloov1.Destination_Upstream{Upstream: usRef}}, Weight: 10},
+			}))
+			Expect(routes[0].GetOptions().GetPrefixRewrite().GetValue()).To(Equal("/api/pets"))
+			Expect(routes[0].GetOptions().GetTimeout()).To(Equal(ptypes.DurationProto(5 * time.Second)))
+			Expect(routes[0].GetOptions().GetRetries().GetNumRetries()).To(BeEquivalentTo(3))
+			Expect(routes[0].GetOptions().GetRetries().GetRetryOn()).To(Equal("5xx"))
+
+			Expect(routes[1].GetRedirectAction()).To(Equal(&gloov1.RedirectAction{
+				PathRewriteSpecifier: &gloov1.RedirectAction_PathRedirect{PathRedirect: "/pets"},
+				ResponseCode:         gloov1.RedirectAction_FOUND,
+			}))
+			Expect(routes[2].GetDelegateAction().GetRef()).To(Equal(&core.ResourceRef{Name: "reviews", Namespace: "default"}))
+			Expect(routes[3].GetRouteAction().GetSingle().GetUpstream()).To(Equal(usRef))
+		})
+
+		It("converts the virtual services without hosts to route tables and skips the ones of the mesh", func() {
+			result, err := migrate.Migrate(ctx, objects(service, `
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+  namespace: default
+spec:
+  http:
+  - match:
+    - uri:
+        prefix: /reviews
+    route:
+    - destination:
+        host: reviews.other.svc
+        port:
+          number: 9080
+        subset: v1
+  - match:
+    - uri:
+        prefix: /
+    route:
+    - destination:
+        host: petstore
+`, `
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: internal
+  namespace: default
+spec:
+  hosts: [petstore]
+  http:
+  - route:
+    - destination:
+        host: petstore
+`), migrate.Options{WriteNamespace: "gloo-system"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issueMessages(result)).To(Equal([]string{
+				"Istio VirtualService default.reviews: http route #0 is not converted: subset v1 of destination reviews.other.svc is not supported",
+				"Istio VirtualService default.internal: only the routes of ingress gateways are converted, not the routes of the mesh",
+			}))
+			Expect(result.VirtualServices).To(BeEmpty())
+			Expect(result.RouteTables).To(HaveLen(1))
+			Expect(result.RouteTables[0].GetMetadata().Ref()).To(Equal(&core.ResourceRef{Name: "reviews", Namespace: "default"}))
+			Expect(result.RouteTables[0].GetRoutes()).To(HaveLen(1))
+		})
+	})
+})
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"strings"
+
+	"github.com/ghodss/yaml"
 )
 
 type CheckPrinters interface {
@@ -12,6 +14,7 @@ type CheckPrinters interface {
 	AppendStatus(name string, status string)
 	AppendMessage(message string)
 	AppendError(err string)
+	AppendCheckReport(report CheckReport)
 	PrintChecks() error
 	NewCheckResult() CheckResult
 }
@@ -20,12 +23,41 @@ type CheckResult struct {
 	Resources []CheckStatus `json:"resources"`
 	Messages  []string      `json:"messages"`
 	Errors    []string      `json:"errors"`
+	Checks    []CheckReport `json:"checks"`
 }
 type CheckStatus struct {
 	Name   string `json:"name"`
 	Status string `json:"status"`
 }
 
+type CheckSeverity string
+
+const (
+	CheckSeverityError   CheckSeverity = "error"
+	CheckSeverityWarning CheckSeverity = "warning"
+)
+
+// The outcome of a single check, identified by the name it is included or excluded with.
+// The status is "ok" if the check found no issues, or the highest severity of its issues otherwise.
+type CheckReport struct {
+	Id     string       `json:"id"`
+	Status string       `json:"status"`
+	Issues []CheckIssue `json:"issues,omitempty"`
+}
+
+type CheckIssue struct {
+	Severity CheckSeverity     `json:"severity"`
+	Message  string            `json:"message"`
+	Resource *CheckResourceRef `json:"resource,omitempty"`
+}
+
+// The resource an issue was found on
+type CheckResourceRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
 type P struct {
 	OutputType  OutputType
 	CheckResult *CheckResult
@@ -34,7 +66,7 @@ type P struct {
 func (p P) AppendCheck(name string) {
 	if p.OutputType.IsTable() {
 		fmt.Printf(name)
-	} else if p.OutputType.IsJSON() {
+	} else if p.isStructured() {
 		cr := CheckStatus{Name: sanitizeName(name)}
 		p.CheckResult.Resources = append(p.CheckResult.Resources, cr)
 	}
@@ -44,7 +76,7 @@ func (p P) AppendStatus(name string, status string) {
 
 	if p.OutputType.IsTable() {
 		fmt.Printf(status + "\n")
-	} else if p.OutputType.IsJSON() {
+	} else if p.isStructured() {
 		for i := range p.CheckResult.Resources {
 			if p.CheckResult.Resources[i].Name == name {
 				p.CheckResult.Resources[i].Status = (status)
@@ -57,7 +89,7 @@ func (p P) AppendStatus(name string, status string) {
 func (p P) AppendMessage(message string) {
 	if p.OutputType.IsTable() {
 		fmt.Printf(message + "\n")
-	} else if p.OutputType.IsJSON() {
+	} else if p.isStructured() {
 		p.CheckResult.Messages = append(p.CheckResult.Messages, strings.ReplaceAll(message, "\n", ""))
 	}
 }
@@ -66,29 +98,48 @@ func (p P) AppendError(err string) {
 	if p.OutputType.IsTable() {
 		// errors are returned by the root cmd, no need to print them here
 		// fmt.Printf(err)
-	} else if p.OutputType.IsJSON() {
+	} else if p.isStructured() {
 		p.CheckResult.Errors = append(p.CheckResult.Errors, err)
 	}
 }
 
-func (p P) PrintChecks(w io.Writer) {
+func (p P) AppendCheckReport(report CheckReport) {
+	// the table output already shows the status and errors of each check
+	if p.isStructured() {
+		p.CheckResult.Checks = append(p.CheckResult.Checks, report)
+	}
+}
 
-	err := json.NewEncoder(w).Encode(p.CheckResult)
+func (p P) PrintChecks(w io.Writer) error {
+	var (
+		out []byte
+		err error
+	)
+	if p.OutputType.IsYAML() {
+		out, err = yaml.Marshal(p.CheckResult)
+	} else {
+		out, err = json.Marshal(p.CheckResult)
+	}
 	if err != nil {
-		fmt.Print(err)
+		return err
 	}
-	fmt.Print(w)
+	_, err = fmt.Fprintln(w, string(out))
+	return err
 }
 
 func (p P) NewCheckResult() *CheckResult {
 
-	if p.OutputType.IsJSON() {
+	if p.isStructured() {
 		return new(CheckResult)
 	}
 
 	return nil
 }
 
+func (p P) isStructured() bool {
+	return p.OutputType.IsJSON() || p.OutputType.IsYAML()
+}
+
 //We must sanitze the name for json formatting because the name comes in as "Checking deployments..."
 //and we just require the type "deployments"
 func sanitizeName(name string) string {
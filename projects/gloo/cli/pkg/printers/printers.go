@@ -122,3 +122,7 @@ func (o *OutputType) IsTable() bool {
 func (o *OutputType) IsJSON() bool {
 	return _OutputValueToIsJSON[*o]
 }
+
+func (o *OutputType) IsYAML() bool {
+	return *o == YAML
+}
@@ -43,6 +43,8 @@ func SecretTable(list v1.SecretList, w io.Writer) {
 			secretType = "AWS"
 		case *v1.Secret_Azure:
 			secretType = "Azure"
+		case *v1.Secret_Gcp:
+			secretType = "GCP"
 		case *v1.Secret_Tls:
 			secretType = "TLS"
 		case *v1.Secret_Oauth:
@@ -77,6 +77,8 @@ func upstreamType(up *v1.Upstream) string {
 		return "Consul"
 	case *v1.Upstream_AwsEc2:
 		return "AWS EC2"
+	case *v1.Upstream_Gcp:
+		return "GCP"
 	case *v1.Upstream_Kube:
 		return "Kubernetes"
 	case *v1.Upstream_Static:
@@ -142,6 +144,11 @@ func upstreamDetails(up *v1.Upstream, xdsDump *xdsinspection.XdsDump) []string {
 			}
 			add(fmt.Sprintf("- %v", functions[i]))
 		}
+	case *v1.Upstream_Gcp:
+		add(
+			fmt.Sprintf("url: %v", usType.Gcp.GetUrl()),
+			fmt.Sprintf("secret: %s", stringifyKey(usType.Gcp.GetSecretRef())),
+		)
 	case *v1.Upstream_Consul:
 		add(
 			fmt.Sprintf("svc name: %v", usType.Consul.GetServiceName()),
@@ -307,6 +307,8 @@ func sslConfig(v *v1.VirtualService) string {
 		return "ssl_files"
 	case *gloov1.SslConfig_Sds:
 		return "sds"
+	case *gloov1.SslConfig_Acme:
+		return "acme"
 	default:
 		return "unknown"
 	}
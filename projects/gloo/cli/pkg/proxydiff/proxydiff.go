@@ -0,0 +1,364 @@
+package proxydiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/proto"
+	"github.com/rotisserie/eris"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/solo-kit/pkg/utils/protoutils"
+)
+
+type ChangeType string
+
+const (
+	Added     ChangeType = "added"
+	Removed   ChangeType = "removed"
+	Modified  ChangeType = "modified"
+	Reordered ChangeType = "reordered"
+)
+
+// the number of unchanged lines printed around the changed lines of a modified element
+const contextLines = 2
+
+// Change is a difference between two versions of a Proxy.
+type Change struct {
+	Type ChangeType
+	// The element which changed: the proxy, followed by the listener, virtual host and route, as deep as the change
+	Path []string
+	// The lines of the diff of the YAML of a modified element, without the elements it contains as they are diffed
+	// separately, or of the order of the elements of a reordered element. Lines are prefixed with "+ " when added,
+	// "- " when removed and "  " when unchanged.
+	Diff []string
+}
+
+// Diff returns the semantic differences between the current version of a Proxy and the proposed one, nil if the
+// proxy is new. Listeners, virtual hosts and TCP hosts are matched by name, the listeners of hybrid listeners by
+// their matcher and routes by name or, for unnamed routes, by their matchers. The statuses and metadata of the proxies
+// are ignored.
+func Diff(current, proposed *v1.Proxy) ([]Change, error) {
+	if proposed == nil {
+		return nil, eris.New("the proposed proxy must not be nil")
+	}
+	proposedElem, err := proxyElement(proposed)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return []Change{{Type: Added, Path: []string{proposedElem.label}}}, nil
+	}
+	currentElem, err := proxyElement(current)
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+	diffElements(nil, currentElem, proposedElem, &changes)
+	return changes, nil
+}
+
+// Print writes the changes, one per line, followed by their diff indented.
+func Print(w io.Writer, changes []Change) {
+	for _, change := range changes {
+		var symbol string
+		switch change.Type {
+		case Added:
+			symbol = "+"
+		case Removed:
+			symbol = "-"
+		default:
+			symbol = "~"
+		}
+		fmt.Fprintf(w, "%s %s (%s)\n", symbol, strings.Join(change.Path, " > "), change.Type)
+		for _, line := range change.Diff {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+}
+
+// an element of a proxy, with the YAML of its fields but the elements it contains
+type element struct {
+	label    string
+	yaml     []string
+	children []*element
+}
+
+func diffElements(parentPath []string, current, proposed *element, changes *[]Change) {
+	path := append(append([]string{}, parentPath...), proposed.label)
+	if lines := diffLines(current.yaml, proposed.yaml); lines != nil {
+		*changes = append(*changes, Change{Type: Modified, Path: path, Diff: lines})
+	}
+
+	currentChildren := map[string]*element{}
+	for _, child := range current.children {
+		currentChildren[child.label] = child
+	}
+	proposedChildren := map[string]*element{}
+	for _, child := range proposed.children {
+		proposedChildren[child.label] = child
+	}
+
+	// the order of the elements which were neither added nor removed, as it matters for routes
+	var currentOrder, proposedOrder []string
+	for _, child := range current.children {
+		if _, ok := proposedChildren[child.label]; !ok {
+			*changes = append(*changes, Change{Type: Removed, Path: append(append([]string{}, path...), child.label)})
+			continue
+		}
+		currentOrder = append(currentOrder, child.label)
+	}
+	for _, child := range proposed.children {
+		if _, ok := currentChildren[child.label]; ok {
+			proposedOrder = append(proposedOrder, child.label)
+		}
+	}
+	if lines := diffLines(currentOrder, proposedOrder); lines != nil {
+		*changes = append(*changes, Change{Type: Reordered, Path: path, Diff: lines})
+	}
+
+	for _, child := range proposed.children {
+		currentChild, ok := currentChildren[child.label]
+		if !ok {
+			*changes = append(*changes, Change{Type: Added, Path: append(append([]string{}, path...), child.label)})
+			continue
+		}
+		diffElements(path, currentChild, child, changes)
+	}
+}
+
+func proxyElement(proxy *v1.Proxy) (*element, error) {
+	elem := &element{label: "proxy " + proxy.GetMetadata().Ref().Key()}
+	for _, listener := range proxy.GetListeners() {
+		child, err := listenerElement(listener)
+		if err != nil {
+			return nil, eris.Wrapf(err, "proxy %v", proxy.GetMetadata().Ref().Key())
+		}
+		elem.children = append(elem.children, child)
+	}
+	uniqueLabels(elem.children)
+	return elem, nil
+}
+
+func listenerElement(listener *v1.Listener) (*element, error) {
+	shell := listener.Clone().(*v1.Listener)
+	var children []*element
+	var err error
+	switch listenerType := shell.GetListenerType().(type) {
+	case *v1.Listener_HttpListener:
+		children, err = virtualHostElements(listenerType.HttpListener.GetVirtualHosts())
+		listenerType.HttpListener.VirtualHosts = nil
+	case *v1.Listener_TcpListener:
+		children, err = tcpHostElements(listenerType.TcpListener.GetTcpHosts())
+		listenerType.TcpListener.TcpHosts = nil
+	case *v1.Listener_HybridListener:
+		for _, matched := range listenerType.HybridListener.GetMatchedListeners() {
+			child, err := matchedListenerElement(matched)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+		listenerType.HybridListener.MatchedListeners = nil
+	}
+	if err != nil {
+		return nil, eris.Wrapf(err, "listener %v", listener.GetName())
+	}
+	return newElement("listener "+listener.GetName(), shell, children)
+}
+
+func matchedListenerElement(matched *v1.MatchedListener) (*element, error) {
+	shell := matched.Clone().(*v1.MatchedListener)
+	var children []*element
+	var err error
+	switch listenerType := shell.GetListenerType().(type) {
+	case *v1.MatchedListener_HttpListener:
+		children, err = virtualHostElements(listenerType.HttpListener.GetVirtualHosts())
+		listenerType.HttpListener.VirtualHosts = nil
+	case *v1.MatchedListener_TcpListener:
+		children, err = tcpHostElements(listenerType.TcpListener.GetTcpHosts())
+		listenerType.TcpListener.TcpHosts = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newElement("matched listener "+matcherLabel(matched.GetMatcher()), shell, children)
+}
+
+// the matchers of hybrid listeners have no name, they are told apart by the connections they match
+func matcherLabel(matcher *v1.Matcher) string {
+	var parts []string
+	if sslConfig := matcher.GetSslConfig(); sslConfig != nil {
+		if sniDomains := sslConfig.GetSniDomains(); len(sniDomains) > 0 {
+			parts = append(parts, "sni "+strings.Join(sniDomains, ","))
+		} else {
+			parts = append(parts, "tls")
+		}
+	}
+	for _, cidr := range matcher.GetSourcePrefixRanges() {
+		parts = append(parts, fmt.Sprintf("source %v/%v", cidr.GetAddressPrefix(), cidr.GetPrefixLen().GetValue()))
+	}
+	if len(parts) == 0 {
+		return "default"
+	}
+	return strings.Join(parts, " ")
+}
+
+func virtualHostElements(virtualHosts []*v1.VirtualHost) ([]*element, error) {
+	var elems []*element
+	for _, virtualHost := range virtualHosts {
+		shell := virtualHost.Clone().(*v1.VirtualHost)
+		shell.Routes = nil
+		var routes []*element
+		for _, route := range virtualHost.GetRoutes() {
+			label, err := routeLabel(route)
+			if err != nil {
+				return nil, err
+			}
+			routeElem, err := newElement(label, route, nil)
+			if err != nil {
+				return nil, err
+			}
+			routes = append(routes, routeElem)
+		}
+		uniqueLabels(routes)
+		elem, err := newElement("virtual host "+virtualHost.GetName(), shell, routes)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+	}
+	uniqueLabels(elems)
+	return elems, nil
+}
+
+func tcpHostElements(tcpHosts []*v1.TcpHost) ([]*element, error) {
+	var elems []*element
+	for _, tcpHost := range tcpHosts {
+		elem, err := newElement("tcp host "+tcpHost.GetName(), tcpHost, nil)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+	}
+	uniqueLabels(elems)
+	return elems, nil
+}
+
+// routes are named after their source when they come from a virtual service or route table; the others are
+// identified by their matchers
+func routeLabel(route *v1.Route) (string, error) {
+	if route.GetName() != "" {
+		return "route " + route.GetName(), nil
+	}
+	var matchers []map[string]interface{}
+	for _, matcher := range route.GetMatchers() {
+		matcherMap, err := protoutils.MarshalMapFromProto(matcher)
+		if err != nil {
+			return "", err
+		}
+		matchers = append(matchers, matcherMap)
+	}
+	jsn, err := json.Marshal(matchers)
+	if err != nil {
+		return "", err
+	}
+	return "route " + string(jsn), nil
+}
+
+// suffixes the labels shared by several elements with their rank, so that elements can be matched by label
+func uniqueLabels(elems []*element) {
+	counts := map[string]int{}
+	for _, elem := range elems {
+		counts[elem.label]++
+		if count := counts[elem.label]; count > 1 {
+			elem.label = fmt.Sprintf("%v #%v", elem.label, count)
+		}
+	}
+}
+
+func newElement(label string, shell proto.Message, children []*element) (*element, error) {
+	shellMap, err := protoutils.MarshalMapFromProto(shell)
+	if err != nil {
+		return nil, eris.Wrapf(err, "marshalling %v", label)
+	}
+	out, err := yaml.Marshal(shellMap)
+	if err != nil {
+		return nil, eris.Wrapf(err, "marshalling %v", label)
+	}
+	return &element{
+		label:    label,
+		yaml:     strings.Split(strings.TrimSuffix(string(out), "\n"), "\n"),
+		children: children,
+	}, nil
+}
+
+// Returns the lines of the diff of a and b, with the unchanged lines far from the changes elided, or nil if a and b
+// are equal.
+func diffLines(a, b []string) []string {
+	// lengths of the longest common subsequences of the suffixes of a and b
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []string
+	changed := false
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, "  "+a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "- "+a[i])
+			changed = true
+			i++
+		default:
+			lines = append(lines, "+ "+b[j])
+			changed = true
+			j++
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return elideUnchanged(lines)
+}
+
+func elideUnchanged(lines []string) []string {
+	keep := make([]bool, len(lines))
+	for i, line := range lines {
+		if strings.HasPrefix(line, "  ") {
+			continue
+		}
+		for k := i - contextLines; k <= i+contextLines; k++ {
+			if k >= 0 && k < len(lines) {
+				keep[k] = true
+			}
+		}
+	}
+	var out []string
+	for i, line := range lines {
+		if keep[i] {
+			out = append(out, line)
+		} else if i == 0 || keep[i-1] {
+			out = append(out, "  ...")
+		}
+	}
+	return out
+}
@@ -0,0 +1,206 @@
+package proxydiff_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/proxydiff"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/core/matchers"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+)
+
+var _ = Describe("Diff", func() {
+
+	prefix := func(prefix string) *matchers.Matcher {
+		return &matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: prefix}}
+	}
+
+	route := func(name, upstream string, routeMatchers ...*matchers.Matcher) *v1.Route {
+		return &v1.Route{
+			Name:     name,
+			Matchers: routeMatchers,
+			Action: &v1.Route_RouteAction{
+				RouteAction: &v1.RouteAction{
+					Destination: &v1.RouteAction_Single{
+						Single: &v1.Destination{
+							DestinationType: &v1.Destination_Upstream{
+								Upstream: &core.ResourceRef{Name: upstream, Namespace: "gloo-system"},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	virtualHost := func(name string, domains []string, routes ...*v1.Route) *v1.VirtualHost {
+		return &v1.VirtualHost{Name: name, Domains: domains, Routes: routes}
+	}
+
+	httpListener := func(name string, port uint32, virtualHosts ...*v1.VirtualHost) *v1.Listener {
+		return &v1.Listener{
+			Name:     name,
+			BindPort: port,
+			ListenerType: &v1.Listener_HttpListener{
+				HttpListener: &v1.HttpListener{VirtualHosts: virtualHosts},
+			},
+		}
+	}
+
+	proxy := func(listeners ...*v1.Listener) *v1.Proxy {
+		return &v1.Proxy{
+			Metadata:  &core.Metadata{Name: "gateway-proxy", Namespace: "gloo-system"},
+			Listeners: listeners,
+		}
+	}
+
+	const proxyLabel = "proxy gloo-system.gateway-proxy"
+
+	It("reports no changes for equal proxies, ignoring their metadata", func() {
+		current := proxy(httpListener("http", 8080, virtualHost("vs", []string{"*"}, route("a", "us"))))
+		proposed := proxy(httpListener("http", 8080, virtualHost("vs", []string{"*"}, route("a", "us"))))
+		proposed.Metadata.ResourceVersion = "2"
+
+		changes, err := proxydiff.Diff(current, proposed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("reports a new proxy", func() {
+		changes, err := proxydiff.Diff(nil, proxy())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(Equal([]proxydiff.Change{{Type: proxydiff.Added, Path: []string{proxyLabel}}}))
+	})
+
+	It("reports added and removed listeners, virtual hosts and routes", func() {
+		current := proxy(
+			httpListener("http", 8080,
+				virtualHost("vs1", []string{"a.com"}, route("a", "us"), route("b", "us")),
+				virtualHost("vs2", []string{"b.com"}, route("c", "us")),
+			),
+			httpListener("https", 8443),
+		)
+		proposed := proxy(
+			httpListener("http", 8080,
+				virtualHost("vs1", []string{"a.com"}, route("a", "us"), route("d", "us")),
+				virtualHost("vs3", []string{"c.com"}),
+			),
+			httpListener("other", 9090),
+		)
+
+		changes, err := proxydiff.Diff(current, proposed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(ConsistOf(
+			proxydiff.Change{Type: proxydiff.Removed, Path: []string{proxyLabel, "listener https"}},
+			proxydiff.Change{Type: proxydiff.Added, Path: []string{proxyLabel, "listener other"}},
+			proxydiff.Change{Type: proxydiff.Removed, Path: []string{proxyLabel, "listener http", "virtual host vs2"}},
+			proxydiff.Change{Type: proxydiff.Added, Path: []string{proxyLabel, "listener http", "virtual host vs3"}},
+			proxydiff.Change{Type: proxydiff.Removed, Path: []string{proxyLabel, "listener http", "virtual host vs1", "route b"}},
+			proxydiff.Change{Type: proxydiff.Added, Path: []string{proxyLabel, "listener http", "virtual host vs1", "route d"}},
+		))
+	})
+
+	It("diffs the fields of modified elements without the elements they contain", func() {
+		current := proxy(httpListener("http", 8080, virtualHost("vs", []string{"a.com"}, route("a", "us1", prefix("/a")))))
+		proposed := proxy(httpListener("http", 8080, virtualHost("vs", []string{"a.com", "b.com"}, route("a", "us2", prefix("/a")))))
+
+		changes, err := proxydiff.Diff(current, proposed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(HaveLen(2))
+
+		Expect(changes[0].Type).To(Equal(proxydiff.Modified))
+		Expect(changes[0].Path).To(Equal([]string{proxyLabel, "listener http", "virtual host vs"}))
+		Expect(changes[0].Diff).To(Equal([]string{
+			"  domains:",
+			"  - a.com",
+			"+ - b.com",
+			"  name: vs",
+		}))
+
+		Expect(changes[1].Type).To(Equal(proxydiff.Modified))
+		Expect(changes[1].Path).To(Equal([]string{proxyLabel, "listener http", "virtual host vs", "route a"}))
+		Expect(changes[1].Diff).To(Equal([]string{
+			"  ...",
+			"    single:",
+			"      upstream:",
+			"-       name: us1",
+			"+       name: us2",
+			"        namespace: gloo-system",
+		}))
+	})
+
+	It("reports reordered routes", func() {
+		current := proxy(httpListener("http", 8080, virtualHost("vs", []string{"*"}, route("a", "us"), route("b", "us"), route("c", "us"))))
+		proposed := proxy(httpListener("http", 8080, virtualHost("vs", []string{"*"}, route("c", "us"), route("a", "us"), route("b", "us"))))
+
+		changes, err := proxydiff.Diff(current, proposed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(Equal([]proxydiff.Change{{
+			Type: proxydiff.Reordered,
+			Path: []string{proxyLabel, "listener http", "virtual host vs"},
+			Diff: []string{"+ route c", "  route a", "  route b", "- route c"},
+		}}))
+	})
+
+	It("matches unnamed routes by their matchers", func() {
+		current := proxy(httpListener("http", 8080, virtualHost("vs", []string{"*"}, route("", "us", prefix("/a")), route("", "us", prefix("/b")))))
+		proposed := proxy(httpListener("http", 8080, virtualHost("vs", []string{"*"}, route("", "us", prefix("/b")))))
+
+		changes, err := proxydiff.Diff(current, proposed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(Equal([]proxydiff.Change{{
+			Type: proxydiff.Removed,
+			Path: []string{proxyLabel, "listener http", "virtual host vs", `route [{"prefix":"/a"}]`},
+		}}))
+	})
+
+	It("matches the listeners of hybrid listeners by their matchers", func() {
+		hybridListener := func(matchedListeners ...*v1.MatchedListener) *v1.Listener {
+			return &v1.Listener{
+				Name:     "hybrid",
+				BindPort: 8443,
+				ListenerType: &v1.Listener_HybridListener{
+					HybridListener: &v1.HybridListener{MatchedListeners: matchedListeners},
+				},
+			}
+		}
+		matchedListener := func(sniDomain string, virtualHosts ...*v1.VirtualHost) *v1.MatchedListener {
+			return &v1.MatchedListener{
+				Matcher: &v1.Matcher{SslConfig: &v1.SslConfig{SniDomains: []string{sniDomain}}},
+				ListenerType: &v1.MatchedListener_HttpListener{
+					HttpListener: &v1.HttpListener{VirtualHosts: virtualHosts},
+				},
+			}
+		}
+		current := proxy(hybridListener(
+			matchedListener("a.com", virtualHost("vs", []string{"a.com"})),
+			matchedListener("b.com", virtualHost("vs", []string{"b.com"})),
+		))
+		proposed := proxy(hybridListener(
+			matchedListener("a.com", virtualHost("vs", []string{"a.com"}, route("a", "us"))),
+		))
+
+		changes, err := proxydiff.Diff(current, proposed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(ConsistOf(
+			proxydiff.Change{Type: proxydiff.Removed, Path: []string{proxyLabel, "listener hybrid", "matched listener sni b.com"}},
+			proxydiff.Change{Type: proxydiff.Added, Path: []string{proxyLabel, "listener hybrid", "matched listener sni a.com", "virtual host vs", "route a"}},
+		))
+	})
+
+	It("prints the changes", func() {
+		changes := []proxydiff.Change{
+			{Type: proxydiff.Added, Path: []string{proxyLabel, "listener http"}},
+			{Type: proxydiff.Modified, Path: []string{proxyLabel, "listener https"}, Diff: []string{"- bindPort: 8443", "+ bindPort: 9443"}},
+		}
+		var out bytes.Buffer
+		proxydiff.Print(&out, changes)
+		Expect(out.String()).To(Equal(`+ proxy gloo-system.gateway-proxy > listener http (added)
+~ proxy gloo-system.gateway-proxy > listener https (modified)
+    - bindPort: 8443
+    + bindPort: 9443
+`))
+	})
+})
@@ -0,0 +1,323 @@
+package resourcefiles
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	gatewayv1 "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	gatewaydefaults "github.com/solo-io/gloo/projects/gateway/pkg/defaults"
+	"github.com/solo-io/gloo/projects/gateway/pkg/translator"
+	"github.com/solo-io/gloo/projects/gateway/pkg/utils"
+	kubeconverters "github.com/solo-io/gloo/projects/gloo/pkg/api/converters/kube"
+	gloov1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	v1snap "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/gloosnapshot"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/kubesecret"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources"
+	"github.com/solo-io/solo-kit/pkg/api/v2/reporter"
+	"github.com/solo-io/solo-kit/pkg/utils/protoutils"
+	kubev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Resources are the Gloo and Gateway resources read from files.
+type Resources struct {
+	Gateways              gatewayv1.GatewayList
+	MatchableHttpGateways gatewayv1.MatchableHttpGatewayList
+	VirtualServices       gatewayv1.VirtualServiceList
+	RouteTables           gatewayv1.RouteTableList
+	VirtualHostOptions    gatewayv1.VirtualHostOptionList
+	RouteOptions          gatewayv1.RouteOptionList
+	Proxies               gloov1.ProxyList
+	Upstreams             gloov1.UpstreamList
+	UpstreamGroups        gloov1.UpstreamGroupList
+	Secrets               gloov1.SecretList
+	Settings              gloov1.SettingsList
+}
+
+var kubeSecretGVK = kubev1.SchemeGroupVersion.WithKind("Secret")
+
+// Reads the resources defined in the given files, which contain Kubernetes-style YAML or JSON documents.
+// The files of directories are read, but not the ones of their subdirectories. Documents of kinds other than
+// the ones of Resources are ignored, and resources without a namespace are placed in the default namespace.
+// Kubernetes Secrets are converted to Gloo Secrets the way Gloo does when reading them from a cluster.
+func ReadFiles(defaultNamespace string, paths ...string) (*Resources, error) {
+	objects, err := ReadObjects(defaultNamespace, paths...)
+	if err != nil {
+		return nil, err
+	}
+	res := &Resources{}
+	for _, obj := range objects {
+		if err := res.add(obj); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Reads the objects defined in the given files, which contain Kubernetes-style YAML or JSON documents, as
+// ReadFiles does, but without parsing them. The items of lists are returned instead of the lists, and objects without
+// a namespace are placed in the default namespace.
+func ReadObjects(defaultNamespace string, paths ...string) ([]unstructured.Unstructured, error) {
+	files, err := expandPaths(paths)
+	if err != nil {
+		return nil, err
+	}
+	var objects []unstructured.Unstructured
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, eris.Wrapf(err, "opening %v", file)
+		}
+		fileObjects, err := DecodeObjects(f, defaultNamespace)
+		_ = f.Close()
+		if err != nil {
+			return nil, eris.Wrapf(err, "reading %v", file)
+		}
+		objects = append(objects, fileObjects...)
+	}
+	return objects, nil
+}
+
+// Reads the resources defined in the Kubernetes-style YAML or JSON documents of the reader.
+func Read(r io.Reader, defaultNamespace string) (*Resources, error) {
+	objects, err := DecodeObjects(r, defaultNamespace)
+	if err != nil {
+		return nil, err
+	}
+	res := &Resources{}
+	for _, obj := range objects {
+		if err := res.add(obj); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func expandPaths(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			switch strings.ToLower(filepath.Ext(entry.Name())) {
+			case ".yaml", ".yml", ".json":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+	}
+	return files, nil
+}
+
+// Reads the objects defined in the Kubernetes-style YAML or JSON documents of the reader, as ReadObjects does.
+func DecodeObjects(reader io.Reader, defaultNamespace string) ([]unstructured.Unstructured, error) {
+	var objects []unstructured.Unstructured
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		var obj unstructured.Unstructured
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		// empty documents
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, list.Items...)
+			continue
+		}
+		objects = append(objects, obj)
+	}
+	for i := range objects {
+		if objects[i].GetNamespace() == "" {
+			objects[i].SetNamespace(defaultNamespace)
+		}
+	}
+	return objects, nil
+}
+
+func (r *Resources) add(obj unstructured.Unstructured) error {
+	gvk := obj.GroupVersionKind()
+
+	var resource resources.Resource
+	switch gvk {
+	case gatewayv1.GatewayGVK:
+		gw := &gatewayv1.Gateway{}
+		r.Gateways = append(r.Gateways, gw)
+		resource = gw
+	case gatewayv1.MatchableHttpGatewayGVK:
+		hgw := &gatewayv1.MatchableHttpGateway{}
+		r.MatchableHttpGateways = append(r.MatchableHttpGateways, hgw)
+		resource = hgw
+	case gatewayv1.VirtualServiceGVK:
+		vs := &gatewayv1.VirtualService{}
+		r.VirtualServices = append(r.VirtualServices, vs)
+		resource = vs
+	case gatewayv1.RouteTableGVK:
+		rt := &gatewayv1.RouteTable{}
+		r.RouteTables = append(r.RouteTables, rt)
+		resource = rt
+	case gatewayv1.VirtualHostOptionGVK:
+		vho := &gatewayv1.VirtualHostOption{}
+		r.VirtualHostOptions = append(r.VirtualHostOptions, vho)
+		resource = vho
+	case gatewayv1.RouteOptionGVK:
+		rto := &gatewayv1.RouteOption{}
+		r.RouteOptions = append(r.RouteOptions, rto)
+		resource = rto
+	case gloov1.ProxyGVK:
+		proxy := &gloov1.Proxy{}
+		r.Proxies = append(r.Proxies, proxy)
+		resource = proxy
+	case gloov1.UpstreamGVK:
+		us := &gloov1.Upstream{}
+		r.Upstreams = append(r.Upstreams, us)
+		resource = us
+	case gloov1.UpstreamGroupGVK:
+		ug := &gloov1.UpstreamGroup{}
+		r.UpstreamGroups = append(r.UpstreamGroups, ug)
+		resource = ug
+	case gloov1.SecretGVK:
+		secret := &gloov1.Secret{}
+		r.Secrets = append(r.Secrets, secret)
+		resource = secret
+	case gloov1.SettingsGVK:
+		settings := &gloov1.Settings{}
+		r.Settings = append(r.Settings, settings)
+		resource = settings
+	case kubeSecretGVK:
+		return r.addKubeSecret(obj)
+	default:
+		return nil
+	}
+
+	jsn, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	if err := protoutils.UnmarshalResource(jsn, resource); err != nil {
+		return eris.Wrapf(err, "parsing %v %v.%v", gvk.Kind, obj.GetNamespace(), obj.GetName())
+	}
+	return nil
+}
+
+func (r *Resources) addKubeSecret(obj unstructured.Unstructured) error {
+	var kubeSecret kubev1.Secret
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &kubeSecret); err != nil {
+		return eris.Wrapf(err, "parsing Secret %v.%v", obj.GetNamespace(), obj.GetName())
+	}
+	// the api server merges stringData into data when the secret is written
+	for key, value := range kubeSecret.StringData {
+		if kubeSecret.Data == nil {
+			kubeSecret.Data = map[string][]byte{}
+		}
+		kubeSecret.Data[key] = []byte(value)
+	}
+	if kubeSecret.Type == "" {
+		kubeSecret.Type = kubev1.SecretTypeOpaque
+	}
+
+	rc, err := kubesecret.NewResourceClientWithSecretConverter(nil, &gloov1.Secret{}, nil, kubeconverters.GlooSecretConverterChain)
+	if err != nil {
+		return err
+	}
+	resource, err := kubeconverters.GlooSecretConverterChain.FromKubeSecret(context.Background(), rc, &kubeSecret)
+	if err != nil {
+		return eris.Wrapf(err, "converting Secret %v.%v", obj.GetNamespace(), obj.GetName())
+	}
+	if resource == nil {
+		// other secrets can only be used by gloo if they were written by a gloo secret client
+		resource, _ = rc.FromKubeSecret(&kubeSecret)
+	}
+	if secret, ok := resource.(*gloov1.Secret); ok {
+		r.Secrets = append(r.Secrets, secret)
+	}
+	return nil
+}
+
+// Returns a snapshot of the gateway resources.
+func (r *Resources) GatewaySnapshot() *gatewayv1.ApiSnapshot {
+	return &gatewayv1.ApiSnapshot{
+		VirtualServices:    r.VirtualServices,
+		RouteTables:        r.RouteTables,
+		Gateways:           r.Gateways,
+		VirtualHostOptions: r.VirtualHostOptions,
+		RouteOptions:       r.RouteOptions,
+		HttpGateways:       r.MatchableHttpGateways,
+	}
+}
+
+// Runs the gateway translator on the gateway resources, returning the Proxies in the write namespace, sorted by name,
+// along with the reports of the gateway resources. The default Gateways are used if there is none in the resources.
+func (r *Resources) TranslateProxies(ctx context.Context, writeNamespace string) (gloov1.ProxyList, reporter.ResourceReports) {
+	snap := r.GatewaySnapshot()
+	if len(snap.Gateways) == 0 {
+		snap.Gateways = gatewayv1.GatewayList{
+			gatewaydefaults.DefaultGateway(writeNamespace),
+			gatewaydefaults.DefaultSslGateway(writeNamespace),
+		}
+	}
+
+	gatewayTranslator := translator.NewDefaultTranslator(translator.Opts{
+		WriteNamespace:                writeNamespace,
+		ReadGatewaysFromAllNamespaces: true,
+	})
+
+	gatewaysByProxy := utils.GatewaysByProxyName(snap.Gateways)
+	proxyNames := make([]string, 0, len(gatewaysByProxy))
+	for proxyName := range gatewaysByProxy {
+		proxyNames = append(proxyNames, proxyName)
+	}
+	sort.Strings(proxyNames)
+
+	var proxies gloov1.ProxyList
+	allReports := make(reporter.ResourceReports)
+	for _, proxyName := range proxyNames {
+		proxy, reports := gatewayTranslator.Translate(ctx, proxyName, writeNamespace, snap, gatewaysByProxy[proxyName])
+		if proxy != nil {
+			proxies = append(proxies, proxy)
+		}
+		allReports.Merge(reports)
+	}
+	return proxies, allReports
+}
+
+// Returns a snapshot of the resources, with the given proxies.
+func (r *Resources) GlooSnapshot(proxies gloov1.ProxyList) *v1snap.ApiSnapshot {
+	return &v1snap.ApiSnapshot{
+		Proxies:            proxies,
+		UpstreamGroups:     r.UpstreamGroups,
+		Secrets:            r.Secrets,
+		Upstreams:          r.Upstreams,
+		VirtualServices:    r.VirtualServices,
+		RouteTables:        r.RouteTables,
+		Gateways:           r.Gateways,
+		VirtualHostOptions: r.VirtualHostOptions,
+		RouteOptions:       r.RouteOptions,
+	}
+}
@@ -0,0 +1,174 @@
+package resourcefiles_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/resourcefiles"
+	"github.com/solo-io/solo-kit/pkg/utils/statusutils"
+)
+
+var _ = Describe("ResourceFiles", func() {
+
+	const resourcesYaml = `
+apiVersion: gateway.solo.io/v1
+kind: VirtualService
+metadata:
+  name: default
+spec:
+  virtualHost:
+    domains:
+    - '*'
+    routes:
+    - matchers:
+      - prefix: /api
+      delegateAction:
+        ref:
+          name: api
+          namespace: apps
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: gateway.solo.io/v1
+  kind: RouteTable
+  metadata:
+    name: api
+    namespace: apps
+  spec:
+    routes:
+    - matchers:
+      - prefix: /api/pets
+      routeAction:
+        single:
+          upstream:
+            name: petstore
+            namespace: gloo-system
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: petstore
+`
+
+	BeforeEach(func() {
+		Expect(os.Setenv(statusutils.PodNamespaceEnvName, "gloo-system")).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv(statusutils.PodNamespaceEnvName)).NotTo(HaveOccurred())
+	})
+
+	It("reads the resources of the documents", func() {
+		res, err := resourcefiles.Read(strings.NewReader(resourcesYaml), "gloo-system")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(res.VirtualServices).To(HaveLen(1))
+		Expect(res.VirtualServices[0].GetMetadata().GetNamespace()).To(Equal("gloo-system"))
+		Expect(res.RouteTables).To(HaveLen(1))
+		Expect(res.RouteTables[0].GetMetadata().GetNamespace()).To(Equal("apps"))
+		Expect(res.RouteTables[0].GetRoutes()[0].GetMatchers()[0].GetPrefix()).To(Equal("/api/pets"))
+	})
+
+	It("translates the resources to proxies with the default gateways", func() {
+		res, err := resourcefiles.Read(strings.NewReader(resourcesYaml), "gloo-system")
+		Expect(err).NotTo(HaveOccurred())
+
+		proxies, reports := res.TranslateProxies(context.Background(), "gloo-system")
+		Expect(reports.Validate()).NotTo(HaveOccurred())
+		Expect(proxies).To(HaveLen(1))
+		Expect(proxies[0].GetMetadata().GetName()).To(Equal("gateway-proxy"))
+
+		routes := proxies[0].GetListeners()[0].GetHttpListener().GetVirtualHosts()[0].GetRoutes()
+		Expect(routes).To(HaveLen(1))
+		Expect(routes[0].GetMatchers()[0].GetPrefix()).To(Equal("/api/pets"))
+	})
+
+	It("reads gloo resources and converts kubernetes secrets", func() {
+		res, err := resourcefiles.Read(strings.NewReader(`
+apiVersion: gloo.solo.io/v1
+kind: Upstream
+metadata:
+  name: petstore
+spec:
+  static:
+    hosts:
+    - addr: petstore.example.com
+      port: 8080
+---
+apiVersion: gloo.solo.io/v1
+kind: Settings
+metadata:
+  name: default
+spec:
+  gloo:
+    invalidConfigPolicy:
+      replaceInvalidRoutes: true
+---
+apiVersion: v1
+kind: Secret
+type: kubernetes.io/tls
+metadata:
+  name: petstore-tls
+stringData:
+  tls.crt: cert
+  tls.key: key
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: unrelated
+data:
+  foo: YmFy
+`), "gloo-system")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(res.Upstreams).To(HaveLen(1))
+		Expect(res.Upstreams[0].GetStatic().GetHosts()[0].GetPort()).To(BeEquivalentTo(8080))
+		Expect(res.Settings).To(HaveLen(1))
+		Expect(res.Settings[0].GetGloo().GetInvalidConfigPolicy().GetReplaceInvalidRoutes()).To(BeTrue())
+
+		// opaque secrets with data are converted to header secrets, as gloo does
+		Expect(res.Secrets).To(HaveLen(2))
+		tls := res.Secrets[0]
+		Expect(tls.GetMetadata().GetName()).To(Equal("petstore-tls"))
+		Expect(tls.GetMetadata().GetNamespace()).To(Equal("gloo-system"))
+		Expect(tls.GetTls().GetCertChain()).To(Equal("cert"))
+		Expect(tls.GetTls().GetPrivateKey()).To(Equal("key"))
+		Expect(res.Secrets[1].GetHeader().GetHeaders()).To(HaveKeyWithValue("foo", "bar"))
+	})
+
+	It("reads the objects of the files without parsing them", func() {
+		dir, err := ioutil.TempDir("", "resourcefiles")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		Expect(ioutil.WriteFile(filepath.Join(dir, "resources.yaml"), []byte(resourcesYaml), 0644)).NotTo(HaveOccurred())
+
+		objects, err := resourcefiles.ReadObjects("gloo-system", dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(3))
+		Expect(objects[0].GetKind()).To(Equal("VirtualService"))
+		Expect(objects[0].GetNamespace()).To(Equal("gloo-system"))
+		Expect(objects[1].GetKind()).To(Equal("RouteTable"))
+		Expect(objects[1].GetNamespace()).To(Equal("apps"))
+		Expect(objects[2].GetKind()).To(Equal("Deployment"))
+	})
+
+	It("returns an error for invalid resources", func() {
+		_, err := resourcefiles.Read(strings.NewReader(`
+apiVersion: gateway.solo.io/v1
+kind: VirtualService
+metadata:
+  name: default
+spec:
+  virtualHost:
+    unknownField: true
+`), "gloo-system")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,317 @@
+package routematch
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"github.com/solo-io/gloo/projects/gateway/pkg/defaults"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/core/matchers"
+)
+
+// Request describes an HTTP request, to find the route Envoy would select for it.
+type Request struct {
+	Method string
+	// The host (authority) of the request
+	Host string
+	// The path of the request, which may contain a query string
+	Path    string
+	Headers http.Header
+	// Query parameters, in addition to the ones of the query string of the path
+	QueryParams url.Values
+	// If set, only the listeners bound to this port are considered
+	Port uint32
+}
+
+// Match is the result of routing a request on an HTTP listener of a Proxy.
+type Match struct {
+	Listener *v1.Listener
+	// The virtual host selected by the host of the request, nil if none matched
+	VirtualHost *v1.VirtualHost
+	// The first route of the virtual host which matched the request, nil if none matched
+	Route *v1.Route
+	// The index of the route in the virtual host
+	RouteIndex int
+	// The matcher of the route which matched the request
+	Matcher *matchers.Matcher
+}
+
+// Returns the route selected for the request on each HTTP listener of the proxy. Listeners which are not bound to
+// the port of the request are skipped, if the request has a port. Hybrid listeners have one result per HTTP
+// listener, as the filter chain Envoy would select depends on the connection.
+func MatchProxy(proxy *v1.Proxy, req Request) ([]*Match, error) {
+	r, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*Match
+	for _, listener := range proxy.GetListeners() {
+		if req.Port != 0 && listener.GetBindPort() != req.Port {
+			continue
+		}
+		var httpListeners []*v1.HttpListener
+		switch listenerType := listener.GetListenerType().(type) {
+		case *v1.Listener_HttpListener:
+			httpListeners = append(httpListeners, listenerType.HttpListener)
+		case *v1.Listener_HybridListener:
+			for _, matched := range listenerType.HybridListener.GetMatchedListeners() {
+				if httpListener := matched.GetHttpListener(); httpListener != nil {
+					httpListeners = append(httpListeners, httpListener)
+				}
+			}
+		}
+
+		for _, httpListener := range httpListeners {
+			match, err := r.matchHttpListener(httpListener)
+			if err != nil {
+				return nil, eris.Wrapf(err, "listener %v", listener.GetName())
+			}
+			match.Listener = listener
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+type request struct {
+	method  string
+	host    string
+	path    string
+	headers http.Header
+	query   url.Values
+}
+
+func newRequest(req Request) (*request, error) {
+	r := &request{
+		method:  req.Method,
+		host:    strings.ToLower(req.Host),
+		path:    req.Path,
+		headers: http.Header{},
+		query:   url.Values{},
+	}
+	if r.method == "" {
+		r.method = http.MethodGet
+	}
+	if r.path == "" {
+		r.path = "/"
+	}
+	if i := strings.Index(r.path, "?"); i >= 0 {
+		query, err := url.ParseQuery(r.path[i+1:])
+		if err != nil {
+			return nil, eris.Wrapf(err, "parsing query string of %v", r.path)
+		}
+		for name, values := range query {
+			r.query[name] = append(r.query[name], values...)
+		}
+	}
+	for name, values := range req.QueryParams {
+		r.query[name] = append(r.query[name], values...)
+	}
+	for name, values := range req.Headers {
+		for _, value := range values {
+			r.headers.Add(name, value)
+		}
+	}
+	return r, nil
+}
+
+// the path without its query string
+func (r *request) pathWithoutQuery() string {
+	if i := strings.Index(r.path, "?"); i >= 0 {
+		return r.path[:i]
+	}
+	return r.path
+}
+
+// returns the values of a header, including the pseudo-headers envoy matches on
+func (r *request) header(name string) ([]string, bool) {
+	switch strings.ToLower(name) {
+	case ":method":
+		return []string{r.method}, true
+	case ":authority", "host":
+		return []string{r.host}, true
+	case ":path":
+		return []string{r.path}, true
+	}
+	values, ok := r.headers[http.CanonicalHeaderKey(name)]
+	return values, ok
+}
+
+func (r *request) matchHttpListener(httpListener *v1.HttpListener) (*Match, error) {
+	match := &Match{RouteIndex: -1}
+	match.VirtualHost = selectVirtualHost(httpListener.GetVirtualHosts(), r.host)
+	if match.VirtualHost == nil {
+		return match, nil
+	}
+	for i, route := range match.VirtualHost.GetRoutes() {
+		matcher, err := r.matchRoute(route)
+		if err != nil {
+			return nil, eris.Wrapf(err, "route %v of virtual host %v", i, match.VirtualHost.GetName())
+		}
+		if matcher != nil {
+			match.Route = route
+			match.RouteIndex = i
+			match.Matcher = matcher
+			return match, nil
+		}
+	}
+	return match, nil
+}
+
+// Selects the virtual host of a host the way Envoy does: exact domains are preferred to suffix wildcards
+// (`*.example.com`), which are preferred to prefix wildcards (`example.*`), which are preferred to `*`.
+// Among wildcards, the longest one wins.
+func selectVirtualHost(virtualHosts []*v1.VirtualHost, host string) *v1.VirtualHost {
+	var (
+		suffixMatch, prefixMatch, defaultMatch *v1.VirtualHost
+		suffixLen, prefixLen                   int
+	)
+	for _, virtualHost := range virtualHosts {
+		domains := virtualHost.GetDomains()
+		if len(domains) == 0 {
+			domains = []string{"*"}
+		}
+		for _, domain := range domains {
+			domain = strings.ToLower(domain)
+			switch {
+			case domain == "*":
+				if defaultMatch == nil {
+					defaultMatch = virtualHost
+				}
+			case domain == host:
+				return virtualHost
+			case strings.HasPrefix(domain, "*"):
+				suffix := domain[1:]
+				if len(host) > len(suffix) && strings.HasSuffix(host, suffix) && len(suffix) > suffixLen {
+					suffixMatch, suffixLen = virtualHost, len(suffix)
+				}
+			case strings.HasSuffix(domain, "*"):
+				prefix := domain[:len(domain)-1]
+				if len(host) > len(prefix) && strings.HasPrefix(host, prefix) && len(prefix) > prefixLen {
+					prefixMatch, prefixLen = virtualHost, len(prefix)
+				}
+			}
+		}
+	}
+	switch {
+	case suffixMatch != nil:
+		return suffixMatch
+	case prefixMatch != nil:
+		return prefixMatch
+	}
+	return defaultMatch
+}
+
+// returns the first matcher of the route which matches the request, or nil if none does
+func (r *request) matchRoute(route *v1.Route) (*matchers.Matcher, error) {
+	routeMatchers := route.GetMatchers()
+	if len(routeMatchers) == 0 {
+		routeMatchers = []*matchers.Matcher{defaults.DefaultMatcher()}
+	}
+	for _, matcher := range routeMatchers {
+		matches, err := r.matches(matcher)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			return matcher, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *request) matches(matcher *matchers.Matcher) (bool, error) {
+	if matches, err := r.matchesPath(matcher); err != nil || !matches {
+		return false, err
+	}
+	if len(matcher.GetMethods()) > 0 && !contains(matcher.GetMethods(), r.method) {
+		return false, nil
+	}
+	for _, headerMatcher := range matcher.GetHeaders() {
+		values, present := r.header(headerMatcher.GetName())
+		if !present && headerMatcher.GetValue() != "" {
+			// envoy never matches a missing header on its value, even when the match is inverted
+			return false, nil
+		}
+		// envoy matches headers with multiple values on their concatenation
+		matches, err := matchesValue(headerMatcher.GetValue(), headerMatcher.GetRegex(), strings.Join(values, ","), present)
+		if err != nil {
+			return false, eris.Wrapf(err, "header matcher %v", headerMatcher.GetName())
+		}
+		if matches == headerMatcher.GetInvertMatch() {
+			return false, nil
+		}
+	}
+	for _, queryMatcher := range matcher.GetQueryParameters() {
+		values, present := r.query[queryMatcher.GetName()]
+		var value string
+		if present && len(values) > 0 {
+			value = values[0]
+		}
+		matches, err := matchesValue(queryMatcher.GetValue(), queryMatcher.GetRegex(), value, present)
+		if err != nil {
+			return false, eris.Wrapf(err, "query parameter matcher %v", queryMatcher.GetName())
+		}
+		if !matches {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (r *request) matchesPath(matcher *matchers.Matcher) (bool, error) {
+	// case sensitivity does not apply to regexes
+	caseSensitive := matcher.GetCaseSensitive() == nil || matcher.GetCaseSensitive().GetValue()
+	switch path := matcher.GetPathSpecifier().(type) {
+	case *matchers.Matcher_Prefix:
+		// the prefix is matched on the path including its query string
+		if caseSensitive {
+			return strings.HasPrefix(r.path, path.Prefix), nil
+		}
+		return strings.HasPrefix(strings.ToLower(r.path), strings.ToLower(path.Prefix)), nil
+	case *matchers.Matcher_Exact:
+		if caseSensitive {
+			return r.pathWithoutQuery() == path.Exact, nil
+		}
+		return strings.EqualFold(r.pathWithoutQuery(), path.Exact), nil
+	case *matchers.Matcher_Regex:
+		return matchesRegex(path.Regex, r.pathWithoutQuery())
+	}
+	// matchers without a path specifier are rejected by Gloo
+	return false, nil
+}
+
+// Gloo header and query parameter matchers without a value only require the value to be present
+func matchesValue(expected string, regex bool, value string, present bool) (bool, error) {
+	switch {
+	case !present:
+		return false, nil
+	case expected == "":
+		return true, nil
+	case regex:
+		return matchesRegex(expected, value)
+	}
+	return value == expected, nil
+}
+
+// envoy regexes must match the whole value
+func matchesRegex(regex, value string) (bool, error) {
+	re, err := regexp.Compile("^(?:" + regex + ")$")
+	if err != nil {
+		return false, eris.Wrapf(err, "invalid regex %v", regex)
+	}
+	return re.MatchString(value), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
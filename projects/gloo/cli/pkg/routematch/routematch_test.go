@@ -0,0 +1,161 @@
+package routematch_test
+
+import (
+	"net/http"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/projects/gloo/cli/pkg/routematch"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/core/matchers"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+)
+
+var _ = Describe("MatchProxy", func() {
+
+	route := func(name string, routeMatchers ...*matchers.Matcher) *v1.Route {
+		return &v1.Route{
+			Name:     name,
+			Matchers: routeMatchers,
+		}
+	}
+
+	prefix := func(prefix string) *matchers.Matcher {
+		return &matchers.Matcher{PathSpecifier: &matchers.Matcher_Prefix{Prefix: prefix}}
+	}
+
+	httpListener := func(name string, port uint32, virtualHosts ...*v1.VirtualHost) *v1.Listener {
+		return &v1.Listener{
+			Name:     name,
+			BindPort: port,
+			ListenerType: &v1.Listener_HttpListener{
+				HttpListener: &v1.HttpListener{VirtualHosts: virtualHosts},
+			},
+		}
+	}
+
+	proxyWithRoutes := func(routes ...*v1.Route) *v1.Proxy {
+		return &v1.Proxy{
+			Metadata: &core.Metadata{Name: "gateway-proxy", Namespace: "gloo-system"},
+			Listeners: []*v1.Listener{
+				httpListener("http", 8080, &v1.VirtualHost{Name: "vhost", Domains: []string{"*"}, Routes: routes}),
+			},
+		}
+	}
+
+	matchedRoute := func(proxy *v1.Proxy, req routematch.Request) string {
+		matches, err := routematch.MatchProxy(proxy, req)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		ExpectWithOffset(1, matches).To(HaveLen(1))
+		return matches[0].Route.GetName()
+	}
+
+	It("selects the first matching route", func() {
+		proxy := proxyWithRoutes(
+			route("exact", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Exact{Exact: "/foo"}}),
+			route("regex", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "/foo/[0-9]+"}}),
+			route("prefix", prefix("/foo")),
+			route("default"),
+		)
+		Expect(matchedRoute(proxy, routematch.Request{Path: "/foo?bar=baz"})).To(Equal("exact"))
+		Expect(matchedRoute(proxy, routematch.Request{Path: "/foo/123"})).To(Equal("regex"))
+		Expect(matchedRoute(proxy, routematch.Request{Path: "/foo/123abc"})).To(Equal("prefix"))
+		Expect(matchedRoute(proxy, routematch.Request{Path: "/bar"})).To(Equal("default"))
+	})
+
+	It("ignores the case of the path when the matcher is case insensitive", func() {
+		insensitive := prefix("/foo")
+		insensitive.CaseSensitive = &wrappers.BoolValue{Value: false}
+		proxy := proxyWithRoutes(
+			route("sensitive", prefix("/Foo")),
+			route("insensitive", insensitive),
+		)
+		Expect(matchedRoute(proxy, routematch.Request{Path: "/Foo"})).To(Equal("sensitive"))
+		Expect(matchedRoute(proxy, routematch.Request{Path: "/FOO/bar"})).To(Equal("insensitive"))
+	})
+
+	DescribeTable("matches methods, headers and query parameters",
+		func(req routematch.Request, expectedRoute string) {
+			proxy := proxyWithRoutes(
+				route("post", &matchers.Matcher{
+					PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/"},
+					Methods:       []string{"POST", "PUT"},
+				}),
+				route("header", &matchers.Matcher{
+					PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/"},
+					Headers: []*matchers.HeaderMatcher{
+						{Name: "x-version", Value: "v[2-3]", Regex: true},
+						{Name: "x-canary", InvertMatch: true},
+					},
+				}),
+				route("query", &matchers.Matcher{
+					PathSpecifier: &matchers.Matcher_Prefix{Prefix: "/"},
+					QueryParameters: []*matchers.QueryParameterMatcher{
+						{Name: "debug"},
+						{Name: "user", Value: "admin"},
+					},
+				}),
+				route("default"),
+			)
+			Expect(matchedRoute(proxy, req)).To(Equal(expectedRoute))
+		},
+		Entry("method", routematch.Request{Method: "PUT"}, "post"),
+		Entry("header regex", routematch.Request{Headers: http.Header{"X-Version": {"v2"}}}, "header"),
+		Entry("header regex must match the whole value", routematch.Request{Headers: http.Header{"X-Version": {"v23"}}}, "default"),
+		Entry("inverted presence", routematch.Request{Headers: http.Header{"X-Version": {"v3"}, "X-Canary": {"true"}}}, "default"),
+		Entry("query parameters", routematch.Request{Path: "/?debug&user=admin"}, "query"),
+		Entry("query parameters outside of the path", routematch.Request{Path: "/?debug", QueryParams: map[string][]string{"user": {"admin"}}}, "query"),
+		Entry("missing query parameter", routematch.Request{Path: "/?user=admin"}, "default"),
+	)
+
+	It("selects the virtual host the way envoy does", func() {
+		vhost := func(name string, domains ...string) *v1.VirtualHost {
+			return &v1.VirtualHost{Name: name, Domains: domains, Routes: []*v1.Route{route(name)}}
+		}
+		proxy := &v1.Proxy{
+			Listeners: []*v1.Listener{httpListener("http", 8080,
+				vhost("default", "*"),
+				vhost("prefix", "api.*"),
+				vhost("short-suffix", "*.com"),
+				vhost("suffix", "*.example.com"),
+				vhost("exact", "api.example.com"),
+			)},
+		}
+		Expect(matchedRoute(proxy, routematch.Request{Host: "API.example.com"})).To(Equal("exact"))
+		Expect(matchedRoute(proxy, routematch.Request{Host: "www.example.com"})).To(Equal("suffix"))
+		Expect(matchedRoute(proxy, routematch.Request{Host: "api.example.org"})).To(Equal("prefix"))
+		Expect(matchedRoute(proxy, routematch.Request{Host: "www.solo.com"})).To(Equal("short-suffix"))
+		Expect(matchedRoute(proxy, routematch.Request{Host: "localhost"})).To(Equal("default"))
+	})
+
+	It("only considers the listeners bound to the port of the request", func() {
+		proxy := &v1.Proxy{
+			Listeners: []*v1.Listener{
+				httpListener("http", 8080, &v1.VirtualHost{Name: "http", Routes: []*v1.Route{route("http")}}),
+				httpListener("https", 8443, &v1.VirtualHost{Name: "https", Routes: []*v1.Route{route("https")}}),
+			},
+		}
+		matches, err := routematch.MatchProxy(proxy, routematch.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(HaveLen(2))
+
+		Expect(matchedRoute(proxy, routematch.Request{Port: 8443})).To(Equal("https"))
+	})
+
+	It("reports when no route matches", func() {
+		proxy := proxyWithRoutes(route("foo", prefix("/foo")))
+		matches, err := routematch.MatchProxy(proxy, routematch.Request{Path: "/bar"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(HaveLen(1))
+		Expect(matches[0].VirtualHost.GetName()).To(Equal("vhost"))
+		Expect(matches[0].Route).To(BeNil())
+	})
+
+	It("returns an error for invalid regexes", func() {
+		proxy := proxyWithRoutes(route("invalid", &matchers.Matcher{PathSpecifier: &matchers.Matcher_Regex{Regex: "("}}))
+		_, err := routematch.MatchProxy(proxy, routematch.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,62 @@
+package acme
+
+import (
+	"encoding/json"
+
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+)
+
+const (
+	// The annotation of the certificate secret that holds the pending HTTP-01 challenges, so that they are part of the
+	// snapshot the proxy configuration is translated from.
+	ChallengesAnnotation = "acme.gloo.solo.io/http01-challenges"
+
+	// The path prefix the certificate authority requests the HTTP-01 challenge responses from.
+	ChallengePathPrefix = "/.well-known/acme-challenge/"
+)
+
+// A pending HTTP-01 challenge: the proxy must answer requests to ChallengePathPrefix + Token for the Domain with the
+// KeyAuthorization.
+type Challenge struct {
+	Domain           string `json:"domain"`
+	Token            string `json:"token"`
+	KeyAuthorization string `json:"keyAuthorization"`
+}
+
+// Returns the pending challenges stored on the secret. Malformed annotations are ignored.
+func ChallengesFromSecret(secret *v1.Secret) []Challenge {
+	value, ok := secret.GetMetadata().GetAnnotations()[ChallengesAnnotation]
+	if !ok {
+		return nil
+	}
+	var challenges []Challenge
+	if err := json.Unmarshal([]byte(value), &challenges); err != nil {
+		return nil
+	}
+	return challenges
+}
+
+// Returns the pending challenges of all the secrets.
+func ChallengesFromSecrets(secrets v1.SecretList) []Challenge {
+	var challenges []Challenge
+	for _, secret := range secrets {
+		challenges = append(challenges, ChallengesFromSecret(secret)...)
+	}
+	return challenges
+}
+
+func setChallenges(secret *v1.Secret, challenges []Challenge) error {
+	if len(challenges) == 0 {
+		delete(secret.GetMetadata().GetAnnotations(), ChallengesAnnotation)
+		return nil
+	}
+	value, err := json.Marshal(challenges)
+	if err != nil {
+		return err
+	}
+	if secret.GetMetadata().GetAnnotations() == nil {
+		secret.GetMetadata().Annotations = map[string]string{}
+	}
+	secret.GetMetadata().GetAnnotations()[ChallengesAnnotation] = string(value)
+	return nil
+}
@@ -0,0 +1,159 @@
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"time"
+
+	"github.com/rotisserie/eris"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	acmeclient "golang.org/x/crypto/acme"
+)
+
+const (
+	userAgent = "gloo"
+
+	// How long to wait for the proxies to serve the challenge responses before asking the certificate authority to
+	// validate them.
+	challengePropagationDelay = 10 * time.Second
+)
+
+// An Issuer obtains certificates from an ACME certificate authority.
+type Issuer interface {
+	// Obtains a certificate for the domains with the DER encoded CSR, and returns its PEM encoded chain.
+	// present is called with the HTTP-01 challenges the proxies must answer before they are validated.
+	Obtain(ctx context.Context, domains []string, csr []byte, present func([]Challenge) error) (string, error)
+}
+
+// Creates the Issuer of an ACME config, registering the account key with the certificate authority if needed.
+type IssuerFactory func(ctx context.Context, config *v1.AcmeConfig, accountKey crypto.Signer) (Issuer, error)
+
+type acmeIssuer struct {
+	client *acmeclient.Client
+}
+
+var _ IssuerFactory = NewIssuer
+
+// The IssuerFactory of the ACME certificate authorities that implement RFC 8555, such as Let's Encrypt and Pebble.
+func NewIssuer(ctx context.Context, config *v1.AcmeConfig, accountKey crypto.Signer) (Issuer, error) {
+	httpClient, err := newHttpClient(config.GetDirectoryRootCa())
+	if err != nil {
+		return nil, err
+	}
+	directoryUrl := config.GetDirectoryUrl()
+	if directoryUrl == "" {
+		directoryUrl = acmeclient.LetsEncryptURL
+	}
+	client := &acmeclient.Client{
+		Key:          accountKey,
+		HTTPClient:   httpClient,
+		DirectoryURL: directoryUrl,
+		UserAgent:    userAgent,
+	}
+
+	account := &acmeclient.Account{}
+	if email := config.GetEmail(); email != "" {
+		account.Contact = []string{"mailto:" + email}
+	}
+	if _, err := client.Register(ctx, account, acmeclient.AcceptTOS); err != nil && err != acmeclient.ErrAccountAlreadyExists {
+		return nil, eris.Wrapf(err, "registering the ACME account with %s", directoryUrl)
+	}
+	return &acmeIssuer{client: client}, nil
+}
+
+func newHttpClient(rootCa string) (*http.Client, error) {
+	if rootCa == "" {
+		return http.DefaultClient, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM([]byte(rootCa)) {
+		return nil, eris.New("the directory root CA of the ACME config is not a valid PEM certificate")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return &http.Client{Transport: transport}, nil
+}
+
+func (i *acmeIssuer) Obtain(ctx context.Context, domains []string, csr []byte, present func([]Challenge) error) (string, error) {
+	order, err := i.client.AuthorizeOrder(ctx, acmeclient.DomainIDs(domains...))
+	if err != nil {
+		return "", eris.Wrap(err, "creating the certificate order")
+	}
+
+	var challenges []Challenge
+	var pending []*acmeclient.Challenge
+	for _, authzUrl := range order.AuthzURLs {
+		authz, err := i.client.GetAuthorization(ctx, authzUrl)
+		if err != nil {
+			return "", eris.Wrap(err, "getting the authorization of the certificate order")
+		}
+		if authz.Status == acmeclient.StatusValid {
+			continue
+		}
+		challenge := http01Challenge(authz)
+		if challenge == nil {
+			return "", eris.Errorf("the certificate authority offers no HTTP-01 challenge for %s", authz.Identifier.Value)
+		}
+		keyAuthorization, err := i.client.HTTP01ChallengeResponse(challenge.Token)
+		if err != nil {
+			return "", err
+		}
+		challenges = append(challenges, Challenge{
+			Domain:           authz.Identifier.Value,
+			Token:            challenge.Token,
+			KeyAuthorization: keyAuthorization,
+		})
+		pending = append(pending, challenge)
+	}
+
+	if len(pending) > 0 {
+		if err := present(challenges); err != nil {
+			return "", eris.Wrap(err, "presenting the HTTP-01 challenges")
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(challengePropagationDelay):
+		}
+		for _, challenge := range pending {
+			if _, err := i.client.Accept(ctx, challenge); err != nil {
+				return "", eris.Wrap(err, "accepting the HTTP-01 challenge")
+			}
+		}
+		for _, authzUrl := range order.AuthzURLs {
+			if _, err := i.client.WaitAuthorization(ctx, authzUrl); err != nil {
+				return "", eris.Wrap(err, "validating the HTTP-01 challenge")
+			}
+		}
+	}
+
+	order, err = i.client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return "", eris.Wrap(err, "waiting for the certificate order")
+	}
+	der, _, err := i.client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return "", eris.Wrap(err, "finalizing the certificate order")
+	}
+	var certChain []byte
+	for _, cert := range der {
+		certChain = append(certChain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})...)
+	}
+	return string(certChain), nil
+}
+
+func http01Challenge(authz *acmeclient.Authorization) *acmeclient.Challenge {
+	for _, challenge := range authz.Challenges {
+		if challenge.Type == "http-01" {
+			return challenge
+		}
+	}
+	return nil
+}
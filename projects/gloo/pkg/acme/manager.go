@@ -0,0 +1,330 @@
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rotisserie/eris"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	v1snap "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/gloosnapshot"
+	"github.com/solo-io/go-utils/contextutils"
+	"github.com/solo-io/go-utils/stringutils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	skerrors "github.com/solo-io/solo-kit/pkg/errors"
+)
+
+const (
+	defaultRenewBefore = 30 * 24 * time.Hour
+
+	// How often the certificates are checked for renewal, in addition to every sync.
+	renewalCheckInterval = time.Hour
+
+	// How long to wait before trying to obtain a certificate again after a failure, to stay within the rate limits
+	// of the certificate authority.
+	retryInterval = 10 * time.Minute
+
+	issuanceTimeout = 5 * time.Minute
+
+	accountSecretSuffix = "-account"
+)
+
+var _ v1snap.ApiSyncer = new(Manager)
+
+// The Manager obtains the certificates of the ACME ssl configs of the proxies, and renews them before they expire.
+// The certificates are stored in Gloo tls secrets, which the ssl configs are resolved from.
+type Manager struct {
+	ctx          context.Context
+	secretClient v1.SecretClient
+	newIssuer    IssuerFactory
+
+	lock       sync.Mutex
+	requests   map[string]*certificateRequest
+	secrets    v1.SecretList
+	inProgress map[string]bool
+	retryAfter map[string]time.Time
+}
+
+// The certificate of an ACME config, keyed by the secret it is stored in.
+type certificateRequest struct {
+	secretRef *core.ResourceRef
+	config    *v1.AcmeConfig
+	domains   []string
+}
+
+func NewManager(ctx context.Context, secretClient v1.SecretClient, newIssuer IssuerFactory) *Manager {
+	m := &Manager{
+		ctx:          ctx,
+		secretClient: secretClient,
+		newIssuer:    newIssuer,
+		inProgress:   map[string]bool{},
+		retryAfter:   map[string]time.Time{},
+	}
+	go m.checkPeriodically()
+	return m
+}
+
+func (m *Manager) Sync(_ context.Context, snap *v1snap.ApiSnapshot) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.requests = certificateRequests(snap.Proxies)
+	m.secrets = snap.Secrets
+	m.reconcile()
+	return nil
+}
+
+func (m *Manager) checkPeriodically() {
+	ticker := time.NewTicker(renewalCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.lock.Lock()
+			m.reconcile()
+			m.lock.Unlock()
+		}
+	}
+}
+
+// Starts obtaining the certificates that are missing, expiring or do not cover their domains. Must be called with
+// the lock held.
+func (m *Manager) reconcile() {
+	now := time.Now()
+	for key, request := range m.requests {
+		if m.inProgress[key] || now.Before(m.retryAfter[key]) {
+			continue
+		}
+		secret, _ := m.secrets.Find(request.secretRef.Strings())
+		if !needsCertificate(request, secret, now) {
+			continue
+		}
+		m.inProgress[key] = true
+		go m.issue(key, request)
+	}
+}
+
+func (m *Manager) issue(key string, request *certificateRequest) {
+	err := m.obtainCertificate(request)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.inProgress, key)
+	if err != nil {
+		m.retryAfter[key] = time.Now().Add(retryInterval)
+		contextutils.LoggerFrom(m.ctx).Errorw("failed to obtain the ACME certificate", "secret", key, "domains", request.domains, "error", err)
+		return
+	}
+	delete(m.retryAfter, key)
+	contextutils.LoggerFrom(m.ctx).Infow("obtained the ACME certificate", "secret", key, "domains", request.domains)
+}
+
+func (m *Manager) obtainCertificate(request *certificateRequest) error {
+	ctx, cancel := context.WithTimeout(m.ctx, issuanceTimeout)
+	defer cancel()
+
+	// the snapshot the certificate was requested from may be older than the last certificate obtained
+	secret, err := m.readSecret(ctx, request.secretRef)
+	if err != nil {
+		return err
+	}
+	if !needsCertificate(request, secret, time.Now()) {
+		return nil
+	}
+
+	accountKey, err := m.accountKey(ctx, request.secretRef)
+	if err != nil {
+		return err
+	}
+	issuer, err := m.newIssuer(ctx, request.config, accountKey)
+	if err != nil {
+		return err
+	}
+
+	certKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: request.domains[0]},
+		DNSNames: request.domains,
+	}, certKey)
+	if err != nil {
+		return err
+	}
+
+	certChain, obtainErr := issuer.Obtain(ctx, request.domains, csr, func(challenges []Challenge) error {
+		return m.updateSecret(ctx, request.secretRef, func(secret *v1.Secret) error {
+			return setChallenges(secret, challenges)
+		})
+	})
+	if obtainErr != nil {
+		// the challenges are removed even if the issuance timed out
+		if err := m.updateSecret(m.ctx, request.secretRef, func(secret *v1.Secret) error {
+			return setChallenges(secret, nil)
+		}); err != nil {
+			contextutils.LoggerFrom(m.ctx).Warnw("failed to remove the ACME challenges", "secret", request.secretRef.Key(), "error", err)
+		}
+		return obtainErr
+	}
+
+	privateKey, err := encodePrivateKey(certKey)
+	if err != nil {
+		return err
+	}
+	return m.updateSecret(ctx, request.secretRef, func(secret *v1.Secret) error {
+		secret.Kind = &v1.Secret_Tls{
+			Tls: &v1.TlsSecret{
+				CertChain:  certChain,
+				PrivateKey: privateKey,
+			},
+		}
+		return setChallenges(secret, nil)
+	})
+}
+
+// Returns the key of the ACME account, which is stored alongside the certificate, creating it if needed.
+func (m *Manager) accountKey(ctx context.Context, certRef *core.ResourceRef) (crypto.Signer, error) {
+	ref := &core.ResourceRef{
+		Name:      certRef.GetName() + accountSecretSuffix,
+		Namespace: certRef.GetNamespace(),
+	}
+	secret, err := m.readSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if privateKey := secret.GetTls().GetPrivateKey(); privateKey != "" {
+		block, _ := pem.Decode([]byte(privateKey))
+		if block == nil {
+			return nil, eris.Errorf("the ACME account secret %s does not hold a PEM encoded private key", ref.Key())
+		}
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := encodePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.updateSecret(ctx, ref, func(secret *v1.Secret) error {
+		secret.Kind = &v1.Secret_Tls{Tls: &v1.TlsSecret{PrivateKey: privateKey}}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Returns nil if the secret does not exist.
+func (m *Manager) readSecret(ctx context.Context, ref *core.ResourceRef) (*v1.Secret, error) {
+	secret, err := m.secretClient.Read(ref.GetNamespace(), ref.GetName(), clients.ReadOpts{Ctx: ctx})
+	if skerrors.IsNotExist(err) {
+		return nil, nil
+	}
+	return secret, err
+}
+
+// Applies the mutation to the latest version of the secret, creating it if needed.
+func (m *Manager) updateSecret(ctx context.Context, ref *core.ResourceRef, mutate func(secret *v1.Secret) error) error {
+	secret, err := m.readSecret(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if secret == nil {
+		secret = &v1.Secret{
+			Metadata: &core.Metadata{
+				Name:      ref.GetName(),
+				Namespace: ref.GetNamespace(),
+			},
+			Kind: &v1.Secret_Tls{Tls: &v1.TlsSecret{}},
+		}
+	}
+	if err := mutate(secret); err != nil {
+		return err
+	}
+	_, err = m.secretClient.Write(secret, clients.WriteOpts{Ctx: ctx, OverwriteExisting: true})
+	return err
+}
+
+// Collects the ACME configs of the listeners, merging the domains of the configs that share a secret.
+func certificateRequests(proxies v1.ProxyList) map[string]*certificateRequest {
+	requests := map[string]*certificateRequest{}
+	add := func(sslConfigs ...*v1.SslConfig) {
+		for _, sslConfig := range sslConfigs {
+			config := sslConfig.GetAcme()
+			// the ssl config translation reports the configs that are not complete
+			if config == nil || !config.GetAcceptTermsOfService() || config.GetSecretRef() == nil || len(config.GetDomains()) == 0 {
+				continue
+			}
+			key := config.GetSecretRef().Key()
+			request, ok := requests[key]
+			if !ok {
+				request = &certificateRequest{secretRef: config.GetSecretRef(), config: config}
+				requests[key] = request
+			}
+			for _, domain := range config.GetDomains() {
+				if !stringutils.ContainsString(domain, request.domains) {
+					request.domains = append(request.domains, domain)
+				}
+			}
+			sort.Strings(request.domains)
+		}
+	}
+	for _, proxy := range proxies {
+		for _, listener := range proxy.GetListeners() {
+			add(listener.GetSslConfigurations()...)
+			for _, matchedListener := range listener.GetHybridListener().GetMatchedListeners() {
+				add(matchedListener.GetSslConfigurations()...)
+				add(matchedListener.GetMatcher().GetSslConfig())
+			}
+		}
+	}
+	return requests
+}
+
+// A certificate is needed if there is none, if it expires within the renewal period, or if it does not cover all
+// of the requested domains.
+func needsCertificate(request *certificateRequest, secret *v1.Secret, now time.Time) bool {
+	block, _ := pem.Decode([]byte(secret.GetTls().GetCertChain()))
+	if block == nil {
+		return true
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+	renewBefore := defaultRenewBefore
+	if request.config.GetRenewBefore() != nil {
+		renewBefore = request.config.GetRenewBefore().AsDuration()
+	}
+	if now.Add(renewBefore).After(cert.NotAfter) {
+		return true
+	}
+	for _, domain := range request.domains {
+		if cert.VerifyHostname(domain) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func encodePrivateKey(key *ecdsa.PrivateKey) (string, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})), nil
+}
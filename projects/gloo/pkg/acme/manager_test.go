@@ -0,0 +1,230 @@
+package acme_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rotisserie/eris"
+	"github.com/solo-io/gloo/projects/gloo/pkg/acme"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	v1snap "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/gloosnapshot"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/factory"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/memory"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+)
+
+// issues certificates for the CSRs it is given, without validating the challenges
+type fakeIssuer struct {
+	secretClient v1.SecretClient
+	validity     time.Duration
+	err          error
+
+	lock        sync.Mutex
+	obtained    int
+	accountKeys []crypto.Signer
+	// the challenges found on the certificate secret once they are presented
+	presented [][]acme.Challenge
+}
+
+func (f *fakeIssuer) newIssuer(_ context.Context, _ *v1.AcmeConfig, accountKey crypto.Signer) (acme.Issuer, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.accountKeys = append(f.accountKeys, accountKey)
+	return f, nil
+}
+
+func (f *fakeIssuer) Obtain(ctx context.Context, domains []string, csrDer []byte, present func([]acme.Challenge) error) (string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.obtained++
+
+	var challenges []acme.Challenge
+	for _, domain := range domains {
+		challenges = append(challenges, acme.Challenge{Domain: domain, Token: "token-" + domain, KeyAuthorization: "token-" + domain + ".thumbprint"})
+	}
+	if err := present(challenges); err != nil {
+		return "", err
+	}
+	secret, err := f.secretClient.Read("gloo-system", "example-acme", clients.ReadOpts{Ctx: ctx})
+	if err != nil {
+		return "", err
+	}
+	f.presented = append(f.presented, acme.ChallengesFromSecret(secret))
+	if f.err != nil {
+		return "", f.err
+	}
+
+	csr, err := x509.ParseCertificateRequest(csrDer)
+	if err != nil {
+		return "", err
+	}
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: csr.Subject.CommonName},
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(f.validity),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, csr.PublicKey, caKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), nil
+}
+
+func (f *fakeIssuer) obtainedCount() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.obtained
+}
+
+var _ = Describe("Manager", func() {
+
+	var (
+		ctx          context.Context
+		cancel       context.CancelFunc
+		secretClient v1.SecretClient
+		issuer       *fakeIssuer
+		manager      *acme.Manager
+		acmeConfig   *v1.AcmeConfig
+		snap         *v1snap.ApiSnapshot
+	)
+
+	readSecret := func(name string) *v1.Secret {
+		secret, err := secretClient.Read("gloo-system", name, clients.ReadOpts{Ctx: ctx})
+		if err != nil {
+			return nil
+		}
+		return secret
+	}
+
+	certChain := func() string {
+		return readSecret("example-acme").GetTls().GetCertChain()
+	}
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		var err error
+		secretClient, err = v1.NewSecretClient(ctx, &factory.MemoryResourceClientFactory{
+			Cache: memory.NewInMemoryResourceCache(),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		issuer = &fakeIssuer{secretClient: secretClient, validity: 90 * 24 * time.Hour}
+		manager = acme.NewManager(ctx, secretClient, issuer.newIssuer)
+
+		acmeConfig = &v1.AcmeConfig{
+			AcceptTermsOfService: true,
+			Domains:              []string{"www.example.com", "example.com"},
+			SecretRef:            &core.ResourceRef{Name: "example-acme", Namespace: "gloo-system"},
+		}
+		snap = &v1snap.ApiSnapshot{
+			Proxies: v1.ProxyList{{
+				Metadata: &core.Metadata{Name: "gateway-proxy", Namespace: "gloo-system"},
+				Listeners: []*v1.Listener{{
+					Name: "listener-::-8443",
+					SslConfigurations: []*v1.SslConfig{{
+						SslSecrets: &v1.SslConfig_Acme{Acme: acmeConfig},
+					}},
+				}},
+			}},
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("obtains the certificates of the acme configs of the proxies", func() {
+		Expect(manager.Sync(ctx, snap)).NotTo(HaveOccurred())
+		Eventually(certChain).ShouldNot(BeEmpty())
+
+		secret := readSecret("example-acme")
+		keyPair, err := tls.X509KeyPair([]byte(secret.GetTls().GetCertChain()), []byte(secret.GetTls().GetPrivateKey()))
+		Expect(err).NotTo(HaveOccurred())
+		cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cert.DNSNames).To(Equal([]string{"example.com", "www.example.com"}))
+
+		By("serving the challenges while the certificate is issued")
+		Expect(issuer.presented).To(Equal([][]acme.Challenge{{
+			{Domain: "example.com", Token: "token-example.com", KeyAuthorization: "token-example.com.thumbprint"},
+			{Domain: "www.example.com", Token: "token-www.example.com", KeyAuthorization: "token-www.example.com.thumbprint"},
+		}}))
+		Expect(secret.GetMetadata().GetAnnotations()).NotTo(HaveKey(acme.ChallengesAnnotation))
+
+		By("storing the account key")
+		Expect(readSecret("example-acme-account").GetTls().GetPrivateKey()).NotTo(BeEmpty())
+	})
+
+	It("keeps a certificate that is still valid", func() {
+		Expect(manager.Sync(ctx, snap)).NotTo(HaveOccurred())
+		Eventually(certChain).ShouldNot(BeEmpty())
+
+		snap.Secrets = v1.SecretList{readSecret("example-acme")}
+		Expect(manager.Sync(ctx, snap)).NotTo(HaveOccurred())
+		Consistently(issuer.obtainedCount, "100ms").Should(Equal(1))
+	})
+
+	It("renews the certificate before it expires, with the same account", func() {
+		issuer.validity = 20 * 24 * time.Hour
+		Expect(manager.Sync(ctx, snap)).NotTo(HaveOccurred())
+		Eventually(certChain).ShouldNot(BeEmpty())
+		Eventually(issuer.obtainedCount).Should(Equal(1))
+
+		snap.Secrets = v1.SecretList{readSecret("example-acme")}
+		Expect(manager.Sync(ctx, snap)).NotTo(HaveOccurred())
+		Eventually(issuer.obtainedCount).Should(Equal(2))
+
+		issuer.lock.Lock()
+		defer issuer.lock.Unlock()
+		Expect(issuer.accountKeys).To(HaveLen(2))
+		Expect(issuer.accountKeys[1].Public()).To(Equal(issuer.accountKeys[0].Public()))
+	})
+
+	It("obtains a new certificate when the domains change", func() {
+		Expect(manager.Sync(ctx, snap)).NotTo(HaveOccurred())
+		Eventually(certChain).ShouldNot(BeEmpty())
+
+		acmeConfig.Domains = append(acmeConfig.Domains, "api.example.com")
+		snap.Secrets = v1.SecretList{readSecret("example-acme")}
+		Expect(manager.Sync(ctx, snap)).NotTo(HaveOccurred())
+		Eventually(issuer.obtainedCount).Should(Equal(2))
+	})
+
+	It("removes the challenges and backs off when the certificate cannot be obtained", func() {
+		issuer.err = eris.New("the challenge could not be validated")
+		Expect(manager.Sync(ctx, snap)).NotTo(HaveOccurred())
+		Eventually(issuer.obtainedCount).Should(Equal(1))
+		Eventually(func() map[string]string {
+			return readSecret("example-acme").GetMetadata().GetAnnotations()
+		}).ShouldNot(HaveKey(acme.ChallengesAnnotation))
+		Expect(certChain()).To(BeEmpty())
+
+		Expect(manager.Sync(ctx, snap)).NotTo(HaveOccurred())
+		Consistently(issuer.obtainedCount, "100ms").Should(Equal(1))
+	})
+
+	It("ignores the acme configs whose terms of service are not accepted", func() {
+		acmeConfig.AcceptTermsOfService = false
+		Expect(manager.Sync(ctx, snap)).NotTo(HaveOccurred())
+		Consistently(issuer.obtainedCount, "100ms").Should(Equal(0))
+	})
+})
@@ -16,6 +16,7 @@ import (
 var GlooSecretConverterChain = NewSecretConverterChain(
 	new(TLSSecretConverter),
 	new(AwsSecretConverter),
+	new(GcpSecretConverter),
 	new(APIKeySecretConverter),
 	new(OAuthSecretConverter),
 	new(OpaqueSecretConverter),
@@ -66,6 +67,13 @@ type TLSSecretConverter struct{}
 
 var _ kubesecret.SecretConverter = &TLSSecretConverter{}
 
+const (
+	// The key of the DER encoded OCSP response of the certificate in kube tls secrets.
+	TLSOcspStapleKey = "tls.ocsp-staple"
+	// The key of the PEM encoded certificate revocation lists in kube tls secrets, checked along with the root ca.
+	TLSCrlKey = "ca.crl"
+)
+
 func (t *TLSSecretConverter) FromKubeSecret(_ context.Context, _ *kubesecret.ResourceClient, secret *kubev1.Secret) (resources.Resource, error) {
 	if secret.Type == kubev1.SecretTypeTLS {
 		glooSecret := &v1.Secret{
@@ -74,6 +82,8 @@ func (t *TLSSecretConverter) FromKubeSecret(_ context.Context, _ *kubesecret.Res
 					PrivateKey: string(secret.Data[kubev1.TLSPrivateKeyKey]),
 					CertChain:  string(secret.Data[kubev1.TLSCertKey]),
 					RootCa:     string(secret.Data[kubev1.ServiceAccountRootCAKey]),
+					OcspStaple: secret.Data[TLSOcspStapleKey],
+					Crl:        string(secret.Data[TLSCrlKey]),
 				},
 			},
 			Metadata: kubeutils.FromKubeMeta(secret.ObjectMeta, true),
@@ -104,6 +114,14 @@ func (t *TLSSecretConverter) ToKubeSecret(_ context.Context, _ *kubesecret.Resou
 				kubeSecret.Data[kubev1.ServiceAccountRootCAKey] = []byte(tlsGlooSecret.Tls.GetRootCa())
 			}
 
+			if len(tlsGlooSecret.Tls.GetOcspStaple()) > 0 {
+				kubeSecret.Data[TLSOcspStapleKey] = tlsGlooSecret.Tls.GetOcspStaple()
+			}
+
+			if tlsGlooSecret.Tls.GetCrl() != "" {
+				kubeSecret.Data[TLSCrlKey] = []byte(tlsGlooSecret.Tls.GetCrl())
+			}
+
 			return kubeSecret, nil
 		}
 	}
@@ -191,3 +209,56 @@ func (t *AwsSecretConverter) ToKubeSecret(_ context.Context, _ *kubesecret.Resou
 	}
 	return kubeSecret, nil
 }
+
+// The purpose of this implementation of the SecretConverter interface is to provide a way for the user to specify GCP
+// secrets without having to use an annotation to identify the secret as a GCP secret. Instead of an annotation, this
+// converter looks for the key of the service account.
+type GcpSecretConverter struct{}
+
+var _ kubesecret.SecretConverter = &GcpSecretConverter{}
+
+const GcpServiceAccountKeyName = "gcp_service_account_key"
+
+func (t *GcpSecretConverter) FromKubeSecret(_ context.Context, _ *kubesecret.ResourceClient, secret *kubev1.Secret) (resources.Resource, error) {
+	serviceAccountKey, hasServiceAccountKey := secret.Data[GcpServiceAccountKeyName]
+	if hasServiceAccountKey {
+		return &v1.Secret{
+			Metadata: &skcore.Metadata{
+				Name:        secret.Name,
+				Namespace:   secret.Namespace,
+				Cluster:     secret.ClusterName,
+				Labels:      secret.Labels,
+				Annotations: secret.Annotations,
+			},
+			Kind: &v1.Secret_Gcp{
+				Gcp: &v1.GcpSecret{
+					ServiceAccountKey: string(serviceAccountKey),
+				},
+			},
+		}, nil
+	}
+	// any unmatched secrets will be handled by subsequent converters
+	return nil, nil
+}
+
+func (t *GcpSecretConverter) ToKubeSecret(_ context.Context, _ *kubesecret.ResourceClient, resource resources.Resource) (*kubev1.Secret, error) {
+	glooSecret, ok := resource.(*v1.Secret)
+	if !ok {
+		return nil, nil
+	}
+	gcpGlooSecret, ok := glooSecret.GetKind().(*v1.Secret_Gcp)
+	if !ok {
+		return nil, nil
+	}
+	objectMeta := kubeutils.ToKubeMeta(glooSecret.GetMetadata())
+	if len(objectMeta.Annotations) == 0 {
+		objectMeta.Annotations = nil
+	}
+	return &kubev1.Secret{
+		ObjectMeta: objectMeta,
+		Type:       kubev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			GcpServiceAccountKeyName: []byte(gcpGlooSecret.Gcp.GetServiceAccountKey()),
+		},
+	}, nil
+}
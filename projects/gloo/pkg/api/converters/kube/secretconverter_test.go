@@ -148,6 +148,33 @@ var _ = Describe("SecretConverter", func() {
 
 	})
 
+	It("should round trip kube ssl secret with an ocsp staple and a crl back to kube ssl secret", func() {
+		secret := &kubev1.Secret{
+			Type: kubev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				kubev1.TLSCertKey:       []byte("cert"),
+				kubev1.TLSPrivateKeyKey: []byte("key"),
+				TLSOcspStapleKey:        {0x30, 0x03, 0x0a, 0x01, 0x00},
+				TLSCrlKey:               []byte("crl"),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "s1",
+				Namespace:       "ns",
+				Labels:          map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{},
+			},
+		}
+		var t TLSSecretConverter
+		resource, err := t.FromKubeSecret(context.Background(), nil, secret)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resource.(*v1.Secret).GetTls().GetOcspStaple()).To(Equal(secret.Data[TLSOcspStapleKey]))
+		Expect(resource.(*v1.Secret).GetTls().GetCrl()).To(Equal("crl"))
+		kubeSecret, err := t.ToKubeSecret(context.Background(), nil, resource)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(secret).To(Equal(kubeSecret))
+	})
+
 	It("should round trip kube aws secret to gloo aws secret and back to kube aws secret", func() {
 		awsSecret := &v1.AwsSecret{
 			AccessKey:    "access",
@@ -179,6 +206,31 @@ var _ = Describe("SecretConverter", func() {
 		Expect(derivedSecret).To(Equal(kubeSecret))
 	})
 
+	It("should round trip kube gcp secret to gloo gcp secret and back to kube gcp secret", func() {
+		gcpSecret := &v1.GcpSecret{
+			ServiceAccountKey: `{"client_email":"invoker@project.iam.gserviceaccount.com"}`,
+		}
+		kubeSecret := &kubev1.Secret{
+			Type: kubev1.SecretTypeOpaque,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "s1",
+				Namespace:       "ns",
+				Labels:          map[string]string{},
+				OwnerReferences: []metav1.OwnerReference{},
+			},
+			Data: map[string][]byte{
+				GcpServiceAccountKeyName: []byte(gcpSecret.ServiceAccountKey),
+			},
+		}
+		resource, err := GlooSecretConverterChain.FromKubeSecret(context.Background(), nil, kubeSecret)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resource.GetMetadata().Name).To(Equal("s1"))
+		Expect(resource.(*v1.Secret).Kind.(*v1.Secret_Gcp).Gcp).To(Equal(gcpSecret))
+		derivedSecret, err := GlooSecretConverterChain.ToKubeSecret(context.Background(), nil, resource)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(derivedSecret).To(Equal(kubeSecret))
+	})
+
 	It("converter chain should exit in expected order", func() {
 		secret := &kubev1.Secret{
 			Data: map[string][]byte{
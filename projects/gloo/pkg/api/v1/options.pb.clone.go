@@ -47,6 +47,8 @@ import (
 
 	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_azure "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/azure"
 
+	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_client_certificate "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/client_certificate"
+
 	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_cors "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/cors"
 
 	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_dynamic_forward_proxy "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/dynamic_forward_proxy"
@@ -65,6 +67,10 @@ import (
 
 	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_healthcheck "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/healthcheck"
 
+	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_http3 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/http3"
+
+	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_invalid_route "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/invalid_route"
+
 	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_lbhash "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/lbhash"
 
 	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_protocol_upgrade "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/protocol_upgrade"
@@ -276,6 +282,12 @@ func (m *HttpListenerOptions) Clone() proto.Message {
 		target.DynamicForwardProxy = proto.Clone(m.GetDynamicForwardProxy()).(*github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_dynamic_forward_proxy.FilterConfig)
 	}
 
+	if h, ok := interface{}(m.GetHttp3()).(clone.Cloner); ok {
+		target.Http3 = h.Clone().(*github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_http3.Http3)
+	} else {
+		target.Http3 = proto.Clone(m.GetHttp3()).(*github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_http3.Http3)
+	}
+
 	return target
 }
 
@@ -400,6 +412,12 @@ func (m *VirtualHostOptions) Clone() proto.Message {
 		target.StagedTransformations = proto.Clone(m.GetStagedTransformations()).(*github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_transformation.TransformationStages)
 	}
 
+	if h, ok := interface{}(m.GetInvalidRoutePolicy()).(clone.Cloner); ok {
+		target.InvalidRoutePolicy = h.Clone().(*github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_invalid_route.InvalidRoutePolicy)
+	} else {
+		target.InvalidRoutePolicy = proto.Clone(m.GetInvalidRoutePolicy()).(*github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_invalid_route.InvalidRoutePolicy)
+	}
+
 	switch m.RateLimitEarlyConfigType.(type) {
 
 	case *VirtualHostOptions_RatelimitEarly:
@@ -641,6 +659,18 @@ func (m *RouteOptions) Clone() proto.Message {
 		target.RegexRewrite = proto.Clone(m.GetRegexRewrite()).(*github_com_solo_io_gloo_projects_gloo_pkg_api_external_envoy_type_matcher_v3.RegexMatchAndSubstitute)
 	}
 
+	if h, ok := interface{}(m.GetInvalidRoutePolicy()).(clone.Cloner); ok {
+		target.InvalidRoutePolicy = h.Clone().(*github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_invalid_route.InvalidRoutePolicy)
+	} else {
+		target.InvalidRoutePolicy = proto.Clone(m.GetInvalidRoutePolicy()).(*github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_invalid_route.InvalidRoutePolicy)
+	}
+
+	if h, ok := interface{}(m.GetClientCertificateAuthorization()).(clone.Cloner); ok {
+		target.ClientCertificateAuthorization = h.Clone().(*github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_client_certificate.ClientCertificateAuthorization)
+	} else {
+		target.ClientCertificateAuthorization = proto.Clone(m.GetClientCertificateAuthorization()).(*github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_client_certificate.ClientCertificateAuthorization)
+	}
+
 	switch m.HostRewriteType.(type) {
 
 	case *RouteOptions_HostRewrite:
@@ -331,6 +331,16 @@ func (m *HttpListenerOptions) Equal(that interface{}) bool {
 		}
 	}
 
+	if h, ok := interface{}(m.GetHttp3()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetHttp3()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetHttp3(), target.GetHttp3()) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -549,6 +559,16 @@ func (m *VirtualHostOptions) Equal(that interface{}) bool {
 		}
 	}
 
+	if h, ok := interface{}(m.GetInvalidRoutePolicy()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetInvalidRoutePolicy()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetInvalidRoutePolicy(), target.GetInvalidRoutePolicy()) {
+			return false
+		}
+	}
+
 	switch m.RateLimitEarlyConfigType.(type) {
 
 	case *VirtualHostOptions_RatelimitEarly:
@@ -924,6 +944,26 @@ func (m *RouteOptions) Equal(that interface{}) bool {
 		}
 	}
 
+	if h, ok := interface{}(m.GetInvalidRoutePolicy()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetInvalidRoutePolicy()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetInvalidRoutePolicy(), target.GetInvalidRoutePolicy()) {
+			return false
+		}
+	}
+
+	if h, ok := interface{}(m.GetClientCertificateAuthorization()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetClientCertificateAuthorization()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetClientCertificateAuthorization(), target.GetClientCertificateAuthorization()) {
+			return false
+		}
+	}
+
 	switch m.HostRewriteType.(type) {
 
 	case *RouteOptions_HostRewrite:
@@ -58,6 +58,48 @@ func (m *UpstreamSpec) Clone() proto.Message {
 
 	target.RoleArn = m.GetRoleArn()
 
+	if h, ok := interface{}(m.GetAssumeRole()).(clone.Cloner); ok {
+		target.AssumeRole = h.Clone().(*AssumeRoleCredentials)
+	} else {
+		target.AssumeRole = proto.Clone(m.GetAssumeRole()).(*AssumeRoleCredentials)
+	}
+
+	if h, ok := interface{}(m.GetFunctionUrl()).(clone.Cloner); ok {
+		target.FunctionUrl = h.Clone().(*FunctionUrl)
+	} else {
+		target.FunctionUrl = proto.Clone(m.GetFunctionUrl()).(*FunctionUrl)
+	}
+
+	return target
+}
+
+// Clone function
+func (m *FunctionUrl) Clone() proto.Message {
+	var target *FunctionUrl
+	if m == nil {
+		return target
+	}
+	target = &FunctionUrl{}
+
+	target.Url = m.GetUrl()
+
+	return target
+}
+
+// Clone function
+func (m *AssumeRoleCredentials) Clone() proto.Message {
+	var target *AssumeRoleCredentials
+	if m == nil {
+		return target
+	}
+	target = &AssumeRoleCredentials{}
+
+	target.RoleArn = m.GetRoleArn()
+
+	target.ExternalId = m.GetExternalId()
+
+	target.SessionName = m.GetSessionName()
+
 	return target
 }
 
@@ -96,5 +138,33 @@ func (m *DestinationSpec) Clone() proto.Message {
 
 	target.UnwrapAsAlb = m.GetUnwrapAsAlb()
 
+	if m.GetWeightedQualifiers() != nil {
+		target.WeightedQualifiers = make([]*WeightedQualifier, len(m.GetWeightedQualifiers()))
+		for idx, v := range m.GetWeightedQualifiers() {
+
+			if h, ok := interface{}(v).(clone.Cloner); ok {
+				target.WeightedQualifiers[idx] = h.Clone().(*WeightedQualifier)
+			} else {
+				target.WeightedQualifiers[idx] = proto.Clone(v).(*WeightedQualifier)
+			}
+
+		}
+	}
+
+	return target
+}
+
+// Clone function
+func (m *WeightedQualifier) Clone() proto.Message {
+	var target *WeightedQualifier
+	if m == nil {
+		return target
+	}
+	target = &WeightedQualifier{}
+
+	target.Qualifier = m.GetQualifier()
+
+	target.Weight = m.GetWeight()
+
 	return target
 }
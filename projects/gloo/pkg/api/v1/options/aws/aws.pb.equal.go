@@ -81,6 +81,90 @@ func (m *UpstreamSpec) Equal(that interface{}) bool {
 		return false
 	}
 
+	if h, ok := interface{}(m.GetAssumeRole()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetAssumeRole()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetAssumeRole(), target.GetAssumeRole()) {
+			return false
+		}
+	}
+
+	if h, ok := interface{}(m.GetFunctionUrl()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetFunctionUrl()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetFunctionUrl(), target.GetFunctionUrl()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal function
+func (m *FunctionUrl) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*FunctionUrl)
+	if !ok {
+		that2, ok := that.(FunctionUrl)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if strings.Compare(m.GetUrl(), target.GetUrl()) != 0 {
+		return false
+	}
+
+	return true
+}
+
+// Equal function
+func (m *AssumeRoleCredentials) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*AssumeRoleCredentials)
+	if !ok {
+		that2, ok := that.(AssumeRoleCredentials)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if strings.Compare(m.GetRoleArn(), target.GetRoleArn()) != 0 {
+		return false
+	}
+
+	if strings.Compare(m.GetExternalId(), target.GetExternalId()) != 0 {
+		return false
+	}
+
+	if strings.Compare(m.GetSessionName(), target.GetSessionName()) != 0 {
+		return false
+	}
+
 	return true
 }
 
@@ -161,5 +245,54 @@ func (m *DestinationSpec) Equal(that interface{}) bool {
 		return false
 	}
 
+	if len(m.GetWeightedQualifiers()) != len(target.GetWeightedQualifiers()) {
+		return false
+	}
+	for idx, v := range m.GetWeightedQualifiers() {
+
+		if h, ok := interface{}(v).(equality.Equalizer); ok {
+			if !h.Equal(target.GetWeightedQualifiers()[idx]) {
+				return false
+			}
+		} else {
+			if !proto.Equal(v, target.GetWeightedQualifiers()[idx]) {
+				return false
+			}
+		}
+
+	}
+
+	return true
+}
+
+// Equal function
+func (m *WeightedQualifier) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*WeightedQualifier)
+	if !ok {
+		that2, ok := that.(WeightedQualifier)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if strings.Compare(m.GetQualifier(), target.GetQualifier()) != 0 {
+		return false
+	}
+
+	if m.GetWeight() != target.GetWeight() {
+		return false
+	}
+
 	return true
 }
@@ -56,6 +56,16 @@ func (m *UpstreamSpec) Clone() proto.Message {
 		}
 	}
 
+	if h, ok := interface{}(m.GetManagedIdentity()).(clone.Cloner); ok {
+		target.ManagedIdentity = h.Clone().(*UpstreamSpec_ManagedIdentity)
+	} else {
+		target.ManagedIdentity = proto.Clone(m.GetManagedIdentity()).(*UpstreamSpec_ManagedIdentity)
+	}
+
+	target.SubscriptionId = m.GetSubscriptionId()
+
+	target.ResourceGroup = m.GetResourceGroup()
+
 	return target
 }
 
@@ -86,3 +96,18 @@ func (m *UpstreamSpec_FunctionSpec) Clone() proto.Message {
 
 	return target
 }
+
+// Clone function
+func (m *UpstreamSpec_ManagedIdentity) Clone() proto.Message {
+	var target *UpstreamSpec_ManagedIdentity
+	if m == nil {
+		return target
+	}
+	target = &UpstreamSpec_ManagedIdentity{}
+
+	target.Resource = m.GetResource()
+
+	target.ClientId = m.GetClientId()
+
+	return target
+}
@@ -77,6 +77,24 @@ func (m *UpstreamSpec) Equal(that interface{}) bool {
 
 	}
 
+	if h, ok := interface{}(m.GetManagedIdentity()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetManagedIdentity()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetManagedIdentity(), target.GetManagedIdentity()) {
+			return false
+		}
+	}
+
+	if strings.Compare(m.GetSubscriptionId(), target.GetSubscriptionId()) != 0 {
+		return false
+	}
+
+	if strings.Compare(m.GetResourceGroup(), target.GetResourceGroup()) != 0 {
+		return false
+	}
+
 	return true
 }
 
@@ -139,3 +157,35 @@ func (m *UpstreamSpec_FunctionSpec) Equal(that interface{}) bool {
 
 	return true
 }
+
+// Equal function
+func (m *UpstreamSpec_ManagedIdentity) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*UpstreamSpec_ManagedIdentity)
+	if !ok {
+		that2, ok := that.(UpstreamSpec_ManagedIdentity)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if strings.Compare(m.GetResource(), target.GetResource()) != 0 {
+		return false
+	}
+
+	if strings.Compare(m.GetClientId(), target.GetClientId()) != 0 {
+		return false
+	}
+
+	return true
+}
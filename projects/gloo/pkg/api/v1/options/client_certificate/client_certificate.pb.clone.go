@@ -0,0 +1,85 @@
+// Code generated by protoc-gen-ext. DO NOT EDIT.
+// source: github.com/solo-io/gloo/projects/gloo/api/v1/options/client_certificate/client_certificate.proto
+
+package client_certificate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/solo-io/protoc-gen-ext/pkg/clone"
+	"google.golang.org/protobuf/proto"
+
+	github_com_solo_io_gloo_projects_gloo_pkg_api_external_envoy_type_matcher_v3 "github.com/solo-io/gloo/projects/gloo/pkg/api/external/envoy/type/matcher/v3"
+)
+
+// ensure the imports are used
+var (
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = binary.LittleEndian
+	_ = bytes.Compare
+	_ = strings.Compare
+	_ = clone.Cloner(nil)
+	_ = proto.Message(nil)
+)
+
+// Clone function
+func (m *ClientCertificateAuthorization) Clone() proto.Message {
+	var target *ClientCertificateAuthorization
+	if m == nil {
+		return target
+	}
+	target = &ClientCertificateAuthorization{}
+
+	if m.GetPrincipals() != nil {
+		target.Principals = make([]*Principal, len(m.GetPrincipals()))
+		for idx, v := range m.GetPrincipals() {
+
+			if h, ok := interface{}(v).(clone.Cloner); ok {
+				target.Principals[idx] = h.Clone().(*Principal)
+			} else {
+				target.Principals[idx] = proto.Clone(v).(*Principal)
+			}
+
+		}
+	}
+
+	return target
+}
+
+// Clone function
+func (m *Principal) Clone() proto.Message {
+	var target *Principal
+	if m == nil {
+		return target
+	}
+	target = &Principal{}
+
+	switch m.Identity.(type) {
+
+	case *Principal_SpiffeId:
+
+		target.Identity = &Principal_SpiffeId{
+			SpiffeId: m.GetSpiffeId(),
+		}
+
+	case *Principal_Name:
+
+		if h, ok := interface{}(m.GetName()).(clone.Cloner); ok {
+			target.Identity = &Principal_Name{
+				Name: h.Clone().(*github_com_solo_io_gloo_projects_gloo_pkg_api_external_envoy_type_matcher_v3.StringMatcher),
+			}
+		} else {
+			target.Identity = &Principal_Name{
+				Name: proto.Clone(m.GetName()).(*github_com_solo_io_gloo_projects_gloo_pkg_api_external_envoy_type_matcher_v3.StringMatcher),
+			}
+		}
+
+	}
+
+	return target
+}
@@ -0,0 +1,124 @@
+// Code generated by protoc-gen-ext. DO NOT EDIT.
+// source: github.com/solo-io/gloo/projects/gloo/api/v1/options/client_certificate/client_certificate.proto
+
+package client_certificate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	equality "github.com/solo-io/protoc-gen-ext/pkg/equality"
+)
+
+// ensure the imports are used
+var (
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = binary.LittleEndian
+	_ = bytes.Compare
+	_ = strings.Compare
+	_ = equality.Equalizer(nil)
+	_ = proto.Message(nil)
+)
+
+// Equal function
+func (m *ClientCertificateAuthorization) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*ClientCertificateAuthorization)
+	if !ok {
+		that2, ok := that.(ClientCertificateAuthorization)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if len(m.GetPrincipals()) != len(target.GetPrincipals()) {
+		return false
+	}
+	for idx, v := range m.GetPrincipals() {
+
+		if h, ok := interface{}(v).(equality.Equalizer); ok {
+			if !h.Equal(target.GetPrincipals()[idx]) {
+				return false
+			}
+		} else {
+			if !proto.Equal(v, target.GetPrincipals()[idx]) {
+				return false
+			}
+		}
+
+	}
+
+	return true
+}
+
+// Equal function
+func (m *Principal) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*Principal)
+	if !ok {
+		that2, ok := that.(Principal)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	switch m.Identity.(type) {
+
+	case *Principal_SpiffeId:
+		if _, ok := target.Identity.(*Principal_SpiffeId); !ok {
+			return false
+		}
+
+		if strings.Compare(m.GetSpiffeId(), target.GetSpiffeId()) != 0 {
+			return false
+		}
+
+	case *Principal_Name:
+		if _, ok := target.Identity.(*Principal_Name); !ok {
+			return false
+		}
+
+		if h, ok := interface{}(m.GetName()).(equality.Equalizer); ok {
+			if !h.Equal(target.GetName()) {
+				return false
+			}
+		} else {
+			if !proto.Equal(m.GetName(), target.GetName()) {
+				return false
+			}
+		}
+
+	default:
+		// m is nil but target is not nil
+		if m.Identity != target.Identity {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,114 @@
+// Code generated by protoc-gen-ext. DO NOT EDIT.
+// source: github.com/solo-io/gloo/projects/gloo/api/v1/options/client_certificate/client_certificate.proto
+
+package client_certificate
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+
+	"github.com/mitchellh/hashstructure"
+	safe_hasher "github.com/solo-io/protoc-gen-ext/pkg/hasher"
+)
+
+// ensure the imports are used
+var (
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = binary.LittleEndian
+	_ = new(hash.Hash64)
+	_ = fnv.New64
+	_ = hashstructure.Hash
+	_ = new(safe_hasher.SafeHasher)
+)
+
+// Hash function
+func (m *ClientCertificateAuthorization) Hash(hasher hash.Hash64) (uint64, error) {
+	if m == nil {
+		return 0, nil
+	}
+	if hasher == nil {
+		hasher = fnv.New64()
+	}
+	var err error
+	if _, err = hasher.Write([]byte("client_certificate.options.gloo.solo.io.github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/client_certificate.ClientCertificateAuthorization")); err != nil {
+		return 0, err
+	}
+
+	for _, v := range m.GetPrincipals() {
+
+		if h, ok := interface{}(v).(safe_hasher.SafeHasher); ok {
+			if _, err = hasher.Write([]byte("")); err != nil {
+				return 0, err
+			}
+			if _, err = h.Hash(hasher); err != nil {
+				return 0, err
+			}
+		} else {
+			if fieldValue, err := hashstructure.Hash(v, nil); err != nil {
+				return 0, err
+			} else {
+				if _, err = hasher.Write([]byte("")); err != nil {
+					return 0, err
+				}
+				if err := binary.Write(hasher, binary.LittleEndian, fieldValue); err != nil {
+					return 0, err
+				}
+			}
+		}
+
+	}
+
+	return hasher.Sum64(), nil
+}
+
+// Hash function
+func (m *Principal) Hash(hasher hash.Hash64) (uint64, error) {
+	if m == nil {
+		return 0, nil
+	}
+	if hasher == nil {
+		hasher = fnv.New64()
+	}
+	var err error
+	if _, err = hasher.Write([]byte("client_certificate.options.gloo.solo.io.github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/client_certificate.Principal")); err != nil {
+		return 0, err
+	}
+
+	switch m.Identity.(type) {
+
+	case *Principal_SpiffeId:
+
+		if _, err = hasher.Write([]byte(m.GetSpiffeId())); err != nil {
+			return 0, err
+		}
+
+	case *Principal_Name:
+
+		if h, ok := interface{}(m.GetName()).(safe_hasher.SafeHasher); ok {
+			if _, err = hasher.Write([]byte("Name")); err != nil {
+				return 0, err
+			}
+			if _, err = h.Hash(hasher); err != nil {
+				return 0, err
+			}
+		} else {
+			if fieldValue, err := hashstructure.Hash(m.GetName(), nil); err != nil {
+				return 0, err
+			} else {
+				if _, err = hasher.Write([]byte("Name")); err != nil {
+					return 0, err
+				}
+				if err := binary.Write(hasher, binary.LittleEndian, fieldValue); err != nil {
+					return 0, err
+				}
+			}
+		}
+
+	}
+
+	return hasher.Sum64(), nil
+}
@@ -0,0 +1,55 @@
+// Code generated by protoc-gen-ext. DO NOT EDIT.
+// source: github.com/solo-io/gloo/projects/gloo/api/v1/options/http3/http3.proto
+
+package http3
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/solo-io/protoc-gen-ext/pkg/clone"
+	"google.golang.org/protobuf/proto"
+
+	github_com_golang_protobuf_ptypes_duration "github.com/golang/protobuf/ptypes/duration"
+
+	github_com_golang_protobuf_ptypes_wrappers "github.com/golang/protobuf/ptypes/wrappers"
+)
+
+// ensure the imports are used
+var (
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = binary.LittleEndian
+	_ = bytes.Compare
+	_ = strings.Compare
+	_ = clone.Cloner(nil)
+	_ = proto.Message(nil)
+)
+
+// Clone function
+func (m *Http3) Clone() proto.Message {
+	var target *Http3
+	if m == nil {
+		return target
+	}
+	target = &Http3{}
+
+	if h, ok := interface{}(m.GetAdvertisedPort()).(clone.Cloner); ok {
+		target.AdvertisedPort = h.Clone().(*github_com_golang_protobuf_ptypes_wrappers.UInt32Value)
+	} else {
+		target.AdvertisedPort = proto.Clone(m.GetAdvertisedPort()).(*github_com_golang_protobuf_ptypes_wrappers.UInt32Value)
+	}
+
+	if h, ok := interface{}(m.GetAltSvcMaxAge()).(clone.Cloner); ok {
+		target.AltSvcMaxAge = h.Clone().(*github_com_golang_protobuf_ptypes_duration.Duration)
+	} else {
+		target.AltSvcMaxAge = proto.Clone(m.GetAltSvcMaxAge()).(*github_com_golang_protobuf_ptypes_duration.Duration)
+	}
+
+	target.DisableAltSvc = m.GetDisableAltSvc()
+
+	return target
+}
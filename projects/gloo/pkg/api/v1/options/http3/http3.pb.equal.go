@@ -0,0 +1,74 @@
+// Code generated by protoc-gen-ext. DO NOT EDIT.
+// source: github.com/solo-io/gloo/projects/gloo/api/v1/options/http3/http3.proto
+
+package http3
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	equality "github.com/solo-io/protoc-gen-ext/pkg/equality"
+)
+
+// ensure the imports are used
+var (
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = binary.LittleEndian
+	_ = bytes.Compare
+	_ = strings.Compare
+	_ = equality.Equalizer(nil)
+	_ = proto.Message(nil)
+)
+
+// Equal function
+func (m *Http3) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*Http3)
+	if !ok {
+		that2, ok := that.(Http3)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if h, ok := interface{}(m.GetAdvertisedPort()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetAdvertisedPort()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetAdvertisedPort(), target.GetAdvertisedPort()) {
+			return false
+		}
+	}
+
+	if h, ok := interface{}(m.GetAltSvcMaxAge()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetAltSvcMaxAge()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetAltSvcMaxAge(), target.GetAltSvcMaxAge()) {
+			return false
+		}
+	}
+
+	if m.GetDisableAltSvc() != target.GetDisableAltSvc() {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,118 @@
+// Code generated by protoc-gen-ext. DO NOT EDIT.
+// source: github.com/solo-io/gloo/projects/gloo/api/v1/options/invalid_route/invalid_route.proto
+
+package invalid_route
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/solo-io/protoc-gen-ext/pkg/clone"
+	"google.golang.org/protobuf/proto"
+)
+
+// ensure the imports are used
+var (
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = binary.LittleEndian
+	_ = bytes.Compare
+	_ = strings.Compare
+	_ = clone.Cloner(nil)
+	_ = proto.Message(nil)
+)
+
+// Clone function
+func (m *InvalidRoutePolicy) Clone() proto.Message {
+	var target *InvalidRoutePolicy
+	if m == nil {
+		return target
+	}
+	target = &InvalidRoutePolicy{}
+
+	switch m.Policy.(type) {
+
+	case *InvalidRoutePolicy_DirectResponse_:
+
+		if h, ok := interface{}(m.GetDirectResponse()).(clone.Cloner); ok {
+			target.Policy = &InvalidRoutePolicy_DirectResponse_{
+				DirectResponse: h.Clone().(*InvalidRoutePolicy_DirectResponse),
+			}
+		} else {
+			target.Policy = &InvalidRoutePolicy_DirectResponse_{
+				DirectResponse: proto.Clone(m.GetDirectResponse()).(*InvalidRoutePolicy_DirectResponse),
+			}
+		}
+
+	case *InvalidRoutePolicy_Redirect_:
+
+		if h, ok := interface{}(m.GetRedirect()).(clone.Cloner); ok {
+			target.Policy = &InvalidRoutePolicy_Redirect_{
+				Redirect: h.Clone().(*InvalidRoutePolicy_Redirect),
+			}
+		} else {
+			target.Policy = &InvalidRoutePolicy_Redirect_{
+				Redirect: proto.Clone(m.GetRedirect()).(*InvalidRoutePolicy_Redirect),
+			}
+		}
+
+	case *InvalidRoutePolicy_KeepLastValidRoute_:
+
+		if h, ok := interface{}(m.GetKeepLastValidRoute()).(clone.Cloner); ok {
+			target.Policy = &InvalidRoutePolicy_KeepLastValidRoute_{
+				KeepLastValidRoute: h.Clone().(*InvalidRoutePolicy_KeepLastValidRoute),
+			}
+		} else {
+			target.Policy = &InvalidRoutePolicy_KeepLastValidRoute_{
+				KeepLastValidRoute: proto.Clone(m.GetKeepLastValidRoute()).(*InvalidRoutePolicy_KeepLastValidRoute),
+			}
+		}
+
+	}
+
+	return target
+}
+
+// Clone function
+func (m *InvalidRoutePolicy_DirectResponse) Clone() proto.Message {
+	var target *InvalidRoutePolicy_DirectResponse
+	if m == nil {
+		return target
+	}
+	target = &InvalidRoutePolicy_DirectResponse{}
+
+	target.Status = m.GetStatus()
+
+	target.Body = m.GetBody()
+
+	return target
+}
+
+// Clone function
+func (m *InvalidRoutePolicy_Redirect) Clone() proto.Message {
+	var target *InvalidRoutePolicy_Redirect
+	if m == nil {
+		return target
+	}
+	target = &InvalidRoutePolicy_Redirect{}
+
+	target.Url = m.GetUrl()
+
+	target.ResponseCode = m.GetResponseCode()
+
+	return target
+}
+
+// Clone function
+func (m *InvalidRoutePolicy_KeepLastValidRoute) Clone() proto.Message {
+	var target *InvalidRoutePolicy_KeepLastValidRoute
+	if m == nil {
+		return target
+	}
+	target = &InvalidRoutePolicy_KeepLastValidRoute{}
+
+	return target
+}
@@ -0,0 +1,192 @@
+// Code generated by protoc-gen-ext. DO NOT EDIT.
+// source: github.com/solo-io/gloo/projects/gloo/api/v1/options/invalid_route/invalid_route.proto
+
+package invalid_route
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	equality "github.com/solo-io/protoc-gen-ext/pkg/equality"
+)
+
+// ensure the imports are used
+var (
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = binary.LittleEndian
+	_ = bytes.Compare
+	_ = strings.Compare
+	_ = equality.Equalizer(nil)
+	_ = proto.Message(nil)
+)
+
+// Equal function
+func (m *InvalidRoutePolicy) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*InvalidRoutePolicy)
+	if !ok {
+		that2, ok := that.(InvalidRoutePolicy)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	switch m.Policy.(type) {
+
+	case *InvalidRoutePolicy_DirectResponse_:
+		if _, ok := target.Policy.(*InvalidRoutePolicy_DirectResponse_); !ok {
+			return false
+		}
+
+		if h, ok := interface{}(m.GetDirectResponse()).(equality.Equalizer); ok {
+			if !h.Equal(target.GetDirectResponse()) {
+				return false
+			}
+		} else {
+			if !proto.Equal(m.GetDirectResponse(), target.GetDirectResponse()) {
+				return false
+			}
+		}
+
+	case *InvalidRoutePolicy_Redirect_:
+		if _, ok := target.Policy.(*InvalidRoutePolicy_Redirect_); !ok {
+			return false
+		}
+
+		if h, ok := interface{}(m.GetRedirect()).(equality.Equalizer); ok {
+			if !h.Equal(target.GetRedirect()) {
+				return false
+			}
+		} else {
+			if !proto.Equal(m.GetRedirect(), target.GetRedirect()) {
+				return false
+			}
+		}
+
+	case *InvalidRoutePolicy_KeepLastValidRoute_:
+		if _, ok := target.Policy.(*InvalidRoutePolicy_KeepLastValidRoute_); !ok {
+			return false
+		}
+
+		if h, ok := interface{}(m.GetKeepLastValidRoute()).(equality.Equalizer); ok {
+			if !h.Equal(target.GetKeepLastValidRoute()) {
+				return false
+			}
+		} else {
+			if !proto.Equal(m.GetKeepLastValidRoute(), target.GetKeepLastValidRoute()) {
+				return false
+			}
+		}
+
+	default:
+		// m is nil but target is not nil
+		if m.Policy != target.Policy {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal function
+func (m *InvalidRoutePolicy_DirectResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*InvalidRoutePolicy_DirectResponse)
+	if !ok {
+		that2, ok := that.(InvalidRoutePolicy_DirectResponse)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if m.GetStatus() != target.GetStatus() {
+		return false
+	}
+
+	if strings.Compare(m.GetBody(), target.GetBody()) != 0 {
+		return false
+	}
+
+	return true
+}
+
+// Equal function
+func (m *InvalidRoutePolicy_Redirect) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*InvalidRoutePolicy_Redirect)
+	if !ok {
+		that2, ok := that.(InvalidRoutePolicy_Redirect)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if strings.Compare(m.GetUrl(), target.GetUrl()) != 0 {
+		return false
+	}
+
+	if m.GetResponseCode() != target.GetResponseCode() {
+		return false
+	}
+
+	return true
+}
+
+// Equal function
+func (m *InvalidRoutePolicy_KeepLastValidRoute) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*InvalidRoutePolicy_KeepLastValidRoute)
+	if !ok {
+		that2, ok := that.(InvalidRoutePolicy_KeepLastValidRoute)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	return true
+}
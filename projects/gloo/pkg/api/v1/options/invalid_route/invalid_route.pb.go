@@ -0,0 +1,443 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.6.1
+// source: github.com/solo-io/gloo/projects/gloo/api/v1/options/invalid_route/invalid_route.proto
+
+package invalid_route
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	_ "github.com/solo-io/protoc-gen-ext/extproto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Determines how Gloo replaces a route that has become invalid, e.g. because it points to an upstream that is
+// missing or has errors. Invalid routes are only replaced if `gloo.invalidConfigPolicy.replaceInvalidRoutes` is
+// enabled in the Settings. A policy defined on a route overrides the one defined on its virtual host, which in turn
+// overrides the global direct response configured in the Settings.
+type InvalidRoutePolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Policy:
+	//	*InvalidRoutePolicy_DirectResponse_
+	//	*InvalidRoutePolicy_Redirect_
+	//	*InvalidRoutePolicy_KeepLastValidRoute_
+	Policy isInvalidRoutePolicy_Policy `protobuf_oneof:"policy"`
+}
+
+func (x *InvalidRoutePolicy) Reset() {
+	*x = InvalidRoutePolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvalidRoutePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidRoutePolicy) ProtoMessage() {}
+
+func (x *InvalidRoutePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidRoutePolicy.ProtoReflect.Descriptor instead.
+func (*InvalidRoutePolicy) Descriptor() ([]byte, []int) {
+	return file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *InvalidRoutePolicy) GetPolicy() isInvalidRoutePolicy_Policy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (x *InvalidRoutePolicy) GetDirectResponse() *InvalidRoutePolicy_DirectResponse {
+	if x, ok := x.GetPolicy().(*InvalidRoutePolicy_DirectResponse_); ok {
+		return x.DirectResponse
+	}
+	return nil
+}
+
+func (x *InvalidRoutePolicy) GetRedirect() *InvalidRoutePolicy_Redirect {
+	if x, ok := x.GetPolicy().(*InvalidRoutePolicy_Redirect_); ok {
+		return x.Redirect
+	}
+	return nil
+}
+
+func (x *InvalidRoutePolicy) GetKeepLastValidRoute() *InvalidRoutePolicy_KeepLastValidRoute {
+	if x, ok := x.GetPolicy().(*InvalidRoutePolicy_KeepLastValidRoute_); ok {
+		return x.KeepLastValidRoute
+	}
+	return nil
+}
+
+type isInvalidRoutePolicy_Policy interface {
+	isInvalidRoutePolicy_Policy()
+}
+
+type InvalidRoutePolicy_DirectResponse_ struct {
+	// Respond to requests with the given status code and body.
+	DirectResponse *InvalidRoutePolicy_DirectResponse `protobuf:"bytes,1,opt,name=direct_response,json=directResponse,proto3,oneof"`
+}
+
+type InvalidRoutePolicy_Redirect_ struct {
+	// Redirect requests to the given URL, e.g. a status page.
+	Redirect *InvalidRoutePolicy_Redirect `protobuf:"bytes,2,opt,name=redirect,proto3,oneof"`
+}
+
+type InvalidRoutePolicy_KeepLastValidRoute_ struct {
+	// Keep serving the last valid version of the route, i.e. the last version with the same matcher that
+	// Gloo served before the route became invalid. If Gloo has not served a valid version of the route since it
+	// started, it falls back to the global direct response configured in the Settings.
+	KeepLastValidRoute *InvalidRoutePolicy_KeepLastValidRoute `protobuf:"bytes,3,opt,name=keep_last_valid_route,json=keepLastValidRoute,proto3,oneof"`
+}
+
+func (*InvalidRoutePolicy_DirectResponse_) isInvalidRoutePolicy_Policy() {}
+
+func (*InvalidRoutePolicy_Redirect_) isInvalidRoutePolicy_Policy() {}
+
+func (*InvalidRoutePolicy_KeepLastValidRoute_) isInvalidRoutePolicy_Policy() {}
+
+type InvalidRoutePolicy_DirectResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The HTTP status code to respond with.
+	Status uint32 `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The body of the response.
+	Body string `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *InvalidRoutePolicy_DirectResponse) Reset() {
+	*x = InvalidRoutePolicy_DirectResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvalidRoutePolicy_DirectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidRoutePolicy_DirectResponse) ProtoMessage() {}
+
+func (x *InvalidRoutePolicy_DirectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidRoutePolicy_DirectResponse.ProtoReflect.Descriptor instead.
+func (*InvalidRoutePolicy_DirectResponse) Descriptor() ([]byte, []int) {
+	return file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *InvalidRoutePolicy_DirectResponse) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *InvalidRoutePolicy_DirectResponse) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type InvalidRoutePolicy_Redirect struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The absolute URL to redirect to, e.g. `https://status.example.com/maintenance`.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// The HTTP status code of the redirect. Must be one of 301, 302, 303, 307 or 308. Defaults to 302.
+	ResponseCode uint32 `protobuf:"varint,2,opt,name=response_code,json=responseCode,proto3" json:"response_code,omitempty"`
+}
+
+func (x *InvalidRoutePolicy_Redirect) Reset() {
+	*x = InvalidRoutePolicy_Redirect{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvalidRoutePolicy_Redirect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidRoutePolicy_Redirect) ProtoMessage() {}
+
+func (x *InvalidRoutePolicy_Redirect) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidRoutePolicy_Redirect.ProtoReflect.Descriptor instead.
+func (*InvalidRoutePolicy_Redirect) Descriptor() ([]byte, []int) {
+	return file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *InvalidRoutePolicy_Redirect) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *InvalidRoutePolicy_Redirect) GetResponseCode() uint32 {
+	if x != nil {
+		return x.ResponseCode
+	}
+	return 0
+}
+
+type InvalidRoutePolicy_KeepLastValidRoute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *InvalidRoutePolicy_KeepLastValidRoute) Reset() {
+	*x = InvalidRoutePolicy_KeepLastValidRoute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvalidRoutePolicy_KeepLastValidRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidRoutePolicy_KeepLastValidRoute) ProtoMessage() {}
+
+func (x *InvalidRoutePolicy_KeepLastValidRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidRoutePolicy_KeepLastValidRoute.ProtoReflect.Descriptor instead.
+func (*InvalidRoutePolicy_KeepLastValidRoute) Descriptor() ([]byte, []int) {
+	return file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDescGZIP(), []int{0, 2}
+}
+
+var File_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto protoreflect.FileDescriptor
+
+var file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDesc = []byte{
+	0x0a, 0x56, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f, 0x6c,
+	0x6f, 0x2d, 0x69, 0x6f, 0x2f, 0x67, 0x6c, 0x6f, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x2f, 0x67, 0x6c, 0x6f, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x2f, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x22, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x67, 0x6c, 0x6f, 0x6f, 0x2e, 0x73, 0x6f, 0x6c, 0x6f, 0x2e, 0x69, 0x6f, 0x1a, 0x12, 0x65, 0x78,
+	0x74, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x78, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x86, 0x04, 0x0a, 0x12, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x70, 0x0a, 0x0f, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x45, 0x2e, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x67, 0x6c, 0x6f, 0x6f, 0x2e, 0x73, 0x6f,
+	0x6c, 0x6f, 0x2e, 0x69, 0x6f, 0x2e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x08, 0x72, 0x65, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3f, 0x2e, 0x69, 0x6e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x67, 0x6c, 0x6f, 0x6f, 0x2e, 0x73, 0x6f, 0x6c, 0x6f, 0x2e, 0x69, 0x6f,
+	0x2e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52, 0x08,
+	0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x12, 0x7e, 0x0a, 0x15, 0x6b, 0x65, 0x65, 0x70,
+	0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x49, 0x2e, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x67, 0x6c, 0x6f, 0x6f, 0x2e, 0x73, 0x6f, 0x6c, 0x6f, 0x2e, 0x69, 0x6f, 0x2e, 0x49, 0x6e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e,
+	0x4b, 0x65, 0x65, 0x70, 0x4c, 0x61, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x48, 0x00, 0x52, 0x12, 0x6b, 0x65, 0x65, 0x70, 0x4c, 0x61, 0x73, 0x74, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x1a, 0x3c, 0x0a, 0x0e, 0x44, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x1a, 0x41, 0x0a, 0x08, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x75, 0x72, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x1a, 0x14, 0x0a, 0x12, 0x4b, 0x65, 0x65,
+	0x70, 0x4c, 0x61, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x42,
+	0x08, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x42, 0x54, 0xb8, 0xf5, 0x04, 0x01, 0xc0,
+	0xf5, 0x04, 0x01, 0xd0, 0xf5, 0x04, 0x01, 0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f, 0x6c, 0x6f, 0x2d, 0x69, 0x6f, 0x2f, 0x67, 0x6c, 0x6f, 0x6f,
+	0x2f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2f, 0x67, 0x6c, 0x6f, 0x6f, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2f, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDescOnce sync.Once
+	file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDescData = file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDesc
+)
+
+func file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDescGZIP() []byte {
+	file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDescOnce.Do(func() {
+		file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDescData)
+	})
+	return file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDescData
+}
+
+var file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_goTypes = []interface{}{
+	(*InvalidRoutePolicy)(nil),                    // 0: invalid_route.options.gloo.solo.io.InvalidRoutePolicy
+	(*InvalidRoutePolicy_DirectResponse)(nil),     // 1: invalid_route.options.gloo.solo.io.InvalidRoutePolicy.DirectResponse
+	(*InvalidRoutePolicy_Redirect)(nil),           // 2: invalid_route.options.gloo.solo.io.InvalidRoutePolicy.Redirect
+	(*InvalidRoutePolicy_KeepLastValidRoute)(nil), // 3: invalid_route.options.gloo.solo.io.InvalidRoutePolicy.KeepLastValidRoute
+}
+var file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_depIdxs = []int32{
+	1, // 0: invalid_route.options.gloo.solo.io.InvalidRoutePolicy.direct_response:type_name -> invalid_route.options.gloo.solo.io.InvalidRoutePolicy.DirectResponse
+	2, // 1: invalid_route.options.gloo.solo.io.InvalidRoutePolicy.redirect:type_name -> invalid_route.options.gloo.solo.io.InvalidRoutePolicy.Redirect
+	3, // 2: invalid_route.options.gloo.solo.io.InvalidRoutePolicy.keep_last_valid_route:type_name -> invalid_route.options.gloo.solo.io.InvalidRoutePolicy.KeepLastValidRoute
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() {
+	file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_init()
+}
+func file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_init() {
+	if File_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvalidRoutePolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvalidRoutePolicy_DirectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvalidRoutePolicy_Redirect); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvalidRoutePolicy_KeepLastValidRoute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*InvalidRoutePolicy_DirectResponse_)(nil),
+		(*InvalidRoutePolicy_Redirect_)(nil),
+		(*InvalidRoutePolicy_KeepLastValidRoute_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_goTypes,
+		DependencyIndexes: file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_depIdxs,
+		MessageInfos:      file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_msgTypes,
+	}.Build()
+	File_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto = out.File
+	file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_rawDesc = nil
+	file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_goTypes = nil
+	file_github_com_solo_io_gloo_projects_gloo_api_v1_options_invalid_route_invalid_route_proto_depIdxs = nil
+}
@@ -0,0 +1,178 @@
+// Code generated by protoc-gen-ext. DO NOT EDIT.
+// source: github.com/solo-io/gloo/projects/gloo/api/v1/options/invalid_route/invalid_route.proto
+
+package invalid_route
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+
+	"github.com/mitchellh/hashstructure"
+	safe_hasher "github.com/solo-io/protoc-gen-ext/pkg/hasher"
+)
+
+// ensure the imports are used
+var (
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = binary.LittleEndian
+	_ = new(hash.Hash64)
+	_ = fnv.New64
+	_ = hashstructure.Hash
+	_ = new(safe_hasher.SafeHasher)
+)
+
+// Hash function
+func (m *InvalidRoutePolicy) Hash(hasher hash.Hash64) (uint64, error) {
+	if m == nil {
+		return 0, nil
+	}
+	if hasher == nil {
+		hasher = fnv.New64()
+	}
+	var err error
+	if _, err = hasher.Write([]byte("invalid_route.options.gloo.solo.io.github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/invalid_route.InvalidRoutePolicy")); err != nil {
+		return 0, err
+	}
+
+	switch m.Policy.(type) {
+
+	case *InvalidRoutePolicy_DirectResponse_:
+
+		if h, ok := interface{}(m.GetDirectResponse()).(safe_hasher.SafeHasher); ok {
+			if _, err = hasher.Write([]byte("DirectResponse")); err != nil {
+				return 0, err
+			}
+			if _, err = h.Hash(hasher); err != nil {
+				return 0, err
+			}
+		} else {
+			if fieldValue, err := hashstructure.Hash(m.GetDirectResponse(), nil); err != nil {
+				return 0, err
+			} else {
+				if _, err = hasher.Write([]byte("DirectResponse")); err != nil {
+					return 0, err
+				}
+				if err := binary.Write(hasher, binary.LittleEndian, fieldValue); err != nil {
+					return 0, err
+				}
+			}
+		}
+
+	case *InvalidRoutePolicy_Redirect_:
+
+		if h, ok := interface{}(m.GetRedirect()).(safe_hasher.SafeHasher); ok {
+			if _, err = hasher.Write([]byte("Redirect")); err != nil {
+				return 0, err
+			}
+			if _, err = h.Hash(hasher); err != nil {
+				return 0, err
+			}
+		} else {
+			if fieldValue, err := hashstructure.Hash(m.GetRedirect(), nil); err != nil {
+				return 0, err
+			} else {
+				if _, err = hasher.Write([]byte("Redirect")); err != nil {
+					return 0, err
+				}
+				if err := binary.Write(hasher, binary.LittleEndian, fieldValue); err != nil {
+					return 0, err
+				}
+			}
+		}
+
+	case *InvalidRoutePolicy_KeepLastValidRoute_:
+
+		if h, ok := interface{}(m.GetKeepLastValidRoute()).(safe_hasher.SafeHasher); ok {
+			if _, err = hasher.Write([]byte("KeepLastValidRoute")); err != nil {
+				return 0, err
+			}
+			if _, err = h.Hash(hasher); err != nil {
+				return 0, err
+			}
+		} else {
+			if fieldValue, err := hashstructure.Hash(m.GetKeepLastValidRoute(), nil); err != nil {
+				return 0, err
+			} else {
+				if _, err = hasher.Write([]byte("KeepLastValidRoute")); err != nil {
+					return 0, err
+				}
+				if err := binary.Write(hasher, binary.LittleEndian, fieldValue); err != nil {
+					return 0, err
+				}
+			}
+		}
+
+	}
+
+	return hasher.Sum64(), nil
+}
+
+// Hash function
+func (m *InvalidRoutePolicy_DirectResponse) Hash(hasher hash.Hash64) (uint64, error) {
+	if m == nil {
+		return 0, nil
+	}
+	if hasher == nil {
+		hasher = fnv.New64()
+	}
+	var err error
+	if _, err = hasher.Write([]byte("invalid_route.options.gloo.solo.io.github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/invalid_route.InvalidRoutePolicy_DirectResponse")); err != nil {
+		return 0, err
+	}
+
+	err = binary.Write(hasher, binary.LittleEndian, m.GetStatus())
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = hasher.Write([]byte(m.GetBody())); err != nil {
+		return 0, err
+	}
+
+	return hasher.Sum64(), nil
+}
+
+// Hash function
+func (m *InvalidRoutePolicy_Redirect) Hash(hasher hash.Hash64) (uint64, error) {
+	if m == nil {
+		return 0, nil
+	}
+	if hasher == nil {
+		hasher = fnv.New64()
+	}
+	var err error
+	if _, err = hasher.Write([]byte("invalid_route.options.gloo.solo.io.github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/invalid_route.InvalidRoutePolicy_Redirect")); err != nil {
+		return 0, err
+	}
+
+	if _, err = hasher.Write([]byte(m.GetUrl())); err != nil {
+		return 0, err
+	}
+
+	err = binary.Write(hasher, binary.LittleEndian, m.GetResponseCode())
+	if err != nil {
+		return 0, err
+	}
+
+	return hasher.Sum64(), nil
+}
+
+// Hash function
+func (m *InvalidRoutePolicy_KeepLastValidRoute) Hash(hasher hash.Hash64) (uint64, error) {
+	if m == nil {
+		return 0, nil
+	}
+	if hasher == nil {
+		hasher = fnv.New64()
+	}
+	var err error
+	if _, err = hasher.Write([]byte("invalid_route.options.gloo.solo.io.github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/invalid_route.InvalidRoutePolicy_KeepLastValidRoute")); err != nil {
+		return 0, err
+	}
+
+	return hasher.Sum64(), nil
+}
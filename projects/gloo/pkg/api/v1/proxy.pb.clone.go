@@ -249,6 +249,12 @@ func (m *TcpHost) Clone() proto.Message {
 		target.Destination = proto.Clone(m.GetDestination()).(*TcpHost_TcpAction)
 	}
 
+	if h, ok := interface{}(m.GetTlsPassthrough()).(clone.Cloner); ok {
+		target.TlsPassthrough = h.Clone().(*TcpHost_TlsPassthrough)
+	} else {
+		target.TlsPassthrough = proto.Clone(m.GetTlsPassthrough()).(*TcpHost_TlsPassthrough)
+	}
+
 	return target
 }
 
@@ -993,6 +999,26 @@ func (m *TcpHost_TcpAction) Clone() proto.Message {
 	return target
 }
 
+// Clone function
+func (m *TcpHost_TlsPassthrough) Clone() proto.Message {
+	var target *TcpHost_TlsPassthrough
+	if m == nil {
+		return target
+	}
+	target = &TcpHost_TlsPassthrough{}
+
+	if m.GetSniDomains() != nil {
+		target.SniDomains = make([]string, len(m.GetSniDomains()))
+		for idx, v := range m.GetSniDomains() {
+
+			target.SniDomains[idx] = v
+
+		}
+	}
+
+	return target
+}
+
 // Clone function
 func (m *SourceMetadata_SourceRef) Clone() proto.Message {
 	var target *SourceMetadata_SourceRef
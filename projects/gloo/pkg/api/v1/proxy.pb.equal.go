@@ -366,6 +366,16 @@ func (m *TcpHost) Equal(that interface{}) bool {
 		}
 	}
 
+	if h, ok := interface{}(m.GetTlsPassthrough()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetTlsPassthrough()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetTlsPassthrough(), target.GetTlsPassthrough()) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -1560,6 +1570,41 @@ func (m *TcpHost_TcpAction) Equal(that interface{}) bool {
 	return true
 }
 
+// Equal function
+func (m *TcpHost_TlsPassthrough) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*TcpHost_TlsPassthrough)
+	if !ok {
+		that2, ok := that.(TcpHost_TlsPassthrough)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if len(m.GetSniDomains()) != len(target.GetSniDomains()) {
+		return false
+	}
+	for idx, v := range m.GetSniDomains() {
+
+		if strings.Compare(v, target.GetSniDomains()[idx]) != 0 {
+			return false
+		}
+
+	}
+
+	return true
+}
+
 // Equal function
 func (m *SourceMetadata_SourceRef) Equal(that interface{}) bool {
 	if that == nil {
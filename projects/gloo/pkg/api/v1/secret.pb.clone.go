@@ -69,6 +69,18 @@ func (m *Secret) Clone() proto.Message {
 			}
 		}
 
+	case *Secret_Gcp:
+
+		if h, ok := interface{}(m.GetGcp()).(clone.Cloner); ok {
+			target.Kind = &Secret_Gcp{
+				Gcp: h.Clone().(*GcpSecret),
+			}
+		} else {
+			target.Kind = &Secret_Gcp{
+				Gcp: proto.Clone(m.GetGcp()).(*GcpSecret),
+			}
+		}
+
 	case *Secret_Tls:
 
 		if h, ok := interface{}(m.GetTls()).(clone.Cloner); ok {
@@ -171,6 +183,19 @@ func (m *AzureSecret) Clone() proto.Message {
 	return target
 }
 
+// Clone function
+func (m *GcpSecret) Clone() proto.Message {
+	var target *GcpSecret
+	if m == nil {
+		return target
+	}
+	target = &GcpSecret{}
+
+	target.ServiceAccountKey = m.GetServiceAccountKey()
+
+	return target
+}
+
 // Clone function
 func (m *TlsSecret) Clone() proto.Message {
 	var target *TlsSecret
@@ -185,6 +210,13 @@ func (m *TlsSecret) Clone() proto.Message {
 
 	target.RootCa = m.GetRootCa()
 
+	if m.GetOcspStaple() != nil {
+		target.OcspStaple = make([]byte, len(m.GetOcspStaple()))
+		copy(target.OcspStaple, m.GetOcspStaple())
+	}
+
+	target.Crl = m.GetCrl()
+
 	return target
 }
 
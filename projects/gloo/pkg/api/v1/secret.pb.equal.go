@@ -88,6 +88,21 @@ func (m *Secret) Equal(that interface{}) bool {
 			}
 		}
 
+	case *Secret_Gcp:
+		if _, ok := target.Kind.(*Secret_Gcp); !ok {
+			return false
+		}
+
+		if h, ok := interface{}(m.GetGcp()).(equality.Equalizer); ok {
+			if !h.Equal(target.GetGcp()) {
+				return false
+			}
+		} else {
+			if !proto.Equal(m.GetGcp(), target.GetGcp()) {
+				return false
+			}
+		}
+
 	case *Secret_Tls:
 		if _, ok := target.Kind.(*Secret_Tls); !ok {
 			return false
@@ -244,6 +259,34 @@ func (m *AzureSecret) Equal(that interface{}) bool {
 	return true
 }
 
+// Equal function
+func (m *GcpSecret) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*GcpSecret)
+	if !ok {
+		that2, ok := that.(GcpSecret)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if strings.Compare(m.GetServiceAccountKey(), target.GetServiceAccountKey()) != 0 {
+		return false
+	}
+
+	return true
+}
+
 // Equal function
 func (m *TlsSecret) Equal(that interface{}) bool {
 	if that == nil {
@@ -277,6 +320,14 @@ func (m *TlsSecret) Equal(that interface{}) bool {
 		return false
 	}
 
+	if bytes.Compare(m.GetOcspStaple(), target.GetOcspStaple()) != 0 {
+		return false
+	}
+
+	if strings.Compare(m.GetCrl(), target.GetCrl()) != 0 {
+		return false
+	}
+
 	return true
 }
 
@@ -104,6 +104,28 @@ func (m *Secret) Hash(hasher hash.Hash64) (uint64, error) {
 			}
 		}
 
+	case *Secret_Gcp:
+
+		if h, ok := interface{}(m.GetGcp()).(safe_hasher.SafeHasher); ok {
+			if _, err = hasher.Write([]byte("Gcp")); err != nil {
+				return 0, err
+			}
+			if _, err = h.Hash(hasher); err != nil {
+				return 0, err
+			}
+		} else {
+			if fieldValue, err := hashstructure.Hash(m.GetGcp(), nil); err != nil {
+				return 0, err
+			} else {
+				if _, err = hasher.Write([]byte("Gcp")); err != nil {
+					return 0, err
+				}
+				if err := binary.Write(hasher, binary.LittleEndian, fieldValue); err != nil {
+					return 0, err
+				}
+			}
+		}
+
 	case *Secret_Tls:
 
 		if h, ok := interface{}(m.GetTls()).(safe_hasher.SafeHasher); ok {
@@ -286,6 +308,26 @@ func (m *AzureSecret) Hash(hasher hash.Hash64) (uint64, error) {
 	return hasher.Sum64(), nil
 }
 
+// Hash function
+func (m *GcpSecret) Hash(hasher hash.Hash64) (uint64, error) {
+	if m == nil {
+		return 0, nil
+	}
+	if hasher == nil {
+		hasher = fnv.New64()
+	}
+	var err error
+	if _, err = hasher.Write([]byte("gloo.solo.io.github.com/solo-io/gloo/projects/gloo/pkg/api/v1.GcpSecret")); err != nil {
+		return 0, err
+	}
+
+	if _, err = hasher.Write([]byte(m.GetServiceAccountKey())); err != nil {
+		return 0, err
+	}
+
+	return hasher.Sum64(), nil
+}
+
 // Hash function
 func (m *TlsSecret) Hash(hasher hash.Hash64) (uint64, error) {
 	if m == nil {
@@ -311,6 +353,14 @@ func (m *TlsSecret) Hash(hasher hash.Hash64) (uint64, error) {
 		return 0, err
 	}
 
+	if _, err = hasher.Write(m.GetOcspStaple()); err != nil {
+		return 0, err
+	}
+
+	if _, err = hasher.Write([]byte(m.GetCrl())); err != nil {
+		return 0, err
+	}
+
 	return hasher.Sum64(), nil
 }
 
@@ -353,6 +353,18 @@ func (m *GlooOptions) Clone() proto.Message {
 		target.AwsOptions = proto.Clone(m.GetAwsOptions()).(*GlooOptions_AWSOptions)
 	}
 
+	if h, ok := interface{}(m.GetGcpOptions()).(clone.Cloner); ok {
+		target.GcpOptions = h.Clone().(*GlooOptions_GCPOptions)
+	} else {
+		target.GcpOptions = proto.Clone(m.GetGcpOptions()).(*GlooOptions_GCPOptions)
+	}
+
+	if h, ok := interface{}(m.GetAzureOptions()).(clone.Cloner); ok {
+		target.AzureOptions = h.Clone().(*GlooOptions_AzureOptions)
+	} else {
+		target.AzureOptions = proto.Clone(m.GetAzureOptions()).(*GlooOptions_AzureOptions)
+	}
+
 	if h, ok := interface{}(m.GetInvalidConfigPolicy()).(clone.Cloner); ok {
 		target.InvalidConfigPolicy = h.Clone().(*GlooOptions_InvalidConfigPolicy)
 	} else {
@@ -399,6 +411,12 @@ func (m *GlooOptions) Clone() proto.Message {
 		target.RemoveUnusedFilters = proto.Clone(m.GetRemoveUnusedFilters()).(*github_com_golang_protobuf_ptypes_wrappers.BoolValue)
 	}
 
+	if h, ok := interface{}(m.GetXdsSnapshotPersistence()).(clone.Cloner); ok {
+		target.XdsSnapshotPersistence = h.Clone().(*GlooOptions_XdsSnapshotPersistence)
+	} else {
+		target.XdsSnapshotPersistence = proto.Clone(m.GetXdsSnapshotPersistence()).(*GlooOptions_XdsSnapshotPersistence)
+	}
+
 	return target
 }
 
@@ -871,6 +889,51 @@ func (m *GlooOptions_AWSOptions) Clone() proto.Message {
 	return target
 }
 
+// Clone function
+func (m *GlooOptions_GCPOptions) Clone() proto.Message {
+	var target *GlooOptions_GCPOptions
+	if m == nil {
+		return target
+	}
+	target = &GlooOptions_GCPOptions{}
+
+	target.TokenUri = m.GetTokenUri()
+
+	if m.GetDiscoveryLocations() != nil {
+		target.DiscoveryLocations = make([]*GlooOptions_GCPOptions_DiscoveryLocation, len(m.GetDiscoveryLocations()))
+		for idx, v := range m.GetDiscoveryLocations() {
+
+			if h, ok := interface{}(v).(clone.Cloner); ok {
+				target.DiscoveryLocations[idx] = h.Clone().(*GlooOptions_GCPOptions_DiscoveryLocation)
+			} else {
+				target.DiscoveryLocations[idx] = proto.Clone(v).(*GlooOptions_GCPOptions_DiscoveryLocation)
+			}
+
+		}
+	}
+
+	if h, ok := interface{}(m.GetDiscoverySecretRef()).(clone.Cloner); ok {
+		target.DiscoverySecretRef = h.Clone().(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef)
+	} else {
+		target.DiscoverySecretRef = proto.Clone(m.GetDiscoverySecretRef()).(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef)
+	}
+
+	return target
+}
+
+// Clone function
+func (m *GlooOptions_AzureOptions) Clone() proto.Message {
+	var target *GlooOptions_AzureOptions
+	if m == nil {
+		return target
+	}
+	target = &GlooOptions_AzureOptions{}
+
+	target.TokenUri = m.GetTokenUri()
+
+	return target
+}
+
 // Clone function
 func (m *GlooOptions_InvalidConfigPolicy) Clone() proto.Message {
 	var target *GlooOptions_InvalidConfigPolicy
@@ -888,6 +951,48 @@ func (m *GlooOptions_InvalidConfigPolicy) Clone() proto.Message {
 	return target
 }
 
+// Clone function
+func (m *GlooOptions_XdsSnapshotPersistence) Clone() proto.Message {
+	var target *GlooOptions_XdsSnapshotPersistence
+	if m == nil {
+		return target
+	}
+	target = &GlooOptions_XdsSnapshotPersistence{}
+
+	switch m.Store.(type) {
+
+	case *GlooOptions_XdsSnapshotPersistence_Directory:
+
+		target.Store = &GlooOptions_XdsSnapshotPersistence_Directory{
+			Directory: m.GetDirectory(),
+		}
+
+	case *GlooOptions_XdsSnapshotPersistence_SecretNamespace:
+
+		target.Store = &GlooOptions_XdsSnapshotPersistence_SecretNamespace{
+			SecretNamespace: m.GetSecretNamespace(),
+		}
+
+	}
+
+	return target
+}
+
+// Clone function
+func (m *GlooOptions_GCPOptions_DiscoveryLocation) Clone() proto.Message {
+	var target *GlooOptions_GCPOptions_DiscoveryLocation
+	if m == nil {
+		return target
+	}
+	target = &GlooOptions_GCPOptions_DiscoveryLocation{}
+
+	target.Project = m.GetProject()
+
+	target.Region = m.GetRegion()
+
+	return target
+}
+
 // Clone function
 func (m *GatewayOptions_ValidationOptions) Clone() proto.Message {
 	var target *GatewayOptions_ValidationOptions
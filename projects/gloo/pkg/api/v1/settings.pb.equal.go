@@ -524,6 +524,26 @@ func (m *GlooOptions) Equal(that interface{}) bool {
 		}
 	}
 
+	if h, ok := interface{}(m.GetGcpOptions()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetGcpOptions()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetGcpOptions(), target.GetGcpOptions()) {
+			return false
+		}
+	}
+
+	if h, ok := interface{}(m.GetAzureOptions()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetAzureOptions()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetAzureOptions(), target.GetAzureOptions()) {
+			return false
+		}
+	}
+
 	if h, ok := interface{}(m.GetInvalidConfigPolicy()).(equality.Equalizer); ok {
 		if !h.Equal(target.GetInvalidConfigPolicy()) {
 			return false
@@ -602,6 +622,16 @@ func (m *GlooOptions) Equal(that interface{}) bool {
 		}
 	}
 
+	if h, ok := interface{}(m.GetXdsSnapshotPersistence()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetXdsSnapshotPersistence()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetXdsSnapshotPersistence(), target.GetXdsSnapshotPersistence()) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -1508,6 +1538,89 @@ func (m *GlooOptions_AWSOptions) Equal(that interface{}) bool {
 	return true
 }
 
+// Equal function
+func (m *GlooOptions_GCPOptions) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*GlooOptions_GCPOptions)
+	if !ok {
+		that2, ok := that.(GlooOptions_GCPOptions)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if strings.Compare(m.GetTokenUri(), target.GetTokenUri()) != 0 {
+		return false
+	}
+
+	if len(m.GetDiscoveryLocations()) != len(target.GetDiscoveryLocations()) {
+		return false
+	}
+	for idx, v := range m.GetDiscoveryLocations() {
+
+		if h, ok := interface{}(v).(equality.Equalizer); ok {
+			if !h.Equal(target.GetDiscoveryLocations()[idx]) {
+				return false
+			}
+		} else {
+			if !proto.Equal(v, target.GetDiscoveryLocations()[idx]) {
+				return false
+			}
+		}
+
+	}
+
+	if h, ok := interface{}(m.GetDiscoverySecretRef()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetDiscoverySecretRef()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetDiscoverySecretRef(), target.GetDiscoverySecretRef()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal function
+func (m *GlooOptions_AzureOptions) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*GlooOptions_AzureOptions)
+	if !ok {
+		that2, ok := that.(GlooOptions_AzureOptions)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if strings.Compare(m.GetTokenUri(), target.GetTokenUri()) != 0 {
+		return false
+	}
+
+	return true
+}
+
 // Equal function
 func (m *GlooOptions_InvalidConfigPolicy) Equal(that interface{}) bool {
 	if that == nil {
@@ -1544,6 +1657,89 @@ func (m *GlooOptions_InvalidConfigPolicy) Equal(that interface{}) bool {
 	return true
 }
 
+// Equal function
+func (m *GlooOptions_XdsSnapshotPersistence) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*GlooOptions_XdsSnapshotPersistence)
+	if !ok {
+		that2, ok := that.(GlooOptions_XdsSnapshotPersistence)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	switch m.Store.(type) {
+
+	case *GlooOptions_XdsSnapshotPersistence_Directory:
+		if _, ok := target.Store.(*GlooOptions_XdsSnapshotPersistence_Directory); !ok {
+			return false
+		}
+
+		if strings.Compare(m.GetDirectory(), target.GetDirectory()) != 0 {
+			return false
+		}
+
+	case *GlooOptions_XdsSnapshotPersistence_SecretNamespace:
+		if _, ok := target.Store.(*GlooOptions_XdsSnapshotPersistence_SecretNamespace); !ok {
+			return false
+		}
+
+		if strings.Compare(m.GetSecretNamespace(), target.GetSecretNamespace()) != 0 {
+			return false
+		}
+
+	default:
+		// m is nil but target is not nil
+		if m.Store != target.Store {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal function
+func (m *GlooOptions_GCPOptions_DiscoveryLocation) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*GlooOptions_GCPOptions_DiscoveryLocation)
+	if !ok {
+		that2, ok := that.(GlooOptions_GCPOptions_DiscoveryLocation)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if strings.Compare(m.GetProject(), target.GetProject()) != 0 {
+		return false
+	}
+
+	if strings.Compare(m.GetRegion(), target.GetRegion()) != 0 {
+		return false
+	}
+
+	return true
+}
+
 // Equal function
 func (m *GatewayOptions_ValidationOptions) Equal(that interface{}) bool {
 	if that == nil {
@@ -90,6 +90,33 @@ func (m *SslConfig) Clone() proto.Message {
 		target.TransportSocketConnectTimeout = proto.Clone(m.GetTransportSocketConnectTimeout()).(*github_com_golang_protobuf_ptypes_duration.Duration)
 	}
 
+	if m.GetAdditionalCertificates() != nil {
+		target.AdditionalCertificates = make([]*SslCertificate, len(m.GetAdditionalCertificates()))
+		for idx, v := range m.GetAdditionalCertificates() {
+
+			if h, ok := interface{}(v).(clone.Cloner); ok {
+				target.AdditionalCertificates[idx] = h.Clone().(*SslCertificate)
+			} else {
+				target.AdditionalCertificates[idx] = proto.Clone(v).(*SslCertificate)
+			}
+
+		}
+	}
+
+	target.OcspStaplePolicy = m.GetOcspStaplePolicy()
+
+	if h, ok := interface{}(m.GetRequireClientCertificate()).(clone.Cloner); ok {
+		target.RequireClientCertificate = h.Clone().(*github_com_golang_protobuf_ptypes_wrappers.BoolValue)
+	} else {
+		target.RequireClientCertificate = proto.Clone(m.GetRequireClientCertificate()).(*github_com_golang_protobuf_ptypes_wrappers.BoolValue)
+	}
+
+	if h, ok := interface{}(m.GetOnlyVerifyLeafCertCrl()).(clone.Cloner); ok {
+		target.OnlyVerifyLeafCertCrl = h.Clone().(*github_com_golang_protobuf_ptypes_wrappers.BoolValue)
+	} else {
+		target.OnlyVerifyLeafCertCrl = proto.Clone(m.GetOnlyVerifyLeafCertCrl()).(*github_com_golang_protobuf_ptypes_wrappers.BoolValue)
+	}
+
 	switch m.SslSecrets.(type) {
 
 	case *SslConfig_SecretRef:
@@ -128,8 +155,99 @@ func (m *SslConfig) Clone() proto.Message {
 			}
 		}
 
+	case *SslConfig_Acme:
+
+		if h, ok := interface{}(m.GetAcme()).(clone.Cloner); ok {
+			target.SslSecrets = &SslConfig_Acme{
+				Acme: h.Clone().(*AcmeConfig),
+			}
+		} else {
+			target.SslSecrets = &SslConfig_Acme{
+				Acme: proto.Clone(m.GetAcme()).(*AcmeConfig),
+			}
+		}
+
+	}
+
+	return target
+}
+
+// Clone function
+func (m *SslCertificate) Clone() proto.Message {
+	var target *SslCertificate
+	if m == nil {
+		return target
+	}
+	target = &SslCertificate{}
+
+	switch m.Source.(type) {
+
+	case *SslCertificate_SecretRef:
+
+		if h, ok := interface{}(m.GetSecretRef()).(clone.Cloner); ok {
+			target.Source = &SslCertificate_SecretRef{
+				SecretRef: h.Clone().(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef),
+			}
+		} else {
+			target.Source = &SslCertificate_SecretRef{
+				SecretRef: proto.Clone(m.GetSecretRef()).(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef),
+			}
+		}
+
+	case *SslCertificate_SslFiles:
+
+		if h, ok := interface{}(m.GetSslFiles()).(clone.Cloner); ok {
+			target.Source = &SslCertificate_SslFiles{
+				SslFiles: h.Clone().(*SSLFiles),
+			}
+		} else {
+			target.Source = &SslCertificate_SslFiles{
+				SslFiles: proto.Clone(m.GetSslFiles()).(*SSLFiles),
+			}
+		}
+
+	}
+
+	return target
+}
+
+// Clone function
+func (m *AcmeConfig) Clone() proto.Message {
+	var target *AcmeConfig
+	if m == nil {
+		return target
+	}
+	target = &AcmeConfig{}
+
+	target.DirectoryUrl = m.GetDirectoryUrl()
+
+	target.Email = m.GetEmail()
+
+	target.AcceptTermsOfService = m.GetAcceptTermsOfService()
+
+	if m.GetDomains() != nil {
+		target.Domains = make([]string, len(m.GetDomains()))
+		for idx, v := range m.GetDomains() {
+
+			target.Domains[idx] = v
+
+		}
+	}
+
+	if h, ok := interface{}(m.GetSecretRef()).(clone.Cloner); ok {
+		target.SecretRef = h.Clone().(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef)
+	} else {
+		target.SecretRef = proto.Clone(m.GetSecretRef()).(*github_com_solo_io_solo_kit_pkg_api_v1_resources_core.ResourceRef)
 	}
 
+	if h, ok := interface{}(m.GetRenewBefore()).(clone.Cloner); ok {
+		target.RenewBefore = h.Clone().(*github_com_golang_protobuf_ptypes_duration.Duration)
+	} else {
+		target.RenewBefore = proto.Clone(m.GetRenewBefore()).(*github_com_golang_protobuf_ptypes_duration.Duration)
+	}
+
+	target.DirectoryRootCa = m.GetDirectoryRootCa()
+
 	return target
 }
 
@@ -147,6 +265,10 @@ func (m *SSLFiles) Clone() proto.Message {
 
 	target.RootCa = m.GetRootCa()
 
+	target.OcspStaple = m.GetOcspStaple()
+
+	target.Crl = m.GetCrl()
+
 	return target
 }
 
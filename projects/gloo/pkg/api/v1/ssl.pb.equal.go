@@ -119,6 +119,47 @@ func (m *SslConfig) Equal(that interface{}) bool {
 		}
 	}
 
+	if len(m.GetAdditionalCertificates()) != len(target.GetAdditionalCertificates()) {
+		return false
+	}
+	for idx, v := range m.GetAdditionalCertificates() {
+
+		if h, ok := interface{}(v).(equality.Equalizer); ok {
+			if !h.Equal(target.GetAdditionalCertificates()[idx]) {
+				return false
+			}
+		} else {
+			if !proto.Equal(v, target.GetAdditionalCertificates()[idx]) {
+				return false
+			}
+		}
+
+	}
+
+	if m.GetOcspStaplePolicy() != target.GetOcspStaplePolicy() {
+		return false
+	}
+
+	if h, ok := interface{}(m.GetRequireClientCertificate()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetRequireClientCertificate()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetRequireClientCertificate(), target.GetRequireClientCertificate()) {
+			return false
+		}
+	}
+
+	if h, ok := interface{}(m.GetOnlyVerifyLeafCertCrl()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetOnlyVerifyLeafCertCrl()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetOnlyVerifyLeafCertCrl(), target.GetOnlyVerifyLeafCertCrl()) {
+			return false
+		}
+	}
+
 	switch m.SslSecrets.(type) {
 
 	case *SslConfig_SecretRef:
@@ -166,6 +207,21 @@ func (m *SslConfig) Equal(that interface{}) bool {
 			}
 		}
 
+	case *SslConfig_Acme:
+		if _, ok := target.SslSecrets.(*SslConfig_Acme); !ok {
+			return false
+		}
+
+		if h, ok := interface{}(m.GetAcme()).(equality.Equalizer); ok {
+			if !h.Equal(target.GetAcme()) {
+				return false
+			}
+		} else {
+			if !proto.Equal(m.GetAcme(), target.GetAcme()) {
+				return false
+			}
+		}
+
 	default:
 		// m is nil but target is not nil
 		if m.SslSecrets != target.SslSecrets {
@@ -176,6 +232,140 @@ func (m *SslConfig) Equal(that interface{}) bool {
 	return true
 }
 
+// Equal function
+func (m *SslCertificate) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*SslCertificate)
+	if !ok {
+		that2, ok := that.(SslCertificate)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	switch m.Source.(type) {
+
+	case *SslCertificate_SecretRef:
+		if _, ok := target.Source.(*SslCertificate_SecretRef); !ok {
+			return false
+		}
+
+		if h, ok := interface{}(m.GetSecretRef()).(equality.Equalizer); ok {
+			if !h.Equal(target.GetSecretRef()) {
+				return false
+			}
+		} else {
+			if !proto.Equal(m.GetSecretRef(), target.GetSecretRef()) {
+				return false
+			}
+		}
+
+	case *SslCertificate_SslFiles:
+		if _, ok := target.Source.(*SslCertificate_SslFiles); !ok {
+			return false
+		}
+
+		if h, ok := interface{}(m.GetSslFiles()).(equality.Equalizer); ok {
+			if !h.Equal(target.GetSslFiles()) {
+				return false
+			}
+		} else {
+			if !proto.Equal(m.GetSslFiles(), target.GetSslFiles()) {
+				return false
+			}
+		}
+
+	default:
+		// m is nil but target is not nil
+		if m.Source != target.Source {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal function
+func (m *AcmeConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return m == nil
+	}
+
+	target, ok := that.(*AcmeConfig)
+	if !ok {
+		that2, ok := that.(AcmeConfig)
+		if ok {
+			target = &that2
+		} else {
+			return false
+		}
+	}
+	if target == nil {
+		return m == nil
+	} else if m == nil {
+		return false
+	}
+
+	if strings.Compare(m.GetDirectoryUrl(), target.GetDirectoryUrl()) != 0 {
+		return false
+	}
+
+	if strings.Compare(m.GetEmail(), target.GetEmail()) != 0 {
+		return false
+	}
+
+	if m.GetAcceptTermsOfService() != target.GetAcceptTermsOfService() {
+		return false
+	}
+
+	if len(m.GetDomains()) != len(target.GetDomains()) {
+		return false
+	}
+	for idx, v := range m.GetDomains() {
+
+		if strings.Compare(v, target.GetDomains()[idx]) != 0 {
+			return false
+		}
+
+	}
+
+	if h, ok := interface{}(m.GetSecretRef()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetSecretRef()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetSecretRef(), target.GetSecretRef()) {
+			return false
+		}
+	}
+
+	if h, ok := interface{}(m.GetRenewBefore()).(equality.Equalizer); ok {
+		if !h.Equal(target.GetRenewBefore()) {
+			return false
+		}
+	} else {
+		if !proto.Equal(m.GetRenewBefore(), target.GetRenewBefore()) {
+			return false
+		}
+	}
+
+	if strings.Compare(m.GetDirectoryRootCa(), target.GetDirectoryRootCa()) != 0 {
+		return false
+	}
+
+	return true
+}
+
 // Equal function
 func (m *SSLFiles) Equal(that interface{}) bool {
 	if that == nil {
@@ -209,6 +399,14 @@ func (m *SSLFiles) Equal(that interface{}) bool {
 		return false
 	}
 
+	if strings.Compare(m.GetOcspStaple(), target.GetOcspStaple()) != 0 {
+		return false
+	}
+
+	if strings.Compare(m.GetCrl(), target.GetCrl()) != 0 {
+		return false
+	}
+
 	return true
 }
 
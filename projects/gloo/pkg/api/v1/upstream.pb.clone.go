@@ -27,6 +27,8 @@ import (
 
 	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_consul "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/consul"
 
+	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_gcp "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/gcp"
+
 	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_kubernetes "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/kubernetes"
 
 	github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_pipe "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/pipe"
@@ -244,6 +246,18 @@ func (m *Upstream) Clone() proto.Message {
 			}
 		}
 
+	case *Upstream_Gcp:
+
+		if h, ok := interface{}(m.GetGcp()).(clone.Cloner); ok {
+			target.UpstreamType = &Upstream_Gcp{
+				Gcp: h.Clone().(*github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_gcp.UpstreamSpec),
+			}
+		} else {
+			target.UpstreamType = &Upstream_Gcp{
+				Gcp: proto.Clone(m.GetGcp()).(*github_com_solo_io_gloo_projects_gloo_pkg_api_v1_options_gcp.UpstreamSpec),
+			}
+		}
+
 	}
 
 	return target
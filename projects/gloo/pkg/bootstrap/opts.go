@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/solo-io/gloo/projects/gloo/pkg/validation"
+	"github.com/solo-io/gloo/projects/gloo/pkg/xds"
 
 	"github.com/solo-io/gloo/projects/gloo/pkg/upstreams/consul"
 
@@ -57,8 +58,9 @@ type Consul struct {
 
 type ControlPlane struct {
 	*GrpcService
-	SnapshotCache cache.SnapshotCache
-	XDSServer     server.Server
+	SnapshotCache  cache.SnapshotCache
+	XDSServer      server.Server
+	XDSDeltaServer xds.DeltaServer
 }
 
 type ValidationServer struct {
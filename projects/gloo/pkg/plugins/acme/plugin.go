@@ -0,0 +1,155 @@
+package acme
+
+import (
+	"net"
+	"strings"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	glooacme "github.com/solo-io/gloo/projects/gloo/pkg/acme"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins"
+)
+
+var (
+	_ plugins.Plugin                  = new(plugin)
+	_ plugins.ResourceGeneratorPlugin = new(plugin)
+)
+
+const (
+	ExtensionName = "acme"
+)
+
+// The plugin answers the pending HTTP-01 challenges of the ACME certificate authorities by adding a direct response
+// route for each of them to the route configurations of the plaintext HTTP listeners.
+type plugin struct{}
+
+func NewPlugin() *plugin {
+	return &plugin{}
+}
+
+func (p *plugin) Name() string {
+	return ExtensionName
+}
+
+func (p *plugin) Init(_ plugins.InitParams) error {
+	return nil
+}
+
+func (p *plugin) GeneratedResources(params plugins.Params,
+	inClusters []*envoy_config_cluster_v3.Cluster,
+	inEndpoints []*envoy_config_endpoint_v3.ClusterLoadAssignment,
+	inRouteConfigurations []*envoy_config_route_v3.RouteConfiguration,
+	inListeners []*envoy_config_listener_v3.Listener,
+) ([]*envoy_config_cluster_v3.Cluster, []*envoy_config_endpoint_v3.ClusterLoadAssignment, []*envoy_config_route_v3.RouteConfiguration, []*envoy_config_listener_v3.Listener, error) {
+
+	challenges := glooacme.ChallengesFromSecrets(params.Snapshot.Secrets)
+	if len(challenges) == 0 {
+		return nil, nil, nil, nil, nil
+	}
+
+	plaintextRouteConfigs := plaintextRouteConfigNames(inListeners)
+	for _, rtConfig := range inRouteConfigurations {
+		if !plaintextRouteConfigs[rtConfig.GetName()] {
+			continue
+		}
+		for _, challenge := range challenges {
+			addChallengeRoute(rtConfig, challenge)
+		}
+	}
+
+	// the input route configurations are modified in place
+	return nil, nil, nil, nil, nil
+}
+
+// Returns the names of the route configurations served by the filter chains without TLS, which are the ones the
+// certificate authority sends the HTTP-01 challenge requests to.
+func plaintextRouteConfigNames(listeners []*envoy_config_listener_v3.Listener) map[string]bool {
+	names := map[string]bool{}
+	for _, listener := range listeners {
+		for _, filterChain := range listener.GetFilterChains() {
+			if filterChain.GetTransportSocket() != nil {
+				continue
+			}
+			for _, filter := range filterChain.GetFilters() {
+				if filter.GetName() != wellknown.HTTPConnectionManager {
+					continue
+				}
+				var hcm envoyhttp.HttpConnectionManager
+				if err := ptypes.UnmarshalAny(filter.GetTypedConfig(), &hcm); err != nil {
+					continue
+				}
+				if name := hcm.GetRds().GetRouteConfigName(); name != "" {
+					names[name] = true
+				}
+			}
+		}
+	}
+	return names
+}
+
+// Prepends the challenge route to the virtual hosts that serve the domain of the challenge, or adds a virtual host
+// for the domain if there is none.
+func addChallengeRoute(rtConfig *envoy_config_route_v3.RouteConfiguration, challenge glooacme.Challenge) {
+	route := challengeRoute(challenge)
+	var matched bool
+	for _, vh := range rtConfig.GetVirtualHosts() {
+		if !virtualHostMatches(vh, challenge.Domain) {
+			continue
+		}
+		vh.Routes = append([]*envoy_config_route_v3.Route{route}, vh.GetRoutes()...)
+		matched = true
+	}
+	if matched {
+		return
+	}
+	rtConfig.VirtualHosts = append(rtConfig.GetVirtualHosts(), &envoy_config_route_v3.VirtualHost{
+		Name:    "acme-challenge-" + challenge.Domain,
+		Domains: []string{challenge.Domain},
+		Routes:  []*envoy_config_route_v3.Route{route},
+	})
+}
+
+func challengeRoute(challenge glooacme.Challenge) *envoy_config_route_v3.Route {
+	return &envoy_config_route_v3.Route{
+		Name: "acme-challenge-" + challenge.Token,
+		Match: &envoy_config_route_v3.RouteMatch{
+			PathSpecifier: &envoy_config_route_v3.RouteMatch_Path{
+				Path: glooacme.ChallengePathPrefix + challenge.Token,
+			},
+		},
+		Action: &envoy_config_route_v3.Route_DirectResponse{
+			DirectResponse: &envoy_config_route_v3.DirectResponseAction{
+				Status: 200,
+				Body: &envoy_config_core_v3.DataSource{
+					Specifier: &envoy_config_core_v3.DataSource_InlineString{
+						InlineString: challenge.KeyAuthorization,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Envoy domains may be exact, or have a leading or a trailing wildcard, and may include a port.
+func virtualHostMatches(vh *envoy_config_route_v3.VirtualHost, domain string) bool {
+	for _, vhDomain := range vh.GetDomains() {
+		if host, _, err := net.SplitHostPort(vhDomain); err == nil {
+			vhDomain = host
+		}
+		switch {
+		case vhDomain == "*" || vhDomain == domain:
+			return true
+		case strings.HasPrefix(vhDomain, "*") && strings.HasSuffix(domain, vhDomain[1:]):
+			return true
+		case strings.HasSuffix(vhDomain, "*") && strings.HasPrefix(domain, vhDomain[:len(vhDomain)-1]):
+			return true
+		}
+	}
+	return false
+}
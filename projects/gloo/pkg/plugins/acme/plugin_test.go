@@ -0,0 +1,135 @@
+package acme_test
+
+import (
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	glooacme "github.com/solo-io/gloo/projects/gloo/pkg/acme"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	v1snap "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/gloosnapshot"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins/acme"
+	"github.com/solo-io/gloo/projects/gloo/pkg/utils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+)
+
+var _ = Describe("Plugin", func() {
+
+	var (
+		params                plugins.Params
+		inRouteConfigurations []*envoy_config_route_v3.RouteConfiguration
+		inListeners           []*envoy_config_listener_v3.Listener
+	)
+
+	hcmFilterChain := func(routeConfigName string, tls bool) *envoy_config_listener_v3.FilterChain {
+		typedConfig, err := utils.MessageToAny(&envoyhttp.HttpConnectionManager{
+			RouteSpecifier: &envoyhttp.HttpConnectionManager_Rds{
+				Rds: &envoyhttp.Rds{RouteConfigName: routeConfigName},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		filterChain := &envoy_config_listener_v3.FilterChain{
+			Filters: []*envoy_config_listener_v3.Filter{{
+				Name:       wellknown.HTTPConnectionManager,
+				ConfigType: &envoy_config_listener_v3.Filter_TypedConfig{TypedConfig: typedConfig},
+			}},
+		}
+		if tls {
+			filterChain.TransportSocket = &envoy_config_core_v3.TransportSocket{Name: wellknown.TransportSocketTls}
+		}
+		return filterChain
+	}
+
+	routeNames := func(vh *envoy_config_route_v3.VirtualHost) []string {
+		var names []string
+		for _, route := range vh.GetRoutes() {
+			names = append(names, route.GetName())
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		params = plugins.Params{
+			Snapshot: &v1snap.ApiSnapshot{
+				Secrets: v1.SecretList{{
+					Metadata: &core.Metadata{
+						Name:      "example-acme",
+						Namespace: "gloo-system",
+						Annotations: map[string]string{
+							glooacme.ChallengesAnnotation: `[{"domain":"example.com","token":"tok","keyAuthorization":"tok.thumbprint"}]`,
+						},
+					},
+					Kind: &v1.Secret_Tls{Tls: &v1.TlsSecret{}},
+				}},
+			},
+		}
+
+		inListeners = []*envoy_config_listener_v3.Listener{
+			{Name: "listener-::-8080", FilterChains: []*envoy_config_listener_v3.FilterChain{hcmFilterChain("listener-::-8080-routes", false)}},
+			{Name: "listener-::-8443", FilterChains: []*envoy_config_listener_v3.FilterChain{hcmFilterChain("listener-::-8443-routes", true)}},
+		}
+
+		inRouteConfigurations = []*envoy_config_route_v3.RouteConfiguration{
+			{
+				Name: "listener-::-8080-routes",
+				VirtualHosts: []*envoy_config_route_v3.VirtualHost{
+					{Name: "gloo-system_example", Domains: []string{"example.com:8080", "example.com"}, Routes: []*envoy_config_route_v3.Route{{Name: "existing"}}},
+					{Name: "gloo-system_other", Domains: []string{"other.com"}, Routes: []*envoy_config_route_v3.Route{{Name: "existing"}}},
+				},
+			},
+			{
+				Name: "listener-::-8443-routes",
+				VirtualHosts: []*envoy_config_route_v3.VirtualHost{
+					{Name: "gloo-system_example", Domains: []string{"example.com"}, Routes: []*envoy_config_route_v3.Route{{Name: "existing"}}},
+				},
+			},
+		}
+	})
+
+	It("prepends the challenge route to the matching virtual hosts of the plaintext listeners", func() {
+		_, _, _, _, err := acme.NewPlugin().GeneratedResources(params, nil, nil, inRouteConfigurations, inListeners)
+		Expect(err).NotTo(HaveOccurred())
+
+		plaintext := inRouteConfigurations[0]
+		Expect(routeNames(plaintext.GetVirtualHosts()[0])).To(Equal([]string{"acme-challenge-tok", "existing"}))
+		Expect(routeNames(plaintext.GetVirtualHosts()[1])).To(Equal([]string{"existing"}))
+		Expect(plaintext.GetVirtualHosts()).To(HaveLen(2))
+
+		route := plaintext.GetVirtualHosts()[0].GetRoutes()[0]
+		Expect(route.GetMatch().GetPath()).To(Equal("/.well-known/acme-challenge/tok"))
+		Expect(route.GetDirectResponse().GetStatus()).To(Equal(uint32(200)))
+		Expect(route.GetDirectResponse().GetBody().GetInlineString()).To(Equal("tok.thumbprint"))
+
+		By("leaving the TLS listeners untouched")
+		Expect(routeNames(inRouteConfigurations[1].GetVirtualHosts()[0])).To(Equal([]string{"existing"}))
+	})
+
+	It("matches wildcard domains", func() {
+		inRouteConfigurations[0].GetVirtualHosts()[0].Domains = []string{"*.com"}
+		_, _, _, _, err := acme.NewPlugin().GeneratedResources(params, nil, nil, inRouteConfigurations, inListeners)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routeNames(inRouteConfigurations[0].GetVirtualHosts()[0])).To(Equal([]string{"acme-challenge-tok", "existing"}))
+	})
+
+	It("adds a virtual host for the domain when no virtual host serves it", func() {
+		inRouteConfigurations[0].VirtualHosts = inRouteConfigurations[0].GetVirtualHosts()[1:]
+		_, _, _, _, err := acme.NewPlugin().GeneratedResources(params, nil, nil, inRouteConfigurations, inListeners)
+		Expect(err).NotTo(HaveOccurred())
+
+		vhs := inRouteConfigurations[0].GetVirtualHosts()
+		Expect(vhs).To(HaveLen(2))
+		Expect(vhs[1].GetDomains()).To(Equal([]string{"example.com"}))
+		Expect(routeNames(vhs[1])).To(Equal([]string{"acme-challenge-tok"}))
+	})
+
+	It("does nothing without pending challenges", func() {
+		params.Snapshot.Secrets[0].GetMetadata().Annotations = nil
+		_, _, _, _, err := acme.NewPlugin().GeneratedResources(params, nil, nil, inRouteConfigurations, inListeners)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routeNames(inRouteConfigurations[0].GetVirtualHosts()[0])).To(Equal([]string{"existing"}))
+	})
+})
@@ -0,0 +1,263 @@
+package aws
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/aws"
+	"github.com/solo-io/gloo/projects/gloo/pkg/translator"
+	"github.com/solo-io/solo-kit/pkg/errors"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	AWS_WEB_IDENTITY_TOKEN_FILE = "AWS_WEB_IDENTITY_TOKEN_FILE"
+	AWS_ROLE_ARN                = "AWS_ROLE_ARN"
+
+	// the role session name used when assuming a role, if the upstream does not specify one
+	defaultRoleSessionName = "gloo"
+	stsTimeout             = 5 * time.Second
+
+	// the credentials of the assumed roles are refreshed when they expire in less than this margin
+	assumedRoleRefreshMargin = 5 * time.Minute
+	// the minimum delay between the refreshes, in case sts returns short-lived credentials
+	minAssumedRoleRefreshDelay = time.Minute
+	// the delays before assuming a role again after a failure, doubled on each consecutive failure
+	minAssumedRoleRetryDelay = 5 * time.Second
+	maxAssumedRoleRetryDelay = 5 * time.Minute
+)
+
+// The roles that the AWS upstreams chain to are assumed by gloo with STS, and the temporary credentials are set on
+// the clusters of the upstreams, since Envoy cannot assume a role with the credentials of an upstream.
+// DefaultAssumedRoleCache signals Refreshes when the credentials of a role are about to expire, or when a role can be
+// assumed again after a failure, so that gloo translates the proxies again and the clusters are updated with new
+// credentials before the old ones expire.
+var DefaultAssumedRoleCache = NewAssumedRoleCache()
+
+// The credentials a role is assumed with. The static credentials of the secret of the upstream are used if they are
+// set, otherwise the ones of gloo.
+type baseCredentials struct {
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	// the role assumed with the web identity token of gloo before assuming the role of the upstream, if any
+	webIdentityRoleArn string
+}
+
+type assumedRoleCacheKey struct {
+	// the access keys of temporary credentials are unique as well, so the access key identifies the credentials
+	accessKey          string
+	webIdentityRoleArn string
+	region             string
+	roleArn            string
+	externalId         string
+	sessionName        string
+}
+
+func (k assumedRoleCacheKey) String() string {
+	return strings.Join([]string{k.accessKey, k.webIdentityRoleArn, k.region, k.roleArn, k.externalId, k.sessionName}, " ")
+}
+
+type assumedRole struct {
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	expiration   time.Time
+}
+
+type assumedRoleFailure struct {
+	err     error
+	retryAt time.Time
+	// the delay before the next retry, if this one fails as well
+	nextDelay time.Duration
+}
+
+type AssumedRoleCache struct {
+	lock     sync.Mutex
+	roles    map[assumedRoleCacheKey]*assumedRole
+	failures map[assumedRoleCacheKey]*assumedRoleFailure
+	// the translations which need a role that is being assumed wait for it, rather than assuming it as well
+	assuming  singleflight.Group
+	refreshes chan struct{}
+	// assumes the role with sts, overridden in tests
+	assume func(ctx context.Context, base baseCredentials, region string, assumeRole *aws.AssumeRoleCredentials) (*assumedRole, error)
+}
+
+func NewAssumedRoleCache() *AssumedRoleCache {
+	return &AssumedRoleCache{
+		roles:    make(map[assumedRoleCacheKey]*assumedRole),
+		failures: make(map[assumedRoleCacheKey]*assumedRoleFailure),
+		// a single pending refresh is enough to translate the proxies again
+		refreshes: make(chan struct{}, 1),
+		assume:    assumeRoleWithSts,
+	}
+}
+
+// The credentials of the role, assumed with the base credentials if they are not cached or expire soon.
+// Failures are cached until the role can be assumed again.
+func (c *AssumedRoleCache) credentials(ctx context.Context, base baseCredentials, region string, assumeRole *aws.AssumeRoleCredentials) (*assumedRole, error) {
+	cacheKey := assumedRoleCacheKey{
+		accessKey:          base.accessKey,
+		webIdentityRoleArn: base.webIdentityRoleArn,
+		region:             region,
+		roleArn:            assumeRole.GetRoleArn(),
+		externalId:         assumeRole.GetExternalId(),
+		sessionName:        assumeRole.GetSessionName(),
+	}
+
+	if role, err, ok := c.cached(cacheKey); ok {
+		return role, err
+	}
+
+	role, err, _ := c.assuming.Do(cacheKey.String(), func() (interface{}, error) {
+		// the role may have been assumed while waiting for the previous call to complete
+		if role, err, ok := c.cached(cacheKey); ok {
+			return role, err
+		}
+		role, err := c.assume(ctx, base, region, assumeRole)
+		if err == nil && role.expiration.IsZero() {
+			err = errors.Errorf("the credentials do not expire")
+		}
+		if err != nil {
+			err = AssumeRoleError(assumeRole.GetRoleArn(), err)
+			c.fail(cacheKey, err)
+			return nil, err
+		}
+		c.store(cacheKey, role)
+		return role, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return role.(*assumedRole), nil
+}
+
+// Returns the cached credentials, or the cached failure, and whether any is still valid.
+func (c *AssumedRoleCache) cached(cacheKey assumedRoleCacheKey) (*assumedRole, error, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if role, ok := c.roles[cacheKey]; ok && time.Until(role.expiration) > assumedRoleRefreshMargin {
+		return role, nil, true
+	}
+	if failure, ok := c.failures[cacheKey]; ok && time.Now().Before(failure.retryAt) {
+		return nil, failure.err, true
+	}
+	return nil, nil, false
+}
+
+func (c *AssumedRoleCache) store(cacheKey assumedRoleCacheKey, role *assumedRole) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// drop the credentials that have expired, e.g. the ones of deleted upstreams
+	now := time.Now()
+	for k, cached := range c.roles {
+		if cached.expiration.Before(now) {
+			delete(c.roles, k)
+		}
+	}
+	c.roles[cacheKey] = role
+	delete(c.failures, cacheKey)
+	refreshDelay := time.Until(role.expiration) - assumedRoleRefreshMargin
+	if refreshDelay < minAssumedRoleRefreshDelay {
+		refreshDelay = minAssumedRoleRefreshDelay
+	}
+	time.AfterFunc(refreshDelay, c.signalRefresh)
+}
+
+func (c *AssumedRoleCache) fail(cacheKey assumedRoleCacheKey, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	delay := minAssumedRoleRetryDelay
+	if failure, ok := c.failures[cacheKey]; ok {
+		delay = failure.nextDelay
+	}
+	// drop the failures that have not been retried for a while, e.g. the ones of deleted upstreams
+	for k, failure := range c.failures {
+		if failure.retryAt.Add(maxAssumedRoleRetryDelay).Before(now) {
+			delete(c.failures, k)
+		}
+	}
+	nextDelay := 2 * delay
+	if nextDelay > maxAssumedRoleRetryDelay {
+		nextDelay = maxAssumedRoleRetryDelay
+	}
+	c.failures[cacheKey] = &assumedRoleFailure{
+		err:       err,
+		retryAt:   now.Add(delay),
+		nextDelay: nextDelay,
+	}
+	time.AfterFunc(delay, c.signalRefresh)
+}
+
+// Signals that the credentials of an assumed role expire soon, or that a failed role can be assumed again.
+func (c *AssumedRoleCache) Refreshes() <-chan struct{} {
+	return c.refreshes
+}
+
+func (c *AssumedRoleCache) signalRefresh() {
+	// the translations which used the credentials must not be reused
+	translator.InvalidateTranslations()
+	select {
+	case c.refreshes <- struct{}{}:
+	default:
+	}
+}
+
+// Assumes the role with the base credentials, like function discovery does.
+// See https://docs.aws.amazon.com/IAM/latest/UserGuide/id_roles_terms-and-concepts.html#iam-term-role-chaining
+func assumeRoleWithSts(ctx context.Context, base baseCredentials, region string, assumeRole *aws.AssumeRoleCredentials) (*assumedRole, error) {
+	ctx, cancel := context.WithTimeout(ctx, stsTimeout)
+	defer cancel()
+
+	config := awssdk.NewConfig().WithRegion(region)
+	if base.accessKey != "" {
+		config = config.WithCredentials(credentials.NewStaticCredentials(base.accessKey, base.secretKey, base.sessionToken))
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating aws session")
+	}
+	if base.webIdentityRoleArn != "" {
+		webIdentity := stscreds.NewWebIdentityCredentials(sess, base.webIdentityRoleArn, "", os.Getenv(AWS_WEB_IDENTITY_TOKEN_FILE))
+		sess = sess.Copy(awssdk.NewConfig().WithCredentials(webIdentity))
+	}
+
+	input := &sts.AssumeRoleInput{
+		RoleArn:         awssdk.String(assumeRole.GetRoleArn()),
+		RoleSessionName: awssdk.String(defaultRoleSessionName),
+	}
+	if assumeRole.GetSessionName() != "" {
+		input.RoleSessionName = awssdk.String(assumeRole.GetSessionName())
+	}
+	if assumeRole.GetExternalId() != "" {
+		input.ExternalId = awssdk.String(assumeRole.GetExternalId())
+	}
+	output, err := sts.New(sess).AssumeRoleWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if output.Credentials == nil {
+		return nil, errors.Errorf("the response has no credentials")
+	}
+	return &assumedRole{
+		accessKey:    awssdk.StringValue(output.Credentials.AccessKeyId),
+		secretKey:    awssdk.StringValue(output.Credentials.SecretAccessKey),
+		sessionToken: awssdk.StringValue(output.Credentials.SessionToken),
+		expiration:   awssdk.TimeValue(output.Credentials.Expiration),
+	}, nil
+}
+
+var (
+	AssumeRoleError = func(roleArn string, err error) error {
+		return errors.Wrapf(err, "assuming the aws role %s", roleArn)
+	}
+)
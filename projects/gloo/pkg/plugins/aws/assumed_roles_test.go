@@ -0,0 +1,167 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/solo-io/gloo/projects/gloo/pkg/api/external/envoy/extensions/aws"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	v1snap "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/gloosnapshot"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/aws"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	"github.com/solo-io/solo-kit/pkg/errors"
+)
+
+var _ = Describe("assumed roles", func() {
+
+	var (
+		ctx        context.Context
+		cache      *AssumedRoleCache
+		assumeRole *aws.AssumeRoleCredentials
+
+		assumed    []baseCredentials
+		expiration time.Duration
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cache = NewAssumedRoleCache()
+		assumeRole = &aws.AssumeRoleCredentials{
+			RoleArn:    "arn:aws:iam::210987654321:role/lambda-invoker",
+			ExternalId: "team-a",
+		}
+
+		assumed = nil
+		expiration = time.Hour
+		cache.assume = func(_ context.Context, base baseCredentials, _ string, _ *aws.AssumeRoleCredentials) (*assumedRole, error) {
+			assumed = append(assumed, base)
+			return &assumedRole{
+				accessKey:    "assumed-access-key",
+				secretKey:    "assumed-secret-key",
+				sessionToken: "assumed-session-token",
+				expiration:   time.Now().Add(expiration),
+			}, nil
+		}
+	})
+
+	Context("cache", func() {
+
+		It("should reuse the credentials while they are valid", func() {
+			for i := 0; i < 3; i++ {
+				role, err := cache.credentials(ctx, baseCredentials{accessKey: "access-key"}, "us-east-1", assumeRole)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(role.accessKey).To(Equal("assumed-access-key"))
+			}
+			Expect(assumed).To(HaveLen(1))
+		})
+
+		It("should assume the role for each set of base credentials", func() {
+			_, err := cache.credentials(ctx, baseCredentials{accessKey: "access-key"}, "us-east-1", assumeRole)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cache.credentials(ctx, baseCredentials{accessKey: "other-access-key"}, "us-east-1", assumeRole)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(assumed).To(HaveLen(2))
+		})
+
+		It("should assume the role again when the credentials expire soon", func() {
+			expiration = assumedRoleRefreshMargin - time.Second
+			_, err := cache.credentials(ctx, baseCredentials{accessKey: "access-key"}, "us-east-1", assumeRole)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cache.credentials(ctx, baseCredentials{accessKey: "access-key"}, "us-east-1", assumeRole)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(assumed).To(HaveLen(2))
+		})
+
+		It("should not assume the role again until the failure can be retried", func() {
+			cache.assume = func(_ context.Context, base baseCredentials, _ string, _ *aws.AssumeRoleCredentials) (*assumedRole, error) {
+				assumed = append(assumed, base)
+				return nil, errors.Errorf("access denied")
+			}
+			for i := 0; i < 3; i++ {
+				_, err := cache.credentials(ctx, baseCredentials{accessKey: "access-key"}, "us-east-1", assumeRole)
+				Expect(err).To(MatchError(ContainSubstring("assuming the aws role arn:aws:iam::210987654321:role/lambda-invoker: access denied")))
+			}
+			Expect(assumed).To(HaveLen(1))
+
+			By("assuming the role again once the failure can be retried")
+			for _, failure := range cache.failures {
+				failure.retryAt = time.Now()
+			}
+			_, err := cache.credentials(ctx, baseCredentials{accessKey: "access-key"}, "us-east-1", assumeRole)
+			Expect(err).To(HaveOccurred())
+			Expect(assumed).To(HaveLen(2))
+
+			By("backing off after consecutive failures")
+			for _, failure := range cache.failures {
+				Expect(failure.retryAt).To(BeTemporally("~", time.Now().Add(2*minAssumedRoleRetryDelay), time.Second))
+			}
+		})
+	})
+
+	Context("plugin", func() {
+
+		var (
+			p        *plugin
+			params   plugins.Params
+			upstream *v1.Upstream
+			out      *envoy_config_cluster_v3.Cluster
+		)
+
+		BeforeEach(func() {
+			p = NewPlugin().(*plugin)
+			p.assumedRoles = cache
+			upstream = &v1.Upstream{
+				Metadata: &core.Metadata{Name: "up", Namespace: "ns"},
+				UpstreamType: &v1.Upstream_Aws{
+					Aws: &aws.UpstreamSpec{
+						Region:     "us-east-1",
+						SecretRef:  &core.ResourceRef{Name: "secretref", Namespace: "ns"},
+						RoleArn:    "arn:aws:iam::123456789012:role/team-a",
+						AssumeRole: assumeRole,
+					},
+				},
+			}
+			params = plugins.Params{
+				Ctx: ctx,
+				Snapshot: &v1snap.ApiSnapshot{
+					Secrets: v1.SecretList{{
+						Metadata: &core.Metadata{Name: "secretref", Namespace: "ns"},
+						Kind: &v1.Secret_Aws{
+							Aws: &v1.AwsSecret{AccessKey: "access-key", SecretKey: "secret-key"},
+						},
+					}},
+				},
+			}
+			out = &envoy_config_cluster_v3.Cluster{}
+			err := p.Init(plugins.InitParams{Ctx: ctx, Settings: &v1.Settings{}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should send envoy the credentials of the role assumed with the credentials of the upstream", func() {
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(assumed).To(ConsistOf(baseCredentials{accessKey: "access-key", secretKey: "secret-key"}))
+
+			var lpe AWSLambdaProtocolExtension
+			err = proto.Unmarshal(out.GetTypedExtensionProtocolOptions()[FilterName].GetValue(), &lpe)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lpe.GetAccessKey()).To(Equal("assumed-access-key"))
+			Expect(lpe.GetSecretKey()).To(Equal("assumed-secret-key"))
+			Expect(lpe.GetSessionToken()).To(Equal("assumed-session-token"))
+			Expect(lpe.GetRoleArn()).To(BeEmpty())
+		})
+
+		It("should error when the role cannot be assumed", func() {
+			cache.assume = func(_ context.Context, _ baseCredentials, _ string, _ *aws.AssumeRoleCredentials) (*assumedRole, error) {
+				return nil, errors.Errorf("access denied")
+			}
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).To(MatchError(ContainSubstring("assuming the aws role arn:aws:iam::210987654321:role/lambda-invoker")))
+		})
+	})
+})
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
+	"strings"
 	"unicode/utf8"
 
 	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
@@ -54,10 +56,11 @@ type plugin struct {
 	settings           *v1.GlooOptions_AWSOptions
 	upstreamOptions    *v1.UpstreamOptions
 	needTransformation bool
+	assumedRoles       *AssumedRoleCache
 }
 
 func NewPlugin() plugins.Plugin {
-	return &plugin{}
+	return &plugin{assumedRoles: DefaultAssumedRoleCache}
 }
 
 func (p *plugin) Name() string {
@@ -77,6 +80,22 @@ func getLambdaHostname(s *aws.UpstreamSpec) string {
 	return fmt.Sprintf("lambda.%s.amazonaws.com", s.GetRegion())
 }
 
+// The host of the function URL of the upstream, which must be in the region of the upstream.
+func getFunctionUrlHostname(s *aws.UpstreamSpec) (string, error) {
+	functionUrl, err := url.Parse(s.GetFunctionUrl().GetUrl())
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing function url")
+	}
+	if functionUrl.Scheme != "https" || functionUrl.Hostname() == "" || functionUrl.Port() != "" ||
+		strings.Trim(functionUrl.Path, "/") != "" || functionUrl.RawQuery != "" {
+		return "", errors.Errorf("function url %s must be of the form https://<url-id>.lambda-url.<region>.on.aws/", s.GetFunctionUrl().GetUrl())
+	}
+	if !strings.HasSuffix(functionUrl.Hostname(), fmt.Sprintf(".lambda-url.%s.on.aws", s.GetRegion())) {
+		return "", errors.Errorf("function url %s is not in the region %s of the upstream", s.GetFunctionUrl().GetUrl(), s.GetRegion())
+	}
+	return functionUrl.Hostname(), nil
+}
+
 func (p *plugin) ProcessUpstream(params plugins.Params, in *v1.Upstream, out *envoy_config_cluster_v3.Cluster) error {
 	upstreamSpec, ok := in.GetUpstreamType().(*v1.Upstream_Aws)
 	if !ok {
@@ -87,6 +106,14 @@ func (p *plugin) ProcessUpstream(params plugins.Params, in *v1.Upstream, out *en
 	p.recordedUpstreams[translator.UpstreamToClusterName(in.GetMetadata().Ref())] = upstreamSpec.Aws
 
 	lambdaHostname := getLambdaHostname(upstreamSpec.Aws)
+	functionUrl := upstreamSpec.Aws.GetFunctionUrl()
+	if functionUrl != nil {
+		var err error
+		lambdaHostname, err = getFunctionUrlHostname(upstreamSpec.Aws)
+		if err != nil {
+			return err
+		}
+	}
 
 	// configure Envoy cluster routing info
 	out.ClusterDiscoveryType = &envoy_config_cluster_v3.Cluster_Type{
@@ -109,6 +136,10 @@ func (p *plugin) ProcessUpstream(params plugins.Params, in *v1.Upstream, out *en
 		Name:       wellknown.TransportSocketTls,
 		ConfigType: &envoy_config_core_v3.TransportSocket_TypedConfig{TypedConfig: utils.MustMessageToAny(tlsContext)},
 	}
+	if functionUrl != nil {
+		// the requests are forwarded to the function url as they are, without being signed by the aws filter
+		return nil
+	}
 
 	var accessKey, sessionToken, secretKey string
 	if upstreamSpec.Aws.GetSecretRef() == nil &&
@@ -116,6 +147,9 @@ func (p *plugin) ProcessUpstream(params plugins.Params, in *v1.Upstream, out *en
 		p.settings.GetServiceAccountCredentials() == nil {
 		return errors.Errorf("no aws secret provided. consider setting enableCredentialsDiscovey to true or enabling service account credentials if running in EKS")
 	}
+	if upstreamSpec.Aws.GetAssumeRole() != nil && upstreamSpec.Aws.GetAssumeRole().GetRoleArn() == "" {
+		return errors.Errorf("assumeRole requires a roleArn")
+	}
 
 	if upstreamSpec.Aws.GetSecretRef() != nil {
 
@@ -151,13 +185,38 @@ func (p *plugin) ProcessUpstream(params plugins.Params, in *v1.Upstream, out *en
 
 	}
 
+	roleArn := upstreamSpec.Aws.GetRoleArn()
+	// envoy cannot assume a role with the credentials of the upstream, so gloo assumes it and sends envoy the
+	// temporary credentials of the role instead
+	if assumeRole := upstreamSpec.Aws.GetAssumeRole(); assumeRole != nil {
+		base := baseCredentials{
+			accessKey:    accessKey,
+			secretKey:    secretKey,
+			sessionToken: sessionToken,
+		}
+		// like function discovery, gloo assumes the role of the upstream with its web identity token if it has one
+		if accessKey == "" && os.Getenv(AWS_WEB_IDENTITY_TOKEN_FILE) != "" {
+			base.webIdentityRoleArn = os.Getenv(AWS_ROLE_ARN)
+			if roleArn != "" {
+				base.webIdentityRoleArn = roleArn
+			}
+		}
+		role, err := p.assumedRoles.credentials(params.Ctx, base, upstreamSpec.Aws.GetRegion(), assumeRole)
+		if err != nil {
+			return err
+		}
+		accessKey, secretKey, sessionToken = role.accessKey, role.secretKey, role.sessionToken
+		// the role_arn is only used by envoy to obtain credentials itself
+		roleArn = ""
+	}
+
 	lpe := &AWSLambdaProtocolExtension{
 		Host:         lambdaHostname,
 		Region:       upstreamSpec.Aws.GetRegion(),
 		AccessKey:    accessKey,
 		SecretKey:    secretKey,
 		SessionToken: sessionToken,
-		RoleArn:      upstreamSpec.Aws.GetRoleArn(),
+		RoleArn:      roleArn,
 	}
 
 	if err := pluginutils.SetExtensionProtocolOptions(out, FilterName, lpe); err != nil {
@@ -167,6 +226,10 @@ func (p *plugin) ProcessUpstream(params plugins.Params, in *v1.Upstream, out *en
 }
 
 func (p *plugin) ProcessRoute(params plugins.RouteParams, in *v1.Route, out *envoy_config_route_v3.Route) error {
+	// the function invoked by the single destination of the route, if its traffic is split across qualifiers
+	var splitRouteFunc *AWSLambdaPerRoute
+	var weightedQualifiers []*aws.WeightedQualifier
+
 	err := pluginutils.MarkPerFilterConfig(p.ctx, params.Snapshot, in, out, FilterName,
 		func(spec *v1.Destination) (proto.Message, error) {
 			// check if it's aws destination
@@ -191,6 +254,12 @@ func (p *plugin) ProcessRoute(params plugins.RouteParams, in *v1.Route, out *env
 				return nil, err
 			}
 			// should be aws upstream
+			if lambdaSpec.GetFunctionUrl() != nil {
+				return nil, errors.Errorf("upstream (%s.%s) invokes a function url and cannot be used with an aws destination spec", upstreamRef.GetName(), upstreamRef.GetNamespace())
+			}
+			if err := validateDestinationSpec(awsDestinationSpec.Aws, in); err != nil {
+				return nil, err
+			}
 
 			// get function
 			logicalName := awsDestinationSpec.Aws.GetLogicalName()
@@ -205,6 +274,10 @@ func (p *plugin) ProcessRoute(params plugins.RouteParams, in *v1.Route, out *env
 						Name:        lambdaFunc.GetLambdaFunctionName(),
 						UnwrapAsAlb: awsDestinationSpec.Aws.GetUnwrapAsAlb(),
 					}
+					if len(awsDestinationSpec.Aws.GetWeightedQualifiers()) > 0 {
+						splitRouteFunc = lambdaRouteFunc
+						weightedQualifiers = awsDestinationSpec.Aws.GetWeightedQualifiers()
+					}
 
 					return lambdaRouteFunc, nil
 				}
@@ -216,6 +289,11 @@ func (p *plugin) ProcessRoute(params plugins.RouteParams, in *v1.Route, out *env
 	if err != nil {
 		return err
 	}
+	if splitRouteFunc != nil {
+		if err := splitQualifiers(out, splitRouteFunc, weightedQualifiers); err != nil {
+			return err
+		}
+	}
 	return pluginutils.ModifyPerFilterConfig(p.ctx, params.Snapshot, in, out, transformation.FilterName,
 		func(spec *v1.Destination, existing *any.Any) (proto.Message, error) {
 			// check if it's aws destination
@@ -301,6 +379,58 @@ func (p *plugin) ProcessRoute(params plugins.RouteParams, in *v1.Route, out *env
 	)
 }
 
+func validateDestinationSpec(spec *aws.DestinationSpec, in *v1.Route) error {
+	if len(spec.GetWeightedQualifiers()) == 0 {
+		return nil
+	}
+	if in.GetRouteAction().GetSingle() == nil {
+		return errors.Errorf("weightedQualifiers are only supported on single destinations")
+	}
+	var totalWeight uint32
+	for _, weightedQualifier := range spec.GetWeightedQualifiers() {
+		if weightedQualifier.GetQualifier() == "" {
+			return errors.Errorf("weightedQualifiers require a qualifier")
+		}
+		totalWeight += weightedQualifier.GetWeight()
+	}
+	if totalWeight == 0 {
+		return errors.Errorf("the weights of weightedQualifiers must not all be 0")
+	}
+	return nil
+}
+
+// Split the traffic of the route across the qualifiers of the invoked function, by turning the cluster of the route
+// into weighted clusters that all target the same cluster, each with the qualifier of the function it invokes.
+func splitQualifiers(out *envoy_config_route_v3.Route, lambdaRouteFunc *AWSLambdaPerRoute, weightedQualifiers []*aws.WeightedQualifier) error {
+	clusterName := out.GetRoute().GetCluster()
+	if clusterName == "" {
+		return errors.Errorf("weightedQualifiers require a route to a single cluster")
+	}
+
+	weightedClusters := &envoy_config_route_v3.WeightedCluster{}
+	var totalWeight uint32
+	for _, weightedQualifier := range weightedQualifiers {
+		qualifiedRouteFunc := proto.Clone(lambdaRouteFunc).(*AWSLambdaPerRoute)
+		qualifiedRouteFunc.Qualifier = url.QueryEscape(weightedQualifier.GetQualifier())
+
+		weightedCluster := &envoy_config_route_v3.WeightedCluster_ClusterWeight{
+			Name:   clusterName,
+			Weight: &wrappers.UInt32Value{Value: weightedQualifier.GetWeight()},
+		}
+		if err := pluginutils.SetWeightedClusterPerFilterConfig(weightedCluster, FilterName, qualifiedRouteFunc); err != nil {
+			return err
+		}
+		weightedClusters.Clusters = append(weightedClusters.GetClusters(), weightedCluster)
+		totalWeight += weightedQualifier.GetWeight()
+	}
+	weightedClusters.TotalWeight = &wrappers.UInt32Value{Value: totalWeight}
+
+	out.GetRoute().ClusterSpecifier = &envoy_config_route_v3.RouteAction_WeightedClusters{
+		WeightedClusters: weightedClusters,
+	}
+	return nil
+}
+
 func (p *plugin) HttpFilters(_ plugins.Params, _ *v1.HttpListener) ([]plugins.StagedHttpFilter, error) {
 	if len(p.recordedUpstreams) == 0 {
 		// no upstreams no filter
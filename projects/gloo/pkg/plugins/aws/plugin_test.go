@@ -387,6 +387,57 @@ var _ = Describe("Plugin", func() {
 			Expect(cfg.UnwrapAsAlb).Should(Equal(true))
 			Expect(cfg.Async).Should(Equal(true))
 		})
+
+		Context("weighted qualifiers", func() {
+
+			BeforeEach(func() {
+				route.GetRouteAction().GetSingle().GetDestinationSpec().GetAws().WeightedQualifiers = []*awsapi.WeightedQualifier{
+					{Qualifier: "live", Weight: 90},
+					{Qualifier: "canary", Weight: 10},
+				}
+			})
+
+			It("should split the traffic across the qualifiers", func() {
+				err := awsPlugin.(plugins.RoutePlugin).ProcessRoute(plugins.RouteParams{VirtualHostParams: vhostParams}, route, outroute)
+				Expect(err).NotTo(HaveOccurred())
+
+				weightedClusters := outroute.GetRoute().GetWeightedClusters()
+				Expect(weightedClusters.GetTotalWeight().GetValue()).To(Equal(uint32(100)))
+				Expect(weightedClusters.GetClusters()).To(HaveLen(2))
+				for i, qualifier := range []string{"live", "canary"} {
+					weightedCluster := weightedClusters.GetClusters()[i]
+					Expect(weightedCluster.GetName()).To(Equal("up"))
+					msg, err := utils.AnyToMessage(weightedCluster.GetTypedPerFilterConfig()[FilterName])
+					Expect(err).NotTo(HaveOccurred())
+					Expect(msg.(*AWSLambdaPerRoute).GetName()).To(Equal("foo"))
+					Expect(msg.(*AWSLambdaPerRoute).GetQualifier()).To(Equal(qualifier))
+				}
+			})
+
+			It("should error on multiple destinations", func() {
+				single := route.GetRouteAction().GetSingle()
+				route.GetRouteAction().Destination = &v1.RouteAction_Multi{
+					Multi: &v1.MultiDestination{
+						Destinations: []*v1.WeightedDestination{{Destination: single, Weight: 1}},
+					},
+				}
+				outroute.GetRoute().ClusterSpecifier = &envoy_config_route_v3.RouteAction_WeightedClusters{
+					WeightedClusters: &envoy_config_route_v3.WeightedCluster{
+						Clusters: []*envoy_config_route_v3.WeightedCluster_ClusterWeight{{Name: "up"}},
+					},
+				}
+				err := awsPlugin.(plugins.RoutePlugin).ProcessRoute(plugins.RouteParams{VirtualHostParams: vhostParams}, route, outroute)
+				Expect(err).To(MatchError(ContainSubstring("weightedQualifiers are only supported on single destinations")))
+			})
+
+			It("should error when all the weights are 0", func() {
+				for _, weightedQualifier := range route.GetRouteAction().GetSingle().GetDestinationSpec().GetAws().GetWeightedQualifiers() {
+					weightedQualifier.Weight = 0
+				}
+				err := awsPlugin.(plugins.RoutePlugin).ProcessRoute(plugins.RouteParams{VirtualHostParams: vhostParams}, route, outroute)
+				Expect(err).To(MatchError(ContainSubstring("the weights of weightedQualifiers must not all be 0")))
+			})
+		})
 	})
 
 	Context("filters", func() {
@@ -577,6 +628,58 @@ var _ = Describe("Plugin", func() {
 		})
 
 	})
+
+	Context("assumed roles", func() {
+
+		It("should error without the arn of the role", func() {
+			upstream.GetAws().AssumeRole = &aws.AssumeRoleCredentials{
+				ExternalId: "team-a",
+			}
+			err := awsPlugin.(plugins.UpstreamPlugin).ProcessUpstream(params, upstream, out)
+			Expect(err).To(MatchError(ContainSubstring("assumeRole requires a roleArn")))
+		})
+	})
+
+	Context("function urls", func() {
+
+		BeforeEach(func() {
+			upstream.GetAws().Region = "us-east-1"
+			upstream.GetAws().FunctionUrl = &aws.FunctionUrl{
+				Url: "https://abcdefghij.lambda-url.us-east-1.on.aws/",
+			}
+		})
+
+		It("should target the function url without signing the requests", func() {
+			// the credentials of the upstream are not used
+			upstream.GetAws().SecretRef = nil
+			err := awsPlugin.(plugins.UpstreamPlugin).ProcessUpstream(params, upstream, out)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(out.GetTypedExtensionProtocolOptions()).NotTo(HaveKey(FilterName))
+			Expect(getClusterTlsContext(out).GetSni()).To(Equal("abcdefghij.lambda-url.us-east-1.on.aws"))
+			Expect(out.GetLoadAssignment().GetEndpoints()[0].GetLbEndpoints()[0].GetEndpoint().GetAddress().GetSocketAddress().GetAddress()).
+				To(Equal("abcdefghij.lambda-url.us-east-1.on.aws"))
+		})
+
+		It("should error on a function url in another region", func() {
+			upstream.GetAws().GetFunctionUrl().Url = "https://abcdefghij.lambda-url.eu-west-1.on.aws/"
+			err := awsPlugin.(plugins.UpstreamPlugin).ProcessUpstream(params, upstream, out)
+			Expect(err).To(MatchError(ContainSubstring("is not in the region us-east-1 of the upstream")))
+		})
+
+		It("should error on a function url with a path", func() {
+			upstream.GetAws().GetFunctionUrl().Url = "https://abcdefghij.lambda-url.us-east-1.on.aws/foo"
+			err := awsPlugin.(plugins.UpstreamPlugin).ProcessUpstream(params, upstream, out)
+			Expect(err).To(MatchError(ContainSubstring("must be of the form https://<url-id>.lambda-url.<region>.on.aws/")))
+		})
+
+		It("should error on routes with an aws destination spec", func() {
+			err := awsPlugin.(plugins.UpstreamPlugin).ProcessUpstream(params, upstream, out)
+			Expect(err).NotTo(HaveOccurred())
+			err = awsPlugin.(plugins.RoutePlugin).ProcessRoute(plugins.RouteParams{VirtualHostParams: vhostParams}, route, outroute)
+			Expect(err).To(MatchError(ContainSubstring("upstream (up.ns) invokes a function url")))
+		})
+	})
 })
 
 func getClusterTlsContext(cluster *envoy_config_cluster_v3.Cluster) *envoyauth.UpstreamTlsContext {
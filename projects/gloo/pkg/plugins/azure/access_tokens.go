@@ -0,0 +1,226 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/solo-io/gloo/projects/gloo/pkg/translator"
+	"github.com/solo-io/solo-kit/pkg/errors"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// https://docs.microsoft.com/en-us/azure/active-directory/managed-identities-azure-resources/how-to-use-vm-token
+	imdsApiVersion = "2018-02-01"
+
+	// the access tokens are refreshed when they expire in less than this margin
+	accessTokenRefreshMargin = 5 * time.Minute
+	// the minimum delay between the refreshes, in case the instance metadata service returns short-lived tokens
+	minAccessTokenRefreshDelay = time.Minute
+	// the delays before requesting a token again after a failure, doubled on each consecutive failure
+	minAccessTokenRetryDelay = 5 * time.Second
+	maxAccessTokenRetryDelay = 5 * time.Minute
+)
+
+// The access tokens of the managed identities of the Azure upstreams are obtained by gloo from the Instance Metadata
+// Service of its node or pod, and added to the requests to the upstreams by their routes.
+// DefaultAccessTokenCache signals Refreshes when a token it has obtained is about to expire, or when a token can be
+// requested again after a failure, so that gloo translates the proxies again and the routes are updated with a new
+// token before the old one expires.
+var DefaultAccessTokenCache = NewAccessTokenCache()
+
+type accessTokenCacheKey struct {
+	tokenUri string
+	resource string
+	clientId string
+}
+
+func (k accessTokenCacheKey) String() string {
+	return strings.Join([]string{k.tokenUri, k.resource, k.clientId}, " ")
+}
+
+type accessToken struct {
+	token     string
+	expiresOn time.Time
+}
+
+type accessTokenFailure struct {
+	err     error
+	retryAt time.Time
+	// the delay before the next retry, if this one fails as well
+	nextDelay time.Duration
+}
+
+type AccessTokenCache struct {
+	lock     sync.Mutex
+	tokens   map[accessTokenCacheKey]*accessToken
+	failures map[accessTokenCacheKey]*accessTokenFailure
+	// the translations which need a token that is being requested wait for it, rather than requesting it as well
+	requesting singleflight.Group
+	refreshes  chan struct{}
+	client     *http.Client
+}
+
+func NewAccessTokenCache() *AccessTokenCache {
+	return &AccessTokenCache{
+		tokens:   make(map[accessTokenCacheKey]*accessToken),
+		failures: make(map[accessTokenCacheKey]*accessTokenFailure),
+		// a single pending refresh is enough to translate the proxies again
+		refreshes: make(chan struct{}, 1),
+		client:    &http.Client{Timeout: tokenEndpointTimeout},
+	}
+}
+
+// The access token of the managed identity for the resource, requested from the Instance Metadata Service if it is
+// not cached or expires soon. The client ID of a user-assigned managed identity is optional.
+// Failures are cached until the token can be requested again.
+func (c *AccessTokenCache) accessToken(ctx context.Context, tokenUri, resource, clientId string) (string, error) {
+	cacheKey := accessTokenCacheKey{
+		tokenUri: tokenUri,
+		resource: resource,
+		clientId: clientId,
+	}
+
+	if token, err, ok := c.cached(cacheKey); ok {
+		return token, err
+	}
+
+	token, err, _ := c.requesting.Do(cacheKey.String(), func() (interface{}, error) {
+		// the token may have been obtained while waiting for the previous call to complete
+		if token, err, ok := c.cached(cacheKey); ok {
+			return token, err
+		}
+		token, err := c.requestAccessToken(ctx, tokenUri, resource, clientId)
+		if err != nil {
+			err = AccessTokenError(resource, err)
+			c.fail(cacheKey, err)
+			return "", err
+		}
+		c.store(cacheKey, token)
+		return token.token, nil
+	})
+	return token.(string), err
+}
+
+// Returns the cached token, or the cached failure, and whether any is still valid.
+func (c *AccessTokenCache) cached(cacheKey accessTokenCacheKey) (string, error, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if token, ok := c.tokens[cacheKey]; ok && time.Until(token.expiresOn) > accessTokenRefreshMargin {
+		return token.token, nil, true
+	}
+	if failure, ok := c.failures[cacheKey]; ok && time.Now().Before(failure.retryAt) {
+		return "", failure.err, true
+	}
+	return "", nil, false
+}
+
+func (c *AccessTokenCache) store(cacheKey accessTokenCacheKey, token *accessToken) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// drop the tokens that have expired, e.g. the ones of deleted upstreams
+	now := time.Now()
+	for k, cached := range c.tokens {
+		if cached.expiresOn.Before(now) {
+			delete(c.tokens, k)
+		}
+	}
+	c.tokens[cacheKey] = token
+	delete(c.failures, cacheKey)
+	refreshDelay := time.Until(token.expiresOn) - accessTokenRefreshMargin
+	if refreshDelay < minAccessTokenRefreshDelay {
+		refreshDelay = minAccessTokenRefreshDelay
+	}
+	time.AfterFunc(refreshDelay, c.signalRefresh)
+}
+
+func (c *AccessTokenCache) fail(cacheKey accessTokenCacheKey, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	delay := minAccessTokenRetryDelay
+	if failure, ok := c.failures[cacheKey]; ok {
+		delay = failure.nextDelay
+	}
+	// drop the failures that have not been retried for a while, e.g. the ones of deleted upstreams
+	for k, failure := range c.failures {
+		if failure.retryAt.Add(maxAccessTokenRetryDelay).Before(now) {
+			delete(c.failures, k)
+		}
+	}
+	nextDelay := 2 * delay
+	if nextDelay > maxAccessTokenRetryDelay {
+		nextDelay = maxAccessTokenRetryDelay
+	}
+	c.failures[cacheKey] = &accessTokenFailure{
+		err:       err,
+		retryAt:   now.Add(delay),
+		nextDelay: nextDelay,
+	}
+	time.AfterFunc(delay, c.signalRefresh)
+}
+
+// Signals that a cached token expires soon, or that a failed token can be requested again.
+func (c *AccessTokenCache) Refreshes() <-chan struct{} {
+	return c.refreshes
+}
+
+func (c *AccessTokenCache) signalRefresh() {
+	// the translations which used the token must not be reused
+	translator.InvalidateTranslations()
+	select {
+	case c.refreshes <- struct{}{}:
+	default:
+	}
+}
+
+// https://docs.microsoft.com/en-us/azure/active-directory/managed-identities-azure-resources/how-to-use-vm-token#get-a-token-using-http
+func (c *AccessTokenCache) requestAccessToken(ctx context.Context, tokenUri, resource, clientId string) (*accessToken, error) {
+	query := url.Values{}
+	query.Set("api-version", imdsApiVersion)
+	query.Set("resource", resource)
+	if clientId != "" {
+		query.Set("client_id", clientId)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenUri+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var response struct {
+		AccessToken string `json:"access_token"`
+		ExpiresOn   string `json:"expires_on"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	if response.AccessToken == "" {
+		return nil, errors.Errorf("the response has no access token")
+	}
+	expiresOn, err := strconv.ParseInt(response.ExpiresOn, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing the expiry of the access token")
+	}
+	return &accessToken{token: response.AccessToken, expiresOn: time.Unix(expiresOn, 0)}, nil
+}
+
+var (
+	AccessTokenError = func(resource string, err error) error {
+		return errors.Wrapf(err, "getting an access token of the managed identity for %s", resource)
+	}
+)
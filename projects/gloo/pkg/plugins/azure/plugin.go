@@ -3,6 +3,7 @@ package azure
 import (
 	"context"
 	"fmt"
+	"time"
 
 	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -21,6 +22,7 @@ import (
 	"github.com/solo-io/gloo/projects/gloo/pkg/upstreams"
 	"github.com/solo-io/gloo/projects/gloo/pkg/utils"
 	"github.com/solo-io/go-utils/contextutils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
 	"github.com/solo-io/solo-kit/pkg/errors"
 )
 
@@ -33,6 +35,12 @@ var (
 const (
 	ExtensionName = "azure"
 	masterKeyName = "_master"
+
+	DefaultTokenUri = "http://169.254.169.254/metadata/identity/oauth2/token"
+)
+
+var (
+	tokenEndpointTimeout = 5 * time.Second
 )
 
 type plugin struct {
@@ -40,10 +48,14 @@ type plugin struct {
 	recordedUpstreams map[string]*azure.UpstreamSpec
 	apiKeys           map[string]string
 	ctx               context.Context
+
+	accessTokens *AccessTokenCache
+	// the access tokens of the managed identities of the upstreams, by cluster name
+	clusterAccessTokens map[string]string
 }
 
 func NewPlugin() plugins.Plugin {
-	return &plugin{}
+	return &plugin{accessTokens: DefaultAccessTokenCache}
 }
 
 func (p *plugin) Name() string {
@@ -54,6 +66,7 @@ func (p *plugin) Init(params plugins.InitParams) error {
 	p.settings = params.Settings
 	p.ctx = params.Ctx
 	p.recordedUpstreams = make(map[string]*azure.UpstreamSpec)
+	p.clusterAccessTokens = make(map[string]string)
 	return nil
 }
 
@@ -102,6 +115,17 @@ func (p *plugin) ProcessUpstream(params plugins.Params, in *v1.Upstream, out *en
 		p.apiKeys = azureSecrets.Azure.GetApiKeys()
 	}
 
+	if managedIdentity := azureUpstream.GetManagedIdentity(); managedIdentity != nil {
+		if managedIdentity.GetResource() == "" {
+			return NoManagedIdentityResourceError(in.GetMetadata().Ref())
+		}
+		token, err := p.accessTokens.accessToken(params.Ctx, p.tokenUri(), managedIdentity.GetResource(), managedIdentity.GetClientId())
+		if err != nil {
+			return err
+		}
+		p.clusterAccessTokens[translator.UpstreamToClusterName(in.GetMetadata().Ref())] = token
+	}
+
 	return nil
 }
 
@@ -122,7 +146,8 @@ func (p *plugin) ProcessRoute(params plugins.RouteParams, in *v1.Route, out *env
 				contextutils.LoggerFrom(p.ctx).Error(err)
 				return nil, err
 			}
-			upstreamSpec, ok := p.recordedUpstreams[translator.UpstreamToClusterName(upstreamRef)]
+			clusterName := translator.UpstreamToClusterName(upstreamRef)
+			upstreamSpec, ok := p.recordedUpstreams[clusterName]
 			if !ok {
 				// TODO(yuval-k): panic in debug
 				return nil, errors.Errorf("%v is not an Azure upstream", *upstreamRef)
@@ -132,25 +157,34 @@ func (p *plugin) ProcessRoute(params plugins.RouteParams, in *v1.Route, out *env
 			functionName := azureDestinationSpec.Azure.GetFunctionName()
 			for _, functionSpec := range upstreamSpec.GetFunctions() {
 				if functionSpec.GetFunctionName() == functionName {
-					path, err := getPath(functionSpec, p.apiKeys)
+					// without a secret, the requests are authenticated with the access tokens of the managed identity
+					// rather than function keys
+					useFunctionKeys := upstreamSpec.GetManagedIdentity() == nil || upstreamSpec.GetSecretRef().GetName() != ""
+					path, err := getPath(functionSpec, p.apiKeys, useFunctionKeys)
 					if err != nil {
 						return nil, err
 					}
 
 					hostname := GetHostname(upstreamSpec)
+					headers := map[string]*transformationapi.InjaTemplate{
+						":path": {
+							Text: path,
+						},
+						":authority": {
+							Text: hostname,
+						},
+					}
+					if accessToken, ok := p.clusterAccessTokens[clusterName]; ok {
+						headers["authorization"] = &transformationapi.InjaTemplate{
+							Text: "Bearer " + accessToken,
+						}
+					}
 					// TODO: consider adding a new add headers transformation allow adding headers with no templates to improve performance.
 					ret := &transformationapi.RouteTransformations{
 						RequestTransformation: &transformationapi.Transformation{
 							TransformationType: &transformationapi.Transformation_TransformationTemplate{
 								TransformationTemplate: &transformationapi.TransformationTemplate{
-									Headers: map[string]*transformationapi.InjaTemplate{
-										":path": {
-											Text: path,
-										},
-										":authority": {
-											Text: hostname,
-										},
-									},
+									Headers: headers,
 									BodyTransformation: &transformationapi.TransformationTemplate_Passthrough{
 										Passthrough: &transformationapi.Passthrough{},
 									},
@@ -167,8 +201,11 @@ func (p *plugin) ProcessRoute(params plugins.RouteParams, in *v1.Route, out *env
 	)
 }
 
-func getPath(functionSpec *azure.UpstreamSpec_FunctionSpec, apiKeys map[string]string) (string, error) {
+func getPath(functionSpec *azure.UpstreamSpec_FunctionSpec, apiKeys map[string]string, useFunctionKeys bool) (string, error) {
 	functionName := functionSpec.GetFunctionName()
+	if !useFunctionKeys {
+		return fmt.Sprintf("/api/%s", functionName), nil
+	}
 
 	pathParameters, err := getPathParameters(functionSpec, apiKeys)
 	if err != nil {
@@ -210,3 +247,21 @@ func getApiKey(apiKeys map[string]string, keyNames []string) (string, error) {
 
 	return "", fmt.Errorf("secret not found for key names %v", keyNames)
 }
+
+func (p *plugin) tokenUri() string {
+	return GetTokenUri(p.settings.GetGloo().GetAzureOptions())
+}
+
+// GetTokenUri returns the endpoint of the Instance Metadata Service of the settings, or the default one.
+func GetTokenUri(azureOptions *v1.GlooOptions_AzureOptions) string {
+	if tokenUri := azureOptions.GetTokenUri(); tokenUri != "" {
+		return tokenUri
+	}
+	return DefaultTokenUri
+}
+
+var (
+	NoManagedIdentityResourceError = func(upstreamRef *core.ResourceRef) error {
+		return errors.Errorf("the managed identity of azure upstream %v requires a resource", upstreamRef.Key())
+	}
+)
@@ -2,8 +2,17 @@ package azure_test
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
 
 	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/golang/protobuf/ptypes"
+	transformationapi "github.com/solo-io/gloo/projects/gloo/pkg/api/external/envoy/extensions/transformation"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins/transformation"
 	"github.com/solo-io/gloo/projects/gloo/pkg/utils"
 
 	envoyauth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
@@ -36,7 +45,7 @@ var _ = Describe("Plugin", func() {
 		initParams = plugins.InitParams{
 			Ctx: context.TODO(),
 		}
-		params = plugins.Params{}
+		params = plugins.Params{Ctx: context.TODO()}
 
 		upstreamSpec = &azure.UpstreamSpec{
 			FunctionAppName: "app-name",
@@ -204,6 +213,191 @@ var _ = Describe("Plugin", func() {
 			})
 
 		})
+
+		Context("with a managed identity", func() {
+
+			var (
+				server   *httptest.Server
+				requests []*http.Request
+			)
+
+			BeforeEach(func() {
+				requests = nil
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requests = append(requests, r)
+					if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("resource") == "unknown" {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(map[string]string{
+						"access_token": "access-token",
+						"expires_on":   strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10),
+					})
+				}))
+				initParams.Settings = &v1.Settings{
+					Gloo: &v1.GlooOptions{
+						AzureOptions: &v1.GlooOptions_AzureOptions{TokenUri: server.URL},
+					},
+				}
+				upstreamSpec.ManagedIdentity = &azure.UpstreamSpec_ManagedIdentity{
+					Resource: "api://app-id",
+					ClientId: "client-id",
+				}
+				upstreamSpec.Functions = []*azure.UpstreamSpec_FunctionSpec{{
+					FunctionName: "foo",
+					AuthLevel:    azure.UpstreamSpec_FunctionSpec_Function,
+				}}
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			processRoute := func() *transformationapi.TransformationTemplate {
+				in := &v1.Route{
+					Action: &v1.Route_RouteAction{
+						RouteAction: &v1.RouteAction{
+							Destination: &v1.RouteAction_Single{
+								Single: &v1.Destination{
+									DestinationType: &v1.Destination_Upstream{
+										Upstream: upstream.GetMetadata().Ref(),
+									},
+									DestinationSpec: &v1.DestinationSpec{
+										DestinationType: &v1.DestinationSpec_Azure{
+											Azure: &azure.DestinationSpec{FunctionName: "foo"},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				outRoute := &envoy_config_route_v3.Route{
+					Action: &envoy_config_route_v3.Route_Route{
+						Route: &envoy_config_route_v3.RouteAction{
+							ClusterSpecifier: &envoy_config_route_v3.RouteAction_Cluster{Cluster: "us"},
+						},
+					},
+				}
+				err := p.(plugins.RoutePlugin).ProcessRoute(plugins.RouteParams{VirtualHostParams: plugins.VirtualHostParams{Params: params}}, in, outRoute)
+				Expect(err).NotTo(HaveOccurred())
+
+				var transformations transformationapi.RouteTransformations
+				err = ptypes.UnmarshalAny(outRoute.GetTypedPerFilterConfig()[transformation.FilterName], &transformations)
+				Expect(err).NotTo(HaveOccurred())
+				return transformations.GetRequestTransformation().GetTransformationTemplate()
+			}
+
+			It("should get an access token of the managed identity from the instance metadata service", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requests).To(HaveLen(1))
+				query := requests[0].URL.Query()
+				Expect(query.Get("resource")).To(Equal("api://app-id"))
+				Expect(query.Get("client_id")).To(Equal("client-id"))
+				Expect(query.Get("api-version")).To(Equal("2018-02-01"))
+			})
+
+			It("should not send anything to envoy to get the tokens", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out.GetTypedExtensionProtocolOptions()).To(BeEmpty())
+				_, isFilterPlugin := p.(plugins.HttpFilterPlugin)
+				Expect(isFilterPlugin).To(BeFalse())
+			})
+
+			It("should add the access token to the requests instead of function keys", func() {
+				template := processRoute()
+				Expect(template.GetHeaders()[":path"].GetText()).To(Equal("/api/foo"))
+				Expect(template.GetHeaders()["authorization"].GetText()).To(Equal("Bearer access-token"))
+			})
+
+			It("should reuse the access token while it is valid", func() {
+				Expect(err).NotTo(HaveOccurred())
+				err = p.Init(initParams)
+				Expect(err).NotTo(HaveOccurred())
+				err = p.(plugins.UpstreamPlugin).ProcessUpstream(params, upstream, out)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requests).To(HaveLen(1))
+			})
+
+			Context("when the instance metadata service rejects the request", func() {
+
+				BeforeEach(func() {
+					upstreamSpec.ManagedIdentity.Resource = "unknown"
+				})
+
+				It("should error", func() {
+					Expect(err).To(MatchError(ContainSubstring("getting an access token of the managed identity for unknown")))
+				})
+
+				It("should not request the access token again until the failure can be retried", func() {
+					err = p.Init(initParams)
+					Expect(err).NotTo(HaveOccurred())
+					err = p.(plugins.UpstreamPlugin).ProcessUpstream(params, upstream, out)
+					Expect(err).To(MatchError(ContainSubstring("getting an access token of the managed identity for unknown")))
+					Expect(requests).To(HaveLen(1))
+				})
+			})
+
+			Context("without a resource", func() {
+
+				BeforeEach(func() {
+					upstreamSpec.ManagedIdentity.Resource = ""
+				})
+
+				It("should error", func() {
+					Expect(err).To(MatchError(ContainSubstring("requires a resource")))
+				})
+			})
+		})
+
+		Context("without a managed identity", func() {
+
+			BeforeEach(func() {
+				upstreamSpec.Functions = []*azure.UpstreamSpec_FunctionSpec{{
+					FunctionName: "foo",
+					AuthLevel:    azure.UpstreamSpec_FunctionSpec_Anonymous,
+				}}
+			})
+
+			It("should not add an authorization header", func() {
+				Expect(err).NotTo(HaveOccurred())
+				in := &v1.Route{
+					Action: &v1.Route_RouteAction{
+						RouteAction: &v1.RouteAction{
+							Destination: &v1.RouteAction_Single{
+								Single: &v1.Destination{
+									DestinationType: &v1.Destination_Upstream{
+										Upstream: upstream.GetMetadata().Ref(),
+									},
+									DestinationSpec: &v1.DestinationSpec{
+										DestinationType: &v1.DestinationSpec_Azure{
+											Azure: &azure.DestinationSpec{FunctionName: "foo"},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				outRoute := &envoy_config_route_v3.Route{
+					Action: &envoy_config_route_v3.Route_Route{
+						Route: &envoy_config_route_v3.RouteAction{
+							ClusterSpecifier: &envoy_config_route_v3.RouteAction_Cluster{Cluster: "us"},
+						},
+					},
+				}
+				err := p.(plugins.RoutePlugin).ProcessRoute(plugins.RouteParams{VirtualHostParams: plugins.VirtualHostParams{Params: params}}, in, outRoute)
+				Expect(err).NotTo(HaveOccurred())
+
+				var transformations transformationapi.RouteTransformations
+				err = ptypes.UnmarshalAny(outRoute.GetTypedPerFilterConfig()[transformation.FilterName], &transformations)
+				Expect(err).NotTo(HaveOccurred())
+				headers := transformations.GetRequestTransformation().GetTransformationTemplate().GetHeaders()
+				Expect(headers[":path"].GetText()).To(Equal("/api/foo"))
+				Expect(headers).NotTo(HaveKey("authorization"))
+			})
+		})
 	})
 })
 
@@ -0,0 +1,173 @@
+package clientcertificate
+
+import (
+	"strings"
+
+	envoy_config_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	envoy_config_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoyrbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	envoy_type_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/rotisserie/eris"
+	gloo_type_matcher "github.com/solo-io/gloo/projects/gloo/pkg/api/external/envoy/type/matcher/v3"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/client_certificate"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins/pluginutils"
+)
+
+var (
+	_ plugins.Plugin           = new(plugin)
+	_ plugins.HttpFilterPlugin = new(plugin)
+	_ plugins.RoutePlugin      = new(plugin)
+)
+
+const (
+	ExtensionName = "client_certificate"
+	FilterName    = wellknown.HTTPRoleBasedAccessControl
+
+	policyName = "client-certificate"
+)
+
+var (
+	pluginStage = plugins.DuringStage(plugins.AuthZStage)
+
+	MissingIdentityError = eris.New("a client certificate principal must have a spiffe id or a name")
+
+	InvalidSpiffeIdError = func(spiffeId string) error {
+		return eris.Errorf("%q is not a SPIFFE ID, it must start with spiffe://", spiffeId)
+	}
+
+	MissingStringMatcherError = eris.New("the name of a client certificate principal must have a match pattern")
+)
+
+// The authorization is enforced by the RBAC filter. The filter has no rules of its own, so it only denies requests on
+// the routes that override its config.
+type plugin struct {
+	filterNeeded bool
+}
+
+func NewPlugin() *plugin {
+	return &plugin{}
+}
+
+func (p *plugin) Name() string {
+	return ExtensionName
+}
+
+func (p *plugin) Init(_ plugins.InitParams) error {
+	p.filterNeeded = false
+	return nil
+}
+
+func (p *plugin) ProcessRoute(_ plugins.RouteParams, in *v1.Route, out *envoy_config_route.Route) error {
+	authorization := in.GetOptions().GetClientCertificateAuthorization()
+	if authorization == nil {
+		return nil
+	}
+
+	perRouteConfig, err := translateAuthorization(authorization)
+	if err != nil {
+		return err
+	}
+	p.filterNeeded = true
+
+	return pluginutils.SetRoutePerFilterConfig(out, FilterName, perRouteConfig)
+}
+
+func (p *plugin) HttpFilters(_ plugins.Params, _ *v1.HttpListener) ([]plugins.StagedHttpFilter, error) {
+	if !p.filterNeeded {
+		return []plugins.StagedHttpFilter{}, nil
+	}
+
+	filter, err := plugins.NewStagedFilterWithConfig(FilterName, &envoyrbac.RBAC{}, pluginStage)
+	if err != nil {
+		return nil, eris.Wrap(err, "generating filter config")
+	}
+	return []plugins.StagedHttpFilter{filter}, nil
+}
+
+func translateAuthorization(in *client_certificate.ClientCertificateAuthorization) (*envoyrbac.RBACPerRoute, error) {
+	var principals []*envoy_config_rbac.Principal
+	for _, principal := range in.GetPrincipals() {
+		principalName, err := translatePrincipalName(principal)
+		if err != nil {
+			return nil, err
+		}
+		principals = append(principals, authenticatedPrincipal(principalName))
+	}
+	if len(principals) == 0 {
+		// any client certificate validated by the listener
+		principals = append(principals, authenticatedPrincipal(nil))
+	}
+
+	return &envoyrbac.RBACPerRoute{
+		Rbac: &envoyrbac.RBAC{
+			Rules: &envoy_config_rbac.RBAC{
+				Action: envoy_config_rbac.RBAC_ALLOW,
+				Policies: map[string]*envoy_config_rbac.Policy{
+					policyName: {
+						Permissions: []*envoy_config_rbac.Permission{{
+							Rule: &envoy_config_rbac.Permission_Any{Any: true},
+						}},
+						Principals: principals,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func authenticatedPrincipal(principalName *envoy_type_matcher.StringMatcher) *envoy_config_rbac.Principal {
+	return &envoy_config_rbac.Principal{
+		Identifier: &envoy_config_rbac.Principal_Authenticated_{
+			Authenticated: &envoy_config_rbac.Principal_Authenticated{
+				PrincipalName: principalName,
+			},
+		},
+	}
+}
+
+// Envoy matches the principal name against the URI SANs, the DNS SANs and the subject of the client certificate.
+func translatePrincipalName(principal *client_certificate.Principal) (*envoy_type_matcher.StringMatcher, error) {
+	switch identity := principal.GetIdentity().(type) {
+	case *client_certificate.Principal_SpiffeId:
+		if !strings.HasPrefix(identity.SpiffeId, "spiffe://") {
+			return nil, InvalidSpiffeIdError(identity.SpiffeId)
+		}
+		return &envoy_type_matcher.StringMatcher{
+			MatchPattern: &envoy_type_matcher.StringMatcher_Exact{
+				Exact: identity.SpiffeId,
+			},
+		}, nil
+	case *client_certificate.Principal_Name:
+		return translateStringMatcher(identity.Name)
+	}
+	return nil, MissingIdentityError
+}
+
+func translateStringMatcher(in *gloo_type_matcher.StringMatcher) (*envoy_type_matcher.StringMatcher, error) {
+	out := &envoy_type_matcher.StringMatcher{
+		IgnoreCase: in.GetIgnoreCase(),
+	}
+	switch pattern := in.GetMatchPattern().(type) {
+	case *gloo_type_matcher.StringMatcher_Exact:
+		out.MatchPattern = &envoy_type_matcher.StringMatcher_Exact{Exact: pattern.Exact}
+	case *gloo_type_matcher.StringMatcher_Prefix:
+		out.MatchPattern = &envoy_type_matcher.StringMatcher_Prefix{Prefix: pattern.Prefix}
+	case *gloo_type_matcher.StringMatcher_Suffix:
+		out.MatchPattern = &envoy_type_matcher.StringMatcher_Suffix{Suffix: pattern.Suffix}
+	case *gloo_type_matcher.StringMatcher_SafeRegex:
+		out.MatchPattern = &envoy_type_matcher.StringMatcher_SafeRegex{
+			SafeRegex: &envoy_type_matcher.RegexMatcher{
+				EngineType: &envoy_type_matcher.RegexMatcher_GoogleRe2{
+					GoogleRe2: &envoy_type_matcher.RegexMatcher_GoogleRE2{},
+				},
+				Regex: pattern.SafeRegex.GetRegex(),
+			},
+		}
+	default:
+		return nil, MissingStringMatcherError
+	}
+	return out, nil
+}
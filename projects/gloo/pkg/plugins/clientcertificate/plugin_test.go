@@ -0,0 +1,129 @@
+package clientcertificate_test
+
+import (
+	"context"
+
+	envoy_config_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	envoy_config_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoyrbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	envoy_type_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	gloo_type_matcher "github.com/solo-io/gloo/projects/gloo/pkg/api/external/envoy/type/matcher/v3"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/client_certificate"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins"
+	. "github.com/solo-io/gloo/projects/gloo/pkg/plugins/clientcertificate"
+	"github.com/solo-io/solo-kit/test/matchers"
+)
+
+var _ = Describe("Plugin", func() {
+
+	var (
+		plugin plugins.Plugin
+		route  *v1.Route
+	)
+
+	BeforeEach(func() {
+		plugin = NewPlugin()
+		Expect(plugin.Init(plugins.InitParams{Ctx: context.TODO()})).To(Succeed())
+		route = &v1.Route{
+			Options: &v1.RouteOptions{
+				ClientCertificateAuthorization: &client_certificate.ClientCertificateAuthorization{
+					Principals: []*client_certificate.Principal{
+						{Identity: &client_certificate.Principal_SpiffeId{SpiffeId: "spiffe://example.org/ns/default/sa/client"}},
+						{Identity: &client_certificate.Principal_Name{Name: &gloo_type_matcher.StringMatcher{
+							MatchPattern: &gloo_type_matcher.StringMatcher_Prefix{Prefix: "CN=client"},
+						}}},
+					},
+				},
+			},
+		}
+	})
+
+	processRoute := func() (*envoy_config_route.Route, error) {
+		out := &envoy_config_route.Route{}
+		err := plugin.(plugins.RoutePlugin).ProcessRoute(plugins.RouteParams{}, route, out)
+		return out, err
+	}
+
+	httpFilters := func() []plugins.StagedHttpFilter {
+		filters, err := plugin.(plugins.HttpFilterPlugin).HttpFilters(plugins.Params{}, &v1.HttpListener{})
+		Expect(err).NotTo(HaveOccurred())
+		return filters
+	}
+
+	authenticated := func(principalName *envoy_type_matcher.StringMatcher) *envoy_config_rbac.Principal {
+		return &envoy_config_rbac.Principal{
+			Identifier: &envoy_config_rbac.Principal_Authenticated_{
+				Authenticated: &envoy_config_rbac.Principal_Authenticated{PrincipalName: principalName},
+			},
+		}
+	}
+
+	perRouteConfig := func(out *envoy_config_route.Route) *envoyrbac.RBACPerRoute {
+		config := &envoyrbac.RBACPerRoute{}
+		Expect(ptypes.UnmarshalAny(out.GetTypedPerFilterConfig()[FilterName], config)).To(Succeed())
+		return config
+	}
+
+	It("only allows the requests whose client certificate matches a principal", func() {
+		out, err := processRoute()
+		Expect(err).NotTo(HaveOccurred())
+
+		rules := perRouteConfig(out).GetRbac().GetRules()
+		Expect(rules.GetAction()).To(Equal(envoy_config_rbac.RBAC_ALLOW))
+		Expect(rules.GetPolicies()).To(HaveLen(1))
+		for _, policy := range rules.GetPolicies() {
+			Expect(policy.GetPermissions()).To(HaveLen(1))
+			Expect(policy.GetPermissions()[0].GetAny()).To(BeTrue())
+			Expect(policy.GetPrincipals()).To(HaveLen(2))
+			Expect(policy.GetPrincipals()[0]).To(matchers.MatchProto(authenticated(&envoy_type_matcher.StringMatcher{
+				MatchPattern: &envoy_type_matcher.StringMatcher_Exact{Exact: "spiffe://example.org/ns/default/sa/client"},
+			})))
+			Expect(policy.GetPrincipals()[1]).To(matchers.MatchProto(authenticated(&envoy_type_matcher.StringMatcher{
+				MatchPattern: &envoy_type_matcher.StringMatcher_Prefix{Prefix: "CN=client"},
+			})))
+		}
+
+		By("adding the rbac filter without rules of its own")
+		filters := httpFilters()
+		Expect(filters).To(HaveLen(1))
+		Expect(filters[0].HttpFilter.GetName()).To(Equal(FilterName))
+		filterConfig := &envoyrbac.RBAC{}
+		Expect(ptypes.UnmarshalAny(filters[0].HttpFilter.GetTypedConfig(), filterConfig)).To(Succeed())
+		Expect(filterConfig.GetRules()).To(BeNil())
+	})
+
+	It("allows any validated client certificate without principals", func() {
+		route.GetOptions().GetClientCertificateAuthorization().Principals = nil
+		out, err := processRoute()
+		Expect(err).NotTo(HaveOccurred())
+		for _, policy := range perRouteConfig(out).GetRbac().GetRules().GetPolicies() {
+			Expect(policy.GetPrincipals()).To(ConsistOf(matchers.MatchProto(authenticated(nil))))
+		}
+	})
+
+	It("does not add the filter when no route needs it", func() {
+		route.Options = nil
+		out, err := processRoute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.GetTypedPerFilterConfig()).To(BeEmpty())
+		Expect(httpFilters()).To(BeEmpty())
+	})
+
+	It("rejects invalid principals", func() {
+		route.GetOptions().GetClientCertificateAuthorization().GetPrincipals()[0].Identity = &client_certificate.Principal_SpiffeId{SpiffeId: "example.org/client"}
+		_, err := processRoute()
+		Expect(err).To(MatchError(InvalidSpiffeIdError("example.org/client")))
+
+		route.GetOptions().GetClientCertificateAuthorization().GetPrincipals()[0].Identity = nil
+		_, err = processRoute()
+		Expect(err).To(MatchError(MissingIdentityError))
+
+		route.GetOptions().GetClientCertificateAuthorization().GetPrincipals()[0].Identity = &client_certificate.Principal_Name{Name: &gloo_type_matcher.StringMatcher{}}
+		_, err = processRoute()
+		Expect(err).To(MatchError(MissingStringMatcherError))
+	})
+})
@@ -0,0 +1,142 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/rotisserie/eris"
+)
+
+const (
+	cloudRunApiUrl       = "https://run.googleapis.com"
+	cloudFunctionsApiUrl = "https://cloudfunctions.googleapis.com"
+
+	// 2nd gen Cloud Functions are Cloud Run services, which are discovered as such
+	cloudFunctionsGen1 = "GEN_1"
+)
+
+// A Cloud Run service or a Cloud Function that can be invoked by URL.
+type cloudService struct {
+	// the short name of the service or function
+	name string
+	// the origin of the service or function, e.g. https://hello-abcdefghij-uc.a.run.app
+	url string
+	// the audience of the ID tokens, if it is not the url
+	audience string
+}
+
+// Lists the Cloud Run services and the Cloud Functions of a location with the Cloud Run Admin API and the Cloud
+// Functions API.
+type cloudApis struct {
+	// an http client that authenticates the requests with the access tokens of a service account
+	client               *http.Client
+	cloudRunApiUrl       string
+	cloudFunctionsApiUrl string
+}
+
+func newCloudApis(client *http.Client) *cloudApis {
+	return &cloudApis{
+		client:               client,
+		cloudRunApiUrl:       cloudRunApiUrl,
+		cloudFunctionsApiUrl: cloudFunctionsApiUrl,
+	}
+}
+
+// https://cloud.google.com/run/docs/reference/rest/v2/projects.locations.services/list
+type listServicesResponse struct {
+	Services []struct {
+		Name string `json:"name"`
+		Uri  string `json:"uri"`
+	} `json:"services"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// https://cloud.google.com/functions/docs/reference/rest/v2/projects.locations.functions/list
+type listFunctionsResponse struct {
+	Functions []struct {
+		Name        string `json:"name"`
+		Environment string `json:"environment"`
+		Url         string `json:"url"`
+	} `json:"functions"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+func (c *cloudApis) listCloudRunServices(ctx context.Context, project, region string) ([]cloudService, error) {
+	var services []cloudService
+	pageToken := ""
+	for {
+		var response listServicesResponse
+		if err := c.list(ctx, c.cloudRunApiUrl, project, region, "services", pageToken, &response); err != nil {
+			return nil, err
+		}
+		for _, service := range response.Services {
+			if service.Uri == "" {
+				// the service is not ready yet
+				continue
+			}
+			services = append(services, cloudService{
+				name: path.Base(service.Name),
+				url:  service.Uri,
+			})
+		}
+		if response.NextPageToken == "" {
+			return services, nil
+		}
+		pageToken = response.NextPageToken
+	}
+}
+
+func (c *cloudApis) listCloudFunctions(ctx context.Context, project, region string) ([]cloudService, error) {
+	var functions []cloudService
+	pageToken := ""
+	for {
+		var response listFunctionsResponse
+		if err := c.list(ctx, c.cloudFunctionsApiUrl, project, region, "functions", pageToken, &response); err != nil {
+			return nil, err
+		}
+		for _, function := range response.Functions {
+			if function.Environment != cloudFunctionsGen1 || function.Url == "" {
+				continue
+			}
+			// 1st gen functions share the host of the project and region, and are told apart by their path,
+			// which is also the audience of their ID tokens
+			functionUrl, err := url.Parse(function.Url)
+			if err != nil {
+				return nil, eris.Wrapf(err, "parsing the url of the cloud function %s", function.Name)
+			}
+			functions = append(functions, cloudService{
+				name:     path.Base(function.Name),
+				url:      fmt.Sprintf("%s://%s", functionUrl.Scheme, functionUrl.Host),
+				audience: function.Url,
+			})
+		}
+		if response.NextPageToken == "" {
+			return functions, nil
+		}
+		pageToken = response.NextPageToken
+	}
+}
+
+func (c *cloudApis) list(ctx context.Context, apiUrl, project, region, collection, pageToken string, response interface{}) error {
+	listUrl := fmt.Sprintf("%s/v2/projects/%s/locations/%s/%s", apiUrl, url.PathEscape(project), url.PathEscape(region), collection)
+	if pageToken != "" {
+		listUrl += "?pageToken=" + url.QueryEscape(pageToken)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, listUrl, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return eris.Wrapf(err, "listing the %s of %s/%s", collection, project, region)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return eris.Errorf("listing the %s of %s/%s: unexpected status %s", collection, project, region, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
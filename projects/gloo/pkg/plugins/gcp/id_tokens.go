@@ -0,0 +1,189 @@
+package gcp
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/solo-io/gloo/projects/gloo/pkg/translator"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// the ID tokens are refreshed when they expire in less than this margin
+	idTokenRefreshMargin = 5 * time.Minute
+	// the minimum delay between the refreshes, in case the token endpoint returns short-lived tokens
+	minIdTokenRefreshDelay = time.Minute
+	// the delays before minting a token again after a failure, doubled on each consecutive failure
+	minIdTokenRetryDelay = 5 * time.Second
+	maxIdTokenRetryDelay = 5 * time.Minute
+)
+
+// The ID tokens of the GCP upstreams are minted by gloo, with the service account keys of their secrets, and added to
+// the requests to the upstreams by their routes, so that the keys never leave gloo.
+// DefaultIdTokenCache signals Refreshes when a token it has minted is about to expire, or when a token can be minted
+// again after a failure, so that gloo translates the proxies again and the routes are updated with a new token before
+// the old one expires.
+var DefaultIdTokenCache = NewIdTokenCache()
+
+type idTokenCacheKey struct {
+	clientEmail  string
+	privateKeyId string
+	audience     string
+	tokenUri     string
+}
+
+func (k idTokenCacheKey) String() string {
+	return strings.Join([]string{k.clientEmail, k.privateKeyId, k.audience, k.tokenUri}, " ")
+}
+
+type idTokenFailure struct {
+	err     error
+	retryAt time.Time
+	// the delay before the next retry, if this one fails as well
+	nextDelay time.Duration
+}
+
+type IdTokenCache struct {
+	lock     sync.Mutex
+	tokens   map[idTokenCacheKey]*oauth2.Token
+	failures map[idTokenCacheKey]*idTokenFailure
+	// the translations which need a token that is being minted wait for it, rather than minting it as well
+	minting   singleflight.Group
+	refreshes chan struct{}
+	// mints a token with the token endpoint, overridden in tests
+	mint func(ctx context.Context, key *serviceAccountKey, audience, tokenUri string) (*oauth2.Token, error)
+}
+
+func NewIdTokenCache() *IdTokenCache {
+	return &IdTokenCache{
+		tokens:   make(map[idTokenCacheKey]*oauth2.Token),
+		failures: make(map[idTokenCacheKey]*idTokenFailure),
+		// a single pending refresh is enough to translate the proxies again
+		refreshes: make(chan struct{}, 1),
+		mint:      mintIdToken,
+	}
+}
+
+// The ID token of the service account for the audience, minted with the token endpoint if it is not cached or
+// expires soon. Failures are cached until the token can be minted again.
+func (c *IdTokenCache) idToken(ctx context.Context, key *serviceAccountKey, audience, tokenUri string) (string, error) {
+	cacheKey := idTokenCacheKey{
+		clientEmail:  key.ClientEmail,
+		privateKeyId: key.PrivateKeyId,
+		audience:     audience,
+		tokenUri:     tokenUri,
+	}
+
+	if token, err, ok := c.cached(cacheKey); ok {
+		return token, err
+	}
+
+	token, err, _ := c.minting.Do(cacheKey.String(), func() (interface{}, error) {
+		// the token may have been minted while waiting for the previous call to complete
+		if token, err, ok := c.cached(cacheKey); ok {
+			return token, err
+		}
+		token, err := c.mint(ctx, key, audience, tokenUri)
+		if err == nil && token.Expiry.IsZero() {
+			err = eris.New("the id token does not expire")
+		}
+		if err != nil {
+			err = IdTokenError(key.ClientEmail, audience, err)
+			c.fail(cacheKey, err)
+			return "", err
+		}
+		c.store(cacheKey, token)
+		return token.AccessToken, nil
+	})
+	return token.(string), err
+}
+
+// Returns the cached token, or the cached failure, and whether any is still valid.
+func (c *IdTokenCache) cached(cacheKey idTokenCacheKey) (string, error, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if token, ok := c.tokens[cacheKey]; ok && time.Until(token.Expiry) > idTokenRefreshMargin {
+		return token.AccessToken, nil, true
+	}
+	if failure, ok := c.failures[cacheKey]; ok && time.Now().Before(failure.retryAt) {
+		return "", failure.err, true
+	}
+	return "", nil, false
+}
+
+func (c *IdTokenCache) store(cacheKey idTokenCacheKey, token *oauth2.Token) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// drop the tokens that have expired, e.g. the ones of deleted upstreams
+	now := time.Now()
+	for k, cached := range c.tokens {
+		if cached.Expiry.Before(now) {
+			delete(c.tokens, k)
+		}
+	}
+	c.tokens[cacheKey] = token
+	delete(c.failures, cacheKey)
+	refreshDelay := time.Until(token.Expiry) - idTokenRefreshMargin
+	if refreshDelay < minIdTokenRefreshDelay {
+		refreshDelay = minIdTokenRefreshDelay
+	}
+	time.AfterFunc(refreshDelay, c.signalRefresh)
+}
+
+func (c *IdTokenCache) fail(cacheKey idTokenCacheKey, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	delay := minIdTokenRetryDelay
+	if failure, ok := c.failures[cacheKey]; ok {
+		delay = failure.nextDelay
+	}
+	// drop the failures that have not been retried for a while, e.g. the ones of deleted upstreams
+	for k, failure := range c.failures {
+		if failure.retryAt.Add(maxIdTokenRetryDelay).Before(now) {
+			delete(c.failures, k)
+		}
+	}
+	nextDelay := 2 * delay
+	if nextDelay > maxIdTokenRetryDelay {
+		nextDelay = maxIdTokenRetryDelay
+	}
+	c.failures[cacheKey] = &idTokenFailure{
+		err:       err,
+		retryAt:   now.Add(delay),
+		nextDelay: nextDelay,
+	}
+	time.AfterFunc(delay, c.signalRefresh)
+}
+
+// Signals that a cached token expires soon, or that a failed token can be minted again.
+func (c *IdTokenCache) Refreshes() <-chan struct{} {
+	return c.refreshes
+}
+
+func (c *IdTokenCache) signalRefresh() {
+	// the translations which used the token must not be reused
+	translator.InvalidateTranslations()
+	select {
+	case c.refreshes <- struct{}{}:
+	default:
+	}
+}
+
+// Exchanges a JWT signed with the key of the service account for a Google-signed ID token for the audience.
+// See https://developers.google.com/identity/protocols/oauth2/service-account#authorizingrequests
+func mintIdToken(ctx context.Context, key *serviceAccountKey, audience, tokenUri string) (*oauth2.Token, error) {
+	ctx, cancel := context.WithTimeout(ctx, tokenEndpointTimeout)
+	defer cancel()
+	return key.idTokenConfig(audience, tokenUri).TokenSource(ctx).Token()
+}
+
+var (
+	IdTokenError = func(clientEmail, audience string, err error) error {
+		return eris.Wrapf(err, "minting an id token of the gcp service account %s for %s", clientEmail, audience)
+	}
+)
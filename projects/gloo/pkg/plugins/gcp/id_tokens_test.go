@@ -0,0 +1,187 @@
+package gcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rotisserie/eris"
+	"golang.org/x/oauth2"
+)
+
+var _ = Describe("id tokens", func() {
+
+	var (
+		ctx   context.Context
+		cache *IdTokenCache
+		key   *serviceAccountKey
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cache = NewIdTokenCache()
+		var err error
+		key, err = parseServiceAccountKey([]byte(testServiceAccountKey()))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("with the token endpoint", func() {
+
+		var (
+			server         *httptest.Server
+			targetAudience string
+		)
+
+		BeforeEach(func() {
+			targetAudience = ""
+			mux := http.NewServeMux()
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				// the claims of the JWT signed with the key of the service account
+				assertion := strings.Split(r.FormValue("assertion"), ".")
+				Expect(assertion).To(HaveLen(3))
+				claims, err := base64.RawURLEncoding.DecodeString(assertion[1])
+				Expect(err).NotTo(HaveOccurred())
+				var claimSet map[string]interface{}
+				Expect(json.Unmarshal(claims, &claimSet)).To(Succeed())
+				targetAudience, _ = claimSet["target_audience"].(string)
+
+				payload, _ := json.Marshal(map[string]interface{}{
+					"aud": targetAudience,
+					"exp": time.Now().Add(time.Hour).Unix(),
+				})
+				idToken := "e30." + base64.RawURLEncoding.EncodeToString(payload) + ".c2lnbmF0dXJl"
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"id_token": idToken,
+				})
+			})
+			server = httptest.NewServer(mux)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should mint an id token for the audience", func() {
+			idToken, err := cache.idToken(ctx, key, "https://hello-abcdefghij-uc.a.run.app", server.URL+"/token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(idToken).To(HavePrefix("e30."))
+			Expect(targetAudience).To(Equal("https://hello-abcdefghij-uc.a.run.app"))
+		})
+
+		It("should error when the token endpoint rejects the key", func() {
+			_, err := cache.idToken(ctx, key, "https://hello-abcdefghij-uc.a.run.app", server.URL+"/unknown")
+			Expect(err).To(MatchError(ContainSubstring("minting an id token of the gcp service account invoker@project.iam.gserviceaccount.com for https://hello-abcdefghij-uc.a.run.app")))
+		})
+	})
+
+	Context("cached tokens", func() {
+
+		var (
+			minted int
+			expiry time.Duration
+		)
+
+		BeforeEach(func() {
+			minted = 0
+			expiry = time.Hour
+			cache.mint = func(_ context.Context, _ *serviceAccountKey, _, _ string) (*oauth2.Token, error) {
+				minted++
+				return &oauth2.Token{AccessToken: "id-token", Expiry: time.Now().Add(expiry)}, nil
+			}
+		})
+
+		It("should reuse the token while it is valid", func() {
+			for i := 0; i < 3; i++ {
+				idToken, err := cache.idToken(ctx, key, "https://hello-abcdefghij-uc.a.run.app", DefaultTokenUri)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(idToken).To(Equal("id-token"))
+			}
+			Expect(minted).To(Equal(1))
+		})
+
+		It("should mint a token per audience", func() {
+			_, err := cache.idToken(ctx, key, "https://hello-abcdefghij-uc.a.run.app", DefaultTokenUri)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cache.idToken(ctx, key, "https://world-abcdefghij-uc.a.run.app", DefaultTokenUri)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(minted).To(Equal(2))
+		})
+
+		It("should mint a new token when the cached one expires soon", func() {
+			expiry = idTokenRefreshMargin - time.Second
+			_, err := cache.idToken(ctx, key, "https://hello-abcdefghij-uc.a.run.app", DefaultTokenUri)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cache.idToken(ctx, key, "https://hello-abcdefghij-uc.a.run.app", DefaultTokenUri)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(minted).To(Equal(2))
+		})
+
+		It("should error on tokens that do not expire", func() {
+			cache.mint = func(_ context.Context, _ *serviceAccountKey, _, _ string) (*oauth2.Token, error) {
+				return &oauth2.Token{AccessToken: "id-token"}, nil
+			}
+			_, err := cache.idToken(ctx, key, "https://hello-abcdefghij-uc.a.run.app", DefaultTokenUri)
+			Expect(err).To(MatchError(ContainSubstring("the id token does not expire")))
+		})
+
+		It("should mint a token once for concurrent translations", func() {
+			release := make(chan struct{})
+			cache.mint = func(_ context.Context, _ *serviceAccountKey, _, _ string) (*oauth2.Token, error) {
+				minted++
+				<-release
+				return &oauth2.Token{AccessToken: "id-token", Expiry: time.Now().Add(expiry)}, nil
+			}
+
+			var wg sync.WaitGroup
+			for i := 0; i < 2; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					idToken, err := cache.idToken(ctx, key, "https://hello-abcdefghij-uc.a.run.app", DefaultTokenUri)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(idToken).To(Equal("id-token"))
+				}()
+			}
+			// let both translations wait for the token before minting it
+			time.Sleep(100 * time.Millisecond)
+			close(release)
+			wg.Wait()
+			Expect(minted).To(Equal(1))
+		})
+
+		It("should not mint a token again until the failure can be retried", func() {
+			cache.mint = func(_ context.Context, _ *serviceAccountKey, _, _ string) (*oauth2.Token, error) {
+				minted++
+				return nil, eris.New("unavailable")
+			}
+			for i := 0; i < 3; i++ {
+				_, err := cache.idToken(ctx, key, "https://hello-abcdefghij-uc.a.run.app", DefaultTokenUri)
+				Expect(err).To(MatchError(ContainSubstring("unavailable")))
+			}
+			Expect(minted).To(Equal(1))
+
+			By("minting the token again once the failure can be retried")
+			for _, failure := range cache.failures {
+				Expect(failure.retryAt).To(BeTemporally("~", time.Now().Add(minIdTokenRetryDelay), time.Second))
+				failure.retryAt = time.Now()
+			}
+			_, err := cache.idToken(ctx, key, "https://hello-abcdefghij-uc.a.run.app", DefaultTokenUri)
+			Expect(err).To(HaveOccurred())
+			Expect(minted).To(Equal(2))
+
+			By("backing off after consecutive failures")
+			for _, failure := range cache.failures {
+				Expect(failure.retryAt).To(BeTemporally("~", time.Now().Add(2*minIdTokenRetryDelay), time.Second))
+			}
+		})
+	})
+})
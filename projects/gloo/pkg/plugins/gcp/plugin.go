@@ -0,0 +1,243 @@
+package gcp
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoyauth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/rotisserie/eris"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/discovery"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins/pluginutils"
+	"github.com/solo-io/gloo/projects/gloo/pkg/translator"
+	"github.com/solo-io/gloo/projects/gloo/pkg/upstreams"
+	"github.com/solo-io/gloo/projects/gloo/pkg/utils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/factory"
+)
+
+var (
+	_ plugins.Plugin            = new(plugin)
+	_ plugins.UpstreamPlugin    = new(plugin)
+	_ plugins.RoutePlugin       = new(plugin)
+	_ discovery.DiscoveryPlugin = new(plugin)
+)
+
+const (
+	ExtensionName = "gcp"
+
+	DefaultTokenUri = "https://oauth2.googleapis.com/token"
+)
+
+var (
+	tokenEndpointTimeout = 5 * time.Second
+)
+
+/*
+Steps:
+- User creates a GCP upstream with the URL of a Cloud Run service or a Cloud Function, or discovery creates one for
+  each service and function of the locations of the settings
+- Gloo plugin creates a cluster for the host of the URL, and mints a Google-signed ID token for the audience of the
+  upstream with the service account key of its secret
+- The routes to the upstream add the ID token to the requests in the authorization header
+- Gloo translates the proxies again before the ID tokens expire, to update the routes with new tokens
+*/
+
+type plugin struct {
+	secretClient v1.SecretClient
+	idTokens     *IdTokenCache
+
+	settings *v1.Settings
+	// the ID tokens of the clusters of the GCP upstreams
+	clusterIdTokens map[string]string
+
+	// pre-initialization only
+	// we need to register the secret client while creating the plugin, since discovery does not call Init
+	// since NewPlugin does not return an error, we will store any non-nil errors from initializing the secret client in the plugin struct
+	// we will check those errors during the Init call
+	constructorErr error
+}
+
+func NewPlugin(ctx context.Context, secretFactory factory.ResourceClientFactory) *plugin {
+	p := &plugin{idTokens: DefaultIdTokenCache}
+	var err error
+	if secretFactory == nil {
+		p.constructorErr = ConstructorInputError("secret")
+		return p
+	}
+	p.secretClient, err = v1.NewSecretClient(ctx, secretFactory)
+	if err != nil {
+		p.constructorErr = ConstructorGetClientError("secret", err)
+		return p
+	}
+	if err := p.secretClient.Register(); err != nil {
+		p.constructorErr = ConstructorRegisterClientError("secret", err)
+		return p
+	}
+	return p
+}
+
+func (p *plugin) Name() string {
+	return ExtensionName
+}
+
+func (p *plugin) Init(params plugins.InitParams) error {
+	p.settings = params.Settings
+	p.clusterIdTokens = make(map[string]string)
+	return p.constructorErr
+}
+
+func (p *plugin) ProcessUpstream(params plugins.Params, in *v1.Upstream, out *envoy_config_cluster_v3.Cluster) error {
+	upstreamSpec, ok := in.GetUpstreamType().(*v1.Upstream_Gcp)
+	if !ok {
+		// not ours
+		return nil
+	}
+	gcpUpstream := upstreamSpec.Gcp
+
+	serviceUrl, err := url.Parse(gcpUpstream.GetUrl())
+	if err != nil || serviceUrl.Scheme != "https" || serviceUrl.Hostname() == "" ||
+		(serviceUrl.Path != "" && serviceUrl.Path != "/") || serviceUrl.RawQuery != "" {
+		return InvalidUrlError(gcpUpstream.GetUrl())
+	}
+
+	if gcpUpstream.GetSecretRef() == nil {
+		return NoSecretError()
+	}
+	secret, err := params.Snapshot.Secrets.Find(gcpUpstream.GetSecretRef().Strings())
+	if err != nil {
+		return eris.Wrapf(err, "retrieving gcp secret")
+	}
+	gcpSecret, ok := secret.GetKind().(*v1.Secret_Gcp)
+	if !ok {
+		return WrongSecretTypeError(secret)
+	}
+	key, err := parseServiceAccountKey([]byte(gcpSecret.Gcp.GetServiceAccountKey()))
+	if err != nil {
+		return err
+	}
+
+	// configure Envoy cluster routing info
+	out.ClusterDiscoveryType = &envoy_config_cluster_v3.Cluster_Type{
+		Type: envoy_config_cluster_v3.Cluster_LOGICAL_DNS,
+	}
+	out.DnsLookupFamily = envoy_config_cluster_v3.Cluster_V4_ONLY
+	if err := p.setAddress(out, serviceUrl); err != nil {
+		return err
+	}
+
+	audience := gcpUpstream.GetAudience()
+	if audience == "" {
+		audience = strings.TrimSuffix(gcpUpstream.GetUrl(), "/")
+	}
+	idToken, err := p.idTokens.idToken(params.Ctx, key, audience, p.tokenUri())
+	if err != nil {
+		return err
+	}
+	p.clusterIdTokens[out.GetName()] = idToken
+	return nil
+}
+
+// Adds the ID token of the upstream to the requests sent to GCP upstreams.
+func (p *plugin) ProcessRoute(params plugins.RouteParams, in *v1.Route, out *envoy_config_route_v3.Route) error {
+	if len(p.clusterIdTokens) == 0 {
+		return nil
+	}
+	return pluginutils.MarkHeaders(params.Ctx, params.Snapshot, in, out, func(spec *v1.Destination) ([]*envoy_config_core_v3.HeaderValueOption, error) {
+		upstreamRef, err := upstreams.DestinationToUpstreamRef(spec)
+		if err != nil {
+			return nil, err
+		}
+		idToken, ok := p.clusterIdTokens[translator.UpstreamToClusterName(upstreamRef)]
+		if !ok {
+			// not a gcp upstream, or its id token could not be minted
+			return nil, nil
+		}
+		return []*envoy_config_core_v3.HeaderValueOption{{
+			Header: &envoy_config_core_v3.HeaderValue{
+				Key:   "authorization",
+				Value: "Bearer " + idToken,
+			},
+			Append: &wrappers.BoolValue{Value: false},
+		}}, nil
+	})
+}
+
+// Points the cluster to the host of the https URL.
+func (p *plugin) setAddress(out *envoy_config_cluster_v3.Cluster, u *url.URL) error {
+	port := uint32(443)
+	if u.Port() != "" {
+		parsedPort, err := strconv.ParseUint(u.Port(), 10, 16)
+		if err != nil {
+			return InvalidUrlError(u.String())
+		}
+		port = uint32(parsedPort)
+	}
+	pluginutils.EnvoySingleEndpointLoadAssignment(out, u.Hostname(), port)
+
+	commonTlsContext, err := utils.GetCommonTlsContextFromUpstreamOptions(p.settings.GetUpstreamOptions())
+	if err != nil {
+		return err
+	}
+	tlsContext := &envoyauth.UpstreamTlsContext{
+		CommonTlsContext: commonTlsContext,
+		Sni:              u.Hostname(),
+	}
+	out.TransportSocket = &envoy_config_core_v3.TransportSocket{
+		Name:       wellknown.TransportSocketTls,
+		ConfigType: &envoy_config_core_v3.TransportSocket_TypedConfig{TypedConfig: utils.MustMessageToAny(tlsContext)},
+	}
+	return nil
+}
+
+func (p *plugin) tokenUri() string {
+	return getTokenUri(p.settings.GetGloo().GetGcpOptions())
+}
+
+func getTokenUri(gcpOptions *v1.GlooOptions_GCPOptions) string {
+	if tokenUri := gcpOptions.GetTokenUri(); tokenUri != "" {
+		return tokenUri
+	}
+	return DefaultTokenUri
+}
+
+var (
+	ConstructorInputError = func(factoryType string) error {
+		return eris.Errorf("must provide %v factory for GCP plugin", factoryType)
+	}
+
+	ConstructorGetClientError = func(name string, err error) error {
+		return eris.Wrapf(err, "unable to get %v client for GCP plugin", name)
+	}
+
+	ConstructorRegisterClientError = func(name string, err error) error {
+		return eris.Wrapf(err, "unable to register %v client for GCP plugin", name)
+	}
+
+	InvalidUrlError = func(u string) error {
+		return eris.Errorf("invalid gcp upstream url %q: it must be an https URL without a path, "+
+			"e.g. https://hello-abcdefghij-uc.a.run.app", u)
+	}
+
+	NoSecretError = func() error {
+		return eris.New("no gcp secret provided. a secret with the key of the service account that invokes the " +
+			"service or function is required")
+	}
+
+	WrongSecretTypeError = func(secret *v1.Secret) error {
+		return eris.Errorf("secret (%s.%s) is not a GCP secret", secret.GetMetadata().GetName(), secret.GetMetadata().GetNamespace())
+	}
+
+	WrongUpstreamTypeError = func(upstream *v1.Upstream) error {
+		return eris.Errorf("internal error: expected *v1.Upstream_Gcp, got %v", reflect.TypeOf(upstream.GetUpstreamType()).Name())
+	}
+)
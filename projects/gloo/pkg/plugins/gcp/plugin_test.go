@@ -0,0 +1,305 @@
+package gcp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"time"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoyauth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/rotisserie/eris"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	v1snap "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/gloosnapshot"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/gcp"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/factory"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/memory"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	"golang.org/x/oauth2"
+)
+
+var _ = Describe("Plugin", func() {
+
+	var (
+		p        *plugin
+		params   plugins.Params
+		settings *v1.Settings
+		upstream *v1.Upstream
+		out      *envoy_config_cluster_v3.Cluster
+		key      string
+
+		// the audiences and token uris of the minted tokens
+		mintedAudiences []string
+		mintedTokenUris []string
+		mintErr         error
+	)
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		p = NewPlugin(ctx, &factory.MemoryResourceClientFactory{Cache: memory.NewInMemoryResourceCache()})
+		mintedAudiences, mintedTokenUris, mintErr = nil, nil, nil
+		p.idTokens = NewIdTokenCache()
+		p.idTokens.mint = func(_ context.Context, _ *serviceAccountKey, audience, tokenUri string) (*oauth2.Token, error) {
+			if mintErr != nil {
+				return nil, mintErr
+			}
+			mintedAudiences = append(mintedAudiences, audience)
+			mintedTokenUris = append(mintedTokenUris, tokenUri)
+			return &oauth2.Token{AccessToken: "id-token-for-" + audience, Expiry: time.Now().Add(time.Hour)}, nil
+		}
+		settings = &v1.Settings{}
+
+		key = testServiceAccountKey()
+		params = plugins.Params{
+			Ctx: ctx,
+			Snapshot: &v1snap.ApiSnapshot{
+				Secrets: v1.SecretList{{
+					Metadata: &core.Metadata{Name: "gcp-secret", Namespace: "gloo-system"},
+					Kind: &v1.Secret_Gcp{
+						Gcp: &v1.GcpSecret{ServiceAccountKey: key},
+					},
+				}},
+			},
+		}
+		upstream = &v1.Upstream{
+			Metadata: &core.Metadata{Name: "hello", Namespace: "gloo-system"},
+			UpstreamType: &v1.Upstream_Gcp{
+				Gcp: &gcp.UpstreamSpec{
+					Url:       "https://hello-abcdefghij-uc.a.run.app",
+					SecretRef: &core.ResourceRef{Name: "gcp-secret", Namespace: "gloo-system"},
+				},
+			},
+		}
+		out = &envoy_config_cluster_v3.Cluster{Name: "hello_gloo-system"}
+	})
+
+	JustBeforeEach(func() {
+		err := p.Init(plugins.InitParams{Ctx: context.Background(), Settings: settings})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("upstreams", func() {
+
+		It("should point the cluster to the host of the url", func() {
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(out.GetType()).To(Equal(envoy_config_cluster_v3.Cluster_LOGICAL_DNS))
+			Expect(out.GetDnsLookupFamily()).To(Equal(envoy_config_cluster_v3.Cluster_V4_ONLY))
+			address := out.GetLoadAssignment().GetEndpoints()[0].GetLbEndpoints()[0].GetEndpoint().GetAddress().GetSocketAddress()
+			Expect(address.GetAddress()).To(Equal("hello-abcdefghij-uc.a.run.app"))
+			Expect(address.GetPortValue()).To(BeEquivalentTo(443))
+
+			var tlsContext envoyauth.UpstreamTlsContext
+			err = ptypes.UnmarshalAny(out.GetTransportSocket().GetTypedConfig(), &tlsContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tlsContext.GetSni()).To(Equal("hello-abcdefghij-uc.a.run.app"))
+		})
+
+		It("should not send the service account key to envoy", func() {
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.GetTypedExtensionProtocolOptions()).To(BeEmpty())
+		})
+
+		It("should mint the id token for the url with the default token uri", func() {
+			upstream.GetGcp().Url = "https://hello-abcdefghij-uc.a.run.app/"
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mintedAudiences).To(Equal([]string{"https://hello-abcdefghij-uc.a.run.app"}))
+			Expect(mintedTokenUris).To(Equal([]string{DefaultTokenUri}))
+		})
+
+		It("should mint the id token for the audience of the upstream", func() {
+			upstream.GetGcp().Url = "https://us-central1-project.cloudfunctions.net"
+			upstream.GetGcp().Audience = "https://us-central1-project.cloudfunctions.net/hello"
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mintedAudiences).To(Equal([]string{"https://us-central1-project.cloudfunctions.net/hello"}))
+		})
+
+		Context("with a token uri in the settings", func() {
+
+			BeforeEach(func() {
+				settings.Gloo = &v1.GlooOptions{
+					GcpOptions: &v1.GlooOptions_GCPOptions{TokenUri: "http://token-server.default:8080/token"},
+				}
+			})
+
+			It("should mint the id token with the token uri of the settings", func() {
+				err := p.ProcessUpstream(params, upstream, out)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mintedTokenUris).To(Equal([]string{"http://token-server.default:8080/token"}))
+			})
+		})
+
+		It("should error when the id token cannot be minted", func() {
+			mintErr = eris.New("invalid_grant")
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).To(MatchError(ContainSubstring("minting an id token of the gcp service account invoker@project.iam.gserviceaccount.com")))
+			Expect(err).To(MatchError(ContainSubstring("invalid_grant")))
+		})
+
+		It("should error on urls with a path", func() {
+			upstream.GetGcp().Url = "https://us-central1-project.cloudfunctions.net/hello"
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).To(MatchError(ContainSubstring("invalid gcp upstream url")))
+		})
+
+		It("should error on urls that are not https", func() {
+			upstream.GetGcp().Url = "http://hello-abcdefghij-uc.a.run.app"
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).To(MatchError(ContainSubstring("invalid gcp upstream url")))
+		})
+
+		It("should error without a secret", func() {
+			upstream.GetGcp().SecretRef = nil
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).To(MatchError(NoSecretError()))
+		})
+
+		It("should error on secrets that are not gcp secrets", func() {
+			params.Snapshot.Secrets[0].Kind = &v1.Secret_Aws{Aws: &v1.AwsSecret{AccessKey: "a", SecretKey: "b"}}
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).To(MatchError(ContainSubstring("is not a GCP secret")))
+		})
+
+		It("should error on invalid service account keys", func() {
+			params.Snapshot.Secrets[0].GetGcp().ServiceAccountKey = `{"client_email":"invoker@project.iam.gserviceaccount.com"}`
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).To(MatchError(ContainSubstring("invalid gcp service account key")))
+		})
+
+		It("should ignore other upstreams", func() {
+			upstream.UpstreamType = &v1.Upstream_Static{}
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.GetLoadAssignment()).To(BeNil())
+			Expect(mintedAudiences).To(BeEmpty())
+		})
+	})
+
+	Context("routes", func() {
+
+		var (
+			routeParams plugins.RouteParams
+			otherRef    *core.ResourceRef
+		)
+
+		BeforeEach(func() {
+			routeParams = plugins.RouteParams{VirtualHostParams: plugins.VirtualHostParams{Params: params}}
+			otherRef = &core.ResourceRef{Name: "other", Namespace: "gloo-system"}
+		})
+
+		JustBeforeEach(func() {
+			err := p.ProcessUpstream(params, upstream, out)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		destination := func(ref *core.ResourceRef) *v1.Destination {
+			return &v1.Destination{DestinationType: &v1.Destination_Upstream{Upstream: ref}}
+		}
+
+		authorization := &envoy_config_core_v3.HeaderValueOption{
+			Header: &envoy_config_core_v3.HeaderValue{
+				Key:   "authorization",
+				Value: "Bearer id-token-for-https://hello-abcdefghij-uc.a.run.app",
+			},
+			Append: &wrappers.BoolValue{Value: false},
+		}
+
+		It("should add the id token to the requests of routes to the upstream", func() {
+			in := &v1.Route{
+				Action: &v1.Route_RouteAction{RouteAction: &v1.RouteAction{
+					Destination: &v1.RouteAction_Single{Single: destination(upstream.GetMetadata().Ref())},
+				}},
+			}
+			outRoute := &envoy_config_route_v3.Route{
+				Action: &envoy_config_route_v3.Route_Route{Route: &envoy_config_route_v3.RouteAction{
+					ClusterSpecifier: &envoy_config_route_v3.RouteAction_Cluster{Cluster: "hello_gloo-system"},
+				}},
+			}
+
+			err := p.ProcessRoute(routeParams, in, outRoute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outRoute.GetRequestHeadersToAdd()).To(ConsistOf(authorization))
+		})
+
+		It("should only add the id token to the requests of the destinations of the upstream", func() {
+			in := &v1.Route{
+				Action: &v1.Route_RouteAction{RouteAction: &v1.RouteAction{
+					Destination: &v1.RouteAction_Multi{Multi: &v1.MultiDestination{
+						Destinations: []*v1.WeightedDestination{
+							{Destination: destination(upstream.GetMetadata().Ref()), Weight: 1},
+							{Destination: destination(otherRef), Weight: 1},
+						},
+					}},
+				}},
+			}
+			outRoute := &envoy_config_route_v3.Route{
+				Action: &envoy_config_route_v3.Route_Route{Route: &envoy_config_route_v3.RouteAction{
+					ClusterSpecifier: &envoy_config_route_v3.RouteAction_WeightedClusters{
+						WeightedClusters: &envoy_config_route_v3.WeightedCluster{
+							Clusters: []*envoy_config_route_v3.WeightedCluster_ClusterWeight{
+								{Name: "hello_gloo-system"},
+								{Name: "other_gloo-system"},
+							},
+						},
+					},
+				}},
+			}
+
+			err := p.ProcessRoute(routeParams, in, outRoute)
+			Expect(err).NotTo(HaveOccurred())
+			clusters := outRoute.GetRoute().GetWeightedClusters().GetClusters()
+			Expect(clusters[0].GetRequestHeadersToAdd()).To(ConsistOf(authorization))
+			Expect(clusters[1].GetRequestHeadersToAdd()).To(BeEmpty())
+			Expect(outRoute.GetRequestHeadersToAdd()).To(BeEmpty())
+		})
+
+		It("should ignore routes to other upstreams", func() {
+			in := &v1.Route{
+				Action: &v1.Route_RouteAction{RouteAction: &v1.RouteAction{
+					Destination: &v1.RouteAction_Single{Single: destination(otherRef)},
+				}},
+			}
+			outRoute := &envoy_config_route_v3.Route{
+				Action: &envoy_config_route_v3.Route_Route{Route: &envoy_config_route_v3.RouteAction{
+					ClusterSpecifier: &envoy_config_route_v3.RouteAction_Cluster{Cluster: "other_gloo-system"},
+				}},
+			}
+
+			err := p.ProcessRoute(routeParams, in, outRoute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outRoute.GetRequestHeadersToAdd()).To(BeEmpty())
+		})
+	})
+})
+
+// a service account key with a freshly generated private key
+func testServiceAccountKey() string {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	Expect(err).NotTo(HaveOccurred())
+	key, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "invoker@project.iam.gserviceaccount.com",
+		"private_key_id": "key-id",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return string(key)
+}
@@ -0,0 +1,59 @@
+package gcp
+
+import (
+	"encoding/json"
+
+	"github.com/rotisserie/eris"
+	"golang.org/x/oauth2/jwt"
+)
+
+// https://cloud.google.com/iam/docs/understanding-roles#cloud-platform-roles
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// The fields of the JSON key of a service account that are needed to mint its tokens.
+type serviceAccountKey struct {
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyId string `json:"private_key_id"`
+}
+
+func parseServiceAccountKey(key []byte) (*serviceAccountKey, error) {
+	var parsed serviceAccountKey
+	if err := json.Unmarshal(key, &parsed); err != nil {
+		return nil, InvalidServiceAccountKeyError(err)
+	}
+	if parsed.ClientEmail == "" || parsed.PrivateKey == "" {
+		return nil, InvalidServiceAccountKeyError(eris.New("client_email and private_key are required"))
+	}
+	return &parsed, nil
+}
+
+// The configuration that mints the access tokens of the service account, which discovery uses to call the Google
+// Cloud APIs.
+func (k *serviceAccountKey) accessTokenConfig(tokenUri string) *jwt.Config {
+	return &jwt.Config{
+		Email:        k.ClientEmail,
+		PrivateKey:   []byte(k.PrivateKey),
+		PrivateKeyID: k.PrivateKeyId,
+		Scopes:       []string{cloudPlatformScope},
+		TokenURL:     tokenUri,
+	}
+}
+
+// The configuration that mints the ID tokens of the service account for the audience.
+func (k *serviceAccountKey) idTokenConfig(audience, tokenUri string) *jwt.Config {
+	return &jwt.Config{
+		Email:         k.ClientEmail,
+		PrivateKey:    []byte(k.PrivateKey),
+		PrivateKeyID:  k.PrivateKeyId,
+		PrivateClaims: map[string]interface{}{"target_audience": audience},
+		UseIDToken:    true,
+		TokenURL:      tokenUri,
+	}
+}
+
+var (
+	InvalidServiceAccountKeyError = func(err error) error {
+		return eris.Wrapf(err, "invalid gcp service account key")
+	}
+)
@@ -0,0 +1,199 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"github.com/solo-io/gloo/pkg/utils/settingsutil"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/gcp"
+	"github.com/solo-io/gloo/projects/gloo/pkg/discovery"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins/utils"
+	"github.com/solo-io/go-utils/contextutils"
+	"github.com/solo-io/k8s-utils/kubeutils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+)
+
+// UDS API
+// lists the Cloud Run services and the Cloud Functions of the discovery locations of the settings, and sends an
+// upstream for each of them
+func (p *plugin) DiscoverUpstreams(_ []string, writeNamespace string, opts clients.WatchOpts, _ discovery.Opts) (chan v1.UpstreamList, chan error, error) {
+	gcpOptions := settingsutil.MaybeFromContext(opts.Ctx).GetGloo().GetGcpOptions()
+	if len(gcpOptions.GetDiscoveryLocations()) == 0 {
+		// discovery is not enabled
+		return nil, nil, nil
+	}
+	if gcpOptions.GetDiscoverySecretRef() == nil {
+		return nil, nil, NoDiscoverySecretError()
+	}
+	if p.constructorErr != nil {
+		return nil, nil, p.constructorErr
+	}
+	contextutils.LoggerFrom(opts.Ctx).Debugw("starting GCP upstream discovery")
+	return newUpstreamsWatcher(opts.Ctx, writeNamespace, gcpOptions, p.secretClient, opts.RefreshRate).poll()
+}
+
+type udsWatcher struct {
+	watchContext   context.Context
+	writeNamespace string
+	gcpOptions     *v1.GlooOptions_GCPOptions
+	secretClient   v1.SecretClient
+	refreshRate    time.Duration
+	// the Cloud APIs, authenticated with the service account key of the discovery secret
+	newCloudApis func(ctx context.Context, key *serviceAccountKey, tokenUri string) *cloudApis
+}
+
+func newUpstreamsWatcher(watchCtx context.Context, writeNamespace string, gcpOptions *v1.GlooOptions_GCPOptions, secretClient v1.SecretClient, parentRefreshRate time.Duration) *udsWatcher {
+	return &udsWatcher{
+		watchContext:   watchCtx,
+		writeNamespace: writeNamespace,
+		gcpOptions:     gcpOptions,
+		secretClient:   secretClient,
+		refreshRate:    getRefreshRate(parentRefreshRate),
+		newCloudApis: func(ctx context.Context, key *serviceAccountKey, tokenUri string) *cloudApis {
+			return newCloudApis(key.accessTokenConfig(tokenUri).Client(ctx))
+		},
+	}
+}
+
+// we call the Google Cloud APIs during our watches, and do not expect new services and functions very frequently,
+// so we set a minimum refresh rate of thirty seconds
+const minRefreshRate = 30 * time.Second
+
+func getRefreshRate(parentRefreshRate time.Duration) time.Duration {
+	if parentRefreshRate < minRefreshRate {
+		return minRefreshRate
+	}
+	return parentRefreshRate
+}
+
+func (w *udsWatcher) updateUpstreamsList(upstreamsChan chan v1.UpstreamList, errs chan error) {
+	upstreams, err := w.discoverUpstreams()
+	if err != nil {
+		select {
+		case <-w.watchContext.Done():
+		case errs <- err:
+		}
+		return
+	}
+	select {
+	case <-w.watchContext.Done():
+	case upstreamsChan <- upstreams:
+	}
+}
+
+func (w *udsWatcher) discoverUpstreams() (v1.UpstreamList, error) {
+	secretRef := w.gcpOptions.GetDiscoverySecretRef()
+	secret, err := w.secretClient.Read(secretRef.GetNamespace(), secretRef.GetName(), clients.ReadOpts{Ctx: w.watchContext})
+	if err != nil {
+		return nil, eris.Wrapf(err, "retrieving gcp discovery secret")
+	}
+	gcpSecret, ok := secret.GetKind().(*v1.Secret_Gcp)
+	if !ok {
+		return nil, WrongSecretTypeError(secret)
+	}
+	key, err := parseServiceAccountKey([]byte(gcpSecret.Gcp.GetServiceAccountKey()))
+	if err != nil {
+		return nil, err
+	}
+	apis := w.newCloudApis(w.watchContext, key, getTokenUri(w.gcpOptions))
+
+	var upstreams v1.UpstreamList
+	for _, location := range w.gcpOptions.GetDiscoveryLocations() {
+		services, err := apis.listCloudRunServices(w.watchContext, location.GetProject(), location.GetRegion())
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range services {
+			upstreams = append(upstreams, w.upstream("run", location, service))
+		}
+		functions, err := apis.listCloudFunctions(w.watchContext, location.GetProject(), location.GetRegion())
+		if err != nil {
+			return nil, err
+		}
+		for _, function := range functions {
+			upstreams = append(upstreams, w.upstream("function", location, function))
+		}
+	}
+	return upstreams, nil
+}
+
+func (w *udsWatcher) upstream(kind string, location *v1.GlooOptions_GCPOptions_DiscoveryLocation, service cloudService) *v1.Upstream {
+	return &v1.Upstream{
+		Metadata: &core.Metadata{
+			Name:      kubeutils.SanitizeNameV2(fmt.Sprintf("gcp-%s-%s-%s-%s", kind, location.GetProject(), location.GetRegion(), service.name)),
+			Namespace: w.writeNamespace,
+		},
+		UpstreamType: &v1.Upstream_Gcp{
+			Gcp: &gcp.UpstreamSpec{
+				Url:       service.url,
+				SecretRef: w.gcpOptions.GetDiscoverySecretRef(),
+				Audience:  service.audience,
+			},
+		},
+	}
+}
+
+func (w *udsWatcher) poll() (chan v1.UpstreamList, chan error, error) {
+	upstreamsChan := make(chan v1.UpstreamList)
+	errs := make(chan error)
+	go func() {
+		defer close(upstreamsChan)
+		defer close(errs)
+
+		w.updateUpstreamsList(upstreamsChan, errs)
+		ticker := time.NewTicker(w.refreshRate)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case _, ok := <-ticker.C:
+				if !ok {
+					return
+				}
+				w.updateUpstreamsList(upstreamsChan, errs)
+			case <-w.watchContext.Done():
+				return
+			}
+		}
+	}()
+	return upstreamsChan, errs, nil
+}
+
+// the discovered upstreams only have the url, the secret and the audience of the services and functions
+func (p *plugin) UpdateUpstream(original, desired *v1.Upstream) (bool, error) {
+	originalSpec, ok := original.GetUpstreamType().(*v1.Upstream_Gcp)
+	if !ok {
+		return false, WrongUpstreamTypeError(original)
+	}
+	desiredSpec, ok := desired.GetUpstreamType().(*v1.Upstream_Gcp)
+	if !ok {
+		return false, WrongUpstreamTypeError(desired)
+	}
+
+	utils.UpdateUpstream(original, desired)
+
+	if originalSpec.Gcp.Equal(desiredSpec.Gcp) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// EDS API
+// GCP upstreams are resolved with DNS, there are no endpoints to discover
+// an empty list is sent once, so that the endpoint discovery knows that this plugin is ready
+func (p *plugin) WatchEndpoints(_ string, _ v1.UpstreamList, _ clients.WatchOpts) (<-chan v1.EndpointList, <-chan error, error) {
+	endpoints := make(chan v1.EndpointList, 1)
+	endpoints <- v1.EndpointList{}
+	close(endpoints)
+	return endpoints, nil, nil
+}
+
+var (
+	NoDiscoverySecretError = func() error {
+		return eris.New("gcp discovery requires the discoverySecretRef of the gcp options of the settings")
+	}
+)
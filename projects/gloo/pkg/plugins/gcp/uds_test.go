@@ -0,0 +1,189 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/gcp"
+	"github.com/solo-io/gloo/projects/gloo/pkg/discovery"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/factory"
+	"github.com/solo-io/solo-kit/pkg/api/v1/clients/memory"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+)
+
+var _ = Describe("discovery", func() {
+
+	var (
+		ctx          context.Context
+		cancel       context.CancelFunc
+		server       *httptest.Server
+		secretClient v1.SecretClient
+		gcpOptions   *v1.GlooOptions_GCPOptions
+		secretRef    *core.ResourceRef
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "access-token",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			})
+		})
+		authorized := func(handler http.HandlerFunc) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer access-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				handler(w, r)
+			}
+		}
+		mux.HandleFunc("/v2/projects/project/locations/us-central1/services", authorized(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("pageToken") == "" {
+				_, _ = w.Write([]byte(`{
+					"services": [
+						{"name": "projects/project/locations/us-central1/services/hello", "uri": "https://hello-abcdefghij-uc.a.run.app"},
+						{"name": "projects/project/locations/us-central1/services/deploying"}
+					],
+					"nextPageToken": "next"
+				}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{
+				"services": [
+					{"name": "projects/project/locations/us-central1/services/world", "uri": "https://world-abcdefghij-uc.a.run.app"}
+				]
+			}`))
+		}))
+		mux.HandleFunc("/v2/projects/project/locations/us-central1/functions", authorized(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{
+				"functions": [
+					{"name": "projects/project/locations/us-central1/functions/gen1", "environment": "GEN_1", "url": "https://us-central1-project.cloudfunctions.net/gen1"},
+					{"name": "projects/project/locations/us-central1/functions/gen2", "environment": "GEN_2", "url": "https://gen2-abcdefghij-uc.a.run.app"}
+				]
+			}`))
+		}))
+		server = httptest.NewServer(mux)
+
+		secretClient, _ = v1.NewSecretClient(ctx, &factory.MemoryResourceClientFactory{Cache: memory.NewInMemoryResourceCache()})
+		secretRef = &core.ResourceRef{Name: "gcp-discovery", Namespace: "gloo-system"}
+		_, err := secretClient.Write(&v1.Secret{
+			Metadata: &core.Metadata{Name: secretRef.GetName(), Namespace: secretRef.GetNamespace()},
+			Kind: &v1.Secret_Gcp{
+				Gcp: &v1.GcpSecret{ServiceAccountKey: testServiceAccountKey()},
+			},
+		}, clients.WriteOpts{})
+		Expect(err).NotTo(HaveOccurred())
+
+		gcpOptions = &v1.GlooOptions_GCPOptions{
+			TokenUri: server.URL + "/token",
+			DiscoveryLocations: []*v1.GlooOptions_GCPOptions_DiscoveryLocation{{
+				Project: "project",
+				Region:  "us-central1",
+			}},
+			DiscoverySecretRef: secretRef,
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+		server.Close()
+	})
+
+	testWatcher := func() *udsWatcher {
+		w := newUpstreamsWatcher(ctx, "gloo-system", gcpOptions, secretClient, time.Second)
+		w.newCloudApis = func(ctx context.Context, key *serviceAccountKey, tokenUri string) *cloudApis {
+			apis := newCloudApis(key.accessTokenConfig(tokenUri).Client(ctx))
+			apis.cloudRunApiUrl = server.URL
+			apis.cloudFunctionsApiUrl = server.URL
+			return apis
+		}
+		return w
+	}
+
+	expectedUpstream := func(name, url, audience string) *v1.Upstream {
+		return &v1.Upstream{
+			Metadata: &core.Metadata{Name: name, Namespace: "gloo-system"},
+			UpstreamType: &v1.Upstream_Gcp{
+				Gcp: &gcp.UpstreamSpec{
+					Url:       url,
+					SecretRef: secretRef,
+					Audience:  audience,
+				},
+			},
+		}
+	}
+
+	It("should discover the cloud run services and the 1st gen cloud functions", func() {
+		upstreamsChan, errs, err := testWatcher().poll()
+		Expect(err).NotTo(HaveOccurred())
+
+		var upstreams v1.UpstreamList
+		select {
+		case err := <-errs:
+			Fail(err.Error())
+		case upstreams = <-upstreamsChan:
+		case <-time.After(5 * time.Second):
+			Fail("timed out waiting for upstreams")
+		}
+		Expect(upstreams).To(Equal(v1.UpstreamList{
+			expectedUpstream("gcp-run-project-us-central1-hello", "https://hello-abcdefghij-uc.a.run.app", ""),
+			expectedUpstream("gcp-run-project-us-central1-world", "https://world-abcdefghij-uc.a.run.app", ""),
+			expectedUpstream("gcp-function-project-us-central1-gen1", "https://us-central1-project.cloudfunctions.net",
+				"https://us-central1-project.cloudfunctions.net/gen1"),
+		}))
+	})
+
+	It("should send an error when the discovery secret is not a gcp secret", func() {
+		secret, err := secretClient.Read(secretRef.GetNamespace(), secretRef.GetName(), clients.ReadOpts{})
+		Expect(err).NotTo(HaveOccurred())
+		secret.Kind = &v1.Secret_Aws{Aws: &v1.AwsSecret{AccessKey: "a", SecretKey: "b"}}
+		_, err = secretClient.Write(secret, clients.WriteOpts{OverwriteExisting: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, errs, err := testWatcher().poll()
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(errs, 5*time.Second).Should(Receive(MatchError(ContainSubstring("is not a GCP secret"))))
+	})
+
+	It("should not discover without discovery locations", func() {
+		p := NewPlugin(ctx, &factory.MemoryResourceClientFactory{Cache: memory.NewInMemoryResourceCache()})
+		upstreamsChan, errs, err := p.DiscoverUpstreams(nil, "gloo-system", clients.WatchOpts{Ctx: ctx}, discovery.Opts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(upstreamsChan).To(BeNil())
+		Expect(errs).To(BeNil())
+	})
+
+	It("should send an empty list of endpoints", func() {
+		p := NewPlugin(ctx, &factory.MemoryResourceClientFactory{Cache: memory.NewInMemoryResourceCache()})
+		endpoints, _, err := p.WatchEndpoints("gloo-system", nil, clients.WatchOpts{Ctx: ctx})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(endpoints).Should(Receive(BeEmpty()))
+	})
+
+	It("should update upstreams when their spec changes", func() {
+		p := NewPlugin(ctx, &factory.MemoryResourceClientFactory{Cache: memory.NewInMemoryResourceCache()})
+		original := expectedUpstream("us", "https://hello-abcdefghij-uc.a.run.app", "")
+		desired := expectedUpstream("us", "https://hello-abcdefghij-uc.a.run.app", "")
+		updated, err := p.UpdateUpstream(original, desired)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated).To(BeFalse())
+
+		desired.GetGcp().Audience = "https://hello-abcdefghij-uc.a.run.app/api"
+		updated, err = p.UpdateUpstream(original, desired)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated).To(BeTrue())
+	})
+})
@@ -1,6 +1,8 @@
 package tcp
 
 import (
+	"strings"
+
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoytcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
@@ -33,6 +35,9 @@ const (
 	DefaultTcpStatPrefix = "tcp"
 
 	SniFilter = "envoy.filters.network.sni_cluster"
+
+	// The transport protocol detected by the tls inspector for TLS connections
+	tlsTransportProtocol = "tls"
 )
 
 var (
@@ -43,6 +48,21 @@ var (
 	InvalidSecretsError = func(err error, name string) error {
 		return eris.Wrapf(err, "invalid secrets for host %v", name)
 	}
+
+	PassthroughWithSslConfigError = func(name string) error {
+		return eris.Errorf("tcp host %v cannot have both an ssl config and tls passthrough", name)
+	}
+
+	InvalidSniDomainError = func(domain, name string) error {
+		return eris.Errorf("invalid SNI domain %v on tcp host %v: a wildcard is only allowed as the first label, e.g. *.example.com", domain, name)
+	}
+
+	ConflictingSniDomainError = func(domain, name, otherName string) error {
+		if domain == "" {
+			return eris.Errorf("tcp hosts %v and %v both match the connections without a matching SNI domain", otherName, name)
+		}
+		return eris.Errorf("tcp hosts %v and %v both match the SNI domain %v", otherName, name, domain)
+	}
 )
 
 type plugin struct {
@@ -68,7 +88,15 @@ func (p *plugin) CreateTcpFilterChains(params plugins.Params, parentListener *v1
 	alsSettings := parentListener.GetOptions().GetAccessLoggingService()
 	tcpListenerOptions := in.GetOptions()
 
+	// the name of the tcp host that matches each SNI domain, to report the hosts whose filter chains conflict
+	sniDomainHosts := map[string]string{}
+
 	for _, tcpHost := range in.GetTcpHosts() {
+		if err := validateSniDomains(tcpHost, sniDomainHosts); err != nil {
+			multiErr.Errors = append(multiErr.Errors, err)
+			continue
+		}
+
 		var listenerFilters []*envoy_config_listener_v3.Filter
 		statPrefix := in.GetStatPrefix()
 		if statPrefix == "" {
@@ -207,6 +235,16 @@ func (p *plugin) computeTcpFilterChain(
 	listenerFilters []*envoy_config_listener_v3.Filter,
 	host *v1.TcpHost,
 ) (*envoy_config_listener_v3.FilterChain, error) {
+	if passthrough := host.GetTlsPassthrough(); passthrough != nil {
+		return &envoy_config_listener_v3.FilterChain{
+			FilterChainMatch: &envoy_config_listener_v3.FilterChainMatch{
+				ServerNames:       passthrough.GetSniDomains(),
+				TransportProtocol: tlsTransportProtocol,
+			},
+			Filters: listenerFilters,
+		}, nil
+	}
+
 	sslConfig := host.GetSslConfig()
 	if sslConfig == nil {
 		return &envoy_config_listener_v3.FilterChain{
@@ -236,7 +274,8 @@ func (p *plugin) newSslFilterChain(
 
 	return &envoy_config_listener_v3.FilterChain{
 		FilterChainMatch: &envoy_config_listener_v3.FilterChainMatch{
-			ServerNames: sniDomains,
+			ServerNames:       sniDomains,
+			TransportProtocol: tlsTransportProtocol,
 		},
 		Filters: listenerFiltersCopy,
 		TransportSocket: &envoy_config_core_v3.TransportSocket{
@@ -247,6 +286,39 @@ func (p *plugin) newSslFilterChain(
 	}
 }
 
+// Checks that the SNI domains of the tcp host are valid, and that no other tcp host of the listener matches them.
+// The tcp hosts that terminate or pass through TLS only match TLS connections, so they do not conflict with the
+// tcp hosts without TLS, which match the connections that no other filter chain matches.
+func validateSniDomains(host *v1.TcpHost, sniDomainHosts map[string]string) error {
+	var transportProtocol string
+	var sniDomains []string
+	switch {
+	case host.GetSslConfig() != nil && host.GetTlsPassthrough() != nil:
+		return PassthroughWithSslConfigError(host.GetName())
+	case host.GetSslConfig() != nil:
+		transportProtocol, sniDomains = tlsTransportProtocol, host.GetSslConfig().GetSniDomains()
+	case host.GetTlsPassthrough() != nil:
+		transportProtocol, sniDomains = tlsTransportProtocol, host.GetTlsPassthrough().GetSniDomains()
+	}
+
+	// a filter chain without SNI domains is the default one of its transport protocol
+	if len(sniDomains) == 0 {
+		sniDomains = []string{""}
+	}
+	for _, domain := range sniDomains {
+		if strings.Contains(domain, "*") && (!strings.HasPrefix(domain, "*.") || strings.Count(domain, "*") > 1) {
+			return InvalidSniDomainError(domain, host.GetName())
+		}
+		if otherName, ok := sniDomainHosts[transportProtocol+"/"+domain]; ok {
+			return ConflictingSniDomainError(domain, host.GetName(), otherName)
+		}
+	}
+	for _, domain := range sniDomains {
+		sniDomainHosts[transportProtocol+"/"+domain] = host.GetName()
+	}
+	return nil
+}
+
 func convertToEnvoyTunnelingConfig(config *tcp.TcpProxySettings_TunnelingConfig) *envoytcp.TcpProxy_TunnelingConfig {
 	if config == nil {
 		return nil
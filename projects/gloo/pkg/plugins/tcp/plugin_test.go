@@ -335,6 +335,158 @@ var _ = Describe("Plugin", func() {
 			Expect(filterChains[0].TransportSocketConnectTimeout.Seconds).To(Equal(int64(3)))
 			Expect(filterChains[0].TransportSocketConnectTimeout.Nanos).To(Equal(int32(0)))
 		})
+
+		Context("sni matching", func() {
+
+			var (
+				single = &v1.TcpHost_TcpAction{
+					Destination: &v1.TcpHost_TcpAction_Single{
+						Single: &v1.Destination{
+							DestinationType: &v1.Destination_Upstream{
+								Upstream: &core.ResourceRef{
+									Name:      "one",
+									Namespace: ns,
+								},
+							},
+						},
+					},
+				}
+				sslConfig *v1.SslConfig
+			)
+
+			BeforeEach(func() {
+				sslConfig = &v1.SslConfig{
+					SslSecrets: &v1.SslConfig_SecretRef{
+						SecretRef: &core.ResourceRef{
+							Name:      "name",
+							Namespace: "namespace",
+						},
+					},
+					SniDomains: []string{"api.example.com"},
+				}
+			})
+
+			It("can pass through, terminate and forward plain connections on the same listener", func() {
+				tcpListener.TcpHosts = []*v1.TcpHost{
+					{
+						Name:           "passthrough",
+						Destination:    single,
+						TlsPassthrough: &v1.TcpHost_TlsPassthrough{SniDomains: []string{"*.example.com"}},
+					},
+					{
+						Name:        "terminated",
+						Destination: single,
+						SslConfig:   sslConfig,
+					},
+					{
+						Name:        "default",
+						Destination: single,
+					},
+				}
+				sslTranslator.EXPECT().
+					ResolveDownstreamSslConfig(snap.Secrets, sslConfig).
+					Return(&envoyauth.DownstreamTlsContext{}, nil)
+
+				filterChains, err := createFilterChains()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(filterChains).To(HaveLen(3))
+
+				Expect(filterChains[0].GetFilterChainMatch()).To(matchers.MatchProto(&envoy_config_listener_v3.FilterChainMatch{
+					ServerNames:       []string{"*.example.com"},
+					TransportProtocol: "tls",
+				}))
+				Expect(filterChains[0].GetTransportSocket()).To(BeNil())
+
+				Expect(filterChains[1].GetFilterChainMatch()).To(matchers.MatchProto(&envoy_config_listener_v3.FilterChainMatch{
+					ServerNames:       []string{"api.example.com"},
+					TransportProtocol: "tls",
+				}))
+				Expect(filterChains[1].GetTransportSocket()).NotTo(BeNil())
+
+				Expect(filterChains[2].GetFilterChainMatch()).To(BeNil())
+			})
+
+			It("allows a default passthrough host alongside a default plain host", func() {
+				tcpListener.TcpHosts = []*v1.TcpHost{
+					{
+						Name:           "passthrough",
+						Destination:    single,
+						TlsPassthrough: &v1.TcpHost_TlsPassthrough{},
+					},
+					{
+						Name:        "default",
+						Destination: single,
+					},
+				}
+
+				filterChains, err := createFilterChains()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(filterChains).To(HaveLen(2))
+				Expect(filterChains[0].GetFilterChainMatch()).To(matchers.MatchProto(&envoy_config_listener_v3.FilterChainMatch{
+					TransportProtocol: "tls",
+				}))
+			})
+
+			It("reports the tcp hosts whose SNI domains overlap", func() {
+				tcpListener.TcpHosts = []*v1.TcpHost{
+					{
+						Name:        "terminated",
+						Destination: single,
+						SslConfig:   sslConfig,
+					},
+					{
+						Name:           "passthrough",
+						Destination:    single,
+						TlsPassthrough: &v1.TcpHost_TlsPassthrough{SniDomains: []string{"www.example.com", "api.example.com"}},
+					},
+				}
+				sslTranslator.EXPECT().
+					ResolveDownstreamSslConfig(snap.Secrets, sslConfig).
+					Return(&envoyauth.DownstreamTlsContext{}, nil)
+
+				_, err := createFilterChains()
+				Expect(err).To(MatchError(ContainSubstring(ConflictingSniDomainError("api.example.com", "passthrough", "terminated").Error())))
+			})
+
+			It("reports multiple default plain tcp hosts", func() {
+				tcpListener.TcpHosts = []*v1.TcpHost{
+					{
+						Name:        "one",
+						Destination: single,
+					},
+					{
+						Name:        "two",
+						Destination: single,
+					},
+				}
+
+				_, err := createFilterChains()
+				Expect(err).To(MatchError(ContainSubstring(ConflictingSniDomainError("", "two", "one").Error())))
+			})
+
+			It("rejects the wildcards that are not the first label", func() {
+				tcpListener.TcpHosts = []*v1.TcpHost{{
+					Name:           "passthrough",
+					Destination:    single,
+					TlsPassthrough: &v1.TcpHost_TlsPassthrough{SniDomains: []string{"api.*.com"}},
+				}}
+
+				_, err := createFilterChains()
+				Expect(err).To(MatchError(ContainSubstring(InvalidSniDomainError("api.*.com", "passthrough").Error())))
+			})
+
+			It("rejects tcp hosts with both an ssl config and tls passthrough", func() {
+				tcpListener.TcpHosts = []*v1.TcpHost{{
+					Name:           "both",
+					Destination:    single,
+					SslConfig:      sslConfig,
+					TlsPassthrough: &v1.TcpHost_TlsPassthrough{},
+				}}
+
+				_, err := createFilterChains()
+				Expect(err).To(MatchError(ContainSubstring(PassthroughWithSslConfigError("both").Error())))
+			})
+		})
 	})
 
 })
@@ -65,9 +65,9 @@ func includeTlsInspectorForListener(in *v1.Listener) bool {
 }
 
 func includeTlsInspectorForTcpListener(in *v1.TcpListener) bool {
-	// check if ssl config is set on tcp host
+	// check if ssl config or tls passthrough is set on tcp host
 	for _, host := range in.GetTcpHosts() {
-		if host.GetSslConfig() != nil || host.GetDestination().GetForwardSniClusterName() != nil {
+		if host.GetSslConfig() != nil || host.GetTlsPassthrough() != nil || host.GetDestination().GetForwardSniClusterName() != nil {
 			return true
 		}
 	}
@@ -151,6 +151,37 @@ var _ = Describe("Plugin", func() {
 			Expect(out.ListenerFilters[0].GetTypedConfig()).To(Equal(config))
 		})
 
+		It("Tcp Host Tls Passthrough is set, tls inspector is added", func() {
+			thost := &v1.TcpHost{
+				TlsPassthrough: &v1.TcpHost_TlsPassthrough{
+					SniDomains: []string{"*.example.com"},
+				},
+			}
+			tl := &v1.TcpListener{
+				TcpHosts: []*v1.TcpHost{thost},
+			}
+			in := &v1.Listener{
+				ListenerType: &v1.Listener_TcpListener{
+					TcpListener: tl,
+				},
+			}
+
+			filters := []*envoy_config_listener_v3.Filter{{}}
+
+			out := &envoy_config_listener_v3.Listener{
+				FilterChains: []*envoy_config_listener_v3.FilterChain{{
+					Filters: filters,
+				}},
+			}
+
+			p := NewPlugin()
+			err := p.ProcessListener(params, in, out)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(out.ListenerFilters).To(HaveLen(1))
+			Expect(out.ListenerFilters[0].GetName()).To(Equal(wellknown.TlsInspector))
+		})
+
 		It("tls inspector is ignored", func() {
 			tl := &v1.TcpListener{}
 			in := &v1.Listener{
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"runtime"
+	"sync"
 
 	syncerstats "github.com/solo-io/gloo/projects/gloo/pkg/syncer/stats"
 	"github.com/solo-io/go-utils/hashutils"
@@ -12,6 +14,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/rotisserie/eris"
 	"github.com/solo-io/gloo/pkg/utils/syncutil"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
 	v1snap "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/gloosnapshot"
 	"github.com/solo-io/gloo/projects/gloo/pkg/plugins"
 	"github.com/solo-io/gloo/projects/gloo/pkg/xds"
@@ -61,6 +64,12 @@ var (
 	)
 )
 
+var (
+	EnvoyNackWarning = func(nodeNack xds.NodeNack) string {
+		return fmt.Sprintf("envoy node %v rejected version %v of %v: %v", nodeNack.NodeId, nodeNack.Nack.Version, nodeNack.TypeUrl, nodeNack.Nack.Message)
+	}
+)
+
 func measureResource(ctx context.Context, resource string, len int) {
 	if ctxWithTags, err := tag.New(ctx, tag.Insert(resourceNameKey, resource)); err == nil {
 		stats.Record(ctxWithTags, envoySnapshotOut.M(int64(len)))
@@ -106,31 +115,22 @@ func (s *translatorSyncer) syncEnvoy(ctx context.Context, snap *v1snap.ApiSnapsh
 		}
 		// preserve keys from the current list of proxies, set previous invalid snapshots to empty snapshot
 		for key, valid := range allKeys {
-			if !valid {
+			// restored snapshots are kept until their proxy has been translated, as proxies may not have been
+			// written yet right after a restart
+			if !valid && !s.snapshotPersistence.IsRestored(key) {
 				s.xdsCache.SetSnapshot(key, emptySnapshot)
+				if err := s.snapshotPersistence.Forget(ctx, key); err != nil {
+					logger.Warnw("Failed to delete persisted xDS snapshot", zap.String("key", key), zap.Error(err))
+				}
 			}
 		}
 	}
 
-	for _, proxy := range snap.Proxies {
-		proxyCtx := ctx
-		if ctxWithTags, err := tag.New(proxyCtx, tag.Insert(syncerstats.ProxyNameKey, proxy.GetMetadata().Ref().Key())); err == nil {
-			proxyCtx = ctxWithTags
-		}
-
-		params := plugins.Params{
-			Ctx:      proxyCtx,
-			Snapshot: snap,
-		}
-
-		// TODO(kdorosh) in follow up PR, update this interface so it can never error
-		// It is logically invalid for us to return an error here (translation of resources always needs to
-		// result in a xds snapshot, so we are resilient to pod restarts)
-		//
-		// for now this can only really fail on plugin initialization e.g. https://github.com/solo-io/gloo/blob/4f133dd2be0875463754fecc84c1eced7d4202fd/projects/gloo/pkg/plugins/consul/plugin.go#L134
-		// we are not in a very bad place today but should update this interface ASAP so we don't introduce new regressions
-		xdsSnapshot, reports, _, err := s.translator.Translate(params, proxy)
-		if err != nil {
+	// proxies are translated in parallel, but their snapshots are sanitized and set in order
+	translations := s.translateProxies(ctx, snap)
+	for i, proxy := range snap.Proxies {
+		proxyCtx, xdsSnapshot, reports := translations[i].ctx, translations[i].xdsSnapshot, translations[i].reports
+		if err := translations[i].err; err != nil {
 			err := eris.Wrapf(err, "translation loop failed")
 			logger.DPanicw("", zap.Error(err))
 			return err
@@ -151,7 +151,22 @@ func (s *translatorSyncer) syncEnvoy(ctx context.Context, snap *v1snap.ApiSnapsh
 		// Merge reports after sanitization to capture changes made by the sanitizers
 		allReports.Merge(reports)
 		key := xds.SnapshotKey(proxy)
+		// report the snapshots that envoy rejected on the proxy
+		for _, nodeNack := range s.nodeStatuses.ActiveNacks(key) {
+			allReports.AddWarning(proxy, EnvoyNackWarning(nodeNack))
+		}
+		validateErr := reports.Validate()
+		// keep serving the last known good snapshot until the proxy translates without errors
+		if validateErr != nil && s.snapshotPersistence.IsRestored(key) {
+			logger.Warnw("Proxy had invalid config, serving the persisted xDS snapshot", zap.String("key", key), zap.Error(validateErr))
+			continue
+		}
 		s.xdsCache.SetSnapshot(key, sanitizedSnapshot)
+		if validateErr == nil {
+			if err := s.snapshotPersistence.Accept(ctx, key, sanitizedSnapshot); err != nil {
+				logger.Warnw("Failed to persist xDS snapshot", zap.String("key", key), zap.Error(err))
+			}
+		}
 
 		// Record some metrics
 		clustersLen := len(xdsSnapshot.GetResources(resource.ClusterTypeV3).Items)
@@ -178,6 +193,57 @@ func (s *translatorSyncer) syncEnvoy(ctx context.Context, snap *v1snap.ApiSnapsh
 	return nil
 }
 
+type proxyTranslation struct {
+	ctx         context.Context
+	xdsSnapshot envoycache.Snapshot
+	reports     reporter.ResourceReports
+	err         error
+}
+
+// translates the proxies of the snapshot in parallel, returning their translations in the order of the proxies
+func (s *translatorSyncer) translateProxies(ctx context.Context, snap *v1snap.ApiSnapshot) []proxyTranslation {
+	translations := make([]proxyTranslation, len(snap.Proxies))
+	// bounds the number of concurrent translations
+	workers := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i, proxy := range snap.Proxies {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int, proxy *v1.Proxy) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			proxyCtx := ctx
+			if ctxWithTags, err := tag.New(proxyCtx, tag.Insert(syncerstats.ProxyNameKey, proxy.GetMetadata().Ref().Key())); err == nil {
+				proxyCtx = ctxWithTags
+			}
+
+			params := plugins.Params{
+				Ctx:      proxyCtx,
+				Snapshot: snap,
+			}
+
+			// TODO(kdorosh) in follow up PR, update this interface so it can never error
+			// It is logically invalid for us to return an error here (translation of resources always needs to
+			// result in a xds snapshot, so we are resilient to pod restarts)
+			//
+			// for now this can only really fail on plugin initialization e.g. https://github.com/solo-io/gloo/blob/4f133dd2be0875463754fecc84c1eced7d4202fd/projects/gloo/pkg/plugins/consul/plugin.go#L134
+			// we are not in a very bad place today but should update this interface ASAP so we don't introduce new regressions
+			xdsSnapshot, reports, _, err := s.translator.Translate(params, proxy)
+			translations[i] = proxyTranslation{
+				ctx:         proxyCtx,
+				xdsSnapshot: xdsSnapshot,
+				reports:     reports,
+				err:         err,
+			}
+		}(i, proxy)
+	}
+	wg.Wait()
+	return translations
+}
+
 // TODO(ilackarms): move this somewhere else, make it part of dev-mode
 func (s *translatorSyncer) ServeXdsSnapshots() error {
 	r := mux.NewRouter()
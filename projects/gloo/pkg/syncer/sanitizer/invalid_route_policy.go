@@ -0,0 +1,157 @@
+package sanitizer
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/rotisserie/eris"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/invalid_route"
+	glooutils "github.com/solo-io/gloo/projects/gloo/pkg/utils"
+)
+
+var (
+	InvalidDirectResponseStatusErr = func(status uint32) error {
+		return eris.Errorf("invalid route policy: direct response status %d must be between 200 and 599", status)
+	}
+	InvalidRedirectUrlErr = func(redirectUrl string) error {
+		return eris.Errorf("invalid route policy: redirect url %q must be an absolute url", redirectUrl)
+	}
+	InvalidRedirectResponseCodeErr = func(code uint32) error {
+		return eris.Errorf("invalid route policy: redirect response code %d must be one of 301, 302, 303, 307, 308", code)
+	}
+
+	redirectResponseCodes = map[uint32]envoy_config_route_v3.RedirectAction_RedirectResponseCode{
+		0:   envoy_config_route_v3.RedirectAction_FOUND,
+		301: envoy_config_route_v3.RedirectAction_MOVED_PERMANENTLY,
+		302: envoy_config_route_v3.RedirectAction_FOUND,
+		303: envoy_config_route_v3.RedirectAction_SEE_OTHER,
+		307: envoy_config_route_v3.RedirectAction_TEMPORARY_REDIRECT,
+		308: envoy_config_route_v3.RedirectAction_PERMANENT_REDIRECT,
+	}
+)
+
+// The invalid route policies defined on the virtual hosts of a proxy, indexed by the name of the Envoy virtual host.
+type invalidRoutePolicies map[string]*virtualHostPolicies
+
+type virtualHostPolicies struct {
+	virtualHost *invalid_route.InvalidRoutePolicy
+	// indexed by the position of the route in the virtual host
+	routes []*invalid_route.InvalidRoutePolicy
+	// the Gloo route each Envoy route of the virtual host has been translated from, indexed by the position of the
+	// Envoy route in the virtual host
+	envoyRoutes []envoyRouteOrigin
+}
+
+// Identifies an Envoy route by the position of the Gloo route and of the matcher it has been translated from.
+type envoyRouteOrigin struct {
+	route   int
+	matcher int
+}
+
+func (o envoyRouteOrigin) String() string {
+	return strconv.Itoa(o.route) + "-" + strconv.Itoa(o.matcher)
+}
+
+func getInvalidRoutePolicies(ctx context.Context, proxy *v1.Proxy) invalidRoutePolicies {
+	policies := invalidRoutePolicies{}
+	addVirtualHosts := func(virtualHosts []*v1.VirtualHost) {
+		for _, vh := range virtualHosts {
+			vhPolicies := &virtualHostPolicies{
+				virtualHost: vh.GetOptions().GetInvalidRoutePolicy(),
+			}
+			for i, route := range vh.GetRoutes() {
+				vhPolicies.routes = append(vhPolicies.routes, route.GetOptions().GetInvalidRoutePolicy())
+				// the translator generates an Envoy route per matcher, or a single one for routes without matchers
+				matchers := len(route.GetMatchers())
+				if matchers == 0 {
+					matchers = 1
+				}
+				for j := 0; j < matchers; j++ {
+					vhPolicies.envoyRoutes = append(vhPolicies.envoyRoutes, envoyRouteOrigin{route: i, matcher: j})
+				}
+			}
+			policies[glooutils.SanitizeForEnvoy(ctx, vh.GetName(), "virtual host")] = vhPolicies
+		}
+	}
+
+	for _, listener := range proxy.GetListeners() {
+		addVirtualHosts(listener.GetHttpListener().GetVirtualHosts())
+		for _, matchedListener := range listener.GetHybridListener().GetMatchedListeners() {
+			addVirtualHosts(matchedListener.GetHttpListener().GetVirtualHosts())
+		}
+	}
+	return policies
+}
+
+// Returns the policy that applies to the Envoy route at the given position of the virtual host, and the Gloo route
+// it has been translated from. The translator generates the Envoy routes in the order of the Gloo routes and of
+// their matchers, which allows us to find the Gloo route even if the Envoy route has no name.
+// The origin is nil if the Envoy routes of the virtual host do not match the ones of the proxy.
+func (p invalidRoutePolicies) forRoute(virtualHostName string, position, envoyRoutes int) (*invalid_route.InvalidRoutePolicy, *envoyRouteOrigin) {
+	vhPolicies, ok := p[virtualHostName]
+	if !ok {
+		return nil, nil
+	}
+	if len(vhPolicies.envoyRoutes) != envoyRoutes {
+		return vhPolicies.virtualHost, nil
+	}
+
+	origin := vhPolicies.envoyRoutes[position]
+	if policy := vhPolicies.routes[origin.route]; policy != nil {
+		return policy, &origin
+	}
+	return vhPolicies.virtualHost, &origin
+}
+
+func toDirectResponseAction(directResponse *invalid_route.InvalidRoutePolicy_DirectResponse) (*envoy_config_route_v3.Route_DirectResponse, error) {
+	if directResponse.GetStatus() < 200 || directResponse.GetStatus() > 599 {
+		return nil, InvalidDirectResponseStatusErr(directResponse.GetStatus())
+	}
+
+	action := &envoy_config_route_v3.DirectResponseAction{
+		Status: directResponse.GetStatus(),
+	}
+	if directResponse.GetBody() != "" {
+		action.Body = &envoy_config_core_v3.DataSource{
+			Specifier: &envoy_config_core_v3.DataSource_InlineString{
+				InlineString: directResponse.GetBody(),
+			},
+		}
+	}
+	return &envoy_config_route_v3.Route_DirectResponse{DirectResponse: action}, nil
+}
+
+func toRedirectAction(redirect *invalid_route.InvalidRoutePolicy_Redirect) (*envoy_config_route_v3.Route_Redirect, error) {
+	redirectUrl, err := url.Parse(redirect.GetUrl())
+	if err != nil || redirectUrl.Scheme == "" || redirectUrl.Host == "" {
+		return nil, InvalidRedirectUrlErr(redirect.GetUrl())
+	}
+
+	responseCode, ok := redirectResponseCodes[redirect.GetResponseCode()]
+	if !ok {
+		return nil, InvalidRedirectResponseCodeErr(redirect.GetResponseCode())
+	}
+
+	action := &envoy_config_route_v3.RedirectAction{
+		SchemeRewriteSpecifier: &envoy_config_route_v3.RedirectAction_SchemeRedirect{
+			SchemeRedirect: redirectUrl.Scheme,
+		},
+		HostRedirect: redirectUrl.Hostname(),
+		PathRewriteSpecifier: &envoy_config_route_v3.RedirectAction_PathRedirect{
+			PathRedirect: redirectUrl.RequestURI(),
+		},
+		ResponseCode: responseCode,
+	}
+	if port := redirectUrl.Port(); port != "" {
+		portNumber, err := strconv.ParseUint(port, 10, 32)
+		if err != nil {
+			return nil, InvalidRedirectUrlErr(redirect.GetUrl())
+		}
+		action.PortRedirect = uint32(portNumber)
+	}
+	return &envoy_config_route_v3.Route_Redirect{Redirect: action}, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"regexp"
 	"sort"
+	"strings"
+	"sync"
 
 	v1snap "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/gloosnapshot"
 
@@ -20,6 +22,7 @@ import (
 	"github.com/rotisserie/eris"
 	"github.com/solo-io/gloo/pkg/utils"
 	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/invalid_route"
 	"github.com/solo-io/gloo/projects/gloo/pkg/syncer/stats"
 	"github.com/solo-io/gloo/projects/gloo/pkg/translator"
 	glooutils "github.com/solo-io/gloo/projects/gloo/pkg/utils"
@@ -51,6 +54,12 @@ type RouteReplacingSanitizer struct {
 	enabled          bool
 	fallbackListener *envoy_config_listener_v3.Listener
 	fallbackCluster  *envoy_config_cluster_v3.Cluster
+
+	// The last valid version of each route that uses the `keepLastValidRoute` invalid route policy.
+	// Indexed by proxy, then by route configuration, virtual host, and the Gloo route and matcher the route has been
+	// translated from.
+	lastValidRoutes     map[string]map[string]*envoy_config_route_v3.Route
+	lastValidRoutesLock sync.Mutex
 }
 
 func NewRouteReplacingSanitizer(cfg *v1.GlooOptions_InvalidConfigPolicy) (*RouteReplacingSanitizer, error) {
@@ -67,6 +76,7 @@ func NewRouteReplacingSanitizer(cfg *v1.GlooOptions_InvalidConfigPolicy) (*Route
 		enabled:          cfg.GetReplaceInvalidRoutes(),
 		fallbackListener: listener,
 		fallbackCluster:  cluster,
+		lastValidRoutes:  map[string]map[string]*envoy_config_route_v3.Route{},
 	}, nil
 }
 
@@ -184,7 +194,7 @@ func (s *RouteReplacingSanitizer) SanitizeSnapshot(
 	proxyReports := reports.FilterByKind("*v1.Proxy")
 	erroredRouteNames := s.removeErroredRoutesFromReport(proxyReports, reports)
 
-	replacedRouteConfigs, needsListener := s.replaceRoutes(ctx, validClusters, routeConfigs, erroredRouteNames)
+	replacedRouteConfigs, needsListener := s.replaceRoutes(ctx, getProxy(proxyReports), validClusters, routeConfigs, erroredRouteNames, reports)
 
 	clusters := xdsSnapshot.GetResources(resource.ClusterTypeV3)
 	listeners := xdsSnapshot.GetResources(resource.ListenerTypeV3)
@@ -226,6 +236,16 @@ func getRoutes(ctx context.Context, snap envoycache.Snapshot) []*envoy_config_ro
 	return routeConfigs
 }
 
+// The reports passed to the sanitizer belong to the translation of a single proxy
+func getProxy(proxyReports reporter.ResourceReports) *v1.Proxy {
+	for res := range proxyReports {
+		if proxy, ok := res.(*v1.Proxy); ok {
+			return proxy
+		}
+	}
+	return nil
+}
+
 func getClusters(glooSnapshot *v1snap.ApiSnapshot, xdsSnapshot envoycache.Snapshot) map[string]struct{} {
 	// mark all valid destination clusters, i.e. those that are in both the gloo snapshot and xds snapshot
 	validClusters := make(map[string]struct{})
@@ -241,9 +261,11 @@ func getClusters(glooSnapshot *v1snap.ApiSnapshot, xdsSnapshot envoycache.Snapsh
 
 func (s *RouteReplacingSanitizer) replaceRoutes(
 	ctx context.Context,
+	proxy *v1.Proxy,
 	validClusters map[string]struct{},
 	routeConfigs []*envoy_config_route_v3.RouteConfiguration,
 	erroredRoutes map[string]struct{},
+	reports reporter.ResourceReports,
 ) ([]*envoy_config_route_v3.RouteConfiguration, bool) {
 	var sanitizedRouteConfigs []*envoy_config_route_v3.RouteConfiguration
 
@@ -255,6 +277,14 @@ func (s *RouteReplacingSanitizer) replaceRoutes(
 
 	debugW := contextutils.LoggerFrom(ctx).Debugw
 
+	policies := getInvalidRoutePolicies(ctx, proxy)
+	proxyKey := proxy.GetMetadata().Ref().Key()
+
+	s.lastValidRoutesLock.Lock()
+	defer s.lastValidRoutesLock.Unlock()
+	previousValidRoutes := s.lastValidRoutes[proxyKey]
+	currentValidRoutes := map[string]*envoy_config_route_v3.Route{}
+
 	var anyRoutesReplaced bool
 
 	// replace any routes which do not point to a valid destination cluster
@@ -268,6 +298,41 @@ func (s *RouteReplacingSanitizer) replaceRoutes(
 				if routeAction == nil {
 					continue
 				}
+
+				policy, origin := policies.forRoute(vh.GetName(), j, len(vh.GetRoutes()))
+				// the last valid version can only be kept for routes we can trace back to the proxy
+				keepLastValid := origin != nil && policy.GetKeepLastValidRoute() != nil
+				var routeKey string
+				var lastValidRoute *envoy_config_route_v3.Route
+				if keepLastValid {
+					routeKey = strings.Join([]string{cfg.GetName(), vh.GetName(), origin.String()}, "/")
+					lastValidRoute = previousValidRoutes[routeKey]
+				}
+				if !hasInvalidClusters(routeAction, func(cluster string) bool { return isInvalid(cluster, route.GetName()) }) {
+					if keepLastValid {
+						currentValidRoutes[routeKey] = route
+					}
+					continue
+				}
+
+				if policy != nil {
+					replacement, err := applyInvalidRoutePolicy(policy, route, lastValidRoute, validClusters)
+					if err != nil && proxy != nil {
+						reports.AddWarning(proxy, eris.Wrapf(err, "route %s", route.GetName()).Error())
+					}
+					if replacement != nil {
+						debugW("replacing invalid route in virtual host according to its invalid route policy",
+							zap.Any("route", j), zap.Any("virtualhost", i))
+						if keepLastValid {
+							// keep serving the same version of the route until it becomes valid again
+							currentValidRoutes[routeKey] = replacement
+						}
+						vh.GetRoutes()[j] = replacement
+						replaced++
+						continue
+					}
+				}
+
 				switch action := routeAction.GetClusterSpecifier().(type) {
 				case *envoy_config_route_v3.RouteAction_Cluster:
 					if isInvalid(action.Cluster, route.GetName()) {
@@ -300,9 +365,73 @@ func (s *RouteReplacingSanitizer) replaceRoutes(
 		sanitizedRouteConfigs = append(sanitizedRouteConfigs, sanitizedRouteConfig)
 	}
 
+	// Only retain the routes that are still part of the proxy, so the cache does not grow indefinitely
+	if len(currentValidRoutes) > 0 {
+		s.lastValidRoutes[proxyKey] = currentValidRoutes
+	} else {
+		delete(s.lastValidRoutes, proxyKey)
+	}
+
 	return sanitizedRouteConfigs, anyRoutesReplaced
 }
 
+// Returns true if any of the clusters the route action points to is invalid.
+// Route actions that do not point to specific clusters are never considered invalid.
+func hasInvalidClusters(routeAction *envoy_config_route_v3.RouteAction, isInvalid func(cluster string) bool) bool {
+	switch action := routeAction.GetClusterSpecifier().(type) {
+	case *envoy_config_route_v3.RouteAction_Cluster:
+		return isInvalid(action.Cluster)
+	case *envoy_config_route_v3.RouteAction_WeightedClusters:
+		for _, weightedCluster := range action.WeightedClusters.GetClusters() {
+			if isInvalid(weightedCluster.GetName()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns the route that replaces the given invalid route according to the policy, or nil if the route should be
+// replaced using the global fallback instead.
+func applyInvalidRoutePolicy(
+	policy *invalid_route.InvalidRoutePolicy,
+	route *envoy_config_route_v3.Route,
+	lastValidRoute *envoy_config_route_v3.Route,
+	validClusters map[string]struct{},
+) (*envoy_config_route_v3.Route, error) {
+	switch policyType := policy.GetPolicy().(type) {
+	case *invalid_route.InvalidRoutePolicy_DirectResponse_:
+		action, err := toDirectResponseAction(policyType.DirectResponse)
+		if err != nil {
+			return nil, err
+		}
+		replacement := proto.Clone(route).(*envoy_config_route_v3.Route)
+		replacement.Action = action
+		return replacement, nil
+	case *invalid_route.InvalidRoutePolicy_Redirect_:
+		action, err := toRedirectAction(policyType.Redirect)
+		if err != nil {
+			return nil, err
+		}
+		replacement := proto.Clone(route).(*envoy_config_route_v3.Route)
+		replacement.Action = action
+		return replacement, nil
+	case *invalid_route.InvalidRoutePolicy_KeepLastValidRoute_:
+		// The key of the last valid version only identifies the position of the route, so make sure the matcher did
+		// not change.
+		// The clusters of the last valid version might have been removed in the meantime.
+		if lastValidRoute == nil || !proto.Equal(lastValidRoute.GetMatch(), route.GetMatch()) ||
+			hasInvalidClusters(lastValidRoute.GetRoute(), func(cluster string) bool {
+				_, valid := validClusters[cluster]
+				return !valid
+			}) {
+			return nil, nil
+		}
+		return proto.Clone(lastValidRoute).(*envoy_config_route_v3.Route), nil
+	}
+	return nil, nil
+}
+
 func (s *RouteReplacingSanitizer) removeErroredRoutesFromReport(
 	proxyReports reporter.ResourceReports,
 	allReports reporter.ResourceReports,
@@ -15,6 +15,7 @@ import (
 	"github.com/rotisserie/eris"
 	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
 	v1snap "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/gloosnapshot"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/invalid_route"
 	"github.com/solo-io/gloo/projects/gloo/pkg/translator"
 	"github.com/solo-io/gloo/projects/gloo/pkg/utils"
 	validationutils "github.com/solo-io/gloo/projects/gloo/pkg/utils/validation"
@@ -333,4 +334,233 @@ var _ = Describe("RouteReplacingSanitizer", func() {
 		Expect(reports[proxy].Errors).To(BeNil())
 		Expect(reports[proxy].Warnings).To(Equal([]string{multiErr.Errors[0].Error()}))
 	})
+
+	Context("invalid route policies", func() {
+
+		var (
+			// dots in virtual host names are replaced with underscores in the xds snapshot
+			vhostName      = "ns.vs"
+			envoyVhostName = "ns_vs"
+			routeName      = envoyVhostName + "-route-0-matcher-0"
+
+			proxy        *v1.Proxy
+			reports      reporter.ResourceReports
+			glooSnapshot *v1snap.ApiSnapshot
+
+			routeWithCluster = func(cluster string) *envoy_config_route_v3.Route {
+				return &envoy_config_route_v3.Route{
+					Name: routeName,
+					Match: &envoy_config_route_v3.RouteMatch{
+						PathSpecifier: &envoy_config_route_v3.RouteMatch_Prefix{Prefix: "/foo"},
+					},
+					Action: &envoy_config_route_v3.Route_Route{
+						Route: &envoy_config_route_v3.RouteAction{
+							ClusterSpecifier: &envoy_config_route_v3.RouteAction_Cluster{
+								Cluster: cluster,
+							},
+						},
+					},
+				}
+			}
+
+			setPolicy = func(vhostPolicy, routePolicy *invalid_route.InvalidRoutePolicy) {
+				proxy.Listeners = []*v1.Listener{{
+					ListenerType: &v1.Listener_HttpListener{
+						HttpListener: &v1.HttpListener{
+							VirtualHosts: []*v1.VirtualHost{{
+								Name:    vhostName,
+								Options: &v1.VirtualHostOptions{InvalidRoutePolicy: vhostPolicy},
+								Routes: []*v1.Route{{
+									Options: &v1.RouteOptions{InvalidRoutePolicy: routePolicy},
+								}},
+							}},
+						},
+					},
+				}}
+			}
+
+			sanitize = func(sanitizer *RouteReplacingSanitizer, route *envoy_config_route_v3.Route) *envoy_config_route_v3.Route {
+				routeCfg := &envoy_config_route_v3.RouteConfiguration{
+					Name: routeCfgName,
+					VirtualHosts: []*envoy_config_route_v3.VirtualHost{{
+						Name:   envoyVhostName,
+						Routes: []*envoy_config_route_v3.Route{route},
+					}},
+				}
+				xdsSnapshot := xds.NewSnapshotFromResources(
+					envoycache.NewResources("", nil),
+					envoycache.NewResources("clusters", []envoycache.Resource{
+						resource.NewEnvoyResource(cluster),
+					}),
+					envoycache.NewResources("routes", []envoycache.Resource{
+						resource.NewEnvoyResource(routeCfg),
+					}),
+					envoycache.NewResources("listeners", []envoycache.Resource{
+						resource.NewEnvoyResource(listener),
+					}),
+				)
+
+				snap := sanitizer.SanitizeSnapshot(context.TODO(), glooSnapshot, xdsSnapshot, reports)
+				sanitizedRoutes := snap.GetResources(resource.RouteTypeV3).Items[routeCfgName]
+				return sanitizedRoutes.ResourceProto().(*envoy_config_route_v3.RouteConfiguration).GetVirtualHosts()[0].GetRoutes()[0]
+			}
+		)
+
+		BeforeEach(func() {
+			proxy = &v1.Proxy{
+				Metadata: &core.Metadata{Name: "proxy", Namespace: "gloo-system"},
+			}
+			reports = reporter.ResourceReports{proxy: {}}
+			glooSnapshot = &v1snap.ApiSnapshot{
+				Upstreams: v1.UpstreamList{us},
+			}
+		})
+
+		It("replaces invalid routes with the direct response of the virtual host policy", func() {
+			setPolicy(&invalid_route.InvalidRoutePolicy{
+				Policy: &invalid_route.InvalidRoutePolicy_DirectResponse_{
+					DirectResponse: &invalid_route.InvalidRoutePolicy_DirectResponse{
+						Status: http.StatusServiceUnavailable,
+						Body:   "team a is down",
+					},
+				},
+			}, nil)
+
+			sanitizer, err := NewRouteReplacingSanitizer(invalidCfgPolicy)
+			Expect(err).NotTo(HaveOccurred())
+
+			sanitized := sanitize(sanitizer, routeWithCluster(missingCluster))
+			Expect(sanitized.GetMatch().GetPrefix()).To(Equal("/foo"))
+			Expect(sanitized.GetDirectResponse().GetStatus()).To(BeEquivalentTo(http.StatusServiceUnavailable))
+			Expect(sanitized.GetDirectResponse().GetBody().GetInlineString()).To(Equal("team a is down"))
+		})
+
+		It("lets route policies override virtual host policies", func() {
+			setPolicy(&invalid_route.InvalidRoutePolicy{
+				Policy: &invalid_route.InvalidRoutePolicy_DirectResponse_{
+					DirectResponse: &invalid_route.InvalidRoutePolicy_DirectResponse{Status: http.StatusServiceUnavailable},
+				},
+			}, &invalid_route.InvalidRoutePolicy{
+				Policy: &invalid_route.InvalidRoutePolicy_Redirect_{
+					Redirect: &invalid_route.InvalidRoutePolicy_Redirect{
+						Url: "https://status.example.com:8443/maintenance?team=a",
+					},
+				},
+			})
+
+			sanitizer, err := NewRouteReplacingSanitizer(invalidCfgPolicy)
+			Expect(err).NotTo(HaveOccurred())
+
+			sanitized := sanitize(sanitizer, routeWithCluster(missingCluster))
+			Expect(sanitized.GetRedirect()).To(matchers.MatchProto(&envoy_config_route_v3.RedirectAction{
+				SchemeRewriteSpecifier: &envoy_config_route_v3.RedirectAction_SchemeRedirect{SchemeRedirect: "https"},
+				HostRedirect:           "status.example.com",
+				PortRedirect:           8443,
+				PathRewriteSpecifier:   &envoy_config_route_v3.RedirectAction_PathRedirect{PathRedirect: "/maintenance?team=a"},
+				ResponseCode:           envoy_config_route_v3.RedirectAction_FOUND,
+			}))
+		})
+
+		It("falls back to the global policy and reports a warning if the policy is invalid", func() {
+			setPolicy(nil, &invalid_route.InvalidRoutePolicy{
+				Policy: &invalid_route.InvalidRoutePolicy_Redirect_{
+					Redirect: &invalid_route.InvalidRoutePolicy_Redirect{Url: "/relative"},
+				},
+			})
+
+			sanitizer, err := NewRouteReplacingSanitizer(invalidCfgPolicy)
+			Expect(err).NotTo(HaveOccurred())
+
+			sanitized := sanitize(sanitizer, routeWithCluster(missingCluster))
+			Expect(sanitized.GetRoute().GetCluster()).To(Equal(fallbackClusterName))
+			Expect(reports[proxy].Warnings).To(ConsistOf(ContainSubstring(InvalidRedirectUrlErr("/relative").Error())))
+		})
+
+		It("keeps the last valid version of the route", func() {
+			setPolicy(&invalid_route.InvalidRoutePolicy{
+				Policy: &invalid_route.InvalidRoutePolicy_KeepLastValidRoute_{
+					KeepLastValidRoute: &invalid_route.InvalidRoutePolicy_KeepLastValidRoute{},
+				},
+			}, nil)
+
+			sanitizer, err := NewRouteReplacingSanitizer(invalidCfgPolicy)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("falling back to the global policy if no valid version has been served")
+			sanitized := sanitize(sanitizer, routeWithCluster(missingCluster))
+			Expect(sanitized.GetRoute().GetCluster()).To(Equal(fallbackClusterName))
+
+			By("serving a valid version")
+			sanitized = sanitize(sanitizer, routeWithCluster(clusterName))
+			Expect(sanitized).To(matchers.MatchProto(routeWithCluster(clusterName)))
+
+			By("keeping the valid version when the route becomes invalid")
+			sanitized = sanitize(sanitizer, routeWithCluster(missingCluster))
+			Expect(sanitized).To(matchers.MatchProto(routeWithCluster(clusterName)))
+			sanitized = sanitize(sanitizer, routeWithCluster(missingCluster))
+			Expect(sanitized).To(matchers.MatchProto(routeWithCluster(clusterName)))
+
+			By("not keeping the valid version if the route matcher changed")
+			changedMatch := routeWithCluster(missingCluster)
+			changedMatch.Match.PathSpecifier = &envoy_config_route_v3.RouteMatch_Prefix{Prefix: "/bar"}
+			sanitized = sanitize(sanitizer, changedMatch)
+			Expect(sanitized.GetRoute().GetCluster()).To(Equal(fallbackClusterName))
+		})
+
+		It("keeps the last valid version of each route without matchers", func() {
+			setPolicy(&invalid_route.InvalidRoutePolicy{
+				Policy: &invalid_route.InvalidRoutePolicy_KeepLastValidRoute_{
+					KeepLastValidRoute: &invalid_route.InvalidRoutePolicy_KeepLastValidRoute{},
+				},
+			}, nil)
+			// routes without matchers result in a single Envoy route without a name
+			vhost := proxy.GetListeners()[0].GetHttpListener().GetVirtualHosts()[0]
+			vhost.Routes = append(vhost.GetRoutes(), &v1.Route{})
+
+			sanitizer, err := NewRouteReplacingSanitizer(invalidCfgPolicy)
+			Expect(err).NotTo(HaveOccurred())
+
+			unnamedRoute := func(cluster string) *envoy_config_route_v3.Route {
+				route := routeWithCluster(cluster)
+				route.Name = ""
+				route.Match.PathSpecifier = &envoy_config_route_v3.RouteMatch_Prefix{Prefix: "/"}
+				return route
+			}
+			sanitizeRoutes := func(routes ...*envoy_config_route_v3.Route) []*envoy_config_route_v3.Route {
+				routeCfg := &envoy_config_route_v3.RouteConfiguration{
+					Name: routeCfgName,
+					VirtualHosts: []*envoy_config_route_v3.VirtualHost{{
+						Name:   envoyVhostName,
+						Routes: routes,
+					}},
+				}
+				xdsSnapshot := xds.NewSnapshotFromResources(
+					envoycache.NewResources("", nil),
+					envoycache.NewResources("clusters", []envoycache.Resource{
+						resource.NewEnvoyResource(cluster),
+					}),
+					envoycache.NewResources("routes", []envoycache.Resource{
+						resource.NewEnvoyResource(routeCfg),
+					}),
+					envoycache.NewResources("listeners", []envoycache.Resource{
+						resource.NewEnvoyResource(listener),
+					}),
+				)
+
+				snap := sanitizer.SanitizeSnapshot(context.TODO(), glooSnapshot, xdsSnapshot, reports)
+				sanitizedRoutes := snap.GetResources(resource.RouteTypeV3).Items[routeCfgName]
+				return sanitizedRoutes.ResourceProto().(*envoy_config_route_v3.RouteConfiguration).GetVirtualHosts()[0].GetRoutes()
+			}
+
+			By("serving a valid version of the first route only")
+			sanitized := sanitizeRoutes(unnamedRoute(clusterName), unnamedRoute(missingCluster))
+			Expect(sanitized[0]).To(matchers.MatchProto(unnamedRoute(clusterName)))
+			Expect(sanitized[1].GetRoute().GetCluster()).To(Equal(fallbackClusterName))
+
+			By("not serving the valid version of the first route in place of the second one")
+			sanitized = sanitizeRoutes(unnamedRoute(missingCluster), unnamedRoute(missingCluster))
+			Expect(sanitized[0]).To(matchers.MatchProto(unnamedRoute(clusterName)))
+			Expect(sanitized[1].GetRoute().GetCluster()).To(Equal(fallbackClusterName))
+		})
+	})
 })
@@ -27,6 +27,7 @@ import (
 	"github.com/solo-io/gloo/pkg/utils/channelutils"
 	"github.com/solo-io/gloo/pkg/utils/setuputils"
 	gateway "github.com/solo-io/gloo/projects/gateway/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/acme"
 	rlv1alpha1 "github.com/solo-io/gloo/projects/gloo/pkg/api/external/solo/ratelimit"
 	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
 	extauth "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/enterprise/options/extauth/v1"
@@ -35,7 +36,10 @@ import (
 	"github.com/solo-io/gloo/projects/gloo/pkg/defaults"
 	"github.com/solo-io/gloo/projects/gloo/pkg/discovery"
 	"github.com/solo-io/gloo/projects/gloo/pkg/plugins"
+	awsplugin "github.com/solo-io/gloo/projects/gloo/pkg/plugins/aws"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins/azure"
 	consulplugin "github.com/solo-io/gloo/projects/gloo/pkg/plugins/consul"
+	"github.com/solo-io/gloo/projects/gloo/pkg/plugins/gcp"
 	"github.com/solo-io/gloo/projects/gloo/pkg/plugins/registry"
 	"github.com/solo-io/gloo/projects/gloo/pkg/syncer/sanitizer"
 	"github.com/solo-io/gloo/projects/gloo/pkg/translator"
@@ -131,6 +135,7 @@ func NewControlPlane(ctx context.Context, grpcServer *grpc.Server, bindAddr net.
 	hasher := &xds.ProxyKeyHasher{}
 	snapshotCache := cache.NewSnapshotCache(true, hasher, contextutils.LoggerFrom(ctx))
 	xdsServer := server.NewServer(ctx, snapshotCache, callbacks)
+	xdsDeltaServer := xds.NewDeltaServer(ctx, snapshotCache, callbacks)
 	reflection.Register(grpcServer)
 
 	return bootstrap.ControlPlane{
@@ -140,8 +145,9 @@ func NewControlPlane(ctx context.Context, grpcServer *grpc.Server, bindAddr net.
 			BindAddr:        bindAddr,
 			Ctx:             ctx,
 		},
-		SnapshotCache: snapshotCache,
-		XDSServer:     xdsServer,
+		SnapshotCache:  snapshotCache,
+		XDSServer:      xdsServer,
+		XDSDeltaServer: xdsDeltaServer,
 	}
 }
 
@@ -236,6 +242,7 @@ func (s *setupSyncer) Setup(ctx context.Context, kubeCache kube.SharedCache, mem
 		if s.extensions != nil {
 			callbacks = s.extensions.XdsCallbacks
 		}
+		callbacks = xds.DefaultNodeStatusTracker.Callbacks(callbacks)
 		s.controlPlane = NewControlPlane(ctx, s.makeGrpcServer(ctx), xdsTcpAddress, callbacks, true)
 		s.previousXdsServer.cancel = cancel
 		s.previousXdsServer.addr = xdsAddr
@@ -463,8 +470,18 @@ func RunGlooWithExtensions(opts bootstrap.Opts, extensions Extensions, apiEmitte
 		return err
 	}
 
+	// Restore the last known good xds snapshots before envoy can connect
+	snapshotStore, err := xds.NewSnapshotStore(opts.Settings, opts.KubeClient)
+	if err != nil {
+		return err
+	}
+	snapshotPersistence := xds.NewSnapshotPersistence(snapshotStore, xds.DefaultNodeStatusTracker)
+	if err := snapshotPersistence.Restore(watchOpts.Ctx, opts.ControlPlane.SnapshotCache); err != nil {
+		contextutils.LoggerFrom(watchOpts.Ctx).Warnw("failed to restore persisted xds snapshots", zap.Error(err))
+	}
+
 	// Register grpc endpoints to the grpc server
-	xds.SetupEnvoyXds(opts.ControlPlane.GrpcServer, opts.ControlPlane.XDSServer, opts.ControlPlane.SnapshotCache)
+	xds.SetupEnvoyXds(opts.ControlPlane.GrpcServer, opts.ControlPlane.XDSServer, opts.ControlPlane.XDSDeltaServer, opts.ControlPlane.SnapshotCache)
 	xdsHasher := xds.NewNodeHasher()
 
 	pluginRegistryFactory := extensions.PluginRegistryFactory
@@ -560,7 +577,21 @@ func RunGlooWithExtensions(opts bootstrap.Opts, extensions Extensions, apiEmitte
 		routeReplacingSanitizer,
 	}
 
-	validator := validation.NewValidator(watchOpts.Ctx, t, xdsSanitizer)
+	// The route replacing sanitizer remembers the last valid version of routes that are served to Envoy, so the
+	// validator needs its own instance in order not to record routes from configuration that is never applied.
+	validationRouteReplacingSanitizer, err := sanitizer.NewRouteReplacingSanitizer(opts.Settings.GetGloo().GetInvalidConfigPolicy())
+	if err != nil {
+		return err
+	}
+
+	// The translator caches the last translation of each proxy, so the validator needs its own instance in order not
+	// to evict the translations of the proxies served to Envoy with translations of proposed configuration.
+	validationTranslator := translator.NewTranslator(sslutils.NewSslConfigTranslator(), opts.Settings, pluginRegistryFactory)
+
+	validator := validation.NewValidator(watchOpts.Ctx, validationTranslator, sanitizer.XdsSanitizers{
+		sanitizer.NewUpstreamRemovingSanitizer(),
+		validationRouteReplacingSanitizer,
+	})
 	if opts.ValidationServer.Server != nil {
 		opts.ValidationServer.Server.SetValidator(validator)
 	}
@@ -593,11 +624,12 @@ func RunGlooWithExtensions(opts bootstrap.Opts, extensions Extensions, apiEmitte
 	if err != nil {
 		return err
 	}
-	translationSync := syncer.NewTranslatorSyncer(t, opts.ControlPlane.SnapshotCache, xdsHasher, xdsSanitizer, rpt, opts.DevMode, syncerExtensions, opts.Settings, statusMetrics)
+	translationSync := syncer.NewTranslatorSyncer(t, opts.ControlPlane.SnapshotCache, xdsHasher, xdsSanitizer, rpt, opts.DevMode, syncerExtensions, opts.Settings, statusMetrics, snapshotPersistence, xds.DefaultNodeStatusTracker)
 
 	syncers := v1snap.ApiSyncers{
 		translationSync,
 		validator,
+		acme.NewManager(watchOpts.Ctx, secretClient, acme.NewIssuer),
 	}
 
 	apiEventLoop := v1snap.NewApiEventLoop(apiCache, syncers)
@@ -617,6 +649,41 @@ func RunGlooWithExtensions(opts bootstrap.Opts, extensions Extensions, apiEmitte
 		}
 	}()
 
+	// resync when envoy rejects a snapshot, so that the rejection is reported on the proxy,
+	// and when the tokens of the GCP and Azure upstreams expire soon, so that their routes carry new tokens, as well as
+	// the credentials of the roles assumed for the AWS upstreams, so that their clusters carry new credentials
+	go func() {
+		for {
+			select {
+			case <-watchOpts.Ctx.Done():
+				return
+			case <-xds.DefaultNodeStatusTracker.Nacks():
+			case <-gcp.DefaultIdTokenCache.Refreshes():
+			case <-azure.DefaultAccessTokenCache.Refreshes():
+			case <-awsplugin.DefaultAssumedRoleCache.Refreshes():
+			}
+			select {
+			case apiEmitterChan <- struct{}{}:
+			case <-watchOpts.Ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// persist the accepted snapshots once envoy acknowledges them
+	go func() {
+		for {
+			select {
+			case <-watchOpts.Ctx.Done():
+				return
+			case <-xds.DefaultNodeStatusTracker.Acks():
+				if err := snapshotPersistence.PersistAcknowledged(watchOpts.Ctx); err != nil {
+					logger.Warnw("failed to persist xds snapshots", zap.Error(err))
+				}
+			}
+		}
+	}()
+
 	if opts.ControlPlane.StartGrpcServer {
 		// copy for the go-routines
 		controlPlane := opts.ControlPlane
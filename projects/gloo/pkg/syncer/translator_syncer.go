@@ -31,6 +31,10 @@ type translatorSyncer struct {
 	extensionKeys map[string]struct{}
 	settings      *v1.Settings
 	statusMetrics metrics.ConfigStatusMetrics
+	// persists the last snapshot that was translated without errors, nil if disabled
+	snapshotPersistence *xds.SnapshotPersistence
+	// tracks the envoy nodes connected to the xds server, nil if not tracked
+	nodeStatuses *xds.NodeStatusTracker
 }
 
 type TranslatorSyncerExtensionParams struct {
@@ -67,16 +71,20 @@ func NewTranslatorSyncer(
 	extensions []TranslatorSyncerExtension,
 	settings *v1.Settings,
 	statusMetrics metrics.ConfigStatusMetrics,
+	snapshotPersistence *xds.SnapshotPersistence,
+	nodeStatuses *xds.NodeStatusTracker,
 ) v1snap.ApiSyncer {
 	s := &translatorSyncer{
-		translator:    translator,
-		xdsCache:      xdsCache,
-		xdsHasher:     xdsHasher,
-		reporter:      reporter,
-		extensions:    extensions,
-		sanitizer:     sanitizer,
-		settings:      settings,
-		statusMetrics: statusMetrics,
+		translator:          translator,
+		xdsCache:            xdsCache,
+		xdsHasher:           xdsHasher,
+		reporter:            reporter,
+		extensions:          extensions,
+		sanitizer:           sanitizer,
+		settings:            settings,
+		statusMetrics:       statusMetrics,
+		snapshotPersistence: snapshotPersistence,
+		nodeStatuses:        nodeStatuses,
 	}
 	if devMode {
 		// TODO(ilackarms): move this somewhere else?
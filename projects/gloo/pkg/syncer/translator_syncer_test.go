@@ -2,7 +2,12 @@ package syncer_test
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
 
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	_struct "github.com/golang/protobuf/ptypes/struct"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/solo-io/gloo/pkg/utils/statusutils"
@@ -17,10 +22,12 @@ import (
 	"github.com/solo-io/solo-kit/pkg/api/v1/clients/factory"
 	"github.com/solo-io/solo-kit/pkg/api/v1/clients/memory"
 	envoycache "github.com/solo-io/solo-kit/pkg/api/v1/control-plane/cache"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/resource"
 	"github.com/solo-io/solo-kit/pkg/api/v1/resources"
 	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
 	"github.com/solo-io/solo-kit/pkg/api/v2/reporter"
 	"github.com/solo-io/solo-kit/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/status"
 )
 
 var _ = Describe("Translate Proxy", func() {
@@ -73,7 +80,7 @@ var _ = Describe("Translate Proxy", func() {
 		rep := reporter.NewReporter(ref, statusClient, proxyClient.BaseClient(), upstreamClient)
 
 		xdsHasher := &xds.ProxyKeyHasher{}
-		syncer = NewTranslatorSyncer(&mockTranslator{true, false, nil}, xdsCache, xdsHasher, sanitizer, rep, false, nil, settings, statusMetrics)
+		syncer = NewTranslatorSyncer(&mockTranslator{true, false, nil}, xdsCache, xdsHasher, sanitizer, rep, false, nil, settings, statusMetrics, nil, nil)
 		snap = &v1snap.ApiSnapshot{
 			Proxies: v1.ProxyList{
 				proxy,
@@ -102,7 +109,7 @@ var _ = Describe("Translate Proxy", func() {
 		Expect(err).NotTo(HaveOccurred())
 		snap.Proxies[0] = p1
 
-		syncer = NewTranslatorSyncer(&mockTranslator{false, false, nil}, xdsCache, xdsHasher, sanitizer, rep, false, nil, settings, statusMetrics)
+		syncer = NewTranslatorSyncer(&mockTranslator{false, false, nil}, xdsCache, xdsHasher, sanitizer, rep, false, nil, settings, statusMetrics, nil, nil)
 
 		err = syncer.Sync(context.Background(), snap)
 		Expect(err).NotTo(HaveOccurred())
@@ -226,7 +233,7 @@ var _ = Describe("Translate multiple proxies with errors", func() {
 		rep := reporter.NewReporter(ref, statusClient, proxyClient.BaseClient(), usClient)
 
 		xdsHasher := &xds.ProxyKeyHasher{}
-		syncer = NewTranslatorSyncer(&mockTranslator{true, true, nil}, xdsCache, xdsHasher, sanitizer, rep, false, nil, settings, statusMetrics)
+		syncer = NewTranslatorSyncer(&mockTranslator{true, true, nil}, xdsCache, xdsHasher, sanitizer, rep, false, nil, settings, statusMetrics, nil, nil)
 		snap = &v1snap.ApiSnapshot{
 			Proxies: v1.ProxyList{
 				proxy1,
@@ -304,6 +311,168 @@ var _ = Describe("Translate multiple proxies with errors", func() {
 	})
 })
 
+var _ = Describe("Translate Proxy with snapshot persistence", func() {
+
+	var (
+		xdsCache      *MockXdsCache
+		sanitizer     *MockXdsSanitizer
+		snap          *v1snap.ApiSnapshot
+		rep           reporter.StatusReporter
+		store         xds.SnapshotStore
+		persistence   *xds.SnapshotPersistence
+		acknowledger  *mockAcknowledger
+		statusMetrics metrics.ConfigStatusMetrics
+		dir           string
+		key           string
+		ns            = "any-ns"
+	)
+
+	newSnapshot := func(version string) *xds.EnvoySnapshot {
+		return xds.NewSnapshot(version, nil, nil, nil, nil)
+	}
+
+	sync := func(translator *mockTranslator) {
+		syncer := NewTranslatorSyncer(translator, xdsCache, &xds.ProxyKeyHasher{}, sanitizer, rep, false, nil, &v1.Settings{}, statusMetrics, persistence, nil)
+		err := syncer.Sync(context.Background(), snap)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	servedVersion := func() string {
+		return xdsCache.SetSnap.GetResources(resource.ClusterTypeV3).Version
+	}
+
+	BeforeEach(func() {
+		var err error
+		xdsCache = &MockXdsCache{}
+		sanitizer = &MockXdsSanitizer{}
+
+		resourceClientFactory := &factory.MemoryResourceClientFactory{
+			Cache: memory.NewInMemoryResourceCache(),
+		}
+		proxyClient, err := v1.NewProxyClient(context.Background(), resourceClientFactory)
+		Expect(err).NotTo(HaveOccurred())
+		proxy, err := proxyClient.Write(&v1.Proxy{
+			Metadata: &core.Metadata{
+				Namespace: ns,
+				Name:      "proxy-name",
+			},
+		}, clients.WriteOpts{})
+		Expect(err).NotTo(HaveOccurred())
+		snap = &v1snap.ApiSnapshot{
+			Proxies: v1.ProxyList{proxy},
+		}
+		key = xds.SnapshotKey(proxy)
+
+		statusMetrics, err = metrics.NewConfigStatusMetrics(metrics.GetDefaultConfigStatusOptions())
+		Expect(err).NotTo(HaveOccurred())
+		rep = reporter.NewReporter("syncer-test", statusutils.GetStatusClientFromEnvOrDefault(ns), proxyClient.BaseClient())
+
+		dir, err = ioutil.TempDir("", "xds-snapshots")
+		Expect(err).NotTo(HaveOccurred())
+		store = xds.NewFileSnapshotStore(dir)
+		Expect(store.Save(context.Background(), key, newSnapshot("persisted"))).NotTo(HaveOccurred())
+
+		acknowledger = &mockAcknowledger{}
+		persistence = xds.NewSnapshotPersistence(store, acknowledger)
+		Expect(persistence.Restore(context.Background(), xdsCache)).NotTo(HaveOccurred())
+		Expect(servedVersion()).To(Equal("persisted"))
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	It("serves the persisted snapshot until the proxy translates without errors", func() {
+		sync(&mockTranslator{reportErrs: true, currentSnapshot: newSnapshot("invalid")})
+		Expect(servedVersion()).To(Equal("persisted"))
+
+		sync(&mockTranslator{currentSnapshot: newSnapshot("valid")})
+		Expect(servedVersion()).To(Equal("valid"))
+		// the snapshot is only persisted once envoy acknowledges it
+		snapshots, err := store.Load(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshots[key].GetResources(resource.ClusterTypeV3).Version).To(Equal("persisted"))
+		acknowledger.acknowledged = true
+		Expect(persistence.PersistAcknowledged(context.Background())).NotTo(HaveOccurred())
+		snapshots, err = store.Load(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshots[key].GetResources(resource.ClusterTypeV3).Version).To(Equal("valid"))
+
+		// once the proxy has been translated, later translations are served even if they have errors
+		sync(&mockTranslator{reportErrs: true, currentSnapshot: newSnapshot("invalid")})
+		Expect(servedVersion()).To(Equal("invalid"))
+		snapshots, err = store.Load(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshots[key].GetResources(resource.ClusterTypeV3).Version).To(Equal("valid"))
+	})
+})
+
+var _ = Describe("Translate Proxy with rejected snapshots", func() {
+
+	It("reports snapshots rejected by envoy as warnings on the proxy", func() {
+		ctx := context.Background()
+		ns := "any-ns"
+		resourceClientFactory := &factory.MemoryResourceClientFactory{
+			Cache: memory.NewInMemoryResourceCache(),
+		}
+		proxyClient, err := v1.NewProxyClient(ctx, resourceClientFactory)
+		Expect(err).NotTo(HaveOccurred())
+		proxy, err := proxyClient.Write(&v1.Proxy{
+			Metadata: &core.Metadata{
+				Namespace: ns,
+				Name:      "proxy-name",
+			},
+		}, clients.WriteOpts{})
+		Expect(err).NotTo(HaveOccurred())
+
+		nodeStatuses := xds.NewNodeStatusTracker()
+		callbacks := nodeStatuses.Callbacks(nil)
+		Expect(callbacks.OnStreamOpen(ctx, 1, resource.AnyType)).NotTo(HaveOccurred())
+		Expect(callbacks.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{
+			Node: &envoy_config_core_v3.Node{
+				Id: "node-1",
+				Metadata: &_struct.Struct{
+					Fields: map[string]*_struct.Value{
+						"role": {Kind: &_struct.Value_StringValue{StringValue: xds.SnapshotKey(proxy)}},
+					},
+				},
+			},
+			TypeUrl: resource.ListenerTypeV3,
+		})).NotTo(HaveOccurred())
+		callbacks.OnStreamResponse(1, &envoy_service_discovery_v3.DiscoveryRequest{}, &envoy_service_discovery_v3.DiscoveryResponse{
+			TypeUrl:     resource.ListenerTypeV3,
+			VersionInfo: "1",
+			Nonce:       "a",
+		})
+		Expect(callbacks.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{
+			TypeUrl:       resource.ListenerTypeV3,
+			ResponseNonce: "a",
+			ErrorDetail:   &status.Status{Message: "bad listener"},
+		})).NotTo(HaveOccurred())
+
+		statusMetrics, err := metrics.NewConfigStatusMetrics(metrics.GetDefaultConfigStatusOptions())
+		Expect(err).NotTo(HaveOccurred())
+		statusClient := statusutils.GetStatusClientFromEnvOrDefault(ns)
+		rep := reporter.NewReporter("syncer-test", statusClient, proxyClient.BaseClient())
+		syncer := NewTranslatorSyncer(&mockTranslator{}, &MockXdsCache{}, &xds.ProxyKeyHasher{}, &MockXdsSanitizer{}, rep, false, nil, &v1.Settings{}, statusMetrics, nil, nodeStatuses)
+		err = syncer.Sync(ctx, &v1snap.ApiSnapshot{Proxies: v1.ProxyList{proxy}})
+		Expect(err).NotTo(HaveOccurred())
+
+		proxy, err = proxyClient.Read(ns, "proxy-name", clients.ReadOpts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(statusClient.GetStatus(proxy).GetState()).To(Equal(core.Status_Warning))
+		Expect(statusClient.GetStatus(proxy).GetReason()).To(ContainSubstring("envoy node node-1 rejected version 1 of " + resource.ListenerTypeV3 + ": bad listener"))
+	})
+})
+
+type mockAcknowledger struct {
+	acknowledged bool
+}
+
+func (m *mockAcknowledger) IsAcknowledged(string, envoycache.Snapshot) bool {
+	return m.acknowledged
+}
+
 type mockTranslator struct {
 	reportErrs         bool
 	reportUpstreamErrs bool // Adds an error to every upstream in the snapshot
@@ -103,7 +103,7 @@ func (t *translatorInstance) initializeCluster(
 	}
 
 	if sslConfig := upstream.GetSslConfig(); sslConfig != nil {
-		applyDefaultsToUpstreamSslConfig(sslConfig, t.settings.GetUpstreamOptions())
+		sslConfig = applyDefaultsToUpstreamSslConfig(sslConfig, t.settings.GetUpstreamOptions())
 		cfg, err := utils.NewSslConfigTranslator().ResolveUpstreamSslConfig(*secrets, sslConfig)
 		if err != nil {
 			reports.AddError(upstream, err)
@@ -369,13 +369,16 @@ func validateRouteDestinationForValidLambdas(
 }
 
 // Apply defaults to UpstreamSslConfig
-func applyDefaultsToUpstreamSslConfig(sslConfig *v1.UpstreamSslConfig, options *v1.UpstreamOptions) {
+// The upstream is not modified, as the snapshot it belongs to may be translated concurrently for other proxies.
+func applyDefaultsToUpstreamSslConfig(sslConfig *v1.UpstreamSslConfig, options *v1.UpstreamOptions) *v1.UpstreamSslConfig {
 	if options == nil {
-		return
+		return sslConfig
 	}
 
 	// Apply default SslParameters if none are defined on upstream
 	if sslConfig.GetParameters() == nil {
+		sslConfig = sslConfig.Clone().(*v1.UpstreamSslConfig)
 		sslConfig.Parameters = options.GetSslParameters()
 	}
+	return sslConfig
 }
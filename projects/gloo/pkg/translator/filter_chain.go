@@ -12,6 +12,7 @@ import (
 
 	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	"github.com/golang/protobuf/proto"
+	"github.com/rotisserie/eris"
 	validationapi "github.com/solo-io/gloo/projects/gloo/pkg/api/grpc/validation"
 	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
 	v1snap "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/gloosnapshot"
@@ -136,6 +137,10 @@ func (h *httpFilterChainTranslator) createFilterChainsFromSslConfiguration(
 	for _, sslConfig := range sslConfigurations {
 		// get secrets
 		downstreamTlsContext, err := h.sslConfigTranslator.ResolveDownstreamSslConfig(snap.Secrets, sslConfig)
+		if eris.Is(err, utils.AcmeCertificatePendingError) {
+			// the filter chain is served once the certificate is issued
+			continue
+		}
 		if err != nil {
 			validation.AppendListenerError(h.parentReport, validationapi.ListenerReport_Error_SSLConfigError, err.Error())
 			continue
@@ -0,0 +1,178 @@
+package translator
+
+import (
+	"fmt"
+	"time"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoyquic "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/quic/v3"
+	envoyauth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	errors "github.com/rotisserie/eris"
+	validationapi "github.com/solo-io/gloo/projects/gloo/pkg/api/grpc/validation"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/http3"
+	"github.com/solo-io/gloo/projects/gloo/pkg/utils"
+	"github.com/solo-io/gloo/projects/gloo/pkg/utils/validation"
+)
+
+const (
+	QuicListenerNameSuffix = "-quic"
+
+	// https://www.rfc-editor.org/rfc/rfc9114.html#section-3.1
+	Http3AlpnProtocol = "h3"
+
+	AltSvcHeader        = "alt-svc"
+	DefaultAltSvcMaxAge = 24 * time.Hour
+)
+
+var (
+	Http3RequiresSslError = func(routeConfigName string) error {
+		return errors.Errorf("http3 is enabled on %s, but HTTP/3 can only be served with TLS: add ssl configurations to the listener", routeConfigName)
+	}
+
+	Http3TlsVersionError = func(serverNames []string, maxVersion envoyauth.TlsParameters_TlsProtocol) error {
+		return errors.Errorf("ssl configuration for sni domains %v is not compatible with HTTP/3: QUIC requires TLSv1_3, but the maximum protocol version is %v", serverNames, maxVersion)
+	}
+)
+
+// Returns the HTTP/3 options of the http listeners of a Gloo listener, keyed by the name of their route configuration,
+// which is the name the HttpConnectionManager of their filter chains refers to.
+func http3OptionsByRouteConfigName(listener *v1.Listener) map[string]*http3.Http3 {
+	options := map[string]*http3.Http3{}
+	switch listenerType := listener.GetListenerType().(type) {
+	case *v1.Listener_HttpListener:
+		if http3Options := listenerType.HttpListener.GetOptions().GetHttp3(); http3Options != nil {
+			options[utils.RouteConfigName(listener)] = http3Options
+		}
+	case *v1.Listener_HybridListener:
+		for _, matchedListener := range listenerType.HybridListener.GetMatchedListeners() {
+			if http3Options := matchedListener.GetHttpListener().GetOptions().GetHttp3(); http3Options != nil {
+				options[utils.MatchedRouteConfigName(listener, matchedListener.GetMatcher())] = http3Options
+			}
+		}
+	}
+	return options
+}
+
+// computeQuicListener derives the UDP listener that serves HTTP/3 from the TCP listener computed for a Gloo listener.
+// Each filter chain of an http listener with HTTP/3 enabled is copied with the same route configuration and TLS
+// certificates, so that both listeners serve the same routes. Returns nil if HTTP/3 is not enabled on the listener.
+func computeQuicListener(
+	listener *v1.Listener,
+	tcpListener *envoy_config_listener_v3.Listener,
+	listenerReport *validationapi.ListenerReport,
+) *envoy_config_listener_v3.Listener {
+	http3Options := http3OptionsByRouteConfigName(listener)
+	if len(http3Options) == 0 {
+		return nil
+	}
+
+	var filterChains []*envoy_config_listener_v3.FilterChain
+	for _, filterChain := range tcpListener.GetFilterChains() {
+		hcmFilterIndex, hcm := getHttpConnectionManager(filterChain)
+		if hcm == nil || http3Options[hcm.GetRds().GetRouteConfigName()] == nil {
+			continue
+		}
+
+		quicFilterChain, err := newQuicFilterChain(filterChain, hcmFilterIndex, hcm)
+		if err != nil {
+			validation.AppendListenerError(listenerReport, validationapi.ListenerReport_Error_SSLConfigError, err.Error())
+			continue
+		}
+		filterChains = append(filterChains, quicFilterChain)
+	}
+	if len(filterChains) == 0 {
+		return nil
+	}
+
+	quicListener := proto.Clone(tcpListener).(*envoy_config_listener_v3.Listener)
+	quicListener.Name = tcpListener.GetName() + QuicListenerNameSuffix
+	quicListener.GetAddress().GetSocketAddress().Protocol = envoy_config_core_v3.SocketAddress_UDP
+	quicListener.UdpListenerConfig = &envoy_config_listener_v3.UdpListenerConfig{
+		QuicOptions: &envoy_config_listener_v3.QuicProtocolOptions{},
+	}
+	quicListener.FilterChains = filterChains
+	// listener filters such as the tls inspector and proxy protocol operate on TCP connections; QUIC connections
+	// carry the sni domain in their own handshake
+	quicListener.ListenerFilters = nil
+	quicListener.ConnectionBalanceConfig = nil
+
+	return quicListener
+}
+
+func getHttpConnectionManager(filterChain *envoy_config_listener_v3.FilterChain) (int, *envoyhttp.HttpConnectionManager) {
+	for i, filter := range filterChain.GetFilters() {
+		if filter.GetName() != wellknown.HTTPConnectionManager {
+			continue
+		}
+		var hcm envoyhttp.HttpConnectionManager
+		if err := ParseTypedConfig(filter, &hcm); err != nil {
+			return 0, nil
+		}
+		return i, &hcm
+	}
+	return 0, nil
+}
+
+// Copies a TCP filter chain of an http listener into a QUIC filter chain: the TLS context is moved into the QUIC
+// transport socket and the HttpConnectionManager speaks HTTP/3.
+func newQuicFilterChain(
+	filterChain *envoy_config_listener_v3.FilterChain,
+	hcmFilterIndex int,
+	hcm *envoyhttp.HttpConnectionManager,
+) (*envoy_config_listener_v3.FilterChain, error) {
+	if filterChain.GetTransportSocket().GetName() != wellknown.TransportSocketTls {
+		return nil, Http3RequiresSslError(hcm.GetRds().GetRouteConfigName())
+	}
+	var downstreamTlsContext envoyauth.DownstreamTlsContext
+	if err := ptypes.UnmarshalAny(filterChain.GetTransportSocket().GetTypedConfig(), &downstreamTlsContext); err != nil {
+		return nil, err
+	}
+	commonTlsContext := downstreamTlsContext.GetCommonTlsContext()
+	switch maxVersion := commonTlsContext.GetTlsParams().GetTlsMaximumProtocolVersion(); maxVersion {
+	case envoyauth.TlsParameters_TLS_AUTO, envoyauth.TlsParameters_TLSv1_3:
+	default:
+		return nil, Http3TlsVersionError(filterChain.GetFilterChainMatch().GetServerNames(), maxVersion)
+	}
+	if commonTlsContext != nil {
+		commonTlsContext.AlpnProtocols = []string{Http3AlpnProtocol}
+	}
+
+	hcm.CodecType = envoyhttp.HttpConnectionManager_HTTP3
+	hcm.Http3ProtocolOptions = &envoy_config_core_v3.Http3ProtocolOptions{}
+	hcmFilter, err := NewFilterWithTypedConfig(wellknown.HTTPConnectionManager, hcm)
+	if err != nil {
+		return nil, err
+	}
+
+	quicFilterChain := proto.Clone(filterChain).(*envoy_config_listener_v3.FilterChain)
+	quicFilterChain.GetFilters()[hcmFilterIndex] = hcmFilter
+	quicFilterChain.TransportSocket = &envoy_config_core_v3.TransportSocket{
+		Name: wellknown.TransportSocketQuic,
+		ConfigType: &envoy_config_core_v3.TransportSocket_TypedConfig{
+			TypedConfig: utils.MustMessageToAny(&envoyquic.QuicDownstreamTransport{
+				DownstreamTlsContext: &downstreamTlsContext,
+			}),
+		},
+	}
+	return quicFilterChain, nil
+}
+
+// Returns the value of the alt-svc header that advertises the HTTP/3 listener to the clients of the TCP listener.
+// https://www.rfc-editor.org/rfc/rfc7838.html#section-3
+func altSvcHeaderValue(options *http3.Http3, bindPort uint32) string {
+	port := bindPort
+	if advertisedPort := options.GetAdvertisedPort(); advertisedPort != nil {
+		port = advertisedPort.GetValue()
+	}
+	maxAge := DefaultAltSvcMaxAge
+	if options.GetAltSvcMaxAge() != nil {
+		maxAge = options.GetAltSvcMaxAge().AsDuration()
+	}
+	return fmt.Sprintf(`%s=":%d"; ma=%d`, Http3AlpnProtocol, port, int64(maxAge.Seconds()))
+}
@@ -70,6 +70,22 @@ func (h *httpRouteConfigurationTranslator) ComputeRouteConfiguration(params plug
 		Name:                           h.routeConfigName,
 		VirtualHosts:                   h.computeVirtualHosts(params),
 		MaxDirectResponseBodySizeBytes: h.parentListener.GetRouteOptions().GetMaxDirectResponseBodySizeBytes(),
+		ResponseHeadersToAdd:           h.computeResponseHeadersToAdd(),
+	}}
+}
+
+// Advertises the HTTP/3 listener, if any, to the clients of the TCP listener
+func (h *httpRouteConfigurationTranslator) computeResponseHeadersToAdd() []*envoy_config_core_v3.HeaderValueOption {
+	http3Options := h.listener.GetOptions().GetHttp3()
+	if http3Options == nil || http3Options.GetDisableAltSvc() {
+		return nil
+	}
+	return []*envoy_config_core_v3.HeaderValueOption{{
+		Header: &envoy_config_core_v3.HeaderValue{
+			Key:   AltSvcHeader,
+			Value: altSvcHeaderValue(http3Options, h.parentListener.GetBindPort()),
+		},
+		Append: &wrappers.BoolValue{Value: false},
 	}}
 }
 
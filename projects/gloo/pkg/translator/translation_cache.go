@@ -0,0 +1,258 @@
+package translator
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/go-multierror"
+	validationapi "github.com/solo-io/gloo/projects/gloo/pkg/api/grpc/validation"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	v1snap "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/gloosnapshot"
+	syncerstats "github.com/solo-io/gloo/projects/gloo/pkg/syncer/stats"
+	"github.com/solo-io/go-utils/hashutils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources"
+	"github.com/solo-io/solo-kit/pkg/api/v2/reporter"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const (
+	// the whole translation of the proxy was reused
+	cacheHit = "hit"
+	// only the endpoints of the proxy were translated, as nothing else changed
+	cacheEndpointsOnly = "endpoints"
+	// the proxy was translated from scratch
+	cacheMiss = "miss"
+
+	// translations are redone from scratch at least this often, so that plugins eventually pick up inputs which are not
+	// part of the snapshot, e.g. the Kubernetes services looked up by the kubernetes plugin
+	maxCachedTranslationAge = time.Minute
+)
+
+var (
+	cacheResultKey, _ = tag.NewKey("result")
+
+	mCacheLookups    = stats.Int64("api.gloo.solo.io/translator/cache_lookups", "The number of proxy translations, by how much of the previous translation could be reused", "1")
+	mTranslationTime = stats.Float64("api.gloo.solo.io/translator/translation_time", "The time spent translating a proxy", "ms")
+
+	cacheLookupsView = &view.View{
+		Name:        "api.gloo.solo.io/translator/cache_lookups",
+		Measure:     mCacheLookups,
+		Description: "The number of proxy translations, by how much of the previous translation could be reused",
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{syncerstats.ProxyNameKey, cacheResultKey},
+	}
+	translationTimeView = &view.View{
+		Name:        "api.gloo.solo.io/translator/translation_time",
+		Measure:     mTranslationTime,
+		Description: "The time spent translating a proxy",
+		Aggregation: view.Distribution(1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000),
+		TagKeys:     []tag.Key{syncerstats.ProxyNameKey},
+	}
+)
+
+func init() {
+	_ = view.Register(cacheLookupsView, translationTimeView)
+}
+
+// incremented each time the translations are invalidated
+var translationsGeneration uint64
+
+// InvalidateTranslations makes the next translation of each proxy start from scratch. Plugins call it when inputs
+// which are not part of the snapshot change, e.g. the tokens gloo obtains for the upstreams.
+func InvalidateTranslations() {
+	atomic.AddUint64(&translationsGeneration, 1)
+}
+
+func recordCacheLookup(ctx context.Context, result string) {
+	if ctxWithTags, err := tag.New(ctx, tag.Insert(cacheResultKey, result)); err == nil {
+		stats.Record(ctxWithTags, mCacheLookups.M(1))
+	}
+}
+
+func recordTranslationTime(ctx context.Context, start time.Time) {
+	stats.Record(ctx, mTranslationTime.M(float64(time.Since(start))/float64(time.Millisecond)))
+}
+
+// translationCache holds the last translation of each proxy, so that it can be reused when its inputs did not change.
+//
+// Plugins record state while processing upstreams which they rely on when processing routes and listeners, so
+// clusters, route configurations and listeners can only be reused together. Endpoints however only depend on the
+// upstreams and endpoints of the snapshot, so when only endpoints changed, the endpoints alone are re-translated.
+type translationCache struct {
+	lock    sync.Mutex
+	proxies map[string]*cachedTranslation
+}
+
+type snapshotInputs struct {
+	// hash of the settings and of everything in the snapshot but the endpoints and proxies, along with the upstreams
+	// which have endpoints
+	hash uint64
+	// hash of the endpoints in the snapshot
+	endpointsHash uint64
+	// false if the snapshot could not be hashed, in which case translations are not cached
+	cacheable bool
+	// the generation of the translations when the snapshot was received
+	generation uint64
+
+	upstreamRefKeyToEndpoints map[string][]*v1.Endpoint
+}
+
+type cachedTranslation struct {
+	translatedAt  time.Time
+	generation    uint64
+	proxyHash     uint64
+	inputsHash    uint64
+	endpointsHash uint64
+
+	clusters           []*envoy_config_cluster_v3.Cluster
+	generatedClusters  []*envoy_config_cluster_v3.Cluster
+	endpoints          []*envoy_config_endpoint_v3.ClusterLoadAssignment
+	generatedEndpoints []*envoy_config_endpoint_v3.ClusterLoadAssignment
+	routeConfigs       []*envoy_config_route_v3.RouteConfiguration
+	listeners          []*envoy_config_listener_v3.Listener
+
+	reports         reporter.ResourceReports
+	endpointReports reporter.ResourceReports
+	proxyReport     *validationapi.ProxyReport
+}
+
+func newTranslationCache() *translationCache {
+	return &translationCache{proxies: map[string]*cachedTranslation{}}
+}
+
+// returns the inputs of the snapshot; they are computed on each translation, as snapshots may be modified in place
+func snapshotInputsOf(settings *v1.Settings, snap *v1snap.ApiSnapshot) *snapshotInputs {
+	inputs := &snapshotInputs{
+		upstreamRefKeyToEndpoints: createUpstreamToEndpointsMap(snap.Upstreams, snap.Endpoints),
+		generation:                atomic.LoadUint64(&translationsGeneration),
+	}
+	inputs.hash, inputs.endpointsHash, inputs.cacheable = hashSnapshotInputs(settings, snap, inputs.upstreamRefKeyToEndpoints)
+	return inputs
+}
+
+func hashSnapshotInputs(settings *v1.Settings, snap *v1snap.ApiSnapshot, upstreamRefKeyToEndpoints map[string][]*v1.Endpoint) (uint64, uint64, bool) {
+	hasher := fnv.New64()
+	if _, err := settings.Hash(hasher); err != nil {
+		return 0, 0, false
+	}
+	withoutEndpoints := *snap
+	withoutEndpoints.Endpoints = nil
+	withoutEndpoints.Proxies = nil
+	if _, err := withoutEndpoints.Hash(hasher); err != nil {
+		return 0, 0, false
+	}
+	// whether an upstream has endpoints determines the type of its cluster
+	var edsUpstreams []string
+	for upstreamRefKey, endpoints := range upstreamRefKeyToEndpoints {
+		if len(endpoints) > 0 {
+			edsUpstreams = append(edsUpstreams, upstreamRefKey)
+		}
+	}
+	sort.Strings(edsUpstreams)
+	for _, upstreamRefKey := range edsUpstreams {
+		_, _ = hasher.Write([]byte(upstreamRefKey))
+	}
+
+	endpointsHash, err := hashutils.HashAllSafe(fnv.New64(), snap.Endpoints.AsInterfaces()...)
+	if err != nil {
+		return 0, 0, false
+	}
+	return hasher.Sum64(), endpointsHash, true
+}
+
+// returns the last translation of the proxy if neither the proxy nor the inputs other than endpoints changed since,
+// and the translations have not been invalidated
+func (c *translationCache) get(proxy *v1.Proxy, proxyHash uint64, inputs *snapshotInputs) *cachedTranslation {
+	if !inputs.cacheable {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cached, ok := c.proxies[proxy.GetMetadata().Ref().Key()]
+	if !ok || cached.generation != inputs.generation || cached.proxyHash != proxyHash || cached.inputsHash != inputs.hash {
+		return nil
+	}
+	if time.Since(cached.translatedAt) > maxCachedTranslationAge {
+		return nil
+	}
+	return cached
+}
+
+// stores the translation of the proxy, and forgets the translations of the proxies which are not in the snapshot
+func (c *translationCache) set(snap *v1snap.ApiSnapshot, proxy *v1.Proxy, translation *cachedTranslation) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.proxies[proxy.GetMetadata().Ref().Key()] = translation
+
+	if len(c.proxies) <= len(snap.Proxies) {
+		return
+	}
+	proxyKeys := map[string]struct{}{
+		proxy.GetMetadata().Ref().Key(): {},
+	}
+	for _, snapProxy := range snap.Proxies {
+		proxyKeys[snapProxy.GetMetadata().Ref().Key()] = struct{}{}
+	}
+	for key := range c.proxies {
+		if _, ok := proxyKeys[key]; !ok {
+			delete(c.proxies, key)
+		}
+	}
+}
+
+// Returns a copy of the cached reports, whose resources are replaced with the resources of the current snapshot.
+// The copy may be modified without affecting the cache.
+func (c *cachedTranslation) copyReports(snap *v1snap.ApiSnapshot, proxy *v1.Proxy, endpointReports reporter.ResourceReports) (reporter.ResourceReports, *validationapi.ProxyReport) {
+	currentResources := map[string]resources.InputResource{
+		resourceKey(proxy): proxy,
+	}
+	for _, res := range snap.Upstreams.AsInputResources() {
+		currentResources[resourceKey(res)] = res
+	}
+	for _, res := range snap.UpstreamGroups.AsInputResources() {
+		currentResources[resourceKey(res)] = res
+	}
+
+	reports := make(reporter.ResourceReports, len(c.reports)+len(endpointReports))
+	for _, cached := range []reporter.ResourceReports{c.reports, endpointReports} {
+		for res, report := range cached {
+			if current, ok := currentResources[resourceKey(res)]; ok {
+				res = current
+			}
+			reports.Merge(reporter.ResourceReports{res: copyReport(report)})
+		}
+	}
+	return reports, proto.Clone(c.proxyReport).(*validationapi.ProxyReport)
+}
+
+func resourceKey(res resources.InputResource) string {
+	return resources.Kind(res) + "/" + res.GetMetadata().Ref().Key()
+}
+
+// reports are modified in place when errors are added, so the cached reports must not be shared
+func copyReport(report reporter.Report) reporter.Report {
+	out := reporter.Report{
+		Errors: report.Errors,
+	}
+	if report.Warnings != nil {
+		out.Warnings = append([]string{}, report.Warnings...)
+	}
+	if errs, ok := report.Errors.(*multierror.Error); ok {
+		out.Errors = &multierror.Error{
+			Errors:      append([]error{}, errs.Errors...),
+			ErrorFormat: errs.ErrorFormat,
+		}
+	}
+	return out
+}
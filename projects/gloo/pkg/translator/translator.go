@@ -3,6 +3,7 @@ package translator
 import (
 	"fmt"
 	"hash/fnv"
+	"time"
 
 	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
@@ -20,7 +21,6 @@ import (
 	"github.com/solo-io/go-utils/contextutils"
 	envoycache "github.com/solo-io/solo-kit/pkg/api/v1/control-plane/cache"
 	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/resource"
-	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
 	"github.com/solo-io/solo-kit/pkg/api/v2/reporter"
 	"go.opencensus.io/trace"
 	proto2 "google.golang.org/protobuf/proto"
@@ -55,6 +55,7 @@ func NewTranslatorWithHasher(
 		settings:              settings,
 		sslConfigTranslator:   sslConfigTranslator,
 		hasher:                hasher,
+		cache:                 newTranslationCache(),
 	}
 }
 
@@ -63,12 +64,15 @@ type translatorFactory struct {
 	settings              *v1.Settings
 	sslConfigTranslator   utils.SslConfigTranslator
 	hasher                func(resources []envoycache.Resource) uint64
+	cache                 *translationCache
 }
 
 func (t *translatorFactory) Translate(
 	params plugins.Params,
 	proxy *v1.Proxy,
 ) (envoycache.Snapshot, reporter.ResourceReports, *validationapi.ProxyReport, error) {
+	defer recordTranslationTime(params.Ctx, time.Now())
+
 	pluginRegistry := t.pluginRegistryFactory(params.Ctx)
 	listenerTranslatorFactory := NewListenerSubsystemTranslatorFactory(pluginRegistry, t.sslConfigTranslator)
 
@@ -77,6 +81,7 @@ func (t *translatorFactory) Translate(
 		settings:                  t.settings,
 		hasher:                    t.hasher,
 		listenerTranslatorFactory: listenerTranslatorFactory,
+		cache:                     t.cache,
 	}
 
 	return instance.Translate(params, proxy)
@@ -89,6 +94,7 @@ type translatorInstance struct {
 	sslConfigTranslator       utils.SslConfigTranslator
 	hasher                    func(resources []envoycache.Resource) uint64
 	listenerTranslatorFactory *ListenerSubsystemTranslatorFactory
+	cache                     *translationCache
 }
 
 func (t *translatorInstance) Translate(
@@ -100,6 +106,17 @@ func (t *translatorInstance) Translate(
 	defer span.End()
 	params.Ctx = contextutils.WithLogger(ctx, "translator")
 
+	// reuse the previous translation of the proxy if only the endpoints changed since
+	inputs := snapshotInputsOf(t.settings, params.Snapshot)
+	proxyHash, err := proxy.Hash(nil)
+	if err != nil {
+		inputs = &snapshotInputs{upstreamRefKeyToEndpoints: inputs.upstreamRefKeyToEndpoints, generation: inputs.generation}
+	}
+	if cached := t.cache.get(proxy, proxyHash, inputs); cached != nil {
+		return t.translateFromCache(params, proxy, inputs, cached)
+	}
+	recordCacheLookup(params.Ctx, cacheMiss)
+
 	// re-initialize plugins on each loop, this is done for 2 reasons:
 	//  1. Each translation run relies on its own context. If a plugin spawns a go-routine
 	//		we need to be able to cancel that go-routine on the next translation
@@ -115,27 +132,31 @@ func (t *translatorInstance) Translate(
 	}
 
 	// prepare reports used to aggregate Warnings/Errors encountered during translation
+	// endpoint reports are kept apart, so that the other reports can be reused when only endpoints change
 	reports := make(reporter.ResourceReports)
+	endpointReports := make(reporter.ResourceReports)
 	proxyReport := validation.MakeReport(proxy)
 
 	// execute translation of listener and cluster subsystems
-	clusters, endpoints := t.translateClusterSubsystemComponents(params, proxy, reports)
+	clusters, endpoints := t.translateClusterSubsystemComponents(params, proxy, inputs.upstreamRefKeyToEndpoints, reports, endpointReports)
 	routeConfigs, listeners := t.translateListenerSubsystemComponents(params, proxy, proxyReport)
 
 	// run Resource Generator Plugins
+	var generatedClusters []*envoy_config_cluster_v3.Cluster
+	var generatedEndpoints []*envoy_config_endpoint_v3.ClusterLoadAssignment
 	for _, plugin := range t.pluginRegistry.GetResourceGeneratorPlugins() {
-		generatedClusters, generatedEndpoints, generatedRouteConfigs, generatedListeners, err := plugin.GeneratedResources(params, clusters, endpoints, routeConfigs, listeners)
+		allClusters := append(append([]*envoy_config_cluster_v3.Cluster{}, clusters...), generatedClusters...)
+		allEndpoints := append(append([]*envoy_config_endpoint_v3.ClusterLoadAssignment{}, endpoints...), generatedEndpoints...)
+		generatedPluginClusters, generatedPluginEndpoints, generatedRouteConfigs, generatedListeners, err := plugin.GeneratedResources(params, allClusters, allEndpoints, routeConfigs, listeners)
 		if err != nil {
 			reports.AddError(proxy, err)
 		}
-		clusters = append(clusters, generatedClusters...)
-		endpoints = append(endpoints, generatedEndpoints...)
+		generatedClusters = append(generatedClusters, generatedPluginClusters...)
+		generatedEndpoints = append(generatedEndpoints, generatedPluginEndpoints...)
 		routeConfigs = append(routeConfigs, generatedRouteConfigs...)
 		listeners = append(listeners, generatedListeners...)
 	}
 
-	xdsSnapshot := t.generateXDSSnapshot(clusters, endpoints, routeConfigs, listeners)
-
 	if err := validation.GetProxyError(proxyReport); err != nil {
 		reports.AddError(proxy, err)
 	}
@@ -147,10 +168,86 @@ func (t *translatorInstance) Translate(
 		}
 	}
 
+	cached := &cachedTranslation{
+		translatedAt:       time.Now(),
+		generation:         inputs.generation,
+		proxyHash:          proxyHash,
+		inputsHash:         inputs.hash,
+		endpointsHash:      inputs.endpointsHash,
+		clusters:           clusters,
+		generatedClusters:  generatedClusters,
+		endpoints:          endpoints,
+		generatedEndpoints: generatedEndpoints,
+		routeConfigs:       routeConfigs,
+		listeners:          listeners,
+		reports:            reports,
+		endpointReports:    endpointReports,
+		proxyReport:        proxyReport,
+	}
+	if inputs.cacheable {
+		t.cache.set(params.Snapshot, proxy, cached)
+	}
+	return t.cachedResult(params, proxy, cached, endpoints, endpointReports)
+}
+
+// translates the proxy from its previous translation, re-translating only the endpoints if they changed
+func (t *translatorInstance) translateFromCache(
+	params plugins.Params,
+	proxy *v1.Proxy,
+	inputs *snapshotInputs,
+	cached *cachedTranslation,
+) (envoycache.Snapshot, reporter.ResourceReports, *validationapi.ProxyReport, error) {
+	if cached.endpointsHash == inputs.endpointsHash {
+		recordCacheLookup(params.Ctx, cacheHit)
+		return t.cachedResult(params, proxy, cached, cached.endpoints, cached.endpointReports)
+	}
+	recordCacheLookup(params.Ctx, cacheEndpointsOnly)
+
+	// only the endpoint plugins take part in the translation
+	for _, p := range t.pluginRegistry.GetEndpointPlugins() {
+		if err := p.Init(plugins.InitParams{
+			Ctx:      params.Ctx,
+			Settings: t.settings,
+		}); err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "plugin init failed")
+		}
+	}
+	endpointReports := make(reporter.ResourceReports)
+	endpoints := t.translateEndpoints(params, cached.clusters, inputs.upstreamRefKeyToEndpoints, endpointReports)
+
+	updated := *cached
+	updated.endpointsHash = inputs.endpointsHash
+	updated.endpoints = endpoints
+	updated.endpointReports = endpointReports
+	t.cache.set(params.Snapshot, proxy, &updated)
+
+	return t.cachedResult(params, proxy, &updated, endpoints, endpointReports)
+}
+
+func (t *translatorInstance) cachedResult(
+	params plugins.Params,
+	proxy *v1.Proxy,
+	cached *cachedTranslation,
+	endpoints []*envoy_config_endpoint_v3.ClusterLoadAssignment,
+	endpointReports reporter.ResourceReports,
+) (envoycache.Snapshot, reporter.ResourceReports, *validationapi.ProxyReport, error) {
+	xdsSnapshot := t.generateXDSSnapshot(
+		append(append([]*envoy_config_cluster_v3.Cluster{}, cached.clusters...), cached.generatedClusters...),
+		append(append([]*envoy_config_endpoint_v3.ClusterLoadAssignment{}, endpoints...), cached.generatedEndpoints...),
+		cached.routeConfigs,
+		cached.listeners,
+	)
+	reports, proxyReport := cached.copyReports(params.Snapshot, proxy, endpointReports)
 	return xdsSnapshot, reports, proxyReport, nil
 }
 
-func (t *translatorInstance) translateClusterSubsystemComponents(params plugins.Params, proxy *v1.Proxy, reports reporter.ResourceReports) (
+func (t *translatorInstance) translateClusterSubsystemComponents(
+	params plugins.Params,
+	proxy *v1.Proxy,
+	upstreamRefKeyToEndpoints map[string][]*v1.Endpoint,
+	reports reporter.ResourceReports,
+	endpointReports reporter.ResourceReports,
+) (
 	[]*envoy_config_cluster_v3.Cluster,
 	[]*envoy_config_endpoint_v3.ClusterLoadAssignment,
 ) {
@@ -159,15 +256,31 @@ func (t *translatorInstance) translateClusterSubsystemComponents(params plugins.
 	logger.Debugf("verifying upstream groups: %v", proxy.GetMetadata().GetName())
 	t.verifyUpstreamGroups(params, reports)
 
-	upstreamRefKeyToEndpoints := createUpstreamToEndpointsMap(params.Snapshot.Upstreams, params.Snapshot.Endpoints)
-
 	// endpoints and listeners are shared between listeners
 	logger.Debugf("computing envoy clusters for proxy: %v", proxy.GetMetadata().GetName())
-	clusters, clusterToUpstreamMap := t.computeClusters(params, reports, upstreamRefKeyToEndpoints, proxy)
+	clusters, _ := t.computeClusters(params, reports, upstreamRefKeyToEndpoints, proxy)
 	logger.Debugf("computing envoy endpoints for proxy: %v", proxy.GetMetadata().GetName())
 
+	endpoints := t.translateEndpoints(params, clusters, upstreamRefKeyToEndpoints, endpointReports)
+
+	return clusters, endpoints
+}
+
+// Computes the endpoints of the clusters. The clusters may have been translated for a previous snapshot, in which case
+// they are shared with other translations and must not be modified.
+func (t *translatorInstance) translateEndpoints(
+	params plugins.Params,
+	clusters []*envoy_config_cluster_v3.Cluster,
+	upstreamRefKeyToEndpoints map[string][]*v1.Endpoint,
+	reports reporter.ResourceReports,
+) []*envoy_config_endpoint_v3.ClusterLoadAssignment {
 	endpoints := t.computeClusterEndpoints(params, upstreamRefKeyToEndpoints, reports)
 
+	clusterToUpstreamMap := make(map[string]*v1.Upstream, len(params.Snapshot.Upstreams))
+	for _, upstream := range params.Snapshot.Upstreams {
+		clusterToUpstreamMap[UpstreamToClusterName(upstream.GetMetadata().Ref())] = upstream
+	}
+
 	// Find all the EDS clusters without endpoints (can happen with kube service that have no endpoints), and create a zero sized load assignment
 	// this is important as otherwise envoy will wait for them forever wondering their fate and not doing much else.
 ClusterLoop:
@@ -176,14 +289,16 @@ ClusterLoop:
 			continue
 		}
 		// get upstream that generated this cluster
-		upstream := clusterToUpstreamMap[c]
+		upstream := clusterToUpstreamMap[c.GetName()]
 		endpointClusterName, err := getEndpointClusterName(c.GetName(), upstream)
 		if err != nil {
 			reports.AddError(upstream, errors.Wrapf(err, "could not marshal upstream to JSON"))
 		}
 		// Workaround for envoy bug: https://github.com/envoyproxy/envoy/issues/13009
 		// Change the cluster eds config, forcing envoy to re-request latest EDS config
-		c.GetEdsClusterConfig().ServiceName = endpointClusterName
+		if c.GetEdsClusterConfig().GetServiceName() != endpointClusterName {
+			c.GetEdsClusterConfig().ServiceName = endpointClusterName
+		}
 		for _, ep := range endpoints {
 			if ep.GetClusterName() == c.GetName() {
 
@@ -196,15 +311,10 @@ ClusterLoop:
 			ClusterName: endpointClusterName,
 		}
 		// make sure to call EndpointPlugin with empty endpoint
-		for _, upstream := range params.Snapshot.Upstreams {
-			if UpstreamToClusterName(&core.ResourceRef{
-				Name:      upstream.GetMetadata().GetName(),
-				Namespace: upstream.GetMetadata().GetNamespace(),
-			}) == c.GetName() {
-				for _, plugin := range t.pluginRegistry.GetEndpointPlugins() {
-					if err := plugin.ProcessEndpoints(params, upstream, emptyendpointlist); err != nil {
-						reports.AddError(upstream, err)
-					}
+		if upstream != nil {
+			for _, plugin := range t.pluginRegistry.GetEndpointPlugins() {
+				if err := plugin.ProcessEndpoints(params, upstream, emptyendpointlist); err != nil {
+					reports.AddError(upstream, err)
 				}
 			}
 		}
@@ -212,7 +322,7 @@ ClusterLoop:
 		endpoints = append(endpoints, emptyendpointlist)
 	}
 
-	return clusters, endpoints
+	return endpoints
 }
 
 func (t *translatorInstance) translateListenerSubsystemComponents(params plugins.Params, proxy *v1.Proxy, proxyReport *validationapi.ProxyReport) (
@@ -251,6 +361,12 @@ func (t *translatorInstance) translateListenerSubsystemComponents(params plugins
 			if len(envoyRouteConfiguration) > 0 {
 				routeConfigs = append(routeConfigs, envoyRouteConfiguration...)
 			}
+
+			// 3. Compute the HTTP/3 Listener
+			// It shares the RouteConfigurations and TLS certificates of the TCP Listener
+			if quicListener := computeQuicListener(listener, envoyListener, listenerReport); quicListener != nil {
+				listeners = append(listeners, quicListener)
+			}
 		}
 	}
 
@@ -25,6 +25,7 @@ import (
 	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	envoytcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	envoyquic "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/quic/v3"
 	envoyauth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_type_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
@@ -50,6 +51,7 @@ import (
 	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/faultinjection"
 	v1grpc "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/grpc"
 	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/headers"
+	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/http3"
 	v1kubernetes "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/kubernetes"
 	"github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/retries"
 	v1static "github.com/solo-io/gloo/projects/gloo/pkg/api/v1/options/static"
@@ -3212,6 +3214,129 @@ var _ = Describe("Translator", func() {
 		})
 	})
 
+	Context("Http3", func() {
+
+		var (
+			http3Options *http3.Http3
+		)
+
+		BeforeEach(func() {
+			http3Options = &http3.Http3{}
+		})
+
+		prepHttp3 := func(sslConfigurations []*v1.SslConfig) {
+			proxy.Listeners = []*v1.Listener{{
+				Name:        "http-listener",
+				BindAddress: "127.0.0.1",
+				BindPort:    443,
+				ListenerType: &v1.Listener_HttpListener{
+					HttpListener: &v1.HttpListener{
+						VirtualHosts: []*v1.VirtualHost{{
+							Name:    "virt1",
+							Domains: []string{"*"},
+							Routes:  routes,
+						}},
+						Options: &v1.HttpListenerOptions{
+							Http3: http3Options,
+						},
+					},
+				},
+				SslConfigurations: sslConfigurations,
+			}}
+		}
+
+		sslConfig := func() *v1.SslConfig {
+			return &v1.SslConfig{
+				SslSecrets: &v1.SslConfig_SslFiles{
+					SslFiles: &v1.SSLFiles{
+						TlsCert: gloohelpers.Certificate(),
+						TlsKey:  gloohelpers.PrivateKey(),
+					},
+				},
+				SniDomains: []string{"a.com"},
+			}
+		}
+
+		It("adds a quic listener that shares the route configuration and certificates of the tcp listener", func() {
+			prepHttp3([]*v1.SslConfig{sslConfig()})
+			translate()
+
+			listeners := snapshot.GetResources(resource.ListenerTypeV3).Items
+			Expect(listeners).To(HaveLen(2))
+			Expect(listeners).To(HaveKey("http-listener-quic"))
+			tcpListener := listeners["http-listener"].ResourceProto().(*envoy_config_listener_v3.Listener)
+			quicListener := listeners["http-listener-quic"].ResourceProto().(*envoy_config_listener_v3.Listener)
+
+			Expect(tcpListener.GetAddress().GetSocketAddress().GetProtocol()).To(Equal(envoy_config_core_v3.SocketAddress_TCP))
+			Expect(quicListener.GetAddress().GetSocketAddress().GetProtocol()).To(Equal(envoy_config_core_v3.SocketAddress_UDP))
+			Expect(quicListener.GetAddress().GetSocketAddress().GetPortValue()).To(BeEquivalentTo(443))
+			Expect(quicListener.GetUdpListenerConfig().GetQuicOptions()).NotTo(BeNil())
+			Expect(quicListener.GetListenerFilters()).To(BeEmpty())
+
+			Expect(quicListener.GetFilterChains()).To(HaveLen(1))
+			fc := quicListener.GetFilterChains()[0]
+			Expect(fc.GetFilterChainMatch().GetServerNames()).To(Equal([]string{"a.com"}))
+			Expect(fc.GetTransportSocket().GetName()).To(Equal(wellknown.TransportSocketQuic))
+			quicTransport := glooutils.MustAnyToMessage(fc.GetTransportSocket().GetTypedConfig()).(*envoyquic.QuicDownstreamTransport)
+			cert := quicTransport.GetDownstreamTlsContext().GetCommonTlsContext().GetTlsCertificates()[0]
+			Expect(cert.GetCertificateChain().GetFilename()).To(Equal(gloohelpers.Certificate()))
+			Expect(quicTransport.GetDownstreamTlsContext().GetCommonTlsContext().GetAlpnProtocols()).To(Equal([]string{"h3"}))
+
+			var hcm envoyhttp.HttpConnectionManager
+			Expect(ParseTypedConfig(fc.GetFilters()[0], &hcm)).NotTo(HaveOccurred())
+			Expect(hcm.GetCodecType()).To(Equal(envoyhttp.HttpConnectionManager_HTTP3))
+			Expect(hcm.GetHttp3ProtocolOptions()).NotTo(BeNil())
+			Expect(hcm.GetRds().GetRouteConfigName()).To(Equal(glooutils.RouteConfigName(proxy.GetListeners()[0])))
+		})
+
+		It("advertises the quic listener with an alt-svc header", func() {
+			http3Options.AdvertisedPort = &wrappers.UInt32Value{Value: 8443}
+			http3Options.AltSvcMaxAge = &duration.Duration{Seconds: 3600}
+			prepHttp3([]*v1.SslConfig{sslConfig()})
+			translate()
+
+			Expect(routeConfiguration.GetResponseHeadersToAdd()).To(HaveLen(1))
+			altSvc := routeConfiguration.GetResponseHeadersToAdd()[0]
+			Expect(altSvc.GetHeader().GetKey()).To(Equal("alt-svc"))
+			Expect(altSvc.GetHeader().GetValue()).To(Equal(`h3=":8443"; ma=3600`))
+			Expect(altSvc.GetAppend().GetValue()).To(BeFalse())
+		})
+
+		It("does not advertise the quic listener if alt-svc is disabled", func() {
+			http3Options.DisableAltSvc = true
+			prepHttp3([]*v1.SslConfig{sslConfig()})
+			translate()
+
+			Expect(routeConfiguration.GetResponseHeadersToAdd()).To(BeEmpty())
+		})
+
+		It("reports an error if the listener has no ssl configurations", func() {
+			prepHttp3(nil)
+
+			report := translateWithError()
+
+			Expect(report.GetListenerReports()[0].GetErrors()).To(ConsistOf(&validation.ListenerReport_Error{
+				Type:   validation.ListenerReport_Error_SSLConfigError,
+				Reason: Http3RequiresSslError(glooutils.RouteConfigName(proxy.GetListeners()[0])).Error(),
+			}))
+		})
+
+		It("reports an error if the ssl configuration does not allow TLS 1.3", func() {
+			tls12 := sslConfig()
+			tls12.Parameters = &v1.SslParameters{
+				MaximumProtocolVersion: v1.SslParameters_TLSv1_2,
+			}
+			prepHttp3([]*v1.SslConfig{tls12})
+
+			report := translateWithError()
+
+			Expect(report.GetListenerReports()[0].GetErrors()).To(ConsistOf(&validation.ListenerReport_Error{
+				Type:   validation.ListenerReport_Error_SSLConfigError,
+				Reason: Http3TlsVersionError([]string{"a.com"}, envoyauth.TlsParameters_TLSv1_2).Error(),
+			}))
+		})
+	})
+
 	It("Should report an error for virtual services with empty domains", func() {
 		virtualHosts := []*v1.VirtualHost{
 			{
@@ -3274,6 +3399,81 @@ var _ = Describe("Translator", func() {
 		Expect(clusterSpecifier).NotTo(BeNil())
 	})
 
+	Context("translation cache", func() {
+		var (
+			processedRoutes int
+		)
+		BeforeEach(func() {
+			processedRoutes = 0
+			routePlugin := &routePluginMock{
+				ProcessRouteFunc: func(params plugins.RouteParams, in *v1.Route, out *envoy_config_route_v3.Route) error {
+					processedRoutes++
+					return nil
+				},
+			}
+			registeredPlugins = append(registeredPlugins, routePlugin)
+
+			upstream.UpstreamType = &v1.Upstream_Kube{
+				Kube: &v1kubernetes.UpstreamSpec{},
+			}
+			params.Snapshot.Endpoints = v1.EndpointList{
+				{
+					Metadata: &core.Metadata{
+						Name:      "test",
+						Namespace: "gloo-system",
+					},
+					Upstreams: []*core.ResourceRef{upstream.Metadata.Ref()},
+					Address:   "1.2.3.4",
+					Port:      1234,
+				},
+			}
+		})
+
+		It("reuses the translation when the inputs did not change", func() {
+			translate()
+			firstSnapshot := snapshot
+			Expect(processedRoutes).NotTo(BeZero())
+			processedRoutes = 0
+
+			translate()
+			Expect(processedRoutes).To(BeZero())
+			Expect(snapshot).To(Equal(firstSnapshot))
+		})
+
+		It("only translates the endpoints when only the endpoints changed", func() {
+			translate()
+			firstCluster := cluster
+			processedRoutes = 0
+
+			params.Snapshot.Endpoints[0].Address = "5.6.7.8"
+			translate()
+			Expect(processedRoutes).To(BeZero())
+			Expect(cluster).To(MatchProto(firstCluster))
+
+			cla := endpoints.Items[getEndpointClusterName(upstream)].ResourceProto().(*envoy_config_endpoint_v3.ClusterLoadAssignment)
+			Expect(cla.GetEndpoints()[0].GetLbEndpoints()[0].GetEndpoint().GetAddress().GetSocketAddress().GetAddress()).To(Equal("5.6.7.8"))
+		})
+
+		It("translates the proxy again when the upstreams changed", func() {
+			translate()
+			processedRoutes = 0
+
+			upstream.IgnoreHealthOnHostRemoval = &wrappers.BoolValue{Value: true}
+			translate()
+			Expect(processedRoutes).NotTo(BeZero())
+			Expect(cluster.GetIgnoreHealthOnHostRemoval()).To(BeTrue())
+		})
+
+		It("translates the proxy again when the translations are invalidated", func() {
+			translate()
+			processedRoutes = 0
+
+			InvalidateTranslations()
+			translate()
+			Expect(processedRoutes).NotTo(BeZero())
+		})
+	})
+
 	Context("IgnoreHealthOnHostRemoval", func() {
 		table.DescribeTable("propagates IgnoreHealthOnHostRemoval to Cluster", func(upstreamValue *wrappers.BoolValue, expectedClusterValue bool) {
 			// Set the value
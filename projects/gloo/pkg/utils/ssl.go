@@ -1,7 +1,12 @@
 package utils
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 
 	envoycore "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -13,6 +18,7 @@ import (
 	"github.com/solo-io/gloo/projects/gloo/constants"
 	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
 	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	"golang.org/x/crypto/ocsp"
 )
 
 //go:generate mockgen -destination mocks/mock_ssl.go github.com/solo-io/gloo/projects/gloo/pkg/utils SslConfigTranslator
@@ -49,6 +55,34 @@ var (
 	MissingValidationContextError = eris.Errorf("must provide validation context name if verifying SAN")
 
 	RootCaMustBeProvidedError = eris.Errorf("a root_ca must be provided if verify_subject_alt_name is not empty")
+
+	CrlRequiresRootCaError = eris.Errorf("a root_ca must be provided if a crl is provided")
+
+	InvalidCrlError = func(ref *core.ResourceRef, err error) error {
+		return eris.Wrapf(err, "%v does not hold a valid PEM encoded certificate revocation list", ref)
+	}
+
+	ClientCertificateRequiresRootCaError = eris.New("a root_ca must be provided to require client certificates")
+
+	AcmeTermsOfServiceNotAcceptedError = eris.New("the terms of service of the ACME certificate authority must be accepted")
+
+	AcmeMissingSecretRefError = eris.New("the ACME config must reference the secret to store the certificate in")
+
+	// Returned while the certificate of an ACME config is being issued, the filter chain that uses it is not served
+	// until then.
+	AcmeCertificatePendingError = eris.New("the ACME certificate has not been issued yet")
+
+	AdditionalCertificatesWithSdsError = eris.New("additional certificates cannot be used with sds")
+
+	DuplicateCertificateKeyTypeError = func(keyType string) error {
+		return eris.Errorf("more than one %s certificate is configured, at most one certificate of each key type can be served", keyType)
+	}
+
+	MissingOcspStapleError = eris.New("every certificate must have an OCSP response with the MUST_STAPLE policy")
+
+	InvalidOcspStapleError = func(ref *core.ResourceRef, err error) error {
+		return eris.Wrapf(err, "%v does not hold a valid OCSP response for its certificate", ref)
+	}
 )
 
 type SslConfigTranslator interface {
@@ -76,13 +110,31 @@ func (s *sslConfigTranslator) ResolveUpstreamSslConfig(secrets v1.SecretList, uc
 	}, nil
 }
 func (s *sslConfigTranslator) ResolveDownstreamSslConfig(secrets v1.SecretList, dc *v1.SslConfig) (*envoyauth.DownstreamTlsContext, error) {
-	common, err := s.ResolveCommonSslConfig(dc, secrets, true)
+	if dc.GetAcme() != nil {
+		var err error
+		if dc, err = resolveAcmeSslConfig(secrets, dc); err != nil {
+			return nil, err
+		}
+	}
+	// the certificate of ssl_secrets is optional when there are additional certificates
+	common, err := s.ResolveCommonSslConfig(dc, secrets, len(dc.GetAdditionalCertificates()) == 0)
 	if err != nil {
 		return nil, err
 	}
+	if err := resolveDownstreamCertificates(secrets, dc, common); err != nil {
+		return nil, err
+	}
 	var requireClientCert *wrappers.BoolValue
 	if common.GetValidationContextType() != nil {
 		requireClientCert = &wrappers.BoolValue{Value: !dc.GetOneWayTls().GetValue()}
+		if dc.GetRequireClientCertificate() != nil {
+			requireClientCert = &wrappers.BoolValue{Value: dc.GetRequireClientCertificate().GetValue()}
+		}
+	} else if dc.GetRequireClientCertificate().GetValue() {
+		return nil, ClientCertificateRequiresRootCaError
+	}
+	if validationContext := common.GetValidationContext(); validationContext.GetCrl() != nil {
+		validationContext.OnlyVerifyLeafCertCrl = dc.GetOnlyVerifyLeafCertCrl().GetValue()
 	}
 
 	// default alpn for downstreams.
@@ -96,6 +148,7 @@ func (s *sslConfigTranslator) ResolveDownstreamSslConfig(secrets v1.SecretList,
 	out := &envoyauth.DownstreamTlsContext{
 		CommonTlsContext:         common,
 		RequireClientCertificate: requireClientCert,
+		OcspStaplePolicy:         envoyauth.DownstreamTlsContext_OcspStaplePolicy(dc.GetOcspStaplePolicy()),
 	}
 	if dc.GetDisableTlsSessionResumption().GetValue() {
 		out.SessionTicketKeysType = &envoyauth.DownstreamTlsContext_DisableStatelessSessionResumption{DisableStatelessSessionResumption: true}
@@ -103,6 +156,162 @@ func (s *sslConfigTranslator) ResolveDownstreamSslConfig(secrets v1.SecretList,
 	return out, nil
 }
 
+// The certificate of an ACME config is served from the secret it is stored in, once it has been issued.
+func resolveAcmeSslConfig(secrets v1.SecretList, dc *v1.SslConfig) (*v1.SslConfig, error) {
+	acme := dc.GetAcme()
+	if !acme.GetAcceptTermsOfService() {
+		return nil, AcmeTermsOfServiceNotAcceptedError
+	}
+	ref := acme.GetSecretRef()
+	if ref == nil {
+		return nil, AcmeMissingSecretRefError
+	}
+	secret, err := secrets.Find(ref.Strings())
+	if err != nil || secret.GetTls().GetCertChain() == "" {
+		return nil, AcmeCertificatePendingError
+	}
+
+	resolved := dc.Clone().(*v1.SslConfig)
+	resolved.SslSecrets = &v1.SslConfig_SecretRef{SecretRef: ref}
+	return resolved, nil
+}
+
+// Resolves the certificates served by a downstream ssl config along with their OCSP responses: the certificate of
+// ssl_secrets, if any, followed by the additional certificates.
+func resolveDownstreamCertificates(secrets v1.SecretList, dc *v1.SslConfig, common *envoyauth.CommonTlsContext) error {
+	if dc.GetSds() != nil {
+		if len(dc.GetAdditionalCertificates()) > 0 {
+			return AdditionalCertificatesWithSdsError
+		}
+		return nil
+	}
+
+	certificates := dc.GetAdditionalCertificates()
+	if len(common.GetTlsCertificates()) > 0 {
+		primary := &v1.SslCertificate{}
+		if ref := dc.GetSecretRef(); ref != nil {
+			primary.Source = &v1.SslCertificate_SecretRef{SecretRef: ref}
+		} else {
+			primary.Source = &v1.SslCertificate_SslFiles{SslFiles: dc.GetSslFiles()}
+		}
+		certificates = append([]*v1.SslCertificate{primary}, certificates...)
+	}
+
+	var tlsCertificates []*envoyauth.TlsCertificate
+	keyTypes := map[string]bool{}
+	for _, certificate := range certificates {
+		tlsCertificate, keyType, err := resolveSslCertificate(secrets, certificate, dc.GetOcspStaplePolicy())
+		if err != nil {
+			return err
+		}
+		if keyType != "" {
+			if keyTypes[keyType] {
+				return DuplicateCertificateKeyTypeError(keyType)
+			}
+			keyTypes[keyType] = true
+		}
+		tlsCertificates = append(tlsCertificates, tlsCertificate)
+	}
+	common.TlsCertificates = tlsCertificates
+	return nil
+}
+
+// Returns the key type of the certificate, which is only known for the certificates stored in secrets, as the files
+// are local to the proxy.
+func resolveSslCertificate(secrets v1.SecretList, certificate *v1.SslCertificate, policy v1.SslConfig_OcspStaplePolicy) (*envoyauth.TlsCertificate, string, error) {
+	var (
+		certChain, privateKey, keyType string
+		ocspStaple                     *envoycore.DataSource
+		inlineDataSource               bool
+	)
+
+	switch source := certificate.GetSource().(type) {
+	case *v1.SslCertificate_SecretRef:
+		ref := source.SecretRef
+		inlineDataSource = true
+		secret, err := secrets.Find(ref.Strings())
+		if err != nil {
+			return nil, "", SslSecretNotFoundError(err)
+		}
+		tlsSecret := secret.GetTls()
+		if tlsSecret == nil {
+			return nil, "", NotTlsSecretError(ref)
+		}
+		certChain, privateKey = tlsSecret.GetCertChain(), tlsSecret.GetPrivateKey()
+		if certChain == "" || privateKey == "" {
+			return nil, "", NoCertificateFoundError
+		}
+		keyPair, err := tls.X509KeyPair([]byte(certChain), []byte(privateKey))
+		if err != nil {
+			return nil, "", InvalidTlsSecretError(ref, err)
+		}
+		keyType = privateKeyType(keyPair)
+		if staple := tlsSecret.GetOcspStaple(); len(staple) > 0 {
+			if err := validateOcspStaple(keyPair, staple); err != nil {
+				return nil, "", InvalidOcspStapleError(ref, err)
+			}
+			ocspStaple = &envoycore.DataSource{
+				Specifier: &envoycore.DataSource_InlineBytes{
+					InlineBytes: staple,
+				},
+			}
+		}
+	case *v1.SslCertificate_SslFiles:
+		certChain, privateKey = source.SslFiles.GetTlsCert(), source.SslFiles.GetTlsKey()
+		if certChain == "" || privateKey == "" {
+			return nil, "", NoCertificateFoundError
+		}
+		if staple := source.SslFiles.GetOcspStaple(); staple != "" {
+			ocspStaple = &envoycore.DataSource{
+				Specifier: &envoycore.DataSource_Filename{
+					Filename: staple,
+				},
+			}
+		}
+	default:
+		return nil, "", NoCertificateFoundError
+	}
+
+	if ocspStaple == nil && policy == v1.SslConfig_MUST_STAPLE {
+		return nil, "", MissingOcspStapleError
+	}
+
+	dataSource := dataSourceGenerator(inlineDataSource)
+	return &envoyauth.TlsCertificate{
+		CertificateChain: dataSource(certChain),
+		PrivateKey:       dataSource(privateKey),
+		OcspStaple:       ocspStaple,
+	}, keyType, nil
+}
+
+func privateKeyType(keyPair tls.Certificate) string {
+	switch keyPair.PrivateKey.(type) {
+	case *rsa.PrivateKey:
+		return "RSA"
+	case *ecdsa.PrivateKey:
+		return "ECDSA"
+	case ed25519.PrivateKey:
+		return "Ed25519"
+	}
+	return ""
+}
+
+// The OCSP response must be about the leaf certificate of the chain.
+func validateOcspStaple(keyPair tls.Certificate, staple []byte) error {
+	response, err := ocsp.ParseResponse(staple, nil)
+	if err != nil {
+		return err
+	}
+	leaf, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return err
+	}
+	if response.SerialNumber == nil || response.SerialNumber.Cmp(leaf.SerialNumber) != 0 {
+		return eris.Errorf("the OCSP response is for the certificate with serial number %v, not %v", response.SerialNumber, leaf.SerialNumber)
+	}
+	return nil
+}
+
 type CertSource interface {
 	GetSecretRef() *core.ResourceRef
 	GetSslFiles() *v1.SSLFiles
@@ -253,7 +462,7 @@ func (s *sslConfigTranslator) handleSds(sslSecrets *v1.SDSConfig, matchSan []*en
 
 func (s *sslConfigTranslator) ResolveCommonSslConfig(cs CertSource, secrets v1.SecretList, mustHaveCert bool) (*envoyauth.CommonTlsContext, error) {
 	var (
-		certChain, privateKey, rootCa string
+		certChain, privateKey, rootCa, crl string
 		// if using a Secret ref, we will inline the certs in the tls config
 		inlineDataSource bool
 	)
@@ -262,12 +471,12 @@ func (s *sslConfigTranslator) ResolveCommonSslConfig(cs CertSource, secrets v1.S
 		var err error
 		inlineDataSource = true
 		ref := sslSecrets
-		certChain, privateKey, rootCa, err = getSslSecrets(*ref, secrets)
+		certChain, privateKey, rootCa, crl, err = getSslSecrets(*ref, secrets)
 		if err != nil {
 			return nil, err
 		}
 	} else if sslSecrets := cs.GetSslFiles(); sslSecrets != nil {
-		certChain, privateKey, rootCa = sslSecrets.GetTlsCert(), sslSecrets.GetTlsKey(), sslSecrets.GetRootCa()
+		certChain, privateKey, rootCa, crl = sslSecrets.GetTlsCert(), sslSecrets.GetTlsKey(), sslSecrets.GetRootCa(), sslSecrets.GetCrl()
 		err := isValidSslKeyPair(certChain, privateKey, rootCa)
 		if err != nil {
 			return nil, InvalidTlsSecretError(nil, err)
@@ -293,7 +502,7 @@ func (s *sslConfigTranslator) ResolveCommonSslConfig(cs CertSource, secrets v1.S
 
 	dataSource := dataSourceGenerator(inlineDataSource)
 
-	var certChainData, privateKeyData, rootCaData *envoycore.DataSource
+	var certChainData, privateKeyData, rootCaData, crlData *envoycore.DataSource
 
 	if certChain != "" {
 		certChainData = dataSource(certChain)
@@ -304,6 +513,9 @@ func (s *sslConfigTranslator) ResolveCommonSslConfig(cs CertSource, secrets v1.S
 	if rootCa != "" {
 		rootCaData = dataSource(rootCa)
 	}
+	if crl != "" {
+		crlData = dataSource(crl)
+	}
 
 	tlsContext := &envoyauth.CommonTlsContext{
 		// default params
@@ -327,6 +539,7 @@ func (s *sslConfigTranslator) ResolveCommonSslConfig(cs CertSource, secrets v1.S
 		validationCtx := &envoyauth.CommonTlsContext_ValidationContext{
 			ValidationContext: &envoyauth.CertificateValidationContext{
 				TrustedCa: rootCaData,
+				Crl:       crlData,
 			},
 		}
 		if len(sanList) != 0 {
@@ -337,6 +550,8 @@ func (s *sslConfigTranslator) ResolveCommonSslConfig(cs CertSource, secrets v1.S
 	} else if len(sanList) != 0 {
 		return nil, RootCaMustBeProvidedError
 
+	} else if crlData != nil {
+		return nil, CrlRequiresRootCaError
 	}
 
 	var err error
@@ -346,27 +561,58 @@ func (s *sslConfigTranslator) ResolveCommonSslConfig(cs CertSource, secrets v1.S
 	return tlsContext, err
 }
 
-func getSslSecrets(ref core.ResourceRef, secrets v1.SecretList) (string, string, string, error) {
+func getSslSecrets(ref core.ResourceRef, secrets v1.SecretList) (string, string, string, string, error) {
 	secret, err := secrets.Find(ref.Strings())
 	if err != nil {
-		return "", "", "", SslSecretNotFoundError(err)
+		return "", "", "", "", SslSecretNotFoundError(err)
 	}
 
 	sslSecret, ok := secret.GetKind().(*v1.Secret_Tls)
 	if !ok {
-		return "", "", "", NotTlsSecretError(secret.GetMetadata().Ref())
+		return "", "", "", "", NotTlsSecretError(secret.GetMetadata().Ref())
 	}
 
 	certChain := sslSecret.Tls.GetCertChain()
 	privateKey := sslSecret.Tls.GetPrivateKey()
 	rootCa := sslSecret.Tls.GetRootCa()
+	crl := sslSecret.Tls.GetCrl()
 
 	err = isValidSslKeyPair(certChain, privateKey, rootCa)
 	if err != nil {
-		return "", "", "", InvalidTlsSecretError(secret.GetMetadata().Ref(), err)
+		return "", "", "", "", InvalidTlsSecretError(secret.GetMetadata().Ref(), err)
+	}
+
+	if crl != "" {
+		if err := isValidCrl(crl); err != nil {
+			return "", "", "", "", InvalidCrlError(secret.GetMetadata().Ref(), err)
+		}
 	}
 
-	return certChain, privateKey, rootCa, nil
+	return certChain, privateKey, rootCa, crl, nil
+}
+
+// Envoy expects one or more PEM encoded CRLs.
+func isValidCrl(crl string) error {
+	rest := []byte(crl)
+	found := false
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "X509 CRL" {
+			continue
+		}
+		if _, err := x509.ParseCRL(block.Bytes); err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return eris.New("no PEM encoded X509 CRL found")
+	}
+	return nil
 }
 
 func isValidSslKeyPair(certChain, privateKey, rootCa string) error {
@@ -1,6 +1,15 @@
 package utils
 
 import (
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
 	envoycore "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoygrpccredential "github.com/envoyproxy/go-control-plane/envoy/config/grpc_credential/v3"
 	envoyauth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
@@ -15,6 +24,7 @@ import (
 	. "github.com/solo-io/go-utils/testutils"
 	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
 	test_matchers "github.com/solo-io/solo-kit/test/matchers"
+	"golang.org/x/crypto/ocsp"
 )
 
 var _ = Describe("Ssl", func() {
@@ -265,6 +275,68 @@ var _ = Describe("Ssl", func() {
 			Expect(cfg.Sni).To(Equal("test.com"))
 		})
 
+		Context("client certificates", func() {
+			// a CRL signed by the root ca, revoking a certificate
+			crl := func() string {
+				keyPair, err := tls.X509KeyPair([]byte(gloohelpers.Certificate()), []byte(gloohelpers.PrivateKey()))
+				Expect(err).NotTo(HaveOccurred())
+				ca, err := x509.ParseCertificate(keyPair.Certificate[0])
+				Expect(err).NotTo(HaveOccurred())
+				der, err := ca.CreateCRL(rand.Reader, keyPair.PrivateKey, []pkix.RevokedCertificate{
+					{SerialNumber: big.NewInt(42), RevocationTime: time.Now()},
+				}, time.Now(), time.Now().Add(time.Hour))
+				Expect(err).NotTo(HaveOccurred())
+				return string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der}))
+			}
+
+			It("should check the client certificates against the crl of the secret", func() {
+				tlsSecret.Crl = crl()
+				downstreamCfg.OnlyVerifyLeafCertCrl = &wrappers.BoolValue{Value: true}
+				cfg, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+				Expect(err).NotTo(HaveOccurred())
+				validationContext := cfg.GetCommonTlsContext().GetValidationContext()
+				Expect(validationContext.GetCrl().GetInlineString()).To(Equal(tlsSecret.Crl))
+				Expect(validationContext.GetOnlyVerifyLeafCertCrl()).To(BeTrue())
+			})
+
+			It("should error with an invalid crl", func() {
+				tlsSecret.Crl = gloohelpers.Certificate()
+				_, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("no PEM encoded X509 CRL found"))
+			})
+
+			It("should error with a crl and not rootca", func() {
+				tlsSecret.RootCa = ""
+				tlsSecret.Crl = crl()
+				_, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+				Expect(err).To(Equal(CrlRequiresRootCaError))
+			})
+
+			It("should make the client certificates optional", func() {
+				downstreamCfg.RequireClientCertificate = &wrappers.BoolValue{Value: false}
+				cfg, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetCommonTlsContext().GetValidationContext()).NotTo(BeNil())
+				Expect(cfg.GetRequireClientCertificate().GetValue()).To(BeFalse())
+			})
+
+			It("should require the client certificates even with one way tls", func() {
+				downstreamCfg.OneWayTls = &wrappers.BoolValue{Value: true}
+				downstreamCfg.RequireClientCertificate = &wrappers.BoolValue{Value: true}
+				cfg, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cfg.GetRequireClientCertificate().GetValue()).To(BeTrue())
+			})
+
+			It("should error when requiring client certificates without rootca", func() {
+				tlsSecret.RootCa = ""
+				downstreamCfg.RequireClientCertificate = &wrappers.BoolValue{Value: true}
+				_, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+				Expect(err).To(Equal(ClientCertificateRequiresRootCaError))
+			})
+		})
+
 		Context("san", func() {
 			It("should error with san and not rootca", func() {
 				tlsSecret.RootCa = ""
@@ -313,6 +385,195 @@ var _ = Describe("Ssl", func() {
 
 	})
 
+	Context("acme", func() {
+		BeforeEach(func() {
+			tlsSecret = &v1.TlsSecret{
+				CertChain:  gloohelpers.Certificate(),
+				PrivateKey: gloohelpers.PrivateKey(),
+			}
+			secret = &v1.Secret{
+				Kind: &v1.Secret_Tls{
+					Tls: tlsSecret,
+				},
+				Metadata: &core.Metadata{
+					Name:      "example-acme",
+					Namespace: "gloo-system",
+				},
+			}
+			secrets = v1.SecretList{secret}
+			downstreamCfg = &v1.SslConfig{
+				SniDomains: []string{"example.com"},
+				SslSecrets: &v1.SslConfig_Acme{
+					Acme: &v1.AcmeConfig{
+						AcceptTermsOfService: true,
+						SecretRef:            secret.Metadata.Ref(),
+					},
+				},
+			}
+			configTranslator = NewSslConfigTranslator()
+		})
+
+		It("should serve the issued certificate", func() {
+			c, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.CommonTlsContext.TlsCertificates[0].CertificateChain.GetInlineString()).To(Equal(gloohelpers.Certificate()))
+			Expect(c.CommonTlsContext.TlsCertificates[0].PrivateKey.GetInlineString()).To(Equal(gloohelpers.PrivateKey()))
+		})
+
+		It("should be pending until the certificate is issued", func() {
+			tlsSecret.CertChain = ""
+			tlsSecret.PrivateKey = ""
+			_, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).To(Equal(AcmeCertificatePendingError))
+
+			_, err = configTranslator.ResolveDownstreamSslConfig(nil, downstreamCfg)
+			Expect(err).To(Equal(AcmeCertificatePendingError))
+		})
+
+		It("should error if the terms of service are not accepted", func() {
+			downstreamCfg.GetAcme().AcceptTermsOfService = false
+			_, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).To(Equal(AcmeTermsOfServiceNotAcceptedError))
+		})
+
+		It("should error without a secret ref", func() {
+			downstreamCfg.GetAcme().SecretRef = nil
+			_, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).To(Equal(AcmeMissingSecretRefError))
+		})
+	})
+
+	Context("multiple certificates", func() {
+		var (
+			ecdsaCert, ecdsaKey string
+		)
+
+		// a self-signed OCSP response about the certificate
+		ocspStaple := func(certChain, privateKey string) []byte {
+			keyPair, err := tls.X509KeyPair([]byte(certChain), []byte(privateKey))
+			Expect(err).NotTo(HaveOccurred())
+			cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+			Expect(err).NotTo(HaveOccurred())
+			staple, err := ocsp.CreateResponse(cert, cert, ocsp.Response{
+				Status:       ocsp.Good,
+				SerialNumber: cert.SerialNumber,
+				ThisUpdate:   time.Now(),
+				NextUpdate:   time.Now().Add(time.Hour),
+			}, keyPair.PrivateKey.(crypto.Signer))
+			Expect(err).NotTo(HaveOccurred())
+			return staple
+		}
+
+		BeforeEach(func() {
+			ecdsaCert, ecdsaKey = gloohelpers.GetCerts(gloohelpers.Params{Hosts: "gateway-proxy", EcdsaCurve: "P256"})
+			secrets = v1.SecretList{
+				{
+					Kind: &v1.Secret_Tls{Tls: &v1.TlsSecret{
+						CertChain:  gloohelpers.Certificate(),
+						PrivateKey: gloohelpers.PrivateKey(),
+						OcspStaple: ocspStaple(gloohelpers.Certificate(), gloohelpers.PrivateKey()),
+					}},
+					Metadata: &core.Metadata{Name: "rsa", Namespace: "gloo-system"},
+				},
+				{
+					Kind: &v1.Secret_Tls{Tls: &v1.TlsSecret{
+						CertChain:  ecdsaCert,
+						PrivateKey: ecdsaKey,
+						OcspStaple: ocspStaple(ecdsaCert, ecdsaKey),
+					}},
+					Metadata: &core.Metadata{Name: "ecdsa", Namespace: "gloo-system"},
+				},
+			}
+			downstreamCfg = &v1.SslConfig{
+				SslSecrets: &v1.SslConfig_SecretRef{
+					SecretRef: &core.ResourceRef{Name: "rsa", Namespace: "gloo-system"},
+				},
+				AdditionalCertificates: []*v1.SslCertificate{{
+					Source: &v1.SslCertificate_SecretRef{
+						SecretRef: &core.ResourceRef{Name: "ecdsa", Namespace: "gloo-system"},
+					},
+				}},
+				OcspStaplePolicy: v1.SslConfig_MUST_STAPLE,
+			}
+			configTranslator = NewSslConfigTranslator()
+		})
+
+		It("should serve every certificate with its ocsp staple", func() {
+			c, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.OcspStaplePolicy).To(Equal(envoyauth.DownstreamTlsContext_MUST_STAPLE))
+
+			certificates := c.CommonTlsContext.TlsCertificates
+			Expect(certificates).To(HaveLen(2))
+			Expect(certificates[0].CertificateChain.GetInlineString()).To(Equal(gloohelpers.Certificate()))
+			Expect(certificates[0].OcspStaple.GetInlineBytes()).To(Equal(secrets[0].GetTls().GetOcspStaple()))
+			Expect(certificates[1].CertificateChain.GetInlineString()).To(Equal(ecdsaCert))
+			Expect(certificates[1].PrivateKey.GetInlineString()).To(Equal(ecdsaKey))
+			Expect(certificates[1].OcspStaple.GetInlineBytes()).To(Equal(secrets[1].GetTls().GetOcspStaple()))
+		})
+
+		It("should not require the certificate of ssl_secrets", func() {
+			downstreamCfg.SslSecrets = nil
+			c, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.CommonTlsContext.TlsCertificates).To(HaveLen(1))
+			Expect(c.CommonTlsContext.TlsCertificates[0].CertificateChain.GetInlineString()).To(Equal(ecdsaCert))
+		})
+
+		It("should reference the certificate files", func() {
+			downstreamCfg.AdditionalCertificates[0].Source = &v1.SslCertificate_SslFiles{
+				SslFiles: &v1.SSLFiles{TlsCert: "/etc/ssl/tls.crt", TlsKey: "/etc/ssl/tls.key", OcspStaple: "/etc/ssl/ocsp.der"},
+			}
+			c, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).NotTo(HaveOccurred())
+			certificate := c.CommonTlsContext.TlsCertificates[1]
+			Expect(certificate.CertificateChain.GetFilename()).To(Equal("/etc/ssl/tls.crt"))
+			Expect(certificate.PrivateKey.GetFilename()).To(Equal("/etc/ssl/tls.key"))
+			Expect(certificate.OcspStaple.GetFilename()).To(Equal("/etc/ssl/ocsp.der"))
+		})
+
+		It("should error with two certificates of the same key type", func() {
+			downstreamCfg.AdditionalCertificates[0].Source = &v1.SslCertificate_SecretRef{
+				SecretRef: &core.ResourceRef{Name: "rsa", Namespace: "gloo-system"},
+			}
+			_, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).To(MatchError(DuplicateCertificateKeyTypeError("RSA")))
+		})
+
+		It("should error if the private key does not match the certificate", func() {
+			secrets[1].GetTls().PrivateKey = gloohelpers.PrivateKey()
+			_, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("private key type does not match public key type"))
+		})
+
+		It("should error if the ocsp staple is not about the certificate", func() {
+			secrets[1].GetTls().OcspStaple = secrets[0].GetTls().GetOcspStaple()
+			_, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not hold a valid OCSP response"))
+		})
+
+		It("should require an ocsp staple for every certificate with the MUST_STAPLE policy", func() {
+			secrets[1].GetTls().OcspStaple = nil
+			_, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).To(Equal(MissingOcspStapleError))
+
+			downstreamCfg.OcspStaplePolicy = v1.SslConfig_LENIENT_STAPLING
+			c, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.CommonTlsContext.TlsCertificates[1].OcspStaple).To(BeNil())
+		})
+
+		It("should error with sds", func() {
+			downstreamCfg.SslSecrets = &v1.SslConfig_Sds{
+				Sds: &v1.SDSConfig{CertificatesSecretName: "cert"},
+			}
+			_, err := configTranslator.ResolveDownstreamSslConfig(secrets, downstreamCfg)
+			Expect(err).To(Equal(AdditionalCertificatesWithSdsError))
+		})
+	})
+
 	Context("sds", func() {
 		var (
 			sdsConfig *v1.SDSConfig
@@ -0,0 +1,504 @@
+package xds
+
+import (
+	"context"
+	"hash/fnv"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+	sk_discovery "github.com/solo-io/solo-kit/pkg/api/external/envoy/api/v2"
+	envoycache "github.com/solo-io/solo-kit/pkg/api/v1/control-plane/cache"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/resource"
+	envoyserver "github.com/solo-io/solo-kit/pkg/api/v1/control-plane/server"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protov2 "google.golang.org/protobuf/proto"
+)
+
+// the resource name used by clients to subscribe to all resources of a type
+const wildcardResourceName = "*"
+
+type DeltaStreamEnvoyV3 interface {
+	Send(response *envoy_service_discovery_v3.DeltaDiscoveryResponse) error
+	Recv() (*envoy_service_discovery_v3.DeltaDiscoveryRequest, error)
+	grpc.ServerStream
+}
+
+type DeltaStreamSolo interface {
+	Send(response *sk_discovery.DeltaDiscoveryResponse) error
+	Recv() (*sk_discovery.DeltaDiscoveryRequest, error)
+	grpc.ServerStream
+}
+
+// DeltaServer serves incremental xDS streams from a snapshot cache. Only the resources which changed since they were
+// last sent to a client are sent to it, along with the names of the resources which were removed.
+type DeltaServer interface {
+	StreamDeltaEnvoyV3(stream DeltaStreamEnvoyV3, defaultTypeURL string) error
+	StreamDeltaSolo(stream DeltaStreamSolo, defaultTypeURL string) error
+}
+
+// Creates a delta server from the snapshot cache that backs the state-of-the-world server.
+// The callbacks are invoked with state-of-the-world equivalents of the delta requests and responses, so that the same
+// callbacks can observe both kinds of streams.
+func NewDeltaServer(ctx context.Context, cache envoycache.SnapshotCache, callbacks envoyserver.Callbacks) DeltaServer {
+	return &deltaServer{
+		ctx:       ctx,
+		cache:     cache,
+		callbacks: callbacks,
+		hasher:    NewNodeHasher(),
+		versions:  map[string]*versionedResources{},
+	}
+}
+
+type deltaServer struct {
+	ctx       context.Context
+	cache     envoycache.SnapshotCache
+	callbacks envoyserver.Callbacks
+	hasher    *ProxyKeyHasher
+
+	// streamCount for counting delta streams; delta stream ids are negative so that they never collide with the ids
+	// of the state-of-the-world streams passed to the same callbacks
+	streamCount int64
+
+	// the versioned resources of the last snapshot sent for each node key and type url, shared by all the streams of
+	// the nodes served the same key so that each snapshot is only hashed once
+	lock     sync.Mutex
+	versions map[string]*versionedResources
+}
+
+type versionedResources struct {
+	version   string
+	resources map[string]*envoy_service_discovery_v3.Resource
+}
+
+// the state of a delta stream for one type of resource
+type deltaWatch struct {
+	// incremented whenever the watch is recreated, so that responses to cancelled watches can be ignored
+	id     int64
+	cancel func()
+
+	// whether the client subscribed to all the resources of the type, or only to the subscribed names
+	wildcard   bool
+	subscribed map[string]struct{}
+	// the versions of the resources the client has, indexed by name
+	clientVersions map[string]string
+
+	// the snapshot version the client was last sent
+	snapshotVersion string
+	// set when the client changed its subscriptions, as it expects a response even if no resource changed
+	pendingResponse bool
+
+	lastNonce   string
+	lastVersion string
+}
+
+// the fields of the cache response of a watch that the delta server uses. The cache responses hold their request by
+// value, so they are not copied around.
+type deltaResponse struct {
+	typeUrl string
+	watchId int64
+	// the version of the snapshot the watch was created for
+	requestVersion string
+	// false when the watch was closed without a response
+	responded bool
+	version   string
+	resources []envoycache.Resource
+}
+
+type deltaSendFunc func(resp *envoy_service_discovery_v3.DeltaDiscoveryResponse) error
+
+func (s *deltaServer) StreamDeltaEnvoyV3(stream DeltaStreamEnvoyV3, defaultTypeURL string) error {
+	reqCh := make(chan *envoy_service_discovery_v3.DeltaDiscoveryRequest)
+	reqStop := int32(0)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if atomic.LoadInt32(&reqStop) != 0 {
+				return
+			}
+			if err != nil {
+				close(reqCh)
+				return
+			}
+			reqCh <- req
+		}
+	}()
+
+	err := s.process(stream.Context(), stream.Send, reqCh, defaultTypeURL)
+
+	// prevents writing to a closed channel if send failed on blocked recv
+	atomic.StoreInt32(&reqStop, 1)
+
+	return err
+}
+
+func (s *deltaServer) StreamDeltaSolo(stream DeltaStreamSolo, defaultTypeURL string) error {
+	reqCh := make(chan *envoy_service_discovery_v3.DeltaDiscoveryRequest)
+	reqStop := int32(0)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if atomic.LoadInt32(&reqStop) != 0 {
+				return
+			}
+			if err != nil {
+				close(reqCh)
+				return
+			}
+			reqCh <- upgradeDeltaDiscoveryRequest(req)
+		}
+	}()
+
+	send := func(resp *envoy_service_discovery_v3.DeltaDiscoveryResponse) error {
+		return stream.Send(downgradeDeltaDiscoveryResponse(resp))
+	}
+	err := s.process(stream.Context(), send, reqCh, defaultTypeURL)
+
+	// prevents writing to a closed channel if send failed on blocked recv
+	atomic.StoreInt32(&reqStop, 1)
+
+	return err
+}
+
+// process handles a bi-di delta stream
+func (s *deltaServer) process(
+	ctx context.Context,
+	send deltaSendFunc,
+	reqCh <-chan *envoy_service_discovery_v3.DeltaDiscoveryRequest,
+	defaultTypeURL string,
+) error {
+	streamID := -atomic.AddInt64(&s.streamCount, 1)
+
+	// unique nonce generator for req-resp pairs per xDS stream
+	var streamNonce int64
+	var watchCount int64
+
+	watches := map[string]*deltaWatch{}
+	defer func() {
+		for _, watch := range watches {
+			watch.cancel()
+		}
+		if s.callbacks != nil {
+			s.callbacks.OnStreamClosed(streamID)
+		}
+	}()
+
+	if s.callbacks != nil {
+		if err := s.callbacks.OnStreamOpen(ctx, streamID, defaultTypeURL); err != nil {
+			return err
+		}
+	}
+
+	responses := make(chan deltaResponse)
+
+	// node may only be set on the first discovery request
+	var node = &envoy_config_core_v3.Node{}
+	for {
+		select {
+		case <-s.ctx.Done():
+			return nil
+		case <-ctx.Done():
+			return nil
+		case resp := <-responses:
+			watch, ok := watches[resp.typeUrl]
+			if !ok || watch.id != resp.watchId {
+				// the watch was recreated since
+				continue
+			}
+			if !resp.responded {
+				return status.Errorf(codes.Unavailable, "watching failed for "+resp.typeUrl)
+			}
+
+			out, err := s.diff(node, watch, resp)
+			if err != nil {
+				return err
+			}
+			if out != nil {
+				streamNonce++
+				out.Nonce = strconv.FormatInt(streamNonce, 10)
+				watch.lastNonce = out.GetNonce()
+				watch.lastVersion = out.GetSystemVersionInfo()
+				if s.callbacks != nil {
+					s.callbacks.OnStreamResponse(streamID, &envoy_service_discovery_v3.DiscoveryRequest{
+						Node:        node,
+						TypeUrl:     resp.typeUrl,
+						VersionInfo: resp.requestVersion,
+					}, &envoy_service_discovery_v3.DiscoveryResponse{
+						VersionInfo: out.GetSystemVersionInfo(),
+						TypeUrl:     out.GetTypeUrl(),
+						Nonce:       out.GetNonce(),
+					})
+				}
+				if err := send(out); err != nil {
+					return err
+				}
+			}
+
+			// wait for the next snapshot
+			watchCount++
+			s.watch(ctx, responses, node, resp.typeUrl, watch, watchCount)
+
+		case req, more := <-reqCh:
+			// input stream ended or errored out
+			if !more {
+				return nil
+			}
+			if req == nil {
+				return status.Errorf(codes.Unavailable, "empty request")
+			}
+
+			// node field in discovery request is delta-compressed
+			if req.GetNode() != nil {
+				node = req.GetNode()
+			}
+
+			// type URL is required for ADS but is implicit for xDS
+			typeUrl := req.GetTypeUrl()
+			if typeUrl == "" {
+				if defaultTypeURL == resource.AnyType {
+					return status.Errorf(codes.InvalidArgument, "type URL is required for ADS")
+				}
+				typeUrl = defaultTypeURL
+			}
+
+			watch, ok := watches[typeUrl]
+			if s.callbacks != nil {
+				sotwReq := &envoy_service_discovery_v3.DiscoveryRequest{
+					Node:          node,
+					TypeUrl:       typeUrl,
+					ResponseNonce: req.GetResponseNonce(),
+					ErrorDetail:   req.GetErrorDetail(),
+				}
+				if ok && req.GetResponseNonce() == watch.lastNonce {
+					sotwReq.VersionInfo = watch.lastVersion
+				}
+				if err := s.callbacks.OnStreamRequest(streamID, sotwReq); err != nil {
+					return err
+				}
+			}
+
+			changed := !ok
+			if !ok {
+				watch = newDeltaWatch(req)
+				watches[typeUrl] = watch
+			}
+			if watch.updateSubscriptions(req) {
+				changed = true
+			}
+			if changed {
+				// request the current snapshot, so that the client is sent the resources it subscribed to
+				watch.pendingResponse = true
+				watch.snapshotVersion = ""
+				watchCount++
+				s.watch(ctx, responses, node, typeUrl, watch, watchCount)
+			}
+		}
+	}
+}
+
+// (re-)creates the cache watch of the given type, which responds once the cache holds a snapshot with a version other
+// than the one the client was last sent
+func (s *deltaServer) watch(ctx context.Context, responses chan<- deltaResponse, node *envoy_config_core_v3.Node, typeUrl string, watch *deltaWatch, id int64) {
+	if watch.cancel != nil {
+		watch.cancel()
+	}
+
+	value, cancelWatch := s.cache.CreateWatch(envoycache.Request{
+		Node:        node,
+		TypeUrl:     typeUrl,
+		VersionInfo: watch.snapshotVersion,
+	})
+	var isCanceled int32
+	canceled := make(chan struct{})
+	watch.id = id
+	watch.cancel = func() {
+		if atomic.CompareAndSwapInt32(&isCanceled, 0, 1) {
+			close(canceled)
+			cancelWatch()
+		}
+	}
+
+	go func() {
+		resp := deltaResponse{typeUrl: typeUrl, watchId: id, requestVersion: watch.snapshotVersion}
+		// canceling the watch closes the channel, so the receive always returns
+		response, ok := reflect.ValueOf(value).Recv()
+		if ok {
+			resp.responded = true
+			resp.version = response.FieldByName("Version").String()
+			resp.resources, _ = response.FieldByName("Resources").Interface().([]envoycache.Resource)
+		} else if atomic.LoadInt32(&isCanceled) != 0 {
+			return
+		}
+		select {
+		case responses <- resp:
+		case <-canceled:
+		case <-ctx.Done():
+		case <-s.ctx.Done():
+		}
+	}()
+}
+
+// builds the response which brings the client from the resources it has to the resources of the snapshot, or
+// returns nil if the client is up to date
+func (s *deltaServer) diff(node *envoy_config_core_v3.Node, watch *deltaWatch, resp deltaResponse) (*envoy_service_discovery_v3.DeltaDiscoveryResponse, error) {
+	resources, err := s.versionedResources(s.hasher.ID(node), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &envoy_service_discovery_v3.DeltaDiscoveryResponse{
+		SystemVersionInfo: resp.version,
+		TypeUrl:           resp.typeUrl,
+	}
+	for name, res := range resources {
+		if !watch.isSubscribed(name) || watch.clientVersions[name] == res.GetVersion() {
+			continue
+		}
+		out.Resources = append(out.Resources, res)
+		watch.clientVersions[name] = res.GetVersion()
+	}
+	for name := range watch.clientVersions {
+		if _, ok := resources[name]; ok {
+			continue
+		}
+		out.RemovedResources = append(out.RemovedResources, name)
+		delete(watch.clientVersions, name)
+	}
+	watch.snapshotVersion = resp.version
+
+	if len(out.GetResources()) == 0 && len(out.GetRemovedResources()) == 0 && !watch.pendingResponse {
+		return nil, nil
+	}
+	watch.pendingResponse = false
+
+	sort.Slice(out.Resources, func(i, j int) bool {
+		return out.Resources[i].GetName() < out.Resources[j].GetName()
+	})
+	sort.Strings(out.RemovedResources)
+	return out, nil
+}
+
+// returns the resources of the response indexed by name, along with their version, which is the hash of the resource
+func (s *deltaServer) versionedResources(nodeKey string, resp deltaResponse) (map[string]*envoy_service_discovery_v3.Resource, error) {
+	key := nodeKey + "/" + resp.typeUrl
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if cached, ok := s.versions[key]; ok && cached.version == resp.version {
+		return cached.resources, nil
+	}
+
+	resources := make(map[string]*envoy_service_discovery_v3.Resource, len(resp.resources))
+	for _, res := range resp.resources {
+		data, err := protov2.MarshalOptions{Deterministic: true}.Marshal(proto.MessageV2(res.ResourceProto()))
+		if err != nil {
+			return nil, err
+		}
+		hash := fnv.New64a()
+		_, _ = hash.Write(data)
+
+		name := res.Self().Name
+		resources[name] = &envoy_service_discovery_v3.Resource{
+			Name:     name,
+			Version:  strconv.FormatUint(hash.Sum64(), 16),
+			Resource: &any.Any{TypeUrl: resp.typeUrl, Value: data},
+		}
+	}
+	s.versions[key] = &versionedResources{version: resp.version, resources: resources}
+	return resources, nil
+}
+
+func newDeltaWatch(req *envoy_service_discovery_v3.DeltaDiscoveryRequest) *deltaWatch {
+	watch := &deltaWatch{
+		subscribed:     map[string]struct{}{},
+		clientVersions: map[string]string{},
+		// clients which subscribe to no resource in their first request subscribe to all resources
+		wildcard: len(req.GetResourceNamesSubscribe()) == 0,
+	}
+	// the resources the client already has, e.g. when it reconnects
+	for name, version := range req.GetInitialResourceVersions() {
+		watch.clientVersions[name] = version
+	}
+	return watch
+}
+
+// applies the subscription changes of the request, returning whether they changed the subscriptions
+func (w *deltaWatch) updateSubscriptions(req *envoy_service_discovery_v3.DeltaDiscoveryRequest) bool {
+	var changed bool
+	for _, name := range req.GetResourceNamesSubscribe() {
+		if name == wildcardResourceName {
+			changed = changed || !w.wildcard
+			w.wildcard = true
+			continue
+		}
+		if _, ok := w.subscribed[name]; !ok {
+			w.subscribed[name] = struct{}{}
+			changed = true
+		}
+	}
+	for _, name := range req.GetResourceNamesUnsubscribe() {
+		if name == wildcardResourceName {
+			changed = changed || w.wildcard
+			w.wildcard = false
+			continue
+		}
+		if _, ok := w.subscribed[name]; ok {
+			delete(w.subscribed, name)
+			changed = true
+		}
+		// the client forgets the resources it unsubscribes from
+		delete(w.clientVersions, name)
+	}
+	return changed
+}
+
+func (w *deltaWatch) isSubscribed(name string) bool {
+	if w.wildcard {
+		return true
+	}
+	_, ok := w.subscribed[name]
+	return ok
+}
+
+func upgradeDeltaDiscoveryRequest(req *sk_discovery.DeltaDiscoveryRequest) *envoy_service_discovery_v3.DeltaDiscoveryRequest {
+	if req == nil {
+		return nil
+	}
+	return &envoy_service_discovery_v3.DeltaDiscoveryRequest{
+		Node:                     util.UpgradeNode(req.GetNode()),
+		TypeUrl:                  req.GetTypeUrl(),
+		ResourceNamesSubscribe:   req.GetResourceNamesSubscribe(),
+		ResourceNamesUnsubscribe: req.GetResourceNamesUnsubscribe(),
+		InitialResourceVersions:  req.GetInitialResourceVersions(),
+		ResponseNonce:            req.GetResponseNonce(),
+		ErrorDetail:              req.GetErrorDetail(),
+	}
+}
+
+func downgradeDeltaDiscoveryResponse(resp *envoy_service_discovery_v3.DeltaDiscoveryResponse) *sk_discovery.DeltaDiscoveryResponse {
+	resources := make([]*sk_discovery.Resource, 0, len(resp.GetResources()))
+	for _, res := range resp.GetResources() {
+		resources = append(resources, &sk_discovery.Resource{
+			Name:     res.GetName(),
+			Aliases:  res.GetAliases(),
+			Version:  res.GetVersion(),
+			Resource: res.GetResource(),
+		})
+	}
+	return &sk_discovery.DeltaDiscoveryResponse{
+		SystemVersionInfo: resp.GetSystemVersionInfo(),
+		Resources:         resources,
+		TypeUrl:           resp.GetTypeUrl(),
+		RemovedResources:  resp.GetRemovedResources(),
+		Nonce:             resp.GetNonce(),
+	}
+}
@@ -0,0 +1,206 @@
+package xds_test
+
+import (
+	"context"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/ptypes/duration"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/projects/gloo/pkg/xds"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/cache"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/resource"
+	"google.golang.org/grpc"
+)
+
+type fakeDeltaStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	requests  chan *envoy_service_discovery_v3.DeltaDiscoveryRequest
+	responses chan *envoy_service_discovery_v3.DeltaDiscoveryResponse
+}
+
+func (s *fakeDeltaStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeDeltaStream) Send(resp *envoy_service_discovery_v3.DeltaDiscoveryResponse) error {
+	s.responses <- resp
+	return nil
+}
+
+func (s *fakeDeltaStream) Recv() (*envoy_service_discovery_v3.DeltaDiscoveryRequest, error) {
+	select {
+	case req := <-s.requests:
+		return req, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+var _ = Describe("DeltaServer", func() {
+
+	var (
+		ctx      context.Context
+		cancel   context.CancelFunc
+		xdsCache cache.SnapshotCache
+		stream   *fakeDeltaStream
+		key      = "gloo-system~gateway-proxy"
+		node     = &envoy_config_core_v3.Node{
+			Id: "gateway-proxy-1",
+			Metadata: &_struct.Struct{
+				Fields: map[string]*_struct.Value{
+					"role": {Kind: &_struct.Value_StringValue{StringValue: key}},
+				},
+			},
+		}
+	)
+
+	cluster := func(name string, timeoutSeconds int64) cache.Resource {
+		return resource.NewEnvoyResource(&envoy_config_cluster_v3.Cluster{
+			Name:           name,
+			ConnectTimeout: &duration.Duration{Seconds: timeoutSeconds},
+		})
+	}
+
+	endpoints := func(clusterName string, weight uint32) cache.Resource {
+		return resource.NewEnvoyResource(&envoy_config_endpoint_v3.ClusterLoadAssignment{
+			ClusterName: clusterName,
+			Endpoints: []*envoy_config_endpoint_v3.LocalityLbEndpoints{{
+				LoadBalancingWeight: &wrappers.UInt32Value{Value: weight},
+			}},
+		})
+	}
+
+	setSnapshot := func(version string, endpoints, clusters []cache.Resource) {
+		xdsCache.SetSnapshot(key, xds.NewSnapshot(version, endpoints, clusters, nil, nil))
+	}
+
+	names := func(resp *envoy_service_discovery_v3.DeltaDiscoveryResponse) []string {
+		var names []string
+		for _, res := range resp.GetResources() {
+			names = append(names, res.GetName())
+		}
+		return names
+	}
+
+	receive := func() *envoy_service_discovery_v3.DeltaDiscoveryResponse {
+		var resp *envoy_service_discovery_v3.DeltaDiscoveryResponse
+		EventuallyWithOffset(1, stream.responses).Should(Receive(&resp))
+		// acknowledge the response
+		stream.requests <- &envoy_service_discovery_v3.DeltaDiscoveryRequest{
+			TypeUrl:       resp.GetTypeUrl(),
+			ResponseNonce: resp.GetNonce(),
+		}
+		return resp
+	}
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		xdsCache = cache.NewSnapshotCache(true, xds.NewNodeHasher(), nil)
+		stream = &fakeDeltaStream{
+			ctx:       ctx,
+			requests:  make(chan *envoy_service_discovery_v3.DeltaDiscoveryRequest, 10),
+			responses: make(chan *envoy_service_discovery_v3.DeltaDiscoveryResponse, 10),
+		}
+
+		server := xds.NewDeltaServer(ctx, xdsCache, nil)
+		go func() {
+			defer GinkgoRecover()
+			Expect(server.StreamDeltaEnvoyV3(stream, resource.AnyType)).NotTo(HaveOccurred())
+		}()
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("only sends the resources which changed", func() {
+		setSnapshot("1", nil, []cache.Resource{cluster("a", 1), cluster("b", 1)})
+		stream.requests <- &envoy_service_discovery_v3.DeltaDiscoveryRequest{
+			Node:    node,
+			TypeUrl: resource.ClusterTypeV3,
+		}
+
+		resp := receive()
+		Expect(resp.GetSystemVersionInfo()).To(Equal("1"))
+		Expect(names(resp)).To(Equal([]string{"a", "b"}))
+		Expect(resp.GetRemovedResources()).To(BeEmpty())
+
+		setSnapshot("2", nil, []cache.Resource{cluster("a", 1), cluster("b", 2), cluster("c", 1)})
+		resp = receive()
+		Expect(resp.GetSystemVersionInfo()).To(Equal("2"))
+		Expect(names(resp)).To(Equal([]string{"b", "c"}))
+
+		setSnapshot("3", nil, []cache.Resource{cluster("b", 2), cluster("c", 1)})
+		resp = receive()
+		Expect(resp.GetResources()).To(BeEmpty())
+		Expect(resp.GetRemovedResources()).To(Equal([]string{"a"}))
+
+		// a new snapshot version with the same resources does not change anything
+		setSnapshot("4", nil, []cache.Resource{cluster("b", 2), cluster("c", 1)})
+		Consistently(stream.responses).ShouldNot(Receive())
+	})
+
+	It("does not resend the resources the client already has", func() {
+		setSnapshot("1", nil, []cache.Resource{cluster("a", 1), cluster("b", 1)})
+		stream.requests <- &envoy_service_discovery_v3.DeltaDiscoveryRequest{
+			Node:    node,
+			TypeUrl: resource.ClusterTypeV3,
+		}
+		initialVersions := map[string]string{}
+		for _, res := range receive().GetResources() {
+			initialVersions[res.GetName()] = res.GetVersion()
+		}
+		cancel()
+
+		// reconnect
+		ctx, cancel = context.WithCancel(context.Background())
+		stream = &fakeDeltaStream{
+			ctx:       ctx,
+			requests:  make(chan *envoy_service_discovery_v3.DeltaDiscoveryRequest, 10),
+			responses: make(chan *envoy_service_discovery_v3.DeltaDiscoveryResponse, 10),
+		}
+		go func() {
+			defer GinkgoRecover()
+			Expect(xds.NewDeltaServer(ctx, xdsCache, nil).StreamDeltaEnvoyV3(stream, resource.AnyType)).NotTo(HaveOccurred())
+		}()
+
+		setSnapshot("2", nil, []cache.Resource{cluster("a", 1), cluster("b", 2)})
+		stream.requests <- &envoy_service_discovery_v3.DeltaDiscoveryRequest{
+			Node:                    node,
+			TypeUrl:                 resource.ClusterTypeV3,
+			InitialResourceVersions: initialVersions,
+		}
+		resp := receive()
+		Expect(names(resp)).To(Equal([]string{"b"}))
+	})
+
+	It("only sends the resources the client subscribed to", func() {
+		setSnapshot("1", []cache.Resource{endpoints("a", 1), endpoints("b", 1)}, nil)
+		stream.requests <- &envoy_service_discovery_v3.DeltaDiscoveryRequest{
+			Node:                   node,
+			TypeUrl:                resource.EndpointTypeV3,
+			ResourceNamesSubscribe: []string{"a"},
+		}
+		resp := receive()
+		Expect(names(resp)).To(Equal([]string{"a"}))
+
+		setSnapshot("2", []cache.Resource{endpoints("a", 1), endpoints("b", 2)}, nil)
+		Consistently(stream.responses).ShouldNot(Receive())
+
+		stream.requests <- &envoy_service_discovery_v3.DeltaDiscoveryRequest{
+			TypeUrl:                  resource.EndpointTypeV3,
+			ResourceNamesSubscribe:   []string{"b"},
+			ResourceNamesUnsubscribe: []string{"a"},
+		}
+		resp = receive()
+		Expect(names(resp)).To(Equal([]string{"b"}))
+		Expect(resp.GetRemovedResources()).To(BeEmpty())
+	})
+})
@@ -72,7 +72,7 @@ func GetValidKeys(proxies v1.ProxyList, extensionKeys map[string]struct{}) []str
 }
 
 // register xDS methods with GRPC server
-func SetupEnvoyXds(grpcServer *grpc.Server, xdsServer envoyserver.Server, envoyCache envoycache.SnapshotCache) {
+func SetupEnvoyXds(grpcServer *grpc.Server, xdsServer envoyserver.Server, deltaServer DeltaServer, envoyCache envoycache.SnapshotCache) {
 
 	// check if we need to register
 	if _, ok := grpcServer.GetServiceInfo()["solo.io.xds.SoloDiscoveryService"]; ok {
@@ -82,10 +82,10 @@ func SetupEnvoyXds(grpcServer *grpc.Server, xdsServer envoyserver.Server, envoyC
 	// The Gloo Server is an XDS server that accepts v2 Envoy ADS requests. The Envoy v2 API has been
 	// deprecated but the ADS api has been preserved internally to support discovery of
 	// ext-auth and rate-limit configurations.
-	glooServer := NewGlooXdsServer(xdsServer)
+	glooServer := NewGlooXdsServer(xdsServer, deltaServer)
 	solo_xds.RegisterSoloDiscoveryServiceServer(grpcServer, glooServer)
 
-	envoyServer := NewEnvoyServerV3(xdsServer)
+	envoyServer := NewEnvoyServerV3(xdsServer, deltaServer)
 	envoy_service_endpoint_v3.RegisterEndpointDiscoveryServiceServer(grpcServer, envoyServer)
 	envoy_service_cluster_v3.RegisterClusterDiscoveryServiceServer(grpcServer, envoyServer)
 	envoy_service_route_v3.RegisterRouteDiscoveryServiceServer(grpcServer, envoyServer)
@@ -17,7 +17,6 @@ package xds
 
 import (
 	"context"
-	"errors"
 
 	envoy_service_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
 	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
@@ -42,11 +41,12 @@ type EnvoyServerV3 interface {
 
 type envoyServerV3 struct {
 	server.Server
+	delta DeltaServer
 }
 
 // NewServer creates handlers from a config watcher and an optional logger.
-func NewEnvoyServerV3(genericServer server.Server) EnvoyServerV3 {
-	return &envoyServerV3{Server: genericServer}
+func NewEnvoyServerV3(genericServer server.Server, deltaServer DeltaServer) EnvoyServerV3 {
+	return &envoyServerV3{Server: genericServer, delta: deltaServer}
 }
 
 func (s *envoyServerV3) StreamAggregatedResources(
@@ -123,24 +123,32 @@ func (s *envoyServerV3) FetchListeners(
 	return s.Server.FetchEnvoyV3(ctx, req)
 }
 
-func (s *envoyServerV3) DeltaEndpoints(envoy_service_endpoint_v3.EndpointDiscoveryService_DeltaEndpointsServer) error {
-	return errors.New("not implemented")
+func (s *envoyServerV3) DeltaEndpoints(
+	stream envoy_service_endpoint_v3.EndpointDiscoveryService_DeltaEndpointsServer,
+) error {
+	return s.delta.StreamDeltaEnvoyV3(stream, resource.EndpointTypeV3)
 }
 
-func (s *envoyServerV3) DeltaClusters(envoy_service_cluster_v3.ClusterDiscoveryService_DeltaClustersServer) error {
-	return errors.New("not implemented")
+func (s *envoyServerV3) DeltaClusters(
+	stream envoy_service_cluster_v3.ClusterDiscoveryService_DeltaClustersServer,
+) error {
+	return s.delta.StreamDeltaEnvoyV3(stream, resource.ClusterTypeV3)
 }
 
-func (s *envoyServerV3) DeltaRoutes(envoy_service_route_v3.RouteDiscoveryService_DeltaRoutesServer) error {
-	return errors.New("not implemented")
+func (s *envoyServerV3) DeltaRoutes(
+	stream envoy_service_route_v3.RouteDiscoveryService_DeltaRoutesServer,
+) error {
+	return s.delta.StreamDeltaEnvoyV3(stream, resource.RouteTypeV3)
 }
 
-func (s *envoyServerV3) DeltaListeners(envoy_service_listener_v3.ListenerDiscoveryService_DeltaListenersServer) error {
-	return errors.New("not implemented")
+func (s *envoyServerV3) DeltaListeners(
+	stream envoy_service_listener_v3.ListenerDiscoveryService_DeltaListenersServer,
+) error {
+	return s.delta.StreamDeltaEnvoyV3(stream, resource.ListenerTypeV3)
 }
 
 func (s *envoyServerV3) DeltaAggregatedResources(
-	envoy_service_discovery_v3.AggregatedDiscoveryService_DeltaAggregatedResourcesServer,
+	stream envoy_service_discovery_v3.AggregatedDiscoveryService_DeltaAggregatedResourcesServer,
 ) error {
-	return errors.New("not implemented")
+	return s.delta.StreamDeltaEnvoyV3(stream, resource.AnyType)
 }
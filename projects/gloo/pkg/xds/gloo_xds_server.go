@@ -16,8 +16,6 @@
 package xds
 
 import (
-	"errors"
-
 	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/resource"
 	discovery_service "github.com/solo-io/solo-kit/pkg/api/xds"
 
@@ -31,10 +29,11 @@ type GlooXdsServer interface {
 
 type glooXdsServer struct {
 	server.Server
+	delta DeltaServer
 }
 
-func NewGlooXdsServer(genericServer server.Server) GlooXdsServer {
-	return &glooXdsServer{Server: genericServer}
+func NewGlooXdsServer(genericServer server.Server, deltaServer DeltaServer) GlooXdsServer {
+	return &glooXdsServer{Server: genericServer, delta: deltaServer}
 }
 
 func (s *glooXdsServer) StreamAggregatedResources(
@@ -44,7 +43,7 @@ func (s *glooXdsServer) StreamAggregatedResources(
 }
 
 func (s *glooXdsServer) DeltaAggregatedResources(
-	discovery_service.SoloDiscoveryService_DeltaAggregatedResourcesServer,
+	stream discovery_service.SoloDiscoveryService_DeltaAggregatedResourcesServer,
 ) error {
-	return errors.New("not implemented")
+	return s.delta.StreamDeltaSolo(stream, resource.AnyType)
 }
@@ -0,0 +1,367 @@
+package xds
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/cache"
+	envoyserver "github.com/solo-io/solo-kit/pkg/api/v1/control-plane/server"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const NodeStatusPath = "/xds/nodes"
+
+var (
+	// Compile-time assertion
+	_ envoyserver.Callbacks = new(nodeStatusCallbacks)
+
+	// Tracks the Envoy nodes connected to the xDS server of this process. The tracker is shared so that the node
+	// statuses can be served by the admin server, which is started before the xDS server.
+	DefaultNodeStatusTracker = NewNodeStatusTracker()
+
+	proxyKeyKey, _ = tag.NewKey("proxy_key")
+	typeUrlKey, _  = tag.NewKey("type_url")
+
+	mConnectedNodes = stats.Int64("api.gloo.solo.io/xds/connected_nodes", "The number of xDS streams opened by Envoy nodes", "1")
+	mNacks          = stats.Int64("api.gloo.solo.io/xds/nacks", "The number of xDS responses rejected by Envoy nodes", "1")
+
+	connectedNodesView = &view.View{
+		Name:        "api.gloo.solo.io/xds/connected_nodes",
+		Measure:     mConnectedNodes,
+		Description: "The number of xDS streams opened by Envoy nodes",
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{proxyKeyKey},
+	}
+	nacksView = &view.View{
+		Name:        "api.gloo.solo.io/xds/nacks",
+		Measure:     mNacks,
+		Description: "The number of xDS responses rejected by Envoy nodes",
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{proxyKeyKey, typeUrlKey},
+	}
+)
+
+func init() {
+	_ = view.Register(connectedNodesView, nacksView)
+}
+
+// NodeStatus describes an xDS stream opened by an Envoy node.
+type NodeStatus struct {
+	NodeId string `json:"nodeId"`
+	// The key of the snapshot served to the node, i.e. `<proxy-namespace>~<proxy-name>` for Gloo proxies
+	ProxyKey    string    `json:"proxyKey"`
+	StreamId    int64     `json:"streamId"`
+	ConnectedAt time.Time `json:"connectedAt"`
+	// Indexed by type url
+	Resources map[string]*ResourceStatus `json:"resources"`
+}
+
+// ResourceStatus describes the state of one type of xDS resource on an Envoy node.
+type ResourceStatus struct {
+	// The last version sent to the node
+	SentVersion string `json:"sentVersion,omitempty"`
+	// The last version acknowledged by the node
+	AckedVersion string `json:"ackedVersion,omitempty"`
+	// The last rejection of the node, unset once the node acknowledges a later version
+	LastNack *Nack `json:"lastNack,omitempty"`
+
+	sentNonce string
+}
+
+// Nack describes an xDS response rejected by an Envoy node.
+type Nack struct {
+	Version string    `json:"version"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// NodeStatusTracker records the ACK/NACK state of the xDS streams opened by Envoy nodes.
+type NodeStatusTracker struct {
+	lock    sync.RWMutex
+	streams map[int64]*NodeStatus
+	nacks   chan struct{}
+	acks    chan struct{}
+	hasher  *ProxyKeyHasher
+}
+
+func NewNodeStatusTracker() *NodeStatusTracker {
+	return &NodeStatusTracker{
+		streams: map[int64]*NodeStatus{},
+		nacks:   make(chan struct{}, 1),
+		acks:    make(chan struct{}, 1),
+		hasher:  NewNodeHasher(),
+	}
+}
+
+// Returns xDS server callbacks which record node statuses before delegating to the given callbacks, which may be nil.
+func (t *NodeStatusTracker) Callbacks(callbacks envoyserver.Callbacks) envoyserver.Callbacks {
+	return &nodeStatusCallbacks{tracker: t, callbacks: callbacks}
+}
+
+// Receives a notification whenever a node rejects a response. Notifications are coalesced while nobody is receiving.
+func (t *NodeStatusTracker) Nacks() <-chan struct{} {
+	return t.nacks
+}
+
+// Receives a notification whenever a node acknowledges a response. Notifications are coalesced while nobody is
+// receiving.
+func (t *NodeStatusTracker) Acks() <-chan struct{} {
+	return t.acks
+}
+
+func (t *NodeStatusTracker) recordClosed(streamID int64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	status, ok := t.streams[streamID]
+	if !ok {
+		return
+	}
+	delete(t.streams, streamID)
+	t.recordConnectedNodes(status.ProxyKey)
+}
+
+func (t *NodeStatusTracker) recordResponse(streamID int64, resp *envoy_service_discovery_v3.DiscoveryResponse) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if status, ok := t.streams[streamID]; ok {
+		resourceStatus := status.resourceStatus(resp.GetTypeUrl())
+		resourceStatus.SentVersion = resp.GetVersionInfo()
+		resourceStatus.sentNonce = resp.GetNonce()
+	}
+}
+
+func (t *NodeStatusTracker) recordRequest(streamID int64, req *envoy_service_discovery_v3.DiscoveryRequest) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// the stream is recorded on its first request rather than when it is opened, as the xds server does not pass
+	// the id of the stream when it is opened
+	status, ok := t.streams[streamID]
+	if !ok {
+		status = &NodeStatus{
+			StreamId:    streamID,
+			ConnectedAt: time.Now(),
+			Resources:   map[string]*ResourceStatus{},
+		}
+		t.streams[streamID] = status
+	}
+	// envoy only sends its node in the first request of a stream
+	if req.GetNode() != nil && status.NodeId == "" {
+		status.NodeId = req.GetNode().GetId()
+		status.ProxyKey = t.hasher.ID(req.GetNode())
+		t.recordConnectedNodes(status.ProxyKey)
+	}
+	// requests without a nonce are initial requests or subscription changes
+	if req.GetResponseNonce() == "" {
+		return
+	}
+
+	resourceStatus := status.resourceStatus(req.GetTypeUrl())
+	if req.GetErrorDetail() == nil {
+		resourceStatus.AckedVersion = req.GetVersionInfo()
+		resourceStatus.LastNack = nil
+		select {
+		case t.acks <- struct{}{}:
+		default:
+		}
+		return
+	}
+
+	var rejectedVersion string
+	if req.GetResponseNonce() == resourceStatus.sentNonce {
+		rejectedVersion = resourceStatus.SentVersion
+	}
+	resourceStatus.LastNack = &Nack{
+		Version: rejectedVersion,
+		Message: req.GetErrorDetail().GetMessage(),
+		Time:    time.Now(),
+	}
+	if ctx, err := tag.New(context.Background(), tag.Insert(proxyKeyKey, status.ProxyKey), tag.Insert(typeUrlKey, req.GetTypeUrl())); err == nil {
+		stats.Record(ctx, mNacks.M(1))
+	}
+	select {
+	case t.nacks <- struct{}{}:
+	default:
+	}
+}
+
+// must be called while holding the lock
+func (t *NodeStatusTracker) recordConnectedNodes(proxyKey string) {
+	var connected int64
+	for _, status := range t.streams {
+		if status.ProxyKey == proxyKey {
+			connected++
+		}
+	}
+	if ctx, err := tag.New(context.Background(), tag.Insert(proxyKeyKey, proxyKey)); err == nil {
+		stats.Record(ctx, mConnectedNodes.M(connected))
+	}
+}
+
+func (s *NodeStatus) resourceStatus(typeUrl string) *ResourceStatus {
+	resourceStatus, ok := s.Resources[typeUrl]
+	if !ok {
+		resourceStatus = &ResourceStatus{}
+		s.Resources[typeUrl] = resourceStatus
+	}
+	return resourceStatus
+}
+
+// Returns the status of all open streams, sorted by proxy key and node id.
+func (t *NodeStatusTracker) List() []NodeStatus {
+	if t == nil {
+		return nil
+	}
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	var statuses []NodeStatus
+	for _, status := range t.streams {
+		statusCopy := *status
+		statusCopy.Resources = map[string]*ResourceStatus{}
+		for typeUrl, resourceStatus := range status.Resources {
+			resourceStatusCopy := *resourceStatus
+			statusCopy.Resources[typeUrl] = &resourceStatusCopy
+		}
+		statuses = append(statuses, statusCopy)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].ProxyKey != statuses[j].ProxyKey {
+			return statuses[i].ProxyKey < statuses[j].ProxyKey
+		}
+		if statuses[i].NodeId != statuses[j].NodeId {
+			return statuses[i].NodeId < statuses[j].NodeId
+		}
+		return statuses[i].StreamId < statuses[j].StreamId
+	})
+	return statuses
+}
+
+// NodeNack is a rejection by an Envoy node that has not been followed by an acknowledgement.
+type NodeNack struct {
+	NodeId  string
+	TypeUrl string
+	Nack    *Nack
+}
+
+// Returns the active rejections of all nodes served the given key, sorted by node id and type url.
+func (t *NodeStatusTracker) ActiveNacks(proxyKey string) []NodeNack {
+	var nacks []NodeNack
+	for _, status := range t.List() {
+		if status.ProxyKey != proxyKey {
+			continue
+		}
+		for typeUrl, resourceStatus := range status.Resources {
+			if resourceStatus.LastNack != nil {
+				nacks = append(nacks, NodeNack{NodeId: status.NodeId, TypeUrl: typeUrl, Nack: resourceStatus.LastNack})
+			}
+		}
+	}
+	sort.SliceStable(nacks, func(i, j int) bool {
+		if nacks[i].NodeId != nacks[j].NodeId {
+			return nacks[i].NodeId < nacks[j].NodeId
+		}
+		return nacks[i].TypeUrl < nacks[j].TypeUrl
+	})
+	return nacks
+}
+
+// Returns true if a node served the given key has acknowledged every resource type of the snapshot, and no node
+// has rejected it.
+func (t *NodeStatusTracker) IsAcknowledged(proxyKey string, snap cache.Snapshot) bool {
+	var acknowledged bool
+	for _, status := range t.List() {
+		if status.ProxyKey != proxyKey {
+			continue
+		}
+		nodeAcknowledged := true
+		for _, typeUrl := range snapshotTypeUrls {
+			resources := snap.GetResources(typeUrl)
+			resourceStatus := status.Resources[typeUrl]
+			if resourceStatus != nil && resourceStatus.LastNack != nil && resourceStatus.LastNack.Version == resources.Version {
+				return false
+			}
+			// nodes only subscribe to the types they need, e.g. there are no endpoints without eds clusters
+			if len(resources.Items) == 0 {
+				continue
+			}
+			if resourceStatus == nil || resourceStatus.AckedVersion != resources.Version {
+				nodeAcknowledged = false
+			}
+		}
+		acknowledged = acknowledged || nodeAcknowledged
+	}
+	return acknowledged
+}
+
+// Serves the node statuses as json.
+func (t *NodeStatusTracker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	statuses := t.List()
+	if statuses == nil {
+		statuses = []NodeStatus{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(statuses)
+}
+
+// Adds the node status endpoint of the DefaultNodeStatusTracker to the admin server.
+func AddNodeStatusHandler(mux *http.ServeMux, profiles map[string]string) {
+	mux.Handle(NodeStatusPath, DefaultNodeStatusTracker)
+	profiles[NodeStatusPath] = "Envoy nodes connected to the xDS server, and the resource versions they acknowledged or rejected"
+}
+
+type nodeStatusCallbacks struct {
+	tracker   *NodeStatusTracker
+	callbacks envoyserver.Callbacks
+}
+
+func (c *nodeStatusCallbacks) OnStreamOpen(ctx context.Context, streamID int64, typeURL string) error {
+	if c.callbacks != nil {
+		return c.callbacks.OnStreamOpen(ctx, streamID, typeURL)
+	}
+	return nil
+}
+
+func (c *nodeStatusCallbacks) OnStreamClosed(streamID int64) {
+	c.tracker.recordClosed(streamID)
+	if c.callbacks != nil {
+		c.callbacks.OnStreamClosed(streamID)
+	}
+}
+
+func (c *nodeStatusCallbacks) OnStreamRequest(streamID int64, req *envoy_service_discovery_v3.DiscoveryRequest) error {
+	c.tracker.recordRequest(streamID, req)
+	if c.callbacks != nil {
+		return c.callbacks.OnStreamRequest(streamID, req)
+	}
+	return nil
+}
+
+func (c *nodeStatusCallbacks) OnStreamResponse(streamID int64, req *envoy_service_discovery_v3.DiscoveryRequest, resp *envoy_service_discovery_v3.DiscoveryResponse) {
+	c.tracker.recordResponse(streamID, resp)
+	if c.callbacks != nil {
+		c.callbacks.OnStreamResponse(streamID, req, resp)
+	}
+}
+
+func (c *nodeStatusCallbacks) OnFetchRequest(ctx context.Context, req *envoy_service_discovery_v3.DiscoveryRequest) error {
+	if c.callbacks != nil {
+		return c.callbacks.OnFetchRequest(ctx, req)
+	}
+	return nil
+}
+
+func (c *nodeStatusCallbacks) OnFetchResponse(req *envoy_service_discovery_v3.DiscoveryRequest, resp *envoy_service_discovery_v3.DiscoveryResponse) {
+	if c.callbacks != nil {
+		c.callbacks.OnFetchResponse(req, resp)
+	}
+}
@@ -0,0 +1,173 @@
+package xds_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/projects/gloo/pkg/xds"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/cache"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/resource"
+	envoyserver "github.com/solo-io/solo-kit/pkg/api/v1/control-plane/server"
+	"google.golang.org/genproto/googleapis/rpc/status"
+)
+
+var _ = Describe("NodeStatusTracker", func() {
+
+	var (
+		tracker   *xds.NodeStatusTracker
+		callbacks envoyserver.Callbacks
+		node      = &envoy_config_core_v3.Node{
+			Id: "gateway-proxy-1",
+			Metadata: &_struct.Struct{
+				Fields: map[string]*_struct.Value{
+					"role": {Kind: &_struct.Value_StringValue{StringValue: "gloo-system~gateway-proxy"}},
+				},
+			},
+		}
+	)
+
+	respond := func(version, nonce string) {
+		callbacks.OnStreamResponse(1, &envoy_service_discovery_v3.DiscoveryRequest{}, &envoy_service_discovery_v3.DiscoveryResponse{
+			TypeUrl:     resource.ClusterTypeV3,
+			VersionInfo: version,
+			Nonce:       nonce,
+		})
+	}
+
+	BeforeEach(func() {
+		tracker = xds.NewNodeStatusTracker()
+		callbacks = tracker.Callbacks(nil)
+
+		Expect(callbacks.OnStreamOpen(context.Background(), 1, resource.AnyType)).NotTo(HaveOccurred())
+		Expect(callbacks.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{
+			Node:    node,
+			TypeUrl: resource.ClusterTypeV3,
+		})).NotTo(HaveOccurred())
+	})
+
+	It("tracks connected nodes", func() {
+		statuses := tracker.List()
+		Expect(statuses).To(HaveLen(1))
+		Expect(statuses[0].NodeId).To(Equal("gateway-proxy-1"))
+		Expect(statuses[0].ProxyKey).To(Equal("gloo-system~gateway-proxy"))
+		Expect(statuses[0].ConnectedAt).NotTo(BeZero())
+
+		callbacks.OnStreamClosed(1)
+		Expect(tracker.List()).To(BeEmpty())
+	})
+
+	It("tracks acknowledged versions", func() {
+		respond("1", "a")
+		Expect(callbacks.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{
+			TypeUrl:       resource.ClusterTypeV3,
+			VersionInfo:   "1",
+			ResponseNonce: "a",
+		})).NotTo(HaveOccurred())
+
+		clusters := tracker.List()[0].Resources[resource.ClusterTypeV3]
+		Expect(clusters.SentVersion).To(Equal("1"))
+		Expect(clusters.AckedVersion).To(Equal("1"))
+		Expect(clusters.LastNack).To(BeNil())
+		Expect(tracker.ActiveNacks("gloo-system~gateway-proxy")).To(BeEmpty())
+	})
+
+	It("tracks rejections until a later version is acknowledged", func() {
+		respond("1", "a")
+		Expect(callbacks.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{
+			TypeUrl:       resource.ClusterTypeV3,
+			VersionInfo:   "0",
+			ResponseNonce: "a",
+			ErrorDetail:   &status.Status{Message: "bad cluster"},
+		})).NotTo(HaveOccurred())
+		Eventually(tracker.Nacks()).Should(Receive())
+
+		nacks := tracker.ActiveNacks("gloo-system~gateway-proxy")
+		Expect(nacks).To(HaveLen(1))
+		Expect(nacks[0].NodeId).To(Equal("gateway-proxy-1"))
+		Expect(nacks[0].TypeUrl).To(Equal(resource.ClusterTypeV3))
+		Expect(nacks[0].Nack.Version).To(Equal("1"))
+		Expect(nacks[0].Nack.Message).To(Equal("bad cluster"))
+		Expect(tracker.ActiveNacks("other")).To(BeEmpty())
+
+		respond("2", "b")
+		Expect(callbacks.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{
+			TypeUrl:       resource.ClusterTypeV3,
+			VersionInfo:   "2",
+			ResponseNonce: "b",
+		})).NotTo(HaveOccurred())
+		Expect(tracker.ActiveNacks("gloo-system~gateway-proxy")).To(BeEmpty())
+	})
+
+	Context("IsAcknowledged", func() {
+
+		var (
+			key  = "gloo-system~gateway-proxy"
+			snap cache.Snapshot
+		)
+
+		BeforeEach(func() {
+			// only the clusters have resources, so envoy does not need to acknowledge the other types
+			snap = xds.NewSnapshot("1", nil, []cache.Resource{resource.NewEnvoyResource(&envoy_config_cluster_v3.Cluster{Name: "cluster"})}, nil, nil)
+			respond("1", "a")
+		})
+
+		It("is true once a node acknowledges the snapshot", func() {
+			Expect(tracker.IsAcknowledged(key, snap)).To(BeFalse())
+
+			Expect(callbacks.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{
+				TypeUrl:       resource.ClusterTypeV3,
+				VersionInfo:   "1",
+				ResponseNonce: "a",
+			})).NotTo(HaveOccurred())
+			Eventually(tracker.Acks()).Should(Receive())
+
+			Expect(tracker.IsAcknowledged(key, snap)).To(BeTrue())
+			Expect(tracker.IsAcknowledged("other", snap)).To(BeFalse())
+		})
+
+		It("is false if a node rejects the snapshot", func() {
+			Expect(callbacks.OnStreamOpen(context.Background(), 2, resource.AnyType)).NotTo(HaveOccurred())
+			Expect(callbacks.OnStreamRequest(2, &envoy_service_discovery_v3.DiscoveryRequest{
+				Node:    &envoy_config_core_v3.Node{Id: "gateway-proxy-2", Metadata: node.GetMetadata()},
+				TypeUrl: resource.ClusterTypeV3,
+			})).NotTo(HaveOccurred())
+
+			Expect(callbacks.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{
+				TypeUrl:       resource.ClusterTypeV3,
+				VersionInfo:   "1",
+				ResponseNonce: "a",
+			})).NotTo(HaveOccurred())
+			callbacks.OnStreamResponse(2, &envoy_service_discovery_v3.DiscoveryRequest{}, &envoy_service_discovery_v3.DiscoveryResponse{
+				TypeUrl:     resource.ClusterTypeV3,
+				VersionInfo: "1",
+				Nonce:       "b",
+			})
+			Expect(callbacks.OnStreamRequest(2, &envoy_service_discovery_v3.DiscoveryRequest{
+				TypeUrl:       resource.ClusterTypeV3,
+				VersionInfo:   "0",
+				ResponseNonce: "b",
+				ErrorDetail:   &status.Status{Message: "bad cluster"},
+			})).NotTo(HaveOccurred())
+
+			Expect(tracker.IsAcknowledged(key, snap)).To(BeFalse())
+		})
+	})
+
+	It("serves the node statuses as json", func() {
+		respond("1", "a")
+		recorder := httptest.NewRecorder()
+		tracker.ServeHTTP(recorder, httptest.NewRequest("GET", xds.NodeStatusPath, nil))
+
+		var statuses []xds.NodeStatus
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &statuses)).NotTo(HaveOccurred())
+		Expect(statuses).To(HaveLen(1))
+		Expect(statuses[0].Resources[resource.ClusterTypeV3].SentVersion).To(Equal("1"))
+	})
+})
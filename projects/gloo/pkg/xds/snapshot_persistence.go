@@ -0,0 +1,588 @@
+package xds
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rotisserie/eris"
+	v1 "github.com/solo-io/gloo/projects/gloo/pkg/api/v1"
+	"github.com/solo-io/go-utils/contextutils"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/cache"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/resource"
+	corev1 "k8s.io/api/core/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	snapshotFileExtension = ".json"
+
+	snapshotSecretPrefix       = "gloo-xds-snapshot-"
+	snapshotSecretLabel        = "gloo.solo.io/xds-snapshot"
+	snapshotSecretKeyHashLabel = "gloo.solo.io/xds-snapshot-key-hash"
+	snapshotSecretKeyAnn       = "gloo.solo.io/xds-snapshot-key"
+	snapshotSecretDigestAnn    = "gloo.solo.io/xds-snapshot-digest"
+	snapshotSecretChunkAnn     = "gloo.solo.io/xds-snapshot-chunk"
+	snapshotSecretChunksAnn    = "gloo.solo.io/xds-snapshot-chunks"
+	snapshotSecretData         = "snapshot"
+
+	// kubernetes objects are limited to 1MiB, which leaves room for the metadata of the Secret
+	snapshotSecretChunkSize = 768 * 1024
+)
+
+var (
+	NoKubeClientForSnapshotPersistenceErr = eris.New("persisting xds snapshots in Secrets requires a kubernetes client")
+
+	IncompleteSnapshotErr = eris.New("the chunks of the snapshot are incomplete or belong to different snapshots")
+)
+
+// SnapshotStore stores xDS snapshots by their node key, so that they survive restarts of Gloo.
+type SnapshotStore interface {
+	Save(ctx context.Context, key string, snap cache.Snapshot) error
+	Delete(ctx context.Context, key string) error
+	// Returns all stored snapshots, indexed by their node key.
+	Load(ctx context.Context) (map[string]cache.Snapshot, error)
+}
+
+// Returns the SnapshotStore configured in the settings, or nil if xDS snapshot persistence is disabled.
+func NewSnapshotStore(settings *v1.Settings, kubeClient kubernetes.Interface) (SnapshotStore, error) {
+	persistence := settings.GetGloo().GetXdsSnapshotPersistence()
+	switch store := persistence.GetStore().(type) {
+	case *v1.GlooOptions_XdsSnapshotPersistence_Directory:
+		return NewFileSnapshotStore(store.Directory), nil
+	case *v1.GlooOptions_XdsSnapshotPersistence_SecretNamespace:
+		if kubeClient == nil {
+			return nil, NoKubeClientForSnapshotPersistenceErr
+		}
+		return NewSecretSnapshotStore(kubeClient, store.SecretNamespace), nil
+	}
+	return nil, nil
+}
+
+type fileSnapshotStore struct {
+	directory string
+}
+
+// Stores each snapshot as a file in the given directory.
+func NewFileSnapshotStore(directory string) SnapshotStore {
+	return &fileSnapshotStore{directory: directory}
+}
+
+func (s *fileSnapshotStore) Save(_ context.Context, key string, snap cache.Snapshot) error {
+	data, err := MarshalSnapshot(snap)
+	if err != nil {
+		return err
+	}
+	// snapshots contain the TLS private keys served by envoy
+	if err := os.MkdirAll(s.directory, 0700); err != nil {
+		return eris.Wrapf(err, "creating xds snapshot directory %v", s.directory)
+	}
+	// write to a temporary file first so that a crash while writing never leaves a truncated snapshot behind
+	tmpFile, err := ioutil.TempFile(s.directory, ".tmp-")
+	if err != nil {
+		return eris.Wrapf(err, "creating temporary xds snapshot file")
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return eris.Wrapf(err, "writing xds snapshot for %v", key)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return eris.Wrapf(err, "writing xds snapshot for %v", key)
+	}
+	return os.Rename(tmpFile.Name(), s.filename(key))
+}
+
+func (s *fileSnapshotStore) Delete(_ context.Context, key string) error {
+	if err := os.Remove(s.filename(key)); err != nil && !os.IsNotExist(err) {
+		return eris.Wrapf(err, "deleting xds snapshot for %v", key)
+	}
+	return nil
+}
+
+func (s *fileSnapshotStore) Load(ctx context.Context) (map[string]cache.Snapshot, error) {
+	files, err := ioutil.ReadDir(s.directory)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "reading xds snapshot directory %v", s.directory)
+	}
+
+	snapshots := map[string]cache.Snapshot{}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), snapshotFileExtension) {
+			continue
+		}
+		key, err := url.PathUnescape(strings.TrimSuffix(file.Name(), snapshotFileExtension))
+		if err != nil {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(s.directory, file.Name()))
+		if err != nil {
+			return nil, eris.Wrapf(err, "reading xds snapshot for %v", key)
+		}
+		snap, err := UnmarshalSnapshot(data)
+		if err != nil {
+			// a corrupt snapshot must not prevent the others from being served
+			contextutils.LoggerFrom(ctx).Warnf("ignoring persisted xds snapshot for %v: %v", key, err)
+			continue
+		}
+		snapshots[key] = snap
+	}
+	return snapshots, nil
+}
+
+func (s *fileSnapshotStore) filename(key string) string {
+	return filepath.Join(s.directory, url.PathEscape(key)+snapshotFileExtension)
+}
+
+type secretSnapshotStore struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+// Stores each snapshot in Secrets in the given namespace, as snapshots contain the TLS private keys served by Envoy.
+// Snapshots are compressed and split into chunks, each of which is stored in its own Secret, so that large snapshots
+// do not exceed the size limit of kubernetes objects.
+func NewSecretSnapshotStore(kubeClient kubernetes.Interface, namespace string) SnapshotStore {
+	return &secretSnapshotStore{kubeClient: kubeClient, namespace: namespace}
+}
+
+func (s *secretSnapshotStore) Save(ctx context.Context, key string, snap cache.Snapshot) error {
+	data, err := MarshalSnapshot(snap)
+	if err != nil {
+		return err
+	}
+	compressed, err := compress(data)
+	if err != nil {
+		return eris.Wrapf(err, "compressing xds snapshot for %v", key)
+	}
+	chunks := splitChunks(compressed, snapshotSecretChunkSize)
+	// every chunk records the digest of the whole snapshot, so that chunks of different saves are never combined
+	digest := fmt.Sprintf("%x", sha256.Sum256(compressed))
+
+	secrets := s.kubeClient.CoreV1().Secrets(s.namespace)
+	for i, chunk := range chunks {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      snapshotSecretName(key, i),
+				Namespace: s.namespace,
+				Labels: map[string]string{
+					snapshotSecretLabel:        "true",
+					snapshotSecretKeyHashLabel: snapshotKeyHash(key),
+				},
+				Annotations: map[string]string{
+					snapshotSecretKeyAnn:    key,
+					snapshotSecretDigestAnn: digest,
+					snapshotSecretChunkAnn:  strconv.Itoa(i),
+					snapshotSecretChunksAnn: strconv.Itoa(len(chunks)),
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{snapshotSecretData: chunk},
+		}
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		if kubeerrors.IsNotFound(err) {
+			_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+		}
+		if err != nil {
+			return eris.Wrapf(err, "writing chunk %d of %d of the xds snapshot for %v (%d bytes compressed)", i+1, len(chunks), key, len(compressed))
+		}
+	}
+
+	// remove the chunks of a previous, larger snapshot
+	return s.deleteChunks(ctx, key, len(chunks))
+}
+
+func (s *secretSnapshotStore) Delete(ctx context.Context, key string) error {
+	return s.deleteChunks(ctx, key, 0)
+}
+
+// deletes the chunks of the snapshot for the key whose index is at least fromChunk
+func (s *secretSnapshotStore) deleteChunks(ctx context.Context, key string, fromChunk int) error {
+	secrets := s.kubeClient.CoreV1().Secrets(s.namespace)
+	existing, err := secrets.List(ctx, metav1.ListOptions{
+		LabelSelector: snapshotSecretKeyHashLabel + "=" + snapshotKeyHash(key),
+	})
+	if err != nil {
+		return eris.Wrapf(err, "listing xds snapshot secrets for %v", key)
+	}
+	for _, secret := range existing.Items {
+		if secret.GetAnnotations()[snapshotSecretKeyAnn] != key {
+			continue
+		}
+		if chunk, err := strconv.Atoi(secret.GetAnnotations()[snapshotSecretChunkAnn]); err == nil && chunk < fromChunk {
+			continue
+		}
+		err := secrets.Delete(ctx, secret.GetName(), metav1.DeleteOptions{})
+		if err != nil && !kubeerrors.IsNotFound(err) {
+			return eris.Wrapf(err, "deleting xds snapshot for %v", key)
+		}
+	}
+	return nil
+}
+
+func (s *secretSnapshotStore) Load(ctx context.Context) (map[string]cache.Snapshot, error) {
+	secrets, err := s.kubeClient.CoreV1().Secrets(s.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: snapshotSecretLabel + "=true",
+	})
+	if err != nil {
+		return nil, eris.Wrapf(err, "listing xds snapshot secrets")
+	}
+
+	secretsByKey := map[string][]corev1.Secret{}
+	for _, secret := range secrets.Items {
+		key, ok := secret.GetAnnotations()[snapshotSecretKeyAnn]
+		if !ok {
+			continue
+		}
+		secretsByKey[key] = append(secretsByKey[key], secret)
+	}
+
+	snapshots := map[string]cache.Snapshot{}
+	for key, keySecrets := range secretsByKey {
+		compressed, err := joinChunks(keySecrets)
+		if err != nil {
+			// an incomplete snapshot must not prevent the others from being served
+			contextutils.LoggerFrom(ctx).Warnf("ignoring persisted xds snapshot for %v: %v", key, err)
+			continue
+		}
+		data, err := decompress(compressed)
+		if err != nil {
+			contextutils.LoggerFrom(ctx).Warnf("ignoring persisted xds snapshot for %v: %v", key, err)
+			continue
+		}
+		snap, err := UnmarshalSnapshot(data)
+		if err != nil {
+			contextutils.LoggerFrom(ctx).Warnf("ignoring persisted xds snapshot for %v: %v", key, err)
+			continue
+		}
+		snapshots[key] = snap
+	}
+	return snapshots, nil
+}
+
+// Reassembles the compressed snapshot stored in the given chunks. The chunks must all belong to the same save, and
+// none may be missing, which may happen if gloo stopped while saving a snapshot.
+func joinChunks(secrets []corev1.Secret) ([]byte, error) {
+	chunks := make([][]byte, len(secrets))
+	digest := secrets[0].GetAnnotations()[snapshotSecretDigestAnn]
+	for _, secret := range secrets {
+		annotations := secret.GetAnnotations()
+		total, err := strconv.Atoi(annotations[snapshotSecretChunksAnn])
+		if err != nil || total != len(secrets) || annotations[snapshotSecretDigestAnn] != digest {
+			return nil, IncompleteSnapshotErr
+		}
+		chunk, err := strconv.Atoi(annotations[snapshotSecretChunkAnn])
+		if err != nil || chunk < 0 || chunk >= total || chunks[chunk] != nil {
+			return nil, IncompleteSnapshotErr
+		}
+		chunks[chunk] = secret.Data[snapshotSecretData]
+	}
+	compressed := bytes.Join(chunks, nil)
+	if fmt.Sprintf("%x", sha256.Sum256(compressed)) != digest {
+		return nil, IncompleteSnapshotErr
+	}
+	return compressed, nil
+}
+
+func splitChunks(data []byte, size int) [][]byte {
+	var chunks [][]byte
+	for len(data) > size {
+		chunks = append(chunks, data[:size])
+		data = data[size:]
+	}
+	return append(chunks, data)
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// node keys may contain characters that are not allowed in kubernetes names and label values, so we name the
+// Secrets after a hash of the key and store the key itself in an annotation
+func snapshotKeyHash(key string) string {
+	hasher := fnv.New64a()
+	_, _ = hasher.Write([]byte(key))
+	return fmt.Sprintf("%x", hasher.Sum64())
+}
+
+func snapshotSecretName(key string, chunk int) string {
+	return fmt.Sprintf("%s%s-%d", snapshotSecretPrefix, snapshotKeyHash(key), chunk)
+}
+
+type persistedSnapshot struct {
+	Endpoints persistedResources `json:"endpoints"`
+	Clusters  persistedResources `json:"clusters"`
+	Routes    persistedResources `json:"routes"`
+	Listeners persistedResources `json:"listeners"`
+}
+
+type persistedResources struct {
+	Version string `json:"version"`
+	// each item is a serialized google.protobuf.Any
+	Items [][]byte `json:"items"`
+}
+
+// Serializes the xDS resources of the snapshot.
+func MarshalSnapshot(snap cache.Snapshot) ([]byte, error) {
+	var (
+		persisted persistedSnapshot
+		err       error
+	)
+	if persisted.Endpoints, err = marshalResources(snap.GetResources(resource.EndpointTypeV3)); err != nil {
+		return nil, err
+	}
+	if persisted.Clusters, err = marshalResources(snap.GetResources(resource.ClusterTypeV3)); err != nil {
+		return nil, err
+	}
+	if persisted.Routes, err = marshalResources(snap.GetResources(resource.RouteTypeV3)); err != nil {
+		return nil, err
+	}
+	if persisted.Listeners, err = marshalResources(snap.GetResources(resource.ListenerTypeV3)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(persisted)
+}
+
+// Deserializes a snapshot serialized with MarshalSnapshot.
+func UnmarshalSnapshot(data []byte) (cache.Snapshot, error) {
+	var persisted persistedSnapshot
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return nil, eris.Wrapf(err, "unmarshalling xds snapshot")
+	}
+	endpoints, err := unmarshalResources(persisted.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := unmarshalResources(persisted.Clusters)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := unmarshalResources(persisted.Routes)
+	if err != nil {
+		return nil, err
+	}
+	listeners, err := unmarshalResources(persisted.Listeners)
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshotFromResources(endpoints, clusters, routes, listeners), nil
+}
+
+func marshalResources(resources cache.Resources) (persistedResources, error) {
+	persisted := persistedResources{Version: resources.Version}
+	for name, item := range resources.Items {
+		anyResource, err := ptypes.MarshalAny(item.ResourceProto())
+		if err != nil {
+			return persistedResources{}, eris.Wrapf(err, "marshalling xds resource %v", name)
+		}
+		data, err := proto.Marshal(anyResource)
+		if err != nil {
+			return persistedResources{}, eris.Wrapf(err, "marshalling xds resource %v", name)
+		}
+		persisted.Items = append(persisted.Items, data)
+	}
+	return persisted, nil
+}
+
+func unmarshalResources(persisted persistedResources) (cache.Resources, error) {
+	var items []cache.Resource
+	for _, data := range persisted.Items {
+		var anyResource any.Any
+		if err := proto.Unmarshal(data, &anyResource); err != nil {
+			return cache.Resources{}, eris.Wrapf(err, "unmarshalling xds resource")
+		}
+		var dynamic ptypes.DynamicAny
+		if err := ptypes.UnmarshalAny(&anyResource, &dynamic); err != nil {
+			return cache.Resources{}, eris.Wrapf(err, "unmarshalling xds resource of type %v", anyResource.GetTypeUrl())
+		}
+		items = append(items, resource.NewEnvoyResource(dynamic.Message))
+	}
+	return cache.NewResources(persisted.Version, items), nil
+}
+
+// SnapshotAcknowledger reports whether the Envoy nodes served a snapshot have acknowledged it.
+type SnapshotAcknowledger interface {
+	IsAcknowledged(key string, snap cache.Snapshot) bool
+}
+
+// SnapshotPersistence persists the last snapshot that was translated without errors and acknowledged by Envoy for
+// each node key, and restores the persisted snapshots into the xDS cache when gloo starts.
+// All methods are safe to call on a nil SnapshotPersistence, which disables persistence.
+type SnapshotPersistence struct {
+	store        SnapshotStore
+	acknowledger SnapshotAcknowledger
+
+	lock sync.Mutex
+	// the keys whose restored snapshot is still being served
+	restored map[string]bool
+	// the versions of the snapshots that are currently persisted, indexed by node key
+	persistedVersions map[string]string
+	// the snapshots that were translated without errors but have not been acknowledged yet, indexed by node key
+	pending map[string]cache.Snapshot
+}
+
+func NewSnapshotPersistence(store SnapshotStore, acknowledger SnapshotAcknowledger) *SnapshotPersistence {
+	if store == nil {
+		return nil
+	}
+	return &SnapshotPersistence{
+		store:             store,
+		acknowledger:      acknowledger,
+		restored:          map[string]bool{},
+		persistedVersions: map[string]string{},
+		pending:           map[string]cache.Snapshot{},
+	}
+}
+
+// Loads the persisted snapshots into the cache. This must be called before the xDS server starts serving.
+func (p *SnapshotPersistence) Restore(ctx context.Context, xdsCache cache.SnapshotCache) error {
+	if p == nil {
+		return nil
+	}
+	snapshots, err := p.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for key, snap := range snapshots {
+		contextutils.LoggerFrom(ctx).Infof("restoring persisted xds snapshot for %v", key)
+		xdsCache.SetSnapshot(key, snap)
+		p.restored[key] = true
+		p.persistedVersions[key] = snapshotVersion(snap)
+	}
+	return nil
+}
+
+// Returns true if the restored snapshot for the key is still being served, i.e. no translation for the key has
+// completed without errors yet.
+func (p *SnapshotPersistence) IsRestored(key string) bool {
+	if p == nil {
+		return false
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.restored[key]
+}
+
+// Records that the snapshot for the key was translated without errors. The snapshot is persisted once Envoy
+// acknowledges it, so that a snapshot rejected by Envoy is never restored.
+func (p *SnapshotPersistence) Accept(ctx context.Context, key string, snap cache.Snapshot) error {
+	if p == nil {
+		return nil
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.restored, key)
+	if persistedVersion, ok := p.persistedVersions[key]; ok && persistedVersion == snapshotVersion(snap) {
+		delete(p.pending, key)
+		return nil
+	}
+	p.pending[key] = snap
+	return p.persistIfAcknowledged(ctx, key)
+}
+
+// Persists the accepted snapshots that Envoy has acknowledged since they were accepted. This should be called
+// whenever Envoy acknowledges a response.
+func (p *SnapshotPersistence) PersistAcknowledged(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var errs error
+	for key := range p.pending {
+		if err := p.persistIfAcknowledged(ctx, key); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// must be called while holding the lock
+func (p *SnapshotPersistence) persistIfAcknowledged(ctx context.Context, key string) error {
+	snap := p.pending[key]
+	if p.acknowledger == nil || !p.acknowledger.IsAcknowledged(key, snap) {
+		return nil
+	}
+	if err := p.store.Save(ctx, key, snap); err != nil {
+		return err
+	}
+	p.persistedVersions[key] = snapshotVersion(snap)
+	delete(p.pending, key)
+	return nil
+}
+
+// Deletes the persisted snapshot for the key, e.g. because its proxy has been deleted.
+func (p *SnapshotPersistence) Forget(ctx context.Context, key string) error {
+	if p == nil {
+		return nil
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.pending, key)
+	if _, ok := p.persistedVersions[key]; !ok {
+		return nil
+	}
+	if err := p.store.Delete(ctx, key); err != nil {
+		return err
+	}
+	delete(p.restored, key)
+	delete(p.persistedVersions, key)
+	return nil
+}
+
+var snapshotTypeUrls = []string{
+	resource.EndpointTypeV3,
+	resource.ClusterTypeV3,
+	resource.RouteTypeV3,
+	resource.ListenerTypeV3,
+}
+
+// the translator derives the version of each resource type from a hash of the resources
+func snapshotVersion(snap cache.Snapshot) string {
+	return strings.Join([]string{
+		snap.GetResources(resource.EndpointTypeV3).Version,
+		snap.GetResources(resource.ClusterTypeV3).Version,
+		snap.GetResources(resource.RouteTypeV3).Version,
+		snap.GetResources(resource.ListenerTypeV3).Version,
+	}, "/")
+}
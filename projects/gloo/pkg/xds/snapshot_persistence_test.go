@@ -0,0 +1,298 @@
+package xds_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/solo-io/gloo/projects/gloo/pkg/xds"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/cache"
+	"github.com/solo-io/solo-kit/pkg/api/v1/control-plane/resource"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Snapshot persistence", func() {
+
+	var (
+		ctx context.Context
+		key = "gloo-system~gateway-proxy"
+	)
+
+	newSnapshot := func(version string) *xds.EnvoySnapshot {
+		return xds.NewSnapshot(version,
+			[]cache.Resource{resource.NewEnvoyResource(&envoy_config_endpoint_v3.ClusterLoadAssignment{ClusterName: "cluster"})},
+			[]cache.Resource{resource.NewEnvoyResource(&envoy_config_cluster_v3.Cluster{Name: "cluster"})},
+			[]cache.Resource{resource.NewEnvoyResource(&envoy_config_route_v3.RouteConfiguration{Name: "routes"})},
+			[]cache.Resource{resource.NewEnvoyResource(&envoy_config_listener_v3.Listener{Name: "listener"})},
+		)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("round-trips snapshots", func() {
+		snap := newSnapshot("1234")
+		data, err := xds.MarshalSnapshot(snap)
+		Expect(err).NotTo(HaveOccurred())
+
+		restored, err := xds.UnmarshalSnapshot(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snap.Equal(restored.(*xds.EnvoySnapshot))).To(BeTrue())
+		Expect(restored.GetResources(resource.ListenerTypeV3).Items).To(HaveKey("listener"))
+	})
+
+	Context("file store", func() {
+
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "xds-snapshots")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(dir)
+		})
+
+		It("saves, loads and deletes snapshots", func() {
+			store := xds.NewFileSnapshotStore(dir)
+			snap := newSnapshot("1234")
+			Expect(store.Save(ctx, key, snap)).NotTo(HaveOccurred())
+
+			snapshots, err := store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(HaveLen(1))
+			Expect(snap.Equal(snapshots[key].(*xds.EnvoySnapshot))).To(BeTrue())
+
+			Expect(store.Delete(ctx, key)).NotTo(HaveOccurred())
+			snapshots, err = store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(BeEmpty())
+		})
+
+		It("ignores corrupt snapshots", func() {
+			store := xds.NewFileSnapshotStore(dir)
+			Expect(store.Save(ctx, key, newSnapshot("1234"))).NotTo(HaveOccurred())
+			Expect(ioutil.WriteFile(dir+"/corrupt.json", []byte("{"), 0644)).NotTo(HaveOccurred())
+
+			snapshots, err := store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(HaveLen(1))
+			Expect(snapshots).To(HaveKey(key))
+		})
+
+		It("loads nothing when the directory does not exist", func() {
+			snapshots, err := xds.NewFileSnapshotStore(dir + "/missing").Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(BeEmpty())
+		})
+	})
+
+	Context("secret store", func() {
+
+		It("saves, loads and deletes snapshots", func() {
+			kubeClient := fake.NewSimpleClientset()
+			store := xds.NewSecretSnapshotStore(kubeClient, "gloo-system")
+
+			Expect(store.Save(ctx, key, newSnapshot("1"))).NotTo(HaveOccurred())
+			// saving again updates the existing secret
+			Expect(store.Save(ctx, key, newSnapshot("2"))).NotTo(HaveOccurred())
+
+			secrets, err := kubeClient.CoreV1().Secrets("gloo-system").List(ctx, metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secrets.Items).To(HaveLen(1))
+
+			snapshots, err := store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(newSnapshot("2").Equal(snapshots[key].(*xds.EnvoySnapshot))).To(BeTrue())
+
+			Expect(store.Delete(ctx, key)).NotTo(HaveOccurred())
+			snapshots, err = store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(BeEmpty())
+			secrets, err = kubeClient.CoreV1().Secrets("gloo-system").List(ctx, metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secrets.Items).To(BeEmpty())
+		})
+
+		It("splits large snapshots across secrets", func() {
+			kubeClient := fake.NewSimpleClientset()
+			store := xds.NewSecretSnapshotStore(kubeClient, "gloo-system")
+
+			// random data does not compress, so the snapshot exceeds the size limit of a single secret
+			largeSnapshot := func(version string) *xds.EnvoySnapshot {
+				randomData := make([]byte, 2*1024*1024)
+				_, err := rand.Read(randomData)
+				Expect(err).NotTo(HaveOccurred())
+				return xds.NewSnapshot(version, nil, nil, nil,
+					[]cache.Resource{resource.NewEnvoyResource(&envoy_config_listener_v3.Listener{
+						Name:       "listener",
+						StatPrefix: base64.StdEncoding.EncodeToString(randomData),
+					})},
+				)
+			}
+			snap := largeSnapshot("1")
+			Expect(store.Save(ctx, key, snap)).NotTo(HaveOccurred())
+
+			secrets, err := kubeClient.CoreV1().Secrets("gloo-system").List(ctx, metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(secrets.Items)).To(BeNumerically(">", 1))
+			for _, secret := range secrets.Items {
+				Expect(secret.Size()).To(BeNumerically("<", 1024*1024))
+			}
+
+			snapshots, err := store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snap.Equal(snapshots[key].(*xds.EnvoySnapshot))).To(BeTrue())
+
+			// the chunks of the larger snapshot are removed when a smaller snapshot is saved
+			Expect(store.Save(ctx, key, newSnapshot("2"))).NotTo(HaveOccurred())
+			secrets, err = kubeClient.CoreV1().Secrets("gloo-system").List(ctx, metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secrets.Items).To(HaveLen(1))
+			snapshots, err = store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(newSnapshot("2").Equal(snapshots[key].(*xds.EnvoySnapshot))).To(BeTrue())
+		})
+
+		It("ignores snapshots with missing chunks", func() {
+			kubeClient := fake.NewSimpleClientset()
+			store := xds.NewSecretSnapshotStore(kubeClient, "gloo-system")
+			Expect(store.Save(ctx, key, newSnapshot("1"))).NotTo(HaveOccurred())
+
+			secrets, err := kubeClient.CoreV1().Secrets("gloo-system").List(ctx, metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			secret := secrets.Items[0]
+			secret.Annotations["gloo.solo.io/xds-snapshot-chunks"] = "2"
+			_, err = kubeClient.CoreV1().Secrets("gloo-system").Update(ctx, &secret, metav1.UpdateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			snapshots, err := store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(BeEmpty())
+		})
+
+		It("ignores secrets that do not hold snapshots", func() {
+			kubeClient := fake.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "gloo-system"},
+			})
+			snapshots, err := xds.NewSecretSnapshotStore(kubeClient, "gloo-system").Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(BeEmpty())
+		})
+	})
+
+	Context("SnapshotPersistence", func() {
+
+		var (
+			dir          string
+			store        xds.SnapshotStore
+			xdsCache     cache.SnapshotCache
+			acknowledger *mockAcknowledger
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "xds-snapshots")
+			Expect(err).NotTo(HaveOccurred())
+			store = xds.NewFileSnapshotStore(dir)
+			xdsCache = cache.NewSnapshotCache(true, xds.NewNodeHasher(), nil)
+			acknowledger = &mockAcknowledger{acknowledged: map[string]bool{}}
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(dir)
+		})
+
+		It("restores persisted snapshots into the cache", func() {
+			Expect(store.Save(ctx, key, newSnapshot("1234"))).NotTo(HaveOccurred())
+
+			persistence := xds.NewSnapshotPersistence(store, acknowledger)
+			Expect(persistence.Restore(ctx, xdsCache)).NotTo(HaveOccurred())
+			Expect(persistence.IsRestored(key)).To(BeTrue())
+
+			snap, err := xdsCache.GetSnapshot(key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(newSnapshot("1234").Equal(snap.(*xds.EnvoySnapshot))).To(BeTrue())
+		})
+
+		It("persists accepted snapshots once they are acknowledged", func() {
+			Expect(store.Save(ctx, key, newSnapshot("1234"))).NotTo(HaveOccurred())
+			persistence := xds.NewSnapshotPersistence(store, acknowledger)
+			Expect(persistence.Restore(ctx, xdsCache)).NotTo(HaveOccurred())
+
+			Expect(persistence.Accept(ctx, key, newSnapshot("5678"))).NotTo(HaveOccurred())
+			Expect(persistence.IsRestored(key)).To(BeFalse())
+
+			snapshots, err := store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(newSnapshot("1234").Equal(snapshots[key].(*xds.EnvoySnapshot))).To(BeTrue())
+
+			acknowledger.acknowledged["5678"] = true
+			Expect(persistence.PersistAcknowledged(ctx)).NotTo(HaveOccurred())
+			snapshots, err = store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(newSnapshot("5678").Equal(snapshots[key].(*xds.EnvoySnapshot))).To(BeTrue())
+
+			Expect(persistence.Forget(ctx, key)).NotTo(HaveOccurred())
+			snapshots, err = store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(BeEmpty())
+		})
+
+		It("persists accepted snapshots immediately if they are already acknowledged", func() {
+			persistence := xds.NewSnapshotPersistence(store, acknowledger)
+			acknowledger.acknowledged["1234"] = true
+			Expect(persistence.Accept(ctx, key, newSnapshot("1234"))).NotTo(HaveOccurred())
+
+			snapshots, err := store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(newSnapshot("1234").Equal(snapshots[key].(*xds.EnvoySnapshot))).To(BeTrue())
+		})
+
+		It("does not persist snapshots that are not acknowledged", func() {
+			persistence := xds.NewSnapshotPersistence(store, acknowledger)
+			Expect(persistence.Accept(ctx, key, newSnapshot("1234"))).NotTo(HaveOccurred())
+			Expect(persistence.PersistAcknowledged(ctx)).NotTo(HaveOccurred())
+
+			// a later snapshot replaces the pending one
+			Expect(persistence.Accept(ctx, key, newSnapshot("5678"))).NotTo(HaveOccurred())
+			acknowledger.acknowledged["1234"] = true
+			Expect(persistence.PersistAcknowledged(ctx)).NotTo(HaveOccurred())
+
+			snapshots, err := store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(BeEmpty())
+		})
+
+		It("is disabled without a store", func() {
+			persistence := xds.NewSnapshotPersistence(nil, acknowledger)
+			Expect(persistence).To(BeNil())
+			Expect(persistence.Restore(ctx, xdsCache)).NotTo(HaveOccurred())
+			Expect(persistence.IsRestored(key)).To(BeFalse())
+			Expect(persistence.Accept(ctx, key, newSnapshot("1234"))).NotTo(HaveOccurred())
+			Expect(persistence.PersistAcknowledged(ctx)).NotTo(HaveOccurred())
+		})
+	})
+})
+
+// acknowledges the snapshots whose cluster version is acknowledged
+type mockAcknowledger struct {
+	acknowledged map[string]bool
+}
+
+func (m *mockAcknowledger) IsAcknowledged(_ string, snap cache.Snapshot) bool {
+	return m.acknowledged[snap.GetResources(resource.ClusterTypeV3).Version]
+}
@@ -101,8 +101,10 @@ func translateProxy(ctx context.Context, namespace string, snap *v1.TranslatorSn
 	}
 }
 
-func upstreamForBackend(upstreams gloov1.UpstreamList, services []*kubev1.Service, ingressNamespace string, backend networkingv1.IngressBackend) (*gloov1.Upstream, error) {
-	serviceName, servicePort, err := getServiceNameAndPort(services, ingressNamespace, backend.Service)
+// UpstreamForBackend returns the upstream of the kube service of the backend, which must be in the namespace of the
+// ingress. When several upstreams match, the one with the smallest selector is returned.
+func UpstreamForBackend(upstreams gloov1.UpstreamList, services []*kubev1.Service, ingressNamespace string, backend networkingv1.IngressBackend) (*gloov1.Upstream, error) {
+	serviceName, servicePort, err := ServiceNameAndPort(services, ingressNamespace, backend.Service)
 	if err != nil {
 		return nil, err
 	}
@@ -133,10 +135,10 @@ func upstreamForBackend(upstreams gloov1.UpstreamList, services []*kubev1.Servic
 	return matchingUpstream, nil
 }
 
-// getServiceNameAndPort returns the service name and port number for an IngressServiceBackend or an error if the
+// ServiceNameAndPort returns the service name and port number for an IngressServiceBackend or an error if the
 // defined IngressServiceBackend does not match any available services.
 // An IngressServiceBackend can have have its port defined either by number or name, so we must handle both cases
-func getServiceNameAndPort(services []*kubev1.Service, namespace string, ingressService *networkingv1.IngressServiceBackend) (string, int32, error) {
+func ServiceNameAndPort(services []*kubev1.Service, namespace string, ingressService *networkingv1.IngressServiceBackend) (string, int32, error) {
 	if ingressService == nil {
 		return "", 0, errors.New("no service specified for ingress backend")
 	}
@@ -211,7 +213,7 @@ func virtualHosts(ctx context.Context, ingresses []*networkingv1.Ingress, upstre
 				continue
 			}
 			for _, route := range rule.HTTP.Paths {
-				upstream, err := upstreamForBackend(upstreams, services, ing.Namespace, route.Backend)
+				upstream, err := UpstreamForBackend(upstreams, services, ing.Namespace, route.Backend)
 				if err != nil {
 					contextutils.LoggerFrom(ctx).Errorf("lookup upstream for ingress %v: %v", ing.Name, err)
 					continue